@@ -27,6 +27,7 @@ import (
 	"github.com/concourse/atc/api/workerserver/workerserverfakes"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
@@ -60,6 +61,7 @@ var (
 	build                         *dbfakes.FakeBuild
 	fakeSchedulerFactory          *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory            *resourceserverfakes.FakeScannerFactory
+	fakeCredentialManager         *credsfakes.FakeCredentialManager
 	configValidationErrorMessages []string
 	configValidationWarnings      []config.Warning
 	peerAddr                      string
@@ -110,6 +112,7 @@ var _ = BeforeEach(func() {
 
 	authValidator = new(authfakes.FakeValidator)
 	userContextReader = new(authfakes.FakeUserContextReader)
+	userContextReader.GetRoleReturns(atc.RoleOwner, true)
 	fakeTokenGenerator = new(authfakes.FakeTokenGenerator)
 	providerFactory = new(authfakes.FakeProviderFactory)
 
@@ -123,6 +126,7 @@ var _ = BeforeEach(func() {
 
 	fakeSchedulerFactory = new(jobserverfakes.FakeSchedulerFactory)
 	fakeScannerFactory = new(resourceserverfakes.FakeScannerFactory)
+	fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 
 	var err error
 
@@ -187,6 +191,7 @@ var _ = BeforeEach(func() {
 
 		fakeSchedulerFactory,
 		fakeScannerFactory,
+		fakeCredentialManager,
 
 		sink,
 
@@ -6,9 +6,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var _ = Describe("Auth API", func() {
@@ -69,11 +71,124 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, teamID, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, teamID, isAdmin, role, userName := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(teamID).To(Equal(savedTeam.ID))
 						Expect(isAdmin).To(Equal(savedTeam.Admin))
+						Expect(role).To(Equal(atc.RoleOwner))
+						Expect(userName).To(BeEmpty())
+					})
+
+					Context("when the team has basic auth", func() {
+						BeforeEach(func() {
+							encryptedPassword, err := bcrypt.GenerateFromPassword([]byte("some-password"), bcrypt.MinCost)
+							Expect(err).NotTo(HaveOccurred())
+
+							savedTeam.BasicAuth = &db.BasicAuth{
+								BasicAuthUsername: "some-user",
+								BasicAuthPassword: string(encryptedPassword),
+							}
+							teamDB.GetTeamReturns(savedTeam, true, nil)
+						})
+
+						Context("when the request is made with its credentials", func() {
+							BeforeEach(func() {
+								request.Header.Del("Authorization")
+								request.SetBasicAuth("some-user", "some-password")
+							})
+
+							It("generates an owner token for the user, qualified as a basic auth user", func() {
+								_, _, _, _, role, userName := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+								Expect(role).To(Equal(atc.RoleOwner))
+								Expect(userName).To(Equal("basic:some-user"))
+							})
+
+							Context("when the team grants the basic auth user a role", func() {
+								BeforeEach(func() {
+									savedTeam.BasicAuth.Role = atc.RoleViewer
+									teamDB.GetTeamReturns(savedTeam, true, nil)
+								})
+
+								It("generates a token with the role", func() {
+									_, _, _, _, role, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+									Expect(role).To(Equal(atc.RoleViewer))
+								})
+							})
+						})
+
+						Context("when the request is made with the wrong credentials", func() {
+							BeforeEach(func() {
+								request.Header.Del("Authorization")
+								request.SetBasicAuth("some-user", "bogus-password")
+							})
+
+							It("returns 401 and does not generate a token", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+								Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the request is made with a token for another team", func() {
+							BeforeEach(func() {
+								userContextReader.GetTeamReturns("some-other-team", 1, false, true)
+								userContextReader.GetRoleReturns(atc.RoleViewer, true)
+							})
+
+							It("returns 401 and does not generate a token", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+								Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(BeZero())
+							})
+						})
+					})
+
+					Context("when the team has no auth and the request is made with basic auth credentials", func() {
+						BeforeEach(func() {
+							request.Header.Del("Authorization")
+							request.SetBasicAuth("some-user", "some-password")
+						})
+
+						It("does not trust the user name", func() {
+							_, _, _, _, _, userName := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(userName).To(BeEmpty())
+						})
+					})
+				})
+
+				Context("when the request is made with a token for the team", func() {
+					BeforeEach(func() {
+						fakeTokenGenerator.GenerateTokenReturns("some type", "some value", nil)
+						userContextReader.GetTeamReturns("some-team", 0, true, true)
+						userContextReader.GetRoleReturns(atc.RolePipelineOperator, true)
+					})
+
+					It("generates a token with the same role", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						_, _, _, _, role, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(role).To(Equal(atc.RolePipelineOperator))
+					})
+
+					Context("when the token has no role", func() {
+						BeforeEach(func() {
+							userContextReader.GetRoleReturns("", false)
+						})
+
+						It("generates a token with the most restrictive role", func() {
+							_, _, _, _, role, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(role).To(Equal(atc.RoleViewer))
+						})
+					})
+
+					Context("when the token identifies a user", func() {
+						BeforeEach(func() {
+							userContextReader.GetUserNameReturns("some-user", true)
+						})
+
+						It("generates a token for the same user", func() {
+							_, _, _, _, _, userName := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(userName).To(Equal("some-user"))
+						})
 					})
 				})
 
@@ -376,7 +491,33 @@ var _ = Describe("Auth API", func() {
 								body, err := ioutil.ReadAll(response.Body)
 								Expect(err).NotTo(HaveOccurred())
 
-								Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"}}`))
+								Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"},"role":"owner"}`))
+							})
+
+							Context("when the user has been granted a role", func() {
+								BeforeEach(func() {
+									userContextReader.GetRoleReturns(atc.RoleViewer, true)
+								})
+
+								It("returns the role", func() {
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"},"role":"viewer"}`))
+								})
+							})
+
+							Context("when the token has no role", func() {
+								BeforeEach(func() {
+									userContextReader.GetRoleReturns("", false)
+								})
+
+								It("returns the most restrictive role", func() {
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"},"role":"viewer"}`))
+								})
 							})
 						})
 					})
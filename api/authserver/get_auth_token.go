@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 )
 
 const CookieName = "ATC-Authorization"
@@ -33,7 +34,34 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.ID, team.Admin)
+	// a token for the team can be exchanged for a new one with the same role
+	// and user; tokens for other teams can not be exchanged at all
+	var role atc.Role
+	var userName string
+
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if authTeamFound && authTeam.IsAuthorized(team.Name) {
+		role = authTeam.Role()
+		userName, _ = auth.GetUserName(r)
+	} else if team.BasicAuth != nil && auth.NewBasicAuthValidator(team).IsAuthenticated(r) {
+		role = team.BasicAuth.Role
+		if role == "" {
+			role = atc.RoleOwner
+		}
+
+		basicAuthUserName, _, _ := r.BasicAuth()
+		userName = auth.QualifiedUserName(auth.BasicAuthProviderName, basicAuthUserName)
+	} else if !team.IsAuthConfigured() {
+		role = atc.RoleOwner
+	} else {
+		logger.Info("not-authenticated-for-team", lager.Data{
+			"teamName": teamName,
+		})
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.ID, team.Admin, role, userName)
 	if err != nil {
 		logger.Error("generate-token", err)
 		w.WriteHeader(http.StatusInternalServerError)
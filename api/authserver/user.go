@@ -38,6 +38,7 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
 			presentedTeam := present.Team(savedTeam)
 			user = User{
 				Team: &presentedTeam,
+				Role: authTeam.Role(),
 			}
 		}
 	}
@@ -48,5 +49,6 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
 
 type User struct {
 	Team   *atc.Team `json:"team,omitempty"`
+	Role   atc.Role  `json:"role,omitempty"`
 	System *bool     `json:"system,omitempty"`
 }
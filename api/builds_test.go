@@ -1148,6 +1148,176 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/builds/:build_id/approvals", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/approvals", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					buildsDB.GetBuildByIDReturns(build, true, nil)
+				})
+
+				Context("when accessing same team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 2, true, true)
+						userContextReader.GetUserNameReturns("github:some-user", true)
+					})
+
+					Context("when the build is waiting for approval", func() {
+						BeforeEach(func() {
+							build.GetPendingApprovalsReturns([]db.BuildApproval{
+								{PlanID: "some-plan"},
+								{PlanID: "some-other-plan", Approvers: []string{"github:some-user"}},
+							}, nil)
+							build.ApproveReturns(true, nil)
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+
+						It("approves each pending approval as the user", func() {
+							Expect(build.ApproveCallCount()).To(Equal(2))
+
+							planID, approver := build.ApproveArgsForCall(0)
+							Expect(planID).To(Equal(atc.PlanID("some-plan")))
+							Expect(approver).To(Equal("github:some-user"))
+
+							planID, approver = build.ApproveArgsForCall(1)
+							Expect(planID).To(Equal(atc.PlanID("some-other-plan")))
+							Expect(approver).To(Equal("github:some-user"))
+						})
+
+						Context("when approving fails", func() {
+							BeforeEach(func() {
+								build.ApproveReturns(false, errors.New("nope"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when the user is not one of the approvers", func() {
+						BeforeEach(func() {
+							build.GetPendingApprovalsReturns([]db.BuildApproval{
+								{PlanID: "some-plan", Approvers: []string{"github:some-other-user"}},
+							}, nil)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+
+						It("does not approve anything", func() {
+							Expect(build.ApproveCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when an approver has the user's name at another provider", func() {
+						BeforeEach(func() {
+							build.GetPendingApprovalsReturns([]db.BuildApproval{
+								{PlanID: "some-plan", Approvers: []string{"oauth:some-user"}},
+							}, nil)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+
+						It("does not approve anything", func() {
+							Expect(build.ApproveCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the token does not identify a user", func() {
+						BeforeEach(func() {
+							userContextReader.GetUserNameReturns("", false)
+
+							build.GetPendingApprovalsReturns([]db.BuildApproval{
+								{PlanID: "some-plan"},
+							}, nil)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+
+						It("does not approve anything", func() {
+							Expect(build.ApproveCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the build is not waiting for approval", func() {
+						BeforeEach(func() {
+							build.GetPendingApprovalsReturns([]db.BuildApproval{}, nil)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+
+					Context("when getting the pending approvals fails", func() {
+						BeforeEach(func() {
+							build.GetPendingApprovalsReturns(nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when accessing other team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-other-team", 2, true, true)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildByIDReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
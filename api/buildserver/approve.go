@@ -0,0 +1,75 @@
+package buildserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+// ApproveBuild approves every approval step the build is waiting on, as the
+// requesting user. Requests whose token does not identify a user are
+// forbidden, as the approval could not be attributed to anyone. If a step
+// names its approvers, the user must be one of them; users are identified by
+// their provider and name (e.g. 'github:some-user'), so a user with the same
+// name at another provider is not.
+func (s *Server) ApproveBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		aLog := s.logger.Session("approve", lager.Data{
+			"build": build.ID(),
+		})
+
+		approvals, err := build.GetPendingApprovals()
+		if err != nil {
+			aLog.Error("failed-to-get-pending-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(approvals) == 0 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		approver, found := auth.GetUserName(r)
+		if !found {
+			aLog.Info("no-user-identity")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		for _, approval := range approvals {
+			if !mayApprove(approval, approver) {
+				aLog.Info("not-an-approver", lager.Data{"approver": approver})
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+
+		for _, approval := range approvals {
+			_, err := build.Approve(approval.PlanID, approver)
+			if err != nil {
+				aLog.Error("failed-to-approve", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func mayApprove(approval db.BuildApproval, userName string) bool {
+	if len(approval.Approvers) == 0 {
+		return true
+	}
+
+	for _, approver := range approval.Approvers {
+		if approver == userName {
+			return true
+		}
+	}
+
+	return false
+}
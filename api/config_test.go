@@ -8,8 +8,10 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/configserver"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/onsi/gomega/gbytes"
@@ -272,7 +274,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+							Expect(teamDB.SaveConfigByCallCount()).To(Equal(0))
 						})
 					})
 
@@ -296,7 +298,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+							Expect(teamDB.SaveConfigByCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -317,18 +319,29 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							Expect(teamDB.SaveConfigByCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+							_, name, savedConfig, id, pipelineState := teamDB.SaveConfigByArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
 						})
 
+						Context("when the request is made by a user", func() {
+							BeforeEach(func() {
+								userContextReader.GetUserNameReturns("some-user", true)
+							})
+
+							It("records who saved it", func() {
+								savedBy, _, _, _, _ := teamDB.SaveConfigByArgsForCall(0)
+								Expect(savedBy).To(Equal("some-user"))
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+								teamDB.SaveConfigByReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -352,7 +365,7 @@ var _ = Describe("Config API", func() {
 										Version: db.ConfigVersion(42),
 									},
 								}
-								teamDB.SaveConfigReturns(returnedPipeline, true, nil)
+								teamDB.SaveConfigByReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -379,7 +392,7 @@ var _ = Describe("Config API", func() {
 							})
 
 							It("does not save it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+								Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
 							})
 						})
 					})
@@ -399,9 +412,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							Expect(teamDB.SaveConfigByCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+							_, name, savedConfig, id, pipelineState := teamDB.SaveConfigByArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -409,9 +422,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							Expect(teamDB.SaveConfigByCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := teamDB.SaveConfigArgsForCall(0)
+							_, _, savedConfig, _, _ := teamDB.SaveConfigByArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -446,9 +459,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+								Expect(teamDB.SaveConfigByCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+								_, name, savedConfig, id, pipelineState := teamDB.SaveConfigByArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -499,7 +512,7 @@ jobs:
 										Version: db.ConfigVersion(42),
 									},
 								}
-								teamDB.SaveConfigReturns(returnedPipeline, true, nil)
+								teamDB.SaveConfigByReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -509,7 +522,7 @@ jobs:
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+								teamDB.SaveConfigByReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -540,7 +553,7 @@ jobs:
 							})
 
 							It("does not save it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+								Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
 							})
 						})
 					})
@@ -584,9 +597,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+								Expect(teamDB.SaveConfigByCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+								_, name, savedConfig, id, pipelineState := teamDB.SaveConfigByArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -605,7 +618,7 @@ jobs:
 											Version: db.ConfigVersion(42),
 										},
 									}
-									teamDB.SaveConfigReturns(returnedPipeline, true, nil)
+									teamDB.SaveConfigByReturns(returnedPipeline, true, nil)
 								})
 
 								It("returns 201", func() {
@@ -615,7 +628,7 @@ jobs:
 
 							Context("and saving it fails", func() {
 								BeforeEach(func() {
-									teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+									teamDB.SaveConfigByReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
 								})
 
 								It("returns 500", func() {
@@ -645,7 +658,7 @@ jobs:
 								})
 
 								It("does not save it", func() {
-									Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+									Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
 								})
 							})
 
@@ -775,7 +788,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+									Expect(teamDB.SaveConfigByCallCount()).To(Equal(0))
 								})
 							})
 
@@ -814,7 +827,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+									Expect(teamDB.SaveConfigByCallCount()).To(Equal(0))
 								})
 							})
 						})
@@ -836,7 +849,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+						Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
 					})
 				})
 
@@ -867,7 +880,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+						Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
 					})
 				})
 			})
@@ -891,7 +904,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
 				})
 			})
 
@@ -914,7 +927,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
 				})
 			})
 		})
@@ -929,7 +942,572 @@ jobs:
 			})
 
 			It("does not save the config", func() {
-				Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+				Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:name/config/validate", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.ValidateConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the config is valid", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "application/json")
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+
+					configValidationWarnings = []config.Warning{
+						{Type: "deprecation", Message: "some-warning"},
+					}
+				})
+
+				Context("when the pipeline already has a config", func() {
+					BeforeEach(func() {
+						existingConfig := atc.Config{
+							Resources: atc.ResourceConfigs{
+								{
+									Name:   "some-resource",
+									Type:   "some-type",
+									Source: atc.Source{"source-config": "some-old-value"},
+								},
+								{
+									Name: "some-old-resource",
+									Type: "some-type",
+								},
+							},
+							ResourceTypes: pipelineConfig.ResourceTypes,
+							Groups:        pipelineConfig.Groups,
+						}
+
+						teamDB.GetConfigReturns(existingConfig, atc.RawConfig(""), db.ConfigVersion(42), nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("looks up the pipeline's config", func() {
+						Expect(teamDB.GetConfigArgsForCall(0)).To(Equal("a-pipeline"))
+					})
+
+					It("returns the config, warnings, diff and resources losing their version history", func() {
+						var body struct {
+							Config   atc.Config        `json:"config"`
+							Errors   []string          `json:"errors"`
+							Warnings []config.Warning  `json:"warnings"`
+							Diff     config.ConfigDiff `json:"diff"`
+
+							ResourcesLosingVersionHistory []string `json:"resources_losing_version_history"`
+						}
+
+						err := json.NewDecoder(response.Body).Decode(&body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body.Config.Jobs).To(HaveLen(1))
+						Expect(body.Config.Jobs[0].Name).To(Equal("some-job"))
+						Expect(body.Errors).To(BeEmpty())
+						Expect(body.Warnings).To(Equal(configValidationWarnings))
+
+						Expect(body.Diff.Groups).To(BeEmpty())
+						Expect(body.Diff.ResourceTypes).To(BeEmpty())
+						Expect(body.Diff.Jobs).To(Equal([]config.ItemDiff{
+							{Name: "some-job", Action: config.DiffActionAdded},
+						}))
+
+						Expect(body.Diff.Resources).To(HaveLen(2))
+						Expect(body.Diff.Resources[0].Name).To(Equal("some-old-resource"))
+						Expect(body.Diff.Resources[0].Action).To(Equal(config.DiffActionRemoved))
+						Expect(body.Diff.Resources[1].Name).To(Equal("some-resource"))
+						Expect(body.Diff.Resources[1].Action).To(Equal(config.DiffActionChanged))
+						Expect(body.Diff.Resources[1].Fields).To(HaveLen(1))
+						Expect(body.Diff.Resources[1].Fields[0].Field).To(Equal("source"))
+
+						Expect(body.ResourcesLosingVersionHistory).To(Equal([]string{
+							"some-old-resource",
+							"some-resource",
+						}))
+					})
+
+					It("does not save anything", func() {
+						Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when getting the existing config fails", func() {
+					BeforeEach(func() {
+						teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "application/json")
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+
+					configValidationErrorMessages = []string{"totally invalid"}
+				})
+
+				It("returns 200 with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var body struct {
+						Errors []string `json:"errors"`
+					}
+
+					err := json.NewDecoder(response.Body).Decode(&body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body.Errors).To(Equal([]string{"totally invalid"}))
+				})
+
+				It("does not save anything", func() {
+					Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the config is malformed", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "application/json")
+					request.Body = gbytes.BufferWithBytes([]byte(`{`))
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("returns error JSON", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+						{
+							"errors": [
+								"malformed config"
+							]
+						}`))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.ListConfigVersions, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when getting the versions succeeds", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionsReturns([]db.PipelineConfigVersion{
+						{
+							Version: 2,
+							Config:  pipelineConfig,
+							SavedBy: "some-user",
+							SavedAt: time.Unix(2, 0),
+						},
+						{
+							Version: 1,
+							Config:  atc.Config{},
+							SavedAt: time.Unix(1, 0),
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("looks up the pipeline's versions", func() {
+					Expect(teamDB.GetConfigVersionsArgsForCall(0)).To(Equal("a-pipeline"))
+				})
+
+				It("returns the versions without their configs", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{"version": 2, "saved_by": "some-user", "saved_at": 2},
+						{"version": 1, "saved_at": 1}
+					]`))
+				})
+			})
+
+			Context("when getting the versions fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionsReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions/:config_version", func() {
+		var (
+			configVersion string
+			response      *http.Response
+		)
+
+		BeforeEach(func() {
+			configVersion = "2"
+		})
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.GetConfigVersion, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": configVersion,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the version exists", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{
+						Version: 2,
+						Config:  pipelineConfig,
+						SavedBy: "some-user",
+						SavedAt: time.Unix(2, 0),
+					}, true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("looks up the version", func() {
+					pipelineName, version := teamDB.GetConfigVersionArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(version).To(Equal(db.ConfigVersion(2)))
+				})
+
+				It("returns the version with its config", func() {
+					var body atc.ConfigVersion
+					err := json.NewDecoder(response.Body).Decode(&body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body.Version).To(Equal(2))
+					Expect(body.SavedBy).To(Equal("some-user"))
+					Expect(body.SavedAt).To(Equal(int64(2)))
+					Expect(body.Config).NotTo(BeNil())
+					Expect(body.Config.Jobs[0].Name).To(Equal("some-job"))
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the version is not a number", func() {
+				BeforeEach(func() {
+					configVersion = "nope"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when getting the version fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{}, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions/:config_version/diff", func() {
+		var (
+			query    string
+			response *http.Response
+
+			oldConfig atc.Config
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			oldConfig = atc.Config{
+				Resources:     pipelineConfig.Resources,
+				ResourceTypes: pipelineConfig.ResourceTypes,
+				Groups:        pipelineConfig.Groups,
+			}
+
+			teamDB.GetConfigVersionStub = func(pipelineName string, version db.ConfigVersion) (db.PipelineConfigVersion, bool, error) {
+				switch version {
+				case 5:
+					return db.PipelineConfigVersion{Version: 5, Config: pipelineConfig}, true, nil
+				case 1:
+					return db.PipelineConfigVersion{Version: 1, Config: atc.Config{}}, true, nil
+				default:
+					return db.PipelineConfigVersion{}, false, nil
+				}
+			}
+
+			teamDB.GetConfigVersionsReturns([]db.PipelineConfigVersion{
+				{Version: 5, Config: pipelineConfig},
+				{Version: 3, Config: oldConfig},
+				{Version: 1, Config: atc.Config{}},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.DiffConfigVersions, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": "5",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.URL.RawQuery = query
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when no version to diff from is given", func() {
+				It("diffs against the version saved before", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var body configserver.DiffConfigVersionsResponse
+					err := json.NewDecoder(response.Body).Decode(&body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body.From).To(Equal(3))
+					Expect(body.To).To(Equal(5))
+					Expect(body.Diff.Resources).To(BeEmpty())
+					Expect(body.Diff.Jobs).To(Equal([]config.ItemDiff{
+						{Name: "some-job", Action: config.DiffActionAdded},
+					}))
+				})
+			})
+
+			Context("when a version to diff from is given", func() {
+				BeforeEach(func() {
+					query = "from=1"
+				})
+
+				It("diffs against it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var body configserver.DiffConfigVersionsResponse
+					err := json.NewDecoder(response.Body).Decode(&body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body.From).To(Equal(1))
+					Expect(body.Diff.Resources).To(Equal([]config.ItemDiff{
+						{Name: "some-resource", Action: config.DiffActionAdded},
+					}))
+				})
+			})
+
+			Context("when the version to diff from does not exist", func() {
+				BeforeEach(func() {
+					query = "from=2"
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config/versions/:config_version/rollback", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.RollbackConfig, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": "3",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+				userContextReader.GetUserNameReturns("some-user", true)
+			})
+
+			Context("when the version exists", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{
+						Version: 3,
+						Config:  pipelineConfig,
+					}, true, nil)
+
+					teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), db.ConfigVersion(5), nil)
+				})
+
+				Context("when the config is valid", func() {
+					BeforeEach(func() {
+						configValidationWarnings = []config.Warning{
+							{Type: "deprecation", Message: "some-warning"},
+						}
+					})
+
+					It("returns 200 with the warnings", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"warnings": [{"type": "deprecation", "message": "some-warning"}]
+						}`))
+					})
+
+					It("saves the old config over the current version", func() {
+						Expect(teamDB.SaveConfigByCallCount()).To(Equal(1))
+
+						savedBy, name, savedConfig, from, pipelineState := teamDB.SaveConfigByArgsForCall(0)
+						Expect(savedBy).To(Equal("some-user"))
+						Expect(name).To(Equal("a-pipeline"))
+						Expect(savedConfig).To(Equal(pipelineConfig))
+						Expect(from).To(Equal(db.ConfigVersion(5)))
+						Expect(pipelineState).To(Equal(db.PipelineNoChange))
+					})
+
+					Context("when saving fails", func() {
+						BeforeEach(func() {
+							teamDB.SaveConfigByReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the config is no longer valid", func() {
+					BeforeEach(func() {
+						configValidationErrorMessages = []string{"totally invalid"}
+					})
+
+					It("returns 400 with the errors", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"errors": ["totally invalid"]
+						}`))
+					})
+
+					It("does not save it", func() {
+						Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(teamDB.SaveConfigByCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 			})
 		})
 	})
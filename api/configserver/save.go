@@ -13,6 +13,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/mitchellh/mapstructure"
@@ -65,42 +66,9 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	config, pausedState, err := saveConfigRequestUnmarshaler(r)
-
-	switch err {
-	case ErrStatusUnsupportedMediaType:
-		w.WriteHeader(http.StatusUnsupportedMediaType)
+	config, pausedState, ok := s.unmarshalConfigRequest(w, r, session)
+	if !ok {
 		return
-	case ErrMalformedRequestPayload:
-		session.Error("malformed-request-payload", err, lager.Data{
-			"content-type": r.Header.Get("Content-Type"),
-		})
-
-		s.handleBadRequest(w, []string{"malformed config"}, session)
-		return
-	case ErrFailedToConstructDecoder:
-		session.Error("failed-to-construct-decoder", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	case ErrCouldNotDecode:
-		session.Error("could-not-decode", err)
-		s.handleBadRequest(w, []string{"failed to decode config"}, session)
-		return
-	case ErrInvalidPausedValue:
-		session.Error("invalid-paused-value", err)
-		s.handleBadRequest(w, []string{"invalid paused value"}, session)
-		return
-	default:
-		if err != nil {
-			if eke, ok := err.(ExtraKeysError); ok {
-				s.handleBadRequest(w, []string{eke.Error()}, session)
-			} else {
-				session.Error("unexpected-error", err)
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-
-			return
-		}
 	}
 
 	warnings, errorMessages := s.validate(config)
@@ -115,8 +83,10 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	pipelineName := rata.Param(r, "pipeline_name")
 	teamName := rata.Param(r, "team_name")
 
+	userName, _ := auth.GetUserName(r)
+
 	teamDB := s.teamDBFactory.GetTeamDB(teamName)
-	_, created, err := teamDB.SaveConfig(pipelineName, config, version, pausedState)
+	_, created, err := teamDB.SaveConfigBy(userName, pipelineName, config, version, pausedState)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -135,6 +105,44 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
 }
 
+// unmarshalConfigRequest decodes the config in the request body. If it cannot
+// be decoded, the appropriate response has been written and false is
+// returned.
+func (s *Server) unmarshalConfigRequest(w http.ResponseWriter, r *http.Request, session lager.Logger) (atc.Config, db.PipelinePausedState, bool) {
+	config, pausedState, err := saveConfigRequestUnmarshaler(r)
+
+	switch err {
+	case nil:
+		return config, pausedState, true
+	case ErrStatusUnsupportedMediaType:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case ErrMalformedRequestPayload:
+		session.Error("malformed-request-payload", err, lager.Data{
+			"content-type": r.Header.Get("Content-Type"),
+		})
+
+		s.handleBadRequest(w, []string{"malformed config"}, session)
+	case ErrFailedToConstructDecoder:
+		session.Error("failed-to-construct-decoder", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	case ErrCouldNotDecode:
+		session.Error("could-not-decode", err)
+		s.handleBadRequest(w, []string{"failed to decode config"}, session)
+	case ErrInvalidPausedValue:
+		session.Error("invalid-paused-value", err)
+		s.handleBadRequest(w, []string{"invalid paused value"}, session)
+	default:
+		if eke, ok := err.(ExtraKeysError); ok {
+			s.handleBadRequest(w, []string{eke.Error()}, session)
+		} else {
+			session.Error("unexpected-error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	return atc.Config{}, db.PipelineNoChange, false
+}
+
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages []string, session lager.Logger) {
 	w.WriteHeader(http.StatusBadRequest)
 	s.writeSaveConfigResponse(w, SaveConfigResponse{
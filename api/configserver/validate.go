@@ -0,0 +1,65 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/tedsuo/rata"
+)
+
+type ValidateConfigResponse struct {
+	Config   atc.Config        `json:"config"`
+	Errors   []string          `json:"errors,omitempty"`
+	Warnings []config.Warning  `json:"warnings,omitempty"`
+	Diff     config.ConfigDiff `json:"diff"`
+
+	// the resources whose existing versions would no longer apply if the
+	// config were saved
+	ResourcesLosingVersionHistory []string `json:"resources_losing_version_history,omitempty"`
+}
+
+// ValidateConfig decodes and validates a config as SaveConfig would, and
+// compares it to the pipeline's current config, without saving anything.
+// Invalid configs are still diffed and responded to with 200; the errors are
+// in the response body.
+func (s *Server) ValidateConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("validate-config")
+
+	newConfig, _, ok := s.unmarshalConfigRequest(w, r, session)
+	if !ok {
+		return
+	}
+
+	warnings, errorMessages := s.validate(newConfig)
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	existingConfig, _, _, err := teamDB.GetConfig(pipelineName)
+	if err != nil {
+		// a malformed config would be replaced entirely, so compare against
+		// nothing
+		if _, ok := err.(atc.MalformedConfigError); !ok {
+			session.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		existingConfig = atc.Config{}
+	}
+
+	diff := config.DiffConfigs(existingConfig, newConfig)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(ValidateConfigResponse{
+		Config:                        newConfig,
+		Errors:                        errorMessages,
+		Warnings:                      warnings,
+		Diff:                          diff,
+		ResourcesLosingVersionHistory: diff.ResourcesLosingVersionHistory(),
+	})
+}
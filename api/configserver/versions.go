@@ -0,0 +1,202 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+type DiffConfigVersionsResponse struct {
+	From int               `json:"from"`
+	To   int               `json:"to"`
+	Diff config.ConfigDiff `json:"diff"`
+
+	// the resources whose existing versions would no longer apply when going
+	// from one config to the other
+	ResourcesLosingVersionHistory []string `json:"resources_losing_version_history,omitempty"`
+}
+
+func (s *Server) ListConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-config-versions")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	configVersions, err := teamDB.GetConfigVersions(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-get-config-versions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := []atc.ConfigVersion{}
+	for _, configVersion := range configVersions {
+		presented = append(presented, present.ConfigVersion(configVersion, false))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presented)
+}
+
+func (s *Server) GetConfigVersion(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-version")
+
+	configVersion, found := s.lookupConfigVersion(w, r, logger)
+	if !found {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(present.ConfigVersion(configVersion, true))
+}
+
+// DiffConfigVersions compares a config version to the one given by the
+// 'from' query parameter, defaulting to the version saved before it. The
+// first version is compared to an empty config.
+func (s *Server) DiffConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("diff-config-versions")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	to, found := s.lookupConfigVersion(w, r, logger)
+	if !found {
+		return
+	}
+
+	from := db.PipelineConfigVersion{}
+
+	fromStr := r.URL.Query().Get("from")
+	if fromStr != "" {
+		fromVersion, err := strconv.Atoi(fromStr)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		from, found, err = teamDB.GetConfigVersion(pipelineName, db.ConfigVersion(fromVersion))
+		if err != nil {
+			logger.Error("failed-to-get-config-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	} else {
+		// versions are not contiguous within a pipeline, so find the one
+		// saved before
+		configVersions, err := teamDB.GetConfigVersions(pipelineName)
+		if err != nil {
+			logger.Error("failed-to-get-config-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		for _, configVersion := range configVersions {
+			if configVersion.Version < to.Version {
+				from = configVersion
+				break
+			}
+		}
+	}
+
+	diff := config.DiffConfigs(from.Config, to.Config)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(DiffConfigVersionsResponse{
+		From:                          int(from.Version),
+		To:                            int(to.Version),
+		Diff:                          diff,
+		ResourcesLosingVersionHistory: diff.ResourcesLosingVersionHistory(),
+	})
+}
+
+// RollbackConfig saves an earlier config version as the pipeline's config,
+// validating it as SaveConfig would.
+func (s *Server) RollbackConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("rollback-config")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	configVersion, found := s.lookupConfigVersion(w, r, session)
+	if !found {
+		return
+	}
+
+	warnings, errorMessages := s.validate(configVersion.Config)
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-config")
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	_, _, currentVersion, err := teamDB.GetConfig(pipelineName)
+	if err != nil {
+		if _, ok := err.(atc.MalformedConfigError); !ok {
+			session.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	session.Info("saving", lager.Data{"version": configVersion.Version})
+
+	userName, _ := auth.GetUserName(r)
+
+	_, _, err = teamDB.SaveConfigBy(userName, pipelineName, configVersion.Config, currentVersion, db.PipelineNoChange)
+	if err != nil {
+		session.Error("failed-to-save-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to save config: %s", err)
+		return
+	}
+
+	session.Info("saved")
+
+	w.WriteHeader(http.StatusOK)
+
+	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
+}
+
+// lookupConfigVersion finds the config version named in the request. If it
+// cannot be found, the appropriate response has been written and false is
+// returned.
+func (s *Server) lookupConfigVersion(w http.ResponseWriter, r *http.Request, logger lager.Logger) (db.PipelineConfigVersion, bool) {
+	version, err := strconv.Atoi(rata.Param(r, "config_version"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return db.PipelineConfigVersion{}, false
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	configVersion, found, err := teamDB.GetConfigVersion(rata.Param(r, "pipeline_name"), db.ConfigVersion(version))
+	if err != nil {
+		logger.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return db.PipelineConfigVersion{}, false
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return db.PipelineConfigVersion{}, false
+	}
+
+	return configVersion, true
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/concourse/atc/api/volumeserver"
 	"github.com/concourse/atc/api/workerserver"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/mainredirect"
@@ -64,6 +65,7 @@ func NewHandler(
 
 	schedulerFactory jobserver.SchedulerFactory,
 	scannerFactory resourceserver.ScannerFactory,
+	credentialManager creds.CredentialManager,
 
 	sink *lager.ReconfigurableSink,
 
@@ -103,11 +105,11 @@ func NewHandler(
 	)
 
 	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
-	resourceServer := resourceserver.NewServer(logger, scannerFactory)
+	resourceServer := resourceserver.NewServer(logger, scannerFactory, credentialManager)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
 
-	pipelineServer := pipelineserver.NewServer(logger, teamDBFactory, pipelinesDB)
+	pipelineServer := pipelineserver.NewServer(logger, teamDBFactory, pipelineDBFactory, pipelinesDB)
 
 	configServer := configserver.NewServer(logger, teamDBFactory, configValidator)
 
@@ -129,14 +131,21 @@ func NewHandler(
 		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
 		atc.GetAuthToken:    http.HandlerFunc(authServer.GetAuthToken),
 
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:      http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:     http.HandlerFunc(configServer.SaveConfig),
+		atc.ValidateConfig: http.HandlerFunc(configServer.ValidateConfig),
+
+		atc.ListConfigVersions: http.HandlerFunc(configServer.ListConfigVersions),
+		atc.GetConfigVersion:   http.HandlerFunc(configServer.GetConfigVersion),
+		atc.DiffConfigVersions: http.HandlerFunc(configServer.DiffConfigVersions),
+		atc.RollbackConfig:     http.HandlerFunc(configServer.RollbackConfig),
 
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
 		atc.CreateBuild:         teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.ApproveBuild:        buildHandlerFactory.HandlerFor(buildServer.ApproveBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
@@ -146,9 +155,10 @@ func NewHandler(
 		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
 		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
 		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.CreateJobBuild: pipelineHandlerFactory.WritableHandlerFor(jobServer.CreateJobBuild),
+		atc.PauseJob:       pipelineHandlerFactory.WritableHandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:     pipelineHandlerFactory.WritableHandlerFor(jobServer.UnpauseJob),
+		atc.ClearJobCaches: pipelineHandlerFactory.WritableHandlerFor(jobServer.ClearJobCaches),
 		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
 		atc.MainJobBadge:   mainredirect.Handler{atc.Routes, atc.JobBadge},
 
@@ -157,22 +167,29 @@ func NewHandler(
 		atc.GetPipeline:      pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipeline),
 		atc.DeletePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline),
 		atc.OrderPipelines:   http.HandlerFunc(pipelineServer.OrderPipelines),
-		atc.PausePipeline:    pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
-		atc.UnpausePipeline:  pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
-		atc.ExposePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.ExposePipeline),
-		atc.HidePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
+		atc.PausePipeline:    pipelineHandlerFactory.WritableHandlerFor(pipelineServer.PausePipeline),
+		atc.UnpausePipeline:  pipelineHandlerFactory.WritableHandlerFor(pipelineServer.UnpausePipeline),
+		atc.ExposePipeline:   pipelineHandlerFactory.WritableHandlerFor(pipelineServer.ExposePipeline),
+		atc.HidePipeline:     pipelineHandlerFactory.WritableHandlerFor(pipelineServer.HidePipeline),
 		atc.GetVersionsDB:    pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
-		atc.RenamePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.RenamePipeline),
-
-		atc.ListResources:   pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
-		atc.GetResource:     pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
-		atc.PauseResource:   pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
-		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
-		atc.CheckResource:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
+		atc.GetPipelineGraph: pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipelineGraph),
+		atc.RenamePipeline:   pipelineHandlerFactory.WritableHandlerFor(pipelineServer.RenamePipeline),
+		atc.ArchivePipeline:  pipelineHandlerFactory.HandlerFor(pipelineServer.ArchivePipeline),
+		atc.ExportPipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.ExportPipeline),
+		atc.ImportPipeline:   http.HandlerFunc(pipelineServer.ImportPipeline),
+
+		atc.ListResources:        pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
+		atc.GetResource:          pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
+		atc.PauseResource:        pipelineHandlerFactory.WritableHandlerFor(resourceServer.PauseResource),
+		atc.UnpauseResource:      pipelineHandlerFactory.WritableHandlerFor(resourceServer.UnpauseResource),
+		atc.CheckResource:        pipelineHandlerFactory.WritableHandlerFor(resourceServer.CheckResource),
+		atc.CheckResourceWebHook: pipelineHandlerFactory.WritableHandlerFor(resourceServer.CheckResourceWebHook),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
-		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
-		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.EnableResourceVersion:         pipelineHandlerFactory.WritableHandlerFor(versionServer.EnableResourceVersion),
+		atc.DisableResourceVersion:        pipelineHandlerFactory.WritableHandlerFor(versionServer.DisableResourceVersion),
+		atc.PinResourceVersion:            pipelineHandlerFactory.WritableHandlerFor(versionServer.PinResourceVersion),
+		atc.UnpinResourceVersion:          pipelineHandlerFactory.WritableHandlerFor(versionServer.UnpinResourceVersion),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 
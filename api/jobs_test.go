@@ -222,6 +222,34 @@ var _ = Describe("Jobs API", func() {
 
 					})
 
+					Context("when the job has schedules", func() {
+						BeforeEach(func() {
+							pipelineDB.GetJobReturns(db.SavedJob{
+								PipelineName: "some-pipeline",
+								Job: db.Job{
+									Name: "some-job",
+								},
+								Config: atc.JobConfig{
+									Name: "some-job",
+									Schedule: []atc.ScheduleConfig{
+										{Cron: "0 0 1 1 *"},
+										{Cron: "@hourly", Location: "Europe/London"},
+									},
+								},
+							}, true, nil)
+						})
+
+						It("returns the next times they will trigger the job, soonest first", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(job.NextScheduledTimes).To(HaveLen(2))
+							Expect(time.Unix(job.NextScheduledTimes[0], 0)).To(BeTemporally("~", time.Now(), time.Hour))
+							Expect(job.NextScheduledTimes[1]).To(BeNumerically(">", job.NextScheduledTimes[0]))
+						})
+					})
+
 					Context("when there are no running or finished builds", func() {
 						BeforeEach(func() {
 							pipelineDB.GetJobFinishedAndNextBuildReturns(nil, nil, nil)
@@ -1707,4 +1735,67 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/job-name/caches", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			It("injects the PipelineDB", func() {
+				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
+				Expect(pipelineName).To(Equal("some-pipeline"))
+				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
+				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
+			})
+
+			Context("when clearing the caches succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.ClearTaskCachesReturns(nil)
+				})
+
+				It("cleared the right job's caches", func() {
+					Expect(pipelineDB.ClearTaskCachesArgsForCall(0)).To(Equal("job-name"))
+				})
+
+				It("returns 204", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+			})
+
+			Context("when clearing the caches fails", func() {
+				BeforeEach(func() {
+					pipelineDB.ClearTaskCachesReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
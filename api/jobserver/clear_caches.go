@@ -0,0 +1,24 @@
+package jobserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ClearJobCaches(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("clear-job-caches")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := rata.Param(r, "job_name")
+
+		err := pipelineDB.ClearTaskCaches(jobName)
+		if err != nil {
+			logger.Error("failed-to-clear-task-caches", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
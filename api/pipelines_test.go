@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -137,6 +138,7 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/main/pipelines/public-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "main",
 					"groups": [
 						{
@@ -151,6 +153,7 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/another/pipelines/another-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "another"
 				}]`))
 			})
@@ -172,6 +175,7 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/main/pipelines/private-pipeline",
 					"paused": false,
 					"public": false,
+					"archived": false,
 					"team_name": "main",
 					"groups": [
 						{
@@ -186,6 +190,7 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/main/pipelines/public-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "main",
 					"groups": [
 						{
@@ -200,6 +205,7 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/another/pipelines/another-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "another"
 				}]`))
 			})
@@ -258,6 +264,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/main/pipelines/private-pipeline",
 						"paused": false,
 						"public": false,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -272,6 +279,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/main/pipelines/public-pipeline",
 						"paused": true,
 						"public": true,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -310,6 +318,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/main/pipelines/public-pipeline",
 						"paused": true,
 						"public": true,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -338,6 +347,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/main/pipelines/public-pipeline",
 						"paused": true,
 						"public": true,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -427,6 +437,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/a-team/pipelines/some-specific-pipeline",
 						"paused": false,
 						"public": true,
+						"archived": false,
 						"team_name": "a-team",
 						"groups": [
 							{
@@ -628,6 +639,19 @@ var _ = Describe("Pipelines API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("when the pipeline is archived", func() {
+					BeforeEach(func() {
+						pipelineDB.PipelineReturns(db.SavedPipeline{Archived: true})
+						pipelineDB.GetPipelineNameReturns("a-pipeline")
+					})
+
+					It("returns 409 without pausing it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("pipeline 'a-pipeline' is archived")))
+						Expect(pipelineDB.PauseCallCount()).To(BeZero())
+					})
+				})
 			})
 
 			Context("when requester does not belong to the team", func() {
@@ -653,6 +677,390 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/archive", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/archive", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			Context("when requester belongs to the team", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", 42, true, true)
+				})
+
+				It("injects the proper pipelineDB", func() {
+					pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				})
+
+				Context("when archiving the pipeline succeeds", func() {
+					BeforeEach(func() {
+						pipelineDB.ArchiveReturns(nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("archives the pipeline", func() {
+						Expect(pipelineDB.ArchiveCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the pipeline is already archived", func() {
+					BeforeEach(func() {
+						pipelineDB.PipelineReturns(db.SavedPipeline{Archived: true})
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when archiving the pipeline fails", func() {
+					BeforeEach(func() {
+						pipelineDB.ArchiveReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when requester does not belong to the team", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("another-team", 42, true, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/export", func() {
+		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/export"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", 42, true, true)
+			})
+
+			Context("when exporting the pipeline succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.ExportStub = func(w io.Writer, includeEvents bool) error {
+						return json.NewEncoder(w).Encode(atc.PipelineExport{
+							Name: "a-pipeline",
+							Versions: []atc.ExportedVersion{
+								{
+									ID:         1,
+									Resource:   "some-resource",
+									Type:       "some-type",
+									Version:    atc.Version{"ver": "1"},
+									Enabled:    true,
+									CheckOrder: 1,
+								},
+							},
+							Builds: []atc.ExportedBuild{
+								{
+									ID:      2,
+									JobName: "some-job",
+									Name:    "1",
+									Status:  "succeeded",
+									Inputs:  []atc.ExportedBuildInput{{Name: "some-input", VersionID: 1}},
+									Outputs: []atc.ExportedBuildOutput{},
+								},
+							},
+						})
+					}
+				})
+
+				It("injects the proper pipelineDB", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("a-team"))
+					Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("a-pipeline"))
+					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns application/json", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the export", func() {
+					var export atc.PipelineExport
+					err := json.NewDecoder(response.Body).Decode(&export)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(export).To(Equal(atc.PipelineExport{
+						Name: "a-pipeline",
+						Versions: []atc.ExportedVersion{
+							{
+								ID:         1,
+								Resource:   "some-resource",
+								Type:       "some-type",
+								Version:    atc.Version{"ver": "1"},
+								Enabled:    true,
+								CheckOrder: 1,
+							},
+						},
+						Builds: []atc.ExportedBuild{
+							{
+								ID:      2,
+								JobName: "some-job",
+								Name:    "1",
+								Status:  "succeeded",
+								Inputs:  []atc.ExportedBuildInput{{Name: "some-input", VersionID: 1}},
+								Outputs: []atc.ExportedBuildOutput{},
+							},
+						},
+					}))
+				})
+
+				It("leaves out build events by default", func() {
+					_, includeEvents := pipelineDB.ExportArgsForCall(0)
+					Expect(includeEvents).To(BeFalse())
+				})
+
+				Context("when build events are requested", func() {
+					BeforeEach(func() {
+						query = "?events=true"
+					})
+
+					It("includes them", func() {
+						_, includeEvents := pipelineDB.ExportArgsForCall(0)
+						Expect(includeEvents).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when exporting the pipeline fails", func() {
+				BeforeEach(func() {
+					pipelineDB.ExportReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when exporting the pipeline fails after it has started", func() {
+				BeforeEach(func() {
+					pipelineDB.ExportStub = func(w io.Writer, includeEvents bool) error {
+						_, err := io.WriteString(w, `{"name":"a-pipeline"`)
+						Expect(err).NotTo(HaveOccurred())
+
+						return errors.New("welp")
+					}
+				})
+
+				It("cuts the export short, so that it can not be parsed", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var export atc.PipelineExport
+					err := json.NewDecoder(response.Body).Decode(&export)
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not export the pipeline", func() {
+				Expect(pipelineDB.ExportCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/import", func() {
+		var response *http.Response
+		var body io.Reader
+
+		BeforeEach(func() {
+			body = bytes.NewBufferString(`{
+				"name": "exported-name",
+				"versions": [{"id": 1, "resource": "some-resource", "type": "some-type", "version": {"ver": "1"}, "enabled": true, "check_order": 1}]
+			}`)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/import", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", 42, true, true)
+			})
+
+			Context("when importing the pipeline succeeds", func() {
+				BeforeEach(func() {
+					teamDB.ImportPipelineReturns(db.SavedPipeline{ID: 3}, nil)
+				})
+
+				It("returns 201", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+
+				It("imports the pipeline into the team in the URL, under the name in the URL", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("a-team"))
+
+					Expect(teamDB.ImportPipelineCallCount()).To(Equal(1))
+					Expect(teamDB.ImportPipelineArgsForCall(0)).To(Equal(atc.PipelineExport{
+						Name: "a-pipeline",
+						Versions: []atc.ExportedVersion{
+							{
+								ID:         1,
+								Resource:   "some-resource",
+								Type:       "some-type",
+								Version:    atc.Version{"ver": "1"},
+								Enabled:    true,
+								CheckOrder: 1,
+							},
+						},
+					}))
+				})
+			})
+
+			Context("when the pipeline already exists", func() {
+				BeforeEach(func() {
+					teamDB.ImportPipelineReturns(db.SavedPipeline{}, db.ErrPipelineAlreadyExists)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when the export is invalid", func() {
+				BeforeEach(func() {
+					teamDB.ImportPipelineReturns(db.SavedPipeline{}, db.InvalidExportError{
+						Errors: []string{"job 'some-job' is not in the config"},
+					})
+				})
+
+				It("returns 400 with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("job 'some-job' is not in the config"))
+				})
+			})
+
+			Context("when importing the pipeline fails", func() {
+				BeforeEach(func() {
+					teamDB.ImportPipelineReturns(db.SavedPipeline{}, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the export is not valid JSON", func() {
+				BeforeEach(func() {
+					body = bytes.NewBufferString(`{`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not import anything", func() {
+					Expect(teamDB.ImportPipelineCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not import anything", func() {
+				Expect(teamDB.ImportPipelineCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", func() {
 		var response *http.Response
 
@@ -992,6 +1400,380 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/graph", func() {
+		var response *http.Response
+		var query string
+
+		var savedPipeline db.SavedPipeline
+
+		BeforeEach(func() {
+			query = ""
+
+			savedPipeline = db.SavedPipeline{
+				ID:       1,
+				TeamName: "a-team",
+				Pipeline: db.Pipeline{
+					Name: "a-pipeline",
+					Config: atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "some-repo", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+						},
+						Jobs: atc.JobConfigs{
+							{
+								Name: "unit",
+								Plan: atc.PlanSequence{{Get: "some-repo", Trigger: true}},
+							},
+							{
+								Name: "deploy",
+								Plan: atc.PlanSequence{{Get: "some-repo", Passed: []string{"unit"}}},
+							},
+						},
+					},
+				},
+			}
+
+			pipelineDB.PipelineReturns(savedPipeline)
+			pipelineDB.ConfigReturns(savedPipeline.Config)
+
+			finishedBuild := new(dbfakes.FakeBuild)
+			finishedBuild.StatusReturns(db.StatusSucceeded)
+
+			nextBuild := new(dbfakes.FakeBuild)
+			nextBuild.StatusReturns(db.StatusStarted)
+
+			pipelineDB.GetDashboardReturns(db.Dashboard{
+				{
+					Job:           db.SavedJob{Job: db.Job{Name: "unit"}},
+					FinishedBuild: finishedBuild,
+				},
+				{
+					Job:       db.SavedJob{Paused: true, Job: db.Job{Name: "deploy"}},
+					NextBuild: nextBuild,
+				},
+			}, nil, nil)
+
+			pipelineDB.GetResourcesReturns([]db.SavedResource{
+				{
+					CheckError: errors.New("some-check-error"),
+					Resource:   db.Resource{Name: "some-repo"},
+				},
+			}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/graph"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns application/json", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the graph of the pipeline's jobs and resources with their status", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"pipelines": [
+						{"team_name": "a-team", "name": "a-pipeline", "paused": false}
+					],
+					"nodes": [
+						{
+							"id": "job:a-pipeline/unit",
+							"type": "job",
+							"team_name": "a-team",
+							"pipeline_name": "a-pipeline",
+							"name": "unit",
+							"paused": false,
+							"status": "succeeded"
+						},
+						{
+							"id": "job:a-pipeline/deploy",
+							"type": "job",
+							"team_name": "a-team",
+							"pipeline_name": "a-pipeline",
+							"name": "deploy",
+							"paused": true,
+							"next_build_status": "started"
+						},
+						{
+							"id": "resource:a-pipeline/some-repo",
+							"type": "resource",
+							"team_name": "a-team",
+							"pipeline_name": "a-pipeline",
+							"name": "some-repo",
+							"paused": false,
+							"check_error": "some-check-error"
+						}
+					],
+					"edges": [
+						{
+							"source": "resource:a-pipeline/some-repo",
+							"target": "job:a-pipeline/unit",
+							"type": "input",
+							"trigger": true
+						},
+						{
+							"source": "job:a-pipeline/unit",
+							"target": "job:a-pipeline/deploy",
+							"type": "passed",
+							"resource": "some-repo"
+						}
+					]
+				}`))
+			})
+
+			Describe("requesting the graph again", func() {
+				var newConfig atc.Config
+
+				getGraph := func() atc.PipelineGraph {
+					response, err := client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/graph")
+					Expect(err).NotTo(HaveOccurred())
+
+					defer response.Body.Close()
+
+					var graph atc.PipelineGraph
+					err = json.NewDecoder(response.Body).Decode(&graph)
+					Expect(err).NotTo(HaveOccurred())
+
+					return graph
+				}
+
+				BeforeEach(func() {
+					newConfig = atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-other-job"}},
+					}
+				})
+
+				It("uses the graph it built before, with the current statuses, while the config is unchanged", func() {
+					pipelineDB.ConfigReturns(newConfig)
+					pipelineDB.GetDashboardReturns(db.Dashboard{
+						{Job: db.SavedJob{Job: db.Job{Name: "unit"}, Paused: true}},
+					}, nil, nil)
+
+					graph := getGraph()
+					Expect(graph.Nodes).To(HaveLen(3))
+					Expect(graph.Nodes[0].Name).To(Equal("unit"))
+					Expect(graph.Nodes[0].Paused).To(BeTrue())
+				})
+
+				It("builds the graph again once the config has changed", func() {
+					pipelineDB.ConfigReturns(newConfig)
+					pipelineDB.ConfigVersionReturns(2)
+
+					graph := getGraph()
+					Expect(graph.Nodes).To(HaveLen(1))
+					Expect(graph.Nodes[0].Name).To(Equal("some-other-job"))
+				})
+			})
+
+			Context("when the format is dot", func() {
+				BeforeEach(func() {
+					query = "?format=dot"
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns text/vnd.graphviz", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("text/vnd.graphviz"))
+				})
+
+				It("returns the graph in the DOT language", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(HavePrefix(`digraph "a-pipeline" {`))
+					Expect(string(body)).To(ContainSubstring(`"job:a-pipeline/unit" -> "job:a-pipeline/deploy"`))
+				})
+			})
+
+			Context("when the format is unknown", func() {
+				BeforeEach(func() {
+					query = "?format=svg"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when groups are given", func() {
+				BeforeEach(func() {
+					config := savedPipeline.Config
+					config.Groups = atc.GroupConfigs{
+						{Name: "some-group", Jobs: []string{"unit"}},
+					}
+
+					pipelineDB.ConfigReturns(config)
+
+					query = "?group=some-group"
+				})
+
+				It("only includes the group's jobs and the resources they use", func() {
+					var graph atc.PipelineGraph
+					err := json.NewDecoder(response.Body).Decode(&graph)
+					Expect(err).NotTo(HaveOccurred())
+
+					ids := []string{}
+					for _, node := range graph.Nodes {
+						ids = append(ids, node.ID)
+					}
+
+					Expect(ids).To(Equal([]string{"job:a-pipeline/unit", "resource:a-pipeline/some-repo"}))
+				})
+			})
+
+			Context("when spanning the team", func() {
+				var otherPipelineDB *dbfakes.FakePipelineDB
+
+				BeforeEach(func() {
+					query = "?span=team"
+
+					otherPipeline := db.SavedPipeline{
+						ID:       2,
+						Paused:   true,
+						TeamName: "a-team",
+						Pipeline: db.Pipeline{
+							Name: "other-pipeline",
+							Config: atc.Config{
+								Resources: atc.ResourceConfigs{
+									{Name: "other-repo", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+								},
+							},
+						},
+					}
+
+					unrelatedPipeline := db.SavedPipeline{
+						ID:       3,
+						TeamName: "a-team",
+						Pipeline: db.Pipeline{
+							Name: "unrelated-pipeline",
+							Config: atc.Config{
+								Resources: atc.ResourceConfigs{
+									{Name: "some-repo", Type: "git", Source: atc.Source{"uri": "unrelated-uri"}},
+								},
+							},
+						},
+					}
+
+					teamDB.GetPipelinesReturns([]db.SavedPipeline{savedPipeline, otherPipeline, unrelatedPipeline}, nil)
+
+					otherPipelineDB = new(dbfakes.FakePipelineDB)
+					otherPipelineDB.PipelineReturns(otherPipeline)
+
+					pipelineDBFactory.BuildStub = func(pipeline db.SavedPipeline) db.PipelineDB {
+						if pipeline.Name == "other-pipeline" {
+							return otherPipelineDB
+						}
+
+						return pipelineDB
+					}
+				})
+
+				AfterEach(func() {
+					pipelineDBFactory.BuildStub = nil
+				})
+
+				It("includes the pipelines which share resources", func() {
+					var graph atc.PipelineGraph
+					err := json.NewDecoder(response.Body).Decode(&graph)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(graph.Pipelines).To(Equal([]atc.GraphPipeline{
+						{TeamName: "a-team", Name: "a-pipeline"},
+						{TeamName: "a-team", Name: "other-pipeline", Paused: true},
+					}))
+
+					Expect(graph.Edges).To(ContainElement(atc.GraphEdge{
+						Source: "resource:a-pipeline/some-repo",
+						Target: "resource:other-pipeline/other-repo",
+						Type:   atc.GraphEdgeShared,
+					}))
+				})
+
+				It("gets the status of the other pipelines", func() {
+					Expect(otherPipelineDB.GetDashboardCallCount()).To(Equal(1))
+					Expect(otherPipelineDB.GetResourcesCallCount()).To(Equal(1))
+				})
+
+				Context("when getting the team's pipelines fails", func() {
+					BeforeEach(func() {
+						teamDB.GetPipelinesReturns(nil, errors.New("disaster"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when getting the dashboard fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetDashboardReturns(nil, nil, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("", 0, false, false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					pipelineDB.IsPublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					pipelineDB.IsPublicReturns(true)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("when spanning the team", func() {
+					BeforeEach(func() {
+						query = "?span=team"
+					})
+
+					It("only considers the team's public pipelines", func() {
+						Expect(teamDB.GetPublicPipelinesCallCount()).To(Equal(1))
+						Expect(teamDB.GetPipelinesCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", func() {
 		var response *http.Response
 
@@ -0,0 +1,25 @@
+package pipelineserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ArchivePipeline(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("archive-pipeline", lager.Data{
+			"name": pipelineDB.GetPipelineName(),
+		})
+
+		err := pipelineDB.Archive()
+		if err != nil {
+			logger.Error("failed-to-archive-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,46 @@
+package pipelineserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+// ExportPipeline streams the pipeline's export as it is read. If it fails
+// once the export has started, the response is cut short, which fails to
+// parse when it is imported.
+func (s *Server) ExportPipeline(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("export-pipeline", lager.Data{
+			"name": pipelineDB.GetPipelineName(),
+		})
+
+		response := &exportResponse{ResponseWriter: w}
+
+		response.Header().Set("Content-Type", "application/json")
+
+		err := pipelineDB.Export(response, r.URL.Query().Get("events") == "true")
+		if err != nil {
+			logger.Error("failed-to-export-pipeline", err)
+
+			if !response.started {
+				response.Header().Del("Content-Type")
+				response.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	})
+}
+
+// exportResponse records whether any of the export has been written, after
+// which its status can no longer be changed.
+type exportResponse struct {
+	http.ResponseWriter
+
+	started bool
+}
+
+func (response *exportResponse) Write(p []byte) (int, error) {
+	response.started = true
+	return response.ResponseWriter.Write(p)
+}
@@ -0,0 +1,235 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/graph"
+)
+
+func (s *Server) GetPipelineGraph(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		savedPipeline := pipelineDB.Pipeline()
+
+		logger := s.logger.Session("get-pipeline-graph", lager.Data{
+			"team":     savedPipeline.TeamName,
+			"pipeline": savedPipeline.Name,
+		})
+
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "dot" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unknown format '%s'", format)
+			return
+		}
+
+		pipeline := graph.Pipeline{
+			TeamName: savedPipeline.TeamName,
+			Name:     savedPipeline.Name,
+			Config:   pipelineDB.Config(),
+			Groups:   r.URL.Query()["group"],
+		}
+
+		pipelineDBs := map[string]db.PipelineDB{
+			pipeline.Name: pipelineDB,
+		}
+
+		pipelines := []graph.Pipeline{pipeline}
+		candidates := []graph.Pipeline{}
+
+		// the graph only depends on the configs it is built from, so it is
+		// cached until one of them changes
+		span := r.URL.Query().Get("span")
+		keyParts := []string{
+			fmt.Sprintf("span=%q", span),
+			fmt.Sprintf("groups=%q", pipeline.Groups),
+			fmt.Sprintf("%q@%d", pipeline.Name, pipelineDB.ConfigVersion()),
+		}
+
+		if span == "team" {
+			teamDB := s.teamDBFactory.GetTeamDB(savedPipeline.TeamName)
+
+			var teamPipelines []db.SavedPipeline
+			var err error
+
+			authTeam, authTeamFound := auth.GetTeam(r)
+			if authTeamFound && authTeam.IsAuthorized(savedPipeline.TeamName) {
+				teamPipelines, err = teamDB.GetPipelines()
+			} else {
+				teamPipelines, err = teamDB.GetPublicPipelines()
+			}
+
+			if err != nil {
+				logger.Error("failed-to-get-team-pipelines", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			for _, teamPipeline := range teamPipelines {
+				if teamPipeline.Name == pipeline.Name || teamPipeline.Archived {
+					continue
+				}
+
+				candidates = append(candidates, graph.Pipeline{
+					TeamName: teamPipeline.TeamName,
+					Name:     teamPipeline.Name,
+					Config:   teamPipeline.Config,
+				})
+
+				keyParts = append(keyParts, fmt.Sprintf("%q@%d", teamPipeline.Name, teamPipeline.Version))
+
+				pipelineDBs[teamPipeline.Name] = s.pipelineDBFactory.Build(teamPipeline)
+			}
+		}
+
+		key := strings.Join(keyParts, " ")
+
+		pipelineGraph, found := s.graphs.get(savedPipeline.ID, key)
+		if !found {
+			if span == "team" {
+				pipelines = graph.Sharing(pipeline, candidates)
+			}
+
+			pipelineGraph = graph.Build(pipelines)
+
+			s.graphs.put(savedPipeline.ID, key, pipelineGraph)
+		}
+
+		err := annotateGraph(pipelineGraph, pipelineDBs)
+		if err != nil {
+			logger.Error("failed-to-get-statuses", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if format == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			fmt.Fprint(w, graph.DOT(pipelineGraph))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pipelineGraph)
+	})
+}
+
+// graphCache holds the graph last built for each pipeline, along with the key
+// identifying the configs, groups and span it was built from.
+type graphCache struct {
+	lock   sync.Mutex
+	graphs map[int]cachedGraph
+}
+
+type cachedGraph struct {
+	key   string
+	graph atc.PipelineGraph
+}
+
+func newGraphCache() *graphCache {
+	return &graphCache{
+		graphs: map[int]cachedGraph{},
+	}
+}
+
+// get returns a copy of the pipeline's cached graph if it was built for the
+// key, which can be annotated without changing the cached graph.
+func (cache *graphCache) get(pipelineID int, key string) (atc.PipelineGraph, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cached, found := cache.graphs[pipelineID]
+	if !found || cached.key != key {
+		return atc.PipelineGraph{}, false
+	}
+
+	return atc.PipelineGraph{
+		Pipelines: append([]atc.GraphPipeline{}, cached.graph.Pipelines...),
+		Nodes:     append([]atc.GraphNode{}, cached.graph.Nodes...),
+		Edges:     cached.graph.Edges,
+	}, true
+}
+
+func (cache *graphCache) put(pipelineID int, key string, pipelineGraph atc.PipelineGraph) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.graphs[pipelineID] = cachedGraph{
+		key: key,
+		graph: atc.PipelineGraph{
+			Pipelines: append([]atc.GraphPipeline{}, pipelineGraph.Pipelines...),
+			Nodes:     append([]atc.GraphNode{}, pipelineGraph.Nodes...),
+			Edges:     pipelineGraph.Edges,
+		},
+	}
+}
+
+// annotateGraph fills in the current state of the graph's pipelines, jobs and
+// resources.
+func annotateGraph(pipelineGraph atc.PipelineGraph, pipelineDBs map[string]db.PipelineDB) error {
+	jobNodes := map[string]*atc.GraphNode{}
+	resourceNodes := map[string]*atc.GraphNode{}
+
+	for i, node := range pipelineGraph.Nodes {
+		switch node.Type {
+		case atc.GraphNodeJob:
+			jobNodes[node.ID] = &pipelineGraph.Nodes[i]
+		case atc.GraphNodeResource:
+			resourceNodes[node.ID] = &pipelineGraph.Nodes[i]
+		}
+	}
+
+	for i, pipeline := range pipelineGraph.Pipelines {
+		pipelineDB := pipelineDBs[pipeline.Name]
+
+		pipelineGraph.Pipelines[i].Paused = pipelineDB.Pipeline().Paused
+
+		dashboard, _, err := pipelineDB.GetDashboard()
+		if err != nil {
+			return err
+		}
+
+		for _, dashboardJob := range dashboard {
+			node, found := jobNodes[graph.JobNodeID(pipeline.Name, dashboardJob.Job.Name)]
+			if !found {
+				continue
+			}
+
+			node.Paused = dashboardJob.Job.Paused
+
+			if dashboardJob.FinishedBuild != nil {
+				node.Status = atc.BuildStatus(dashboardJob.FinishedBuild.Status())
+			}
+
+			if dashboardJob.NextBuild != nil {
+				node.NextBuildStatus = atc.BuildStatus(dashboardJob.NextBuild.Status())
+			}
+		}
+
+		resources, _, err := pipelineDB.GetResources()
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources {
+			node, found := resourceNodes[graph.ResourceNodeID(pipeline.Name, resource.Name)]
+			if !found {
+				continue
+			}
+
+			node.Paused = resource.Paused
+
+			if resource.CheckError != nil {
+				node.CheckError = resource.CheckError.Error()
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ImportPipeline(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	pipelineName := r.FormValue(":pipeline_name")
+
+	logger := s.logger.Session("import-pipeline", lager.Data{
+		"team": teamName,
+		"name": pipelineName,
+	})
+
+	var export atc.PipelineExport
+	err := json.NewDecoder(r.Body).Decode(&export)
+	if err != nil {
+		logger.Error("invalid-json", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	export.Name = pipelineName
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, err = teamDB.ImportPipeline(export)
+	if err != nil {
+		if err == db.ErrPipelineAlreadyExists {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		if invalidErr, ok := err.(db.InvalidExportError); ok {
+			logger.Info("invalid-export", lager.Data{"errors": invalidErr.Errors})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, invalidErr.Error())
+			return
+		}
+
+		logger.Error("failed-to-import-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
@@ -1,6 +1,7 @@
 package pipelineserver
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/atc/auth"
@@ -47,3 +48,19 @@ func (pdbh *ScopedHandlerFactory) HandlerFor(pipelineScopedHandler func(db.Pipel
 		pipelineScopedHandler(pipelineDB).ServeHTTP(w, r)
 	}
 }
+
+// WritableHandlerFor is like HandlerFor, but for handlers which modify the
+// pipeline. Archived pipelines are read-only, so requests to modify them are
+// rejected with 409 Conflict.
+func (pdbh *ScopedHandlerFactory) WritableHandlerFor(pipelineScopedHandler func(db.PipelineDB) http.Handler) http.HandlerFunc {
+	return pdbh.HandlerFor(func(pipelineDB db.PipelineDB) http.Handler {
+		if pipelineDB.Pipeline().Archived {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "pipeline '%s' is archived", pipelineDB.GetPipelineName())
+			})
+		}
+
+		return pipelineScopedHandler(pipelineDB)
+	})
+}
@@ -107,6 +107,34 @@ var _ = Describe("Handler", func() {
 			})
 		})
 	})
+
+	Context("when the handler modifies the pipeline", func() {
+		BeforeEach(func() {
+			handlerFactory := pipelineserver.NewScopedHandlerFactory(new(dbfakes.FakePipelineDBFactory), teamDBFactory)
+			handler = &wrapHandler{handlerFactory.WritableHandlerFor(delegate.GetHandler), pipelineDB}
+		})
+
+		Context("when the pipeline is archived", func() {
+			BeforeEach(func() {
+				pipelineDB.PipelineReturns(db.SavedPipeline{Archived: true})
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+
+			It("does not call the scoped handler", func() {
+				Expect(delegate.IsCalled).To(BeFalse())
+			})
+		})
+
+		Context("when the pipeline is not archived", func() {
+			It("calls the scoped handler", func() {
+				Expect(delegate.IsCalled).To(BeTrue())
+				Expect(delegate.PipelineDB).To(BeIdenticalTo(pipelineDB))
+			})
+		})
+	})
 })
 
 type delegateHandler struct {
@@ -7,21 +7,26 @@ import (
 )
 
 type Server struct {
-	logger        lager.Logger
-	teamDBFactory db.TeamDBFactory
-	rejector      auth.Rejector
-	pipelinesDB   db.PipelinesDB
+	logger            lager.Logger
+	teamDBFactory     db.TeamDBFactory
+	pipelineDBFactory db.PipelineDBFactory
+	rejector          auth.Rejector
+	pipelinesDB       db.PipelinesDB
+	graphs            *graphCache
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamDBFactory db.TeamDBFactory,
+	pipelineDBFactory db.PipelineDBFactory,
 	pipelinesDB db.PipelinesDB,
 ) *Server {
 	return &Server{
-		logger:        logger,
-		teamDBFactory: teamDBFactory,
-		rejector:      auth.UnauthorizedRejector{},
-		pipelinesDB:   pipelinesDB,
+		logger:            logger,
+		teamDBFactory:     teamDBFactory,
+		pipelineDBFactory: pipelineDBFactory,
+		rejector:          auth.UnauthorizedRejector{},
+		pipelinesDB:       pipelinesDB,
+		graphs:            newGraphCache(),
 	}
 }
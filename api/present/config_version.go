@@ -0,0 +1,21 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func ConfigVersion(configVersion db.PipelineConfigVersion, withConfig bool) atc.ConfigVersion {
+	presented := atc.ConfigVersion{
+		Version: int(configVersion.Version),
+		SavedBy: configVersion.SavedBy,
+		SavedAt: configVersion.SavedAt.Unix(),
+	}
+
+	if withConfig {
+		config := configVersion.Config
+		presented.Config = &config
+	}
+
+	return presented
+}
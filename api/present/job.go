@@ -1,8 +1,12 @@
 package present
 
 import (
+	"sort"
+	"time"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/cron"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
 	"github.com/tedsuo/rata"
@@ -69,6 +73,21 @@ func Job(
 		})
 	}
 
+	var nextScheduledTimes []int64
+	now := time.Now()
+	for _, scheduleConfig := range job.Config.Schedule {
+		schedule, err := cron.Parse(scheduleConfig.Cron, scheduleConfig.Location)
+		if err != nil {
+			continue
+		}
+
+		if next := schedule.Next(now); !next.IsZero() {
+			nextScheduledTimes = append(nextScheduledTimes, next.Unix())
+		}
+	}
+
+	sort.Sort(int64Slice(nextScheduledTimes))
+
 	return atc.Job{
 		Name:                 job.Name,
 		URL:                  req.URL.String(),
@@ -82,5 +101,13 @@ func Job(
 		Outputs: sanitizedOutputs,
 
 		Groups: groupNames,
+
+		NextScheduledTimes: nextScheduledTimes,
 	}
 }
+
+type int64Slice []int64
+
+func (s int64Slice) Len() int           { return len(s) }
+func (s int64Slice) Less(i, j int) bool { return s[i] < s[j] }
+func (s int64Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
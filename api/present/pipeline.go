@@ -23,6 +23,7 @@ func Pipeline(savedPipeline db.SavedPipeline) atc.Pipeline {
 		URL:      pathForRoute,
 		Paused:   savedPipeline.Paused,
 		Public:   savedPipeline.Public,
+		Archived: savedPipeline.Archived,
 		Groups:   savedPipeline.Config.Groups,
 	}
 }
@@ -43,7 +43,8 @@ func Resource(resource db.SavedResource, groups atc.GroupConfigs, showCheckError
 		Groups: groupNames,
 		URL:    req.URL.String(),
 
-		Paused: resource.Paused,
+		Paused:        resource.Paused,
+		PinnedVersion: atc.Version(resource.PinnedVersion),
 
 		FailingToCheck: resource.FailingToCheck(),
 		CheckError:     checkErrString,
@@ -365,6 +365,37 @@ var _ = Describe("Resources API", func() {
 							}`))
 				})
 			})
+
+			Context("when the resource has a pinned version", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceReturns(db.SavedResource{
+						ID:              1,
+						PipelineName:    "a-pipeline",
+						PinnedVersionID: 42,
+						PinnedVersion:   db.Version{"ref": "abcdef"},
+						Resource: db.Resource{
+							Name: "resource-1",
+						},
+						Config: atc.ResourceConfig{
+							Type: "type-1",
+						},
+					}, true, nil)
+				})
+
+				It("returns the resource json with the pinned version", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"type": "type-1",
+								"groups": [],
+								"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-1",
+								"pinned_version": {"ref": "abcdef"}
+							}`))
+				})
+			})
 		})
 	})
 
@@ -704,4 +735,205 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var fakeScanner *radarfakes.FakeScanner
+		var webhookToken string
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			webhookToken = "some-token"
+
+			fakePipelineDB.GetResourceReturns(db.SavedResource{
+				Resource: db.Resource{Name: "resource-name"},
+				Config: atc.ResourceConfig{
+					Name:         "resource-name",
+					WebhookToken: "some-token",
+				},
+			}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token="+webhookToken, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("looks up the resource", func() {
+			Expect(fakePipelineDB.GetResourceCallCount()).To(Equal(1))
+			Expect(fakePipelineDB.GetResourceArgsForCall(0)).To(Equal("resource-name"))
+		})
+
+		It("tries to scan with no version specified", func() {
+			Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+			_, actualResourceName, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+			Expect(actualResourceName).To(Equal("resource-name"))
+			Expect(actualFromVersion).To(BeNil())
+		})
+
+		It("returns 200 without authentication", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		Context("when the resource already has versions", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetLatestVersionedResourceReturns(db.SavedVersionedResource{
+					VersionedResource: db.VersionedResource{
+						Resource: "resource-name",
+						Version:  db.Version{"some": "version"},
+					},
+				}, true, nil)
+			})
+
+			It("tries to scan from the latest version", func() {
+				Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				_, _, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+				Expect(actualFromVersion).To(Equal(atc.Version{"some": "version"}))
+			})
+		})
+
+		Context("when the webhook token is wrong", func() {
+			BeforeEach(func() {
+				webhookToken = "some-other-token"
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not scan", func() {
+				Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the webhook token is missing", func() {
+			BeforeEach(func() {
+				webhookToken = ""
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+
+			It("does not scan", func() {
+				Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the webhook token is a credential", func() {
+			BeforeEach(func() {
+				fakePipelineDB.TeamNameReturns("a-team")
+				fakePipelineDB.GetPipelineNameReturns("a-pipeline")
+
+				fakePipelineDB.GetResourceReturns(db.SavedResource{
+					Resource: db.Resource{Name: "resource-name"},
+					Config: atc.ResourceConfig{
+						Name:         "resource-name",
+						WebhookToken: "((webhook-token))",
+					},
+				}, true, nil)
+			})
+
+			Context("when the credential is found", func() {
+				BeforeEach(func() {
+					fakeCredentialManager.GetReturns("some-token", true, nil)
+				})
+
+				It("looks it up for the pipeline", func() {
+					Expect(fakeCredentialManager.GetCallCount()).To(Equal(1))
+					teamName, pipelineName, varName := fakeCredentialManager.GetArgsForCall(0)
+					Expect(teamName).To(Equal("a-team"))
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(varName).To(Equal("webhook-token"))
+				})
+
+				It("compares the given token with its value", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				})
+
+				Context("when the given token is the placeholder", func() {
+					BeforeEach(func() {
+						webhookToken = "((webhook-token))"
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+			})
+
+			Context("when the credential is not found", func() {
+				BeforeEach(func() {
+					fakeCredentialManager.GetReturns(nil, false, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when looking up the credential fails", func() {
+				BeforeEach(func() {
+					fakeCredentialManager.GetReturns(nil, false, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the resource has no webhook token configured", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetResourceReturns(db.SavedResource{
+					Resource: db.Resource{Name: "resource-name"},
+					Config:   atc.ResourceConfig{Name: "resource-name"},
+				}, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when the resource cannot be found", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetResourceReturns(db.SavedResource{}, false, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when looking up the resource fails", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetResourceReturns(db.SavedResource{}, false, errors.New("disaster"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when checking the resource fails", func() {
+			BeforeEach(func() {
+				fakeScanner.ScanFromVersionReturns(errors.New("welp"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
 })
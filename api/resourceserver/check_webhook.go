@@ -0,0 +1,93 @@
+package resourceserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) CheckResourceWebHook(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("check-resource-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		webhookToken := r.URL.Query().Get("webhook_token")
+
+		if webhookToken == "" {
+			logger.Info("no-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resource, found, err := pipelineDB.GetResource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err, lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// respond the same way for a missing resource as for a bad token, so
+		// that resource names of private pipelines are not revealed
+		if !found {
+			logger.Info("invalid-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		variables := creds.NewVariables(s.credentialManager, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
+
+		configuredToken, err := creds.EvaluateString(variables, resource.Config.WebhookToken)
+		if _, undefined := err.(creds.UndefinedVariablesError); undefined {
+			// a token which cannot be evaluated cannot be matched
+			logger.Error("failed-to-evaluate-webhook-token", err, lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			logger.Error("failed-to-evaluate-webhook-token", err, lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !tokenMatches(configuredToken, webhookToken) {
+			logger.Info("invalid-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var fromVersion atc.Version
+		latestVersion, found, err := pipelineDB.GetLatestVersionedResource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-latest-versioned-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if found {
+			fromVersion = atc.Version(latestVersion.Version)
+		}
+
+		scanner := s.scannerFactory.NewResourceScanner(pipelineDB)
+
+		err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
+		if err != nil {
+			logger.Error("failed-to-scan-from-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func tokenMatches(configured string, given string) bool {
+	if configured == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(configured), []byte(given)) == 1
+}
@@ -2,6 +2,7 @@ package resourceserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/radar"
 )
 
@@ -12,13 +13,19 @@ type ScannerFactory interface {
 }
 
 type Server struct {
-	logger         lager.Logger
-	scannerFactory ScannerFactory
+	logger            lager.Logger
+	scannerFactory    ScannerFactory
+	credentialManager creds.CredentialManager
 }
 
-func NewServer(logger lager.Logger, scannerFactory ScannerFactory) *Server {
+func NewServer(
+	logger lager.Logger,
+	scannerFactory ScannerFactory,
+	credentialManager creds.CredentialManager,
+) *Server {
 	return &Server{
-		logger:         logger,
-		scannerFactory: scannerFactory,
+		logger:            logger,
+		scannerFactory:    scannerFactory,
+		credentialManager: credentialManager,
 	}
 }
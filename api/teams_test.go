@@ -237,6 +237,22 @@ var _ = Describe("Teams API", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
 						})
 					})
+
+					Context("Role is not a known role", func() {
+						BeforeEach(func() {
+							team = atc.Team{
+								BasicAuth: &atc.BasicAuth{
+									BasicAuthUsername: "Hank Venture",
+									BasicAuthPassword: "Brock Samson",
+									Role:              "henchman",
+								},
+							}
+						})
+
+						It("returns a 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
 				})
 
 				Describe("GitHub authenticaiton", func() {
@@ -268,6 +284,25 @@ var _ = Describe("Teams API", func() {
 						})
 					})
 
+					Context("a role is not a known role", func() {
+						BeforeEach(func() {
+							team = atc.Team{
+								GitHubAuth: &atc.GitHubAuth{
+									ClientID:      "Brock Samson",
+									ClientSecret:  "09262-8765-001",
+									Organizations: []string{"Venture Industries"},
+									Roles: []atc.GitHubRole{
+										{Role: "henchman", Users: []string{"Henchman 21"}},
+									},
+								},
+							}
+						})
+
+						It("returns a 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
 					Context("require at least one org, org/team, or username", func() {
 						Context("when all are missing", func() {
 							BeforeEach(func() {
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
@@ -110,6 +112,10 @@ func (s *Server) validate(team db.Team) error {
 		if team.BasicAuth.BasicAuthUsername == "" || team.BasicAuth.BasicAuthPassword == "" {
 			return errors.New("basic auth missing BasicAuthUsername or BasicAuthPassword")
 		}
+
+		if team.BasicAuth.Role != "" && !team.BasicAuth.Role.IsValid() {
+			return errInvalidRole(team.BasicAuth.Role)
+		}
 	}
 
 	if team.GitHubAuth != nil {
@@ -122,6 +128,12 @@ func (s *Server) validate(team db.Team) error {
 			len(team.GitHubAuth.Users) == 0 {
 			return errors.New("GitHub auth requires at least one Organization, Team, or User")
 		}
+
+		for _, role := range team.GitHubAuth.Roles {
+			if !role.Role.IsValid() {
+				return errInvalidRole(role.Role)
+			}
+		}
 	}
 
 	if team.UAAAuth != nil {
@@ -150,6 +162,12 @@ func (s *Server) validate(team db.Team) error {
 		if team.UAAAuth.AuthURL == "" || team.UAAAuth.TokenURL == "" || team.UAAAuth.CFURL == "" {
 			return errors.New("CF auth requires AuthURL, TokenURL and APIURL")
 		}
+
+		for _, role := range team.UAAAuth.Roles {
+			if !role.Role.IsValid() {
+				return errInvalidRole(role.Role)
+			}
+		}
 	}
 
 	if team.GenericOAuth != nil {
@@ -164,7 +182,21 @@ func (s *Server) validate(team db.Team) error {
 		if team.GenericOAuth.DisplayName == "" {
 			return errors.New("Generic OAuth requires a Display Name")
 		}
+
+		for _, role := range team.GenericOAuth.Roles {
+			if !role.Role.IsValid() {
+				return errInvalidRole(role.Role)
+			}
+
+			if role.Scope == "" {
+				return errors.New("Generic OAuth roles require a Scope")
+			}
+		}
 	}
 
 	return nil
 }
+
+func errInvalidRole(role atc.Role) error {
+	return fmt.Errorf("invalid role '%s'", role)
+}
@@ -0,0 +1,31 @@
+package atccmd
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+)
+
+type CipherFlag struct {
+	cipher.AEAD
+}
+
+func (flag *CipherFlag) UnmarshalFlag(val string) error {
+	if len(val) != 32 {
+		return fmt.Errorf("key must be exactly 32 bytes long, got %d", len(val))
+	}
+
+	block, err := aes.NewCipher([]byte(val))
+	if err != nil {
+		return err
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	flag.AEAD = aesgcm
+
+	return nil
+}
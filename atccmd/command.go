@@ -23,6 +23,9 @@ import (
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/creds"
+	credsfile "github.com/concourse/atc/creds/file"
+	"github.com/concourse/atc/creds/vault"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/engine"
@@ -72,17 +75,40 @@ type ATCCommand struct {
 
 	PostgresDataSource string `long:"postgres-data-source" default:"postgres://127.0.0.1:5432/atc?sslmode=disable" description:"PostgreSQL connection string."`
 
+	EncryptionKey    CipherFlag `long:"encryption-key"     description:"A 32-byte key used to encrypt sensitive data (pipeline configs, resource metadata, build plans) before storing it in the database."`
+	OldEncryptionKey CipherFlag `long:"old-encryption-key" description:"The previous encryption key. On startup, all data encrypted with this key is re-encrypted with --encryption-key."`
+
 	DebugBindIP   IPFlag `long:"debug-bind-ip"   default:"127.0.0.1" description:"IP address on which to listen for the pprof debugger endpoints."`
 	DebugBindPort uint16 `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
 
 	SessionSigningKey FileFlag `long:"session-signing-key" description:"File containing an RSA private key, used to sign session tokens."`
 
-	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
-	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
-	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
+	ResourceCheckingInterval            time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
+	ResourceWithWebhookCheckingInterval time.Duration `long:"resource-with-webhook-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources that have a webhook token configured."`
+	OldResourceGracePeriod              time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
+	ResourceCacheCleanupInterval        time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
+
+	ContainerPlacementStrategy string `long:"container-placement-strategy" default:"volume-locality" choice:"random" choice:"fewest-active-containers" choice:"volume-locality" description:"Method by which a worker is selected for a container. The default prefers the worker already holding the most of its inputs, falling back to the one running the fewest containers."`
 
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	BuildLogRetention struct {
+		DefaultBuilds  int `long:"default-builds"  description:"Default number of builds of a job to retain the logs of, if the job does not configure it."`
+		DefaultDays    int `long:"default-days"    description:"Default number of days to retain the logs of a job's builds for, if the job does not configure it."`
+		MaxBuilds      int `long:"max-builds"      description:"Maximum number of builds of a job to retain the logs of, regardless of the job's configuration."`
+		MaxDays        int `long:"max-days"        description:"Maximum number of days to retain the logs of a job's builds for, regardless of the job's configuration."`
+		ArchivedBuilds int `long:"archived-builds" description:"Number of builds of each job of an archived pipeline to retain the logs of. By default, they are retained forever."`
+		ArchivedDays   int `long:"archived-days"   description:"Number of days to retain the logs of an archived pipeline's builds for. By default, they are retained forever."`
+		OneOffDays     int `long:"one-off-days"    description:"Number of days to retain the logs of one-off builds for. By default, they are retained forever."`
+	} `group:"Build Log Retention" namespace:"build-log-retention"`
+
+	TaskLimits struct {
+		DefaultCPU    uint64 `long:"default-cpu"    description:"Default CPU shares of a task's container, if the task does not configure it. By default, there is no limit."`
+		DefaultMemory uint64 `long:"default-memory" description:"Default memory limit of a task's container in bytes, if the task does not configure it. By default, there is no limit."`
+		MaxCPU        uint64 `long:"max-cpu"        description:"Maximum CPU shares of a task's container, regardless of the task's configuration."`
+		MaxMemory     uint64 `long:"max-memory"     description:"Maximum memory limit of a task's container in bytes, regardless of the task's configuration."`
+	} `group:"Task Container Limits" namespace:"task-limits"`
+
 	Developer struct {
 		DevelopmentMode bool `short:"d" long:"development-mode"  description:"Lax security rules to make local development easier."`
 		Noop            bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
@@ -115,8 +141,18 @@ type ATCCommand struct {
 		RiemannHost          string `long:"riemann-host"                description:"Riemann server address to emit metrics to."`
 		RiemannPort          uint16 `long:"riemann-port" default:"5555" description:"Port of the Riemann server to emit metrics to."`
 		RiemannServicePrefix string `long:"riemann-service-prefix" default:"" description:"An optional prefix for emitted Riemann services"`
+
+		Prometheus bool `long:"prometheus" description:"Expose Prometheus metrics at /metrics on the debug bind address."`
 	} `group:"Metrics & Diagnostics"`
 
+	Credentials struct {
+		File FileFlag `long:"file" description:"YAML file containing credentials for each team and pipeline."`
+
+		VaultURL        URLFlag `long:"vault-url"         description:"Vault server address used to look up credentials."`
+		VaultToken      string  `long:"vault-token"       description:"Vault token used to authenticate with the Vault server."`
+		VaultPathPrefix string  `long:"vault-path-prefix" default:"/concourse" description:"Path under which to look up team and pipeline credentials in Vault."`
+	} `group:"Credential Management" namespace:"credentials"`
+
 	LogDBQueries bool `long:"log-db-queries" description:"Log database queries."`
 }
 
@@ -139,9 +175,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
-	if cmd.Metrics.RiemannHost != "" {
-		cmd.configureMetrics(logger)
-	}
+	cmd.configureMetrics(logger)
 
 	dbConn, err := cmd.constructDBConn(logger)
 	if err != nil {
@@ -164,23 +198,36 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	trackerFactory := resource.NewTrackerFactory()
 	resourceFetcherFactory := resource.NewFetcherFactory(sqlDB, clock.NewClock())
 	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
-	workerClient := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory, pipelineDBFactory)
+	workerClient, err := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory, pipelineDBFactory)
+	if err != nil {
+		return nil, err
+	}
 
 	tracker := trackerFactory.TrackerFor(workerClient)
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
-	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory)
+
+	credentialManager, err := cmd.constructCredentialManager()
+	if err != nil {
+		return nil, err
+	}
+
+	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory, credentialManager)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
+		cmd.ResourceWithWebhookCheckingInterval,
 		engine,
+		credentialManager,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
+		cmd.ResourceWithWebhookCheckingInterval,
 		cmd.ExternalURL.String(),
+		credentialManager,
 	)
 
 	signingKey, err := cmd.loadOrGenerateSigningKey()
@@ -223,6 +270,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		drain,
 		radarSchedulerFactory,
 		radarScannerFactory,
+		credentialManager,
 	)
 
 	if err != nil {
@@ -358,6 +406,20 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				sqlDB,
 				pipelineDBFactory,
 				500,
+				clock.NewClock(),
+				atc.BuildLogRetention{
+					Builds: cmd.BuildLogRetention.DefaultBuilds,
+					Days:   cmd.BuildLogRetention.DefaultDays,
+				},
+				atc.BuildLogRetention{
+					Builds: cmd.BuildLogRetention.MaxBuilds,
+					Days:   cmd.BuildLogRetention.MaxDays,
+				},
+				atc.BuildLogRetention{
+					Builds: cmd.BuildLogRetention.ArchivedBuilds,
+					Days:   cmd.BuildLogRetention.ArchivedDays,
+				},
+				cmd.BuildLogRetention.OneOffDays,
 			),
 			"build-reaper",
 			sqlDB,
@@ -522,6 +584,13 @@ func (cmd *ATCCommand) validate() error {
 		)
 	}
 
+	if cmd.Credentials.File != "" && cmd.Credentials.VaultURL.URL() != nil {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --credentials-file or --credentials-vault-url"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -574,13 +643,34 @@ func (cmd *ATCCommand) configureMetrics(logger lager.Logger) {
 		host, _ = os.Hostname()
 	}
 
+	emitters := []metric.Emitter{}
+
+	if cmd.Metrics.RiemannHost != "" {
+		emitters = append(emitters, metric.NewRiemannEmitter(
+			fmt.Sprintf("%s:%d", cmd.Metrics.RiemannHost, cmd.Metrics.RiemannPort),
+			cmd.Metrics.RiemannServicePrefix,
+		))
+	}
+
+	if cmd.Metrics.Prometheus {
+		prometheusEmitter := metric.NewPrometheusEmitter()
+
+		// served by the debug server, alongside pprof
+		http.Handle("/metrics", prometheusEmitter.Handler())
+
+		emitters = append(emitters, prometheusEmitter)
+	}
+
+	if len(emitters) == 0 {
+		return
+	}
+
 	metric.Initialize(
 		logger.Session("metrics"),
-		fmt.Sprintf("%s:%d", cmd.Metrics.RiemannHost, cmd.Metrics.RiemannPort),
 		host,
 		cmd.Metrics.Tags,
 		cmd.Metrics.Attributes,
-		cmd.Metrics.RiemannServicePrefix,
+		emitters,
 	)
 }
 
@@ -595,18 +685,43 @@ func (cmd *ATCCommand) constructDBConn(logger lager.Logger) (db.Conn, error) {
 
 	dbConn.SetMaxOpenConns(64)
 
+	var strategy db.EncryptionStrategy = db.NoEncryption{}
+	if cmd.EncryptionKey.AEAD != nil {
+		strategy = db.NewAESGCMEncryption(cmd.EncryptionKey.AEAD)
+	}
+
+	var oldStrategy db.EncryptionStrategy
+	if cmd.OldEncryptionKey.AEAD != nil {
+		oldStrategy = db.NewAESGCMEncryption(cmd.OldEncryptionKey.AEAD)
+	}
+
+	dbConn = db.WithEncryption(dbConn, strategy)
+
+	err = db.EncryptionRotator{
+		Logger:      logger.Session("encryption-rotator"),
+		Conn:        dbConn,
+		OldStrategy: oldStrategy,
+	}.Rotate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate encryption key: %s", err)
+	}
+
 	return metric.CountQueries(dbConn), nil
 }
 
 func (cmd *ATCCommand) constructLockConn() (*db.RetryableConn, error) {
+	return constructLockConn(cmd.PostgresDataSource)
+}
+
+func constructLockConn(dataSource string) (*db.RetryableConn, error) {
 	var pgxConfig pgx.ConnConfig
 	var err error
 
-	if strings.HasPrefix(cmd.PostgresDataSource, "postgres://") ||
-		strings.HasPrefix(cmd.PostgresDataSource, "postgresql://") {
-		pgxConfig, err = pgx.ParseURI(cmd.PostgresDataSource)
+	if strings.HasPrefix(dataSource, "postgres://") ||
+		strings.HasPrefix(dataSource, "postgresql://") {
+		pgxConfig, err = pgx.ParseURI(dataSource)
 	} else {
-		pgxConfig, err = pgx.ParseDSN(cmd.PostgresDataSource)
+		pgxConfig, err = pgx.ParseDSN(dataSource)
 	}
 
 	if err != nil {
@@ -629,8 +744,14 @@ func (cmd *ATCCommand) constructWorkerPool(
 	trackerFactory resource.TrackerFactory,
 	resourceFetcherFactory resource.FetcherFactory,
 	pipelineDBFactory db.PipelineDBFactory,
-) worker.Client {
+) (worker.Client, error) {
+	strategy, err := worker.NewContainerPlacementStrategy(cmd.ContainerPlacementStrategy)
+	if err != nil {
+		return nil, err
+	}
+
 	return worker.NewPool(
+		logger.Session("worker-pool"),
 		worker.NewDBWorkerProvider(
 			logger,
 			sqlDB,
@@ -639,7 +760,8 @@ func (cmd *ATCCommand) constructWorkerPool(
 			image.NewFactory(trackerFactory, resourceFetcherFactory),
 			pipelineDBFactory,
 		),
-	)
+		strategy,
+	), nil
 }
 
 func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
@@ -757,22 +879,49 @@ func (cmd *ATCCommand) configureAuthForDefaultTeam(teamDBFactory db.TeamDBFactor
 	return nil
 }
 
+func (cmd *ATCCommand) constructCredentialManager() (creds.CredentialManager, error) {
+	if cmd.Credentials.File != "" {
+		return credsfile.NewCredentialManager(string(cmd.Credentials.File))
+	}
+
+	if cmd.Credentials.VaultURL.URL() != nil {
+		return vault.NewCredentialManager(
+			cmd.Credentials.VaultURL.String(),
+			cmd.Credentials.VaultToken,
+			cmd.Credentials.VaultPathPrefix,
+		), nil
+	}
+
+	return creds.NoopCredentialManager{}, nil
+}
+
 func (cmd *ATCCommand) constructEngine(
 	workerClient worker.Client,
 	tracker resource.Tracker,
 	resourceFetcher resource.Fetcher,
 	teamDBFactory db.TeamDBFactory,
+	credentialManager creds.CredentialManager,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
 		tracker,
 		resourceFetcher,
+		teamDBFactory,
+		atc.ContainerLimits{
+			CPU:    cmd.TaskLimits.DefaultCPU,
+			Memory: cmd.TaskLimits.DefaultMemory,
+		},
+		atc.ContainerLimits{
+			CPU:    cmd.TaskLimits.MaxCPU,
+			Memory: cmd.TaskLimits.MaxMemory,
+		},
 	)
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
 		engine.NewBuildDelegateFactory(),
 		teamDBFactory,
+		credentialManager,
 		cmd.ExternalURL.String(),
 	)
 
@@ -816,6 +965,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 	drain <-chan struct{},
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
+	credentialManager creds.CredentialManager,
 ) (http.Handler, error) {
 	authValidator := auth.JWTValidator{
 		PublicKey: &signingKey.PublicKey,
@@ -874,6 +1024,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		workerClient,
 		radarSchedulerFactory,
 		radarScannerFactory,
+		credentialManager,
 
 		reconfigurableSink,
 
@@ -0,0 +1,150 @@
+package atccmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/migrations"
+	"github.com/lib/pq"
+)
+
+// PipelineDBFlags are the flags needed to reach a cluster's pipelines
+// directly through its database, for commands that run alongside the ATC.
+type PipelineDBFlags struct {
+	PostgresDataSource string     `long:"postgres-data-source" default:"postgres://127.0.0.1:5432/atc?sslmode=disable" description:"PostgreSQL connection string."`
+	EncryptionKey      CipherFlag `long:"encryption-key"       description:"The key the ATCs use to encrypt data in the database, if any."`
+
+	Team string `long:"team" default:"main" description:"Name of the team the pipeline belongs to."`
+}
+
+func (flags PipelineDBFlags) teamDB() (db.TeamDB, db.PipelineDBFactory, error) {
+	// the database belongs to the ATCs, so it is up to them to migrate it
+	dbConn, err := migrations.OpenWithoutMigrating("postgres", flags.PostgresDataSource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %s", err)
+	}
+
+	var strategy db.EncryptionStrategy = db.NoEncryption{}
+	if flags.EncryptionKey.AEAD != nil {
+		strategy = db.NewAESGCMEncryption(flags.EncryptionKey.AEAD)
+	}
+
+	dbConn = db.WithEncryption(dbConn, strategy)
+
+	lockConn, err := constructLockConn(flags.PostgresDataSource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lockFactory := db.NewLockFactory(lockConn)
+
+	listener := pq.NewListener(flags.PostgresDataSource, time.Second, time.Minute, nil)
+	bus := db.NewNotificationsBus(listener, dbConn)
+
+	teamDB := db.NewTeamDBFactory(dbConn, bus, lockFactory).GetTeamDB(flags.Team)
+	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+
+	return teamDB, pipelineDBFactory, nil
+}
+
+type ExportPipelineCommand struct {
+	PipelineDBFlags
+
+	Pipeline      string `long:"pipeline"       required:"true" description:"Name of the pipeline to export."`
+	IncludeEvents bool   `long:"include-events"                 description:"Include the events of each build, i.e. its logs."`
+	Output        string `long:"output"                         description:"File to write the export to. By default, it is written to stdout."`
+}
+
+func (cmd *ExportPipelineCommand) Execute(args []string) error {
+	logger := lager.NewLogger("export-pipeline")
+	logger.RegisterSink(lager.NewWriterSink(os.Stderr, lager.INFO))
+
+	teamDB, pipelineDBFactory, err := cmd.teamDB()
+	if err != nil {
+		return err
+	}
+
+	savedPipeline, found, err := teamDB.GetPipelineByName(cmd.Pipeline)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("pipeline '%s' not found in team '%s'", cmd.Pipeline, cmd.Team)
+	}
+
+	var output io.Writer = os.Stdout
+	if cmd.Output != "" {
+		file, err := os.Create(cmd.Output)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		output = file
+	}
+
+	// builds, and their events if included, are written as they are read
+	// rather than all at once
+	return pipelineDBFactory.Build(savedPipeline).Export(output, cmd.IncludeEvents)
+}
+
+type ImportPipelineCommand struct {
+	PipelineDBFlags
+
+	Pipeline string   `long:"pipeline" description:"Name to give the imported pipeline. By default, the name it was exported with is used."`
+	Input    FileFlag `long:"input"    description:"File to read the export from. By default, it is read from stdin."`
+}
+
+func (cmd *ImportPipelineCommand) Execute(args []string) error {
+	logger := lager.NewLogger("import-pipeline")
+	logger.RegisterSink(lager.NewWriterSink(os.Stderr, lager.INFO))
+
+	var input io.Reader = os.Stdin
+	if cmd.Input != "" {
+		file, err := os.Open(string(cmd.Input))
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		input = file
+	}
+
+	var export atc.PipelineExport
+	err := json.NewDecoder(input).Decode(&export)
+	if err != nil {
+		return fmt.Errorf("failed to parse export: %s", err)
+	}
+
+	if cmd.Pipeline != "" {
+		export.Name = cmd.Pipeline
+	}
+
+	teamDB, _, err := cmd.teamDB()
+	if err != nil {
+		return err
+	}
+
+	savedPipeline, err := teamDB.ImportPipeline(export)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("imported", lager.Data{
+		"team":     savedPipeline.TeamName,
+		"pipeline": savedPipeline.Name,
+		"versions": len(export.Versions),
+		"builds":   len(export.Builds),
+	})
+
+	return nil
+}
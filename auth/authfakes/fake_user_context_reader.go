@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 )
 
@@ -29,6 +30,24 @@ type FakeUserContextReader struct {
 		result1 bool
 		result2 bool
 	}
+	GetRoleStub        func(r *http.Request) (atc.Role, bool)
+	getRoleMutex       sync.RWMutex
+	getRoleArgsForCall []struct {
+		r *http.Request
+	}
+	getRoleReturns struct {
+		result1 atc.Role
+		result2 bool
+	}
+	GetUserNameStub        func(r *http.Request) (string, bool)
+	getUserNameMutex       sync.RWMutex
+	getUserNameArgsForCall []struct {
+		r *http.Request
+	}
+	getUserNameReturns struct {
+		result1 string
+		result2 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -103,6 +122,74 @@ func (fake *FakeUserContextReader) GetSystemReturns(result1 bool, result2 bool)
 	}{result1, result2}
 }
 
+func (fake *FakeUserContextReader) GetRole(r *http.Request) (atc.Role, bool) {
+	fake.getRoleMutex.Lock()
+	fake.getRoleArgsForCall = append(fake.getRoleArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetRole", []interface{}{r})
+	fake.getRoleMutex.Unlock()
+	if fake.GetRoleStub != nil {
+		return fake.GetRoleStub(r)
+	} else {
+		return fake.getRoleReturns.result1, fake.getRoleReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetRoleCallCount() int {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return len(fake.getRoleArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetRoleArgsForCall(i int) *http.Request {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return fake.getRoleArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetRoleReturns(result1 atc.Role, result2 bool) {
+	fake.GetRoleStub = nil
+	fake.getRoleReturns = struct {
+		result1 atc.Role
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetUserName(r *http.Request) (string, bool) {
+	fake.getUserNameMutex.Lock()
+	fake.getUserNameArgsForCall = append(fake.getUserNameArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetUserName", []interface{}{r})
+	fake.getUserNameMutex.Unlock()
+	if fake.GetUserNameStub != nil {
+		return fake.GetUserNameStub(r)
+	} else {
+		return fake.getUserNameReturns.result1, fake.getUserNameReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetUserNameCallCount() int {
+	fake.getUserNameMutex.RLock()
+	defer fake.getUserNameMutex.RUnlock()
+	return len(fake.getUserNameArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetUserNameArgsForCall(i int) *http.Request {
+	fake.getUserNameMutex.RLock()
+	defer fake.getUserNameMutex.RUnlock()
+	return fake.getUserNameArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetUserNameReturns(result1 string, result2 bool) {
+	fake.GetUserNameStub = nil
+	fake.getUserNameReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -110,6 +197,10 @@ func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	defer fake.getTeamMutex.RUnlock()
 	fake.getSystemMutex.RLock()
 	defer fake.getSystemMutex.RUnlock()
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	fake.getUserNameMutex.RLock()
+	defer fake.getUserNameMutex.RUnlock()
 	return fake.invocations
 }
 
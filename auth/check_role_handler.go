@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+type checkRoleHandler struct {
+	handler  http.Handler
+	role     atc.Role
+	rejector Rejector
+}
+
+// CheckRoleHandler rejects requests made by users who have not been granted
+// at least the given role within their team. Requests which are not made on
+// behalf of a team are left for the wrapped handler to authorize.
+func CheckRoleHandler(
+	handler http.Handler,
+	role atc.Role,
+	rejector Rejector,
+) http.Handler {
+	return checkRoleHandler{
+		handler:  handler,
+		role:     role,
+		rejector: rejector,
+	}
+}
+
+func (h checkRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authTeam, authTeamFound := GetTeam(r)
+	if IsAuthenticated(r) && authTeamFound && !authTeam.Role().Permits(h.role) {
+		h.rejector.Forbidden(w, r)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
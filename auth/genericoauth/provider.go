@@ -1,11 +1,15 @@
 package genericoauth
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
+	"github.com/dgrijalva/jwt-go"
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 )
@@ -35,8 +39,16 @@ func NewProvider(
 		oauthVerifier = NoopVerifier{}
 	}
 
+	grants := []verifier.RoleGrant{}
+	for _, role := range genericOAuth.Roles {
+		grants = append(grants, verifier.RoleGrant{
+			Role:     role.Role,
+			Verifier: NewScopeVerifier(role.Scope),
+		})
+	}
+
 	return Provider{
-		Verifier: oauthVerifier,
+		RoleVerifier: verifier.NewRoleBasket(oauthVerifier, grants...),
 		Config: ConfigOverride{
 			Config: oauth2.Config{
 				ClientID:     genericOAuth.ClientID,
@@ -50,7 +62,7 @@ func NewProvider(
 }
 
 type Provider struct {
-	verifier.Verifier
+	verifier.RoleVerifier
 	Config ConfigOverride
 }
 
@@ -90,3 +102,48 @@ func (Provider) PreTokenClient() (*http.Client, error) {
 		},
 	}, nil
 }
+
+type GenericOAuthUser struct {
+	UserName          string `json:"user_name"`
+	PreferredUserName string `json:"preferred_username"`
+	Subject           string `json:"sub"`
+}
+
+// UserName identifies the user by the claims in their access token. Access
+// tokens which are not JWTs do not identify anyone.
+func (Provider) UserName(logger lager.Logger, httpClient *http.Client) (string, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return "", errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return "", err
+	}
+
+	tokenParts := strings.Split(token.AccessToken, ".")
+	if len(tokenParts) < 2 {
+		logger.Info("access-token-is-opaque")
+		return "", nil
+	}
+
+	decodedClaims, err := jwt.DecodeSegment(tokenParts[1])
+	if err != nil {
+		return "", err
+	}
+
+	var user GenericOAuthUser
+	err = json.Unmarshal(decodedClaims, &user)
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range []string{user.UserName, user.PreferredUserName, user.Subject} {
+		if name != "" {
+			return name, nil
+		}
+	}
+
+	return "", nil
+}
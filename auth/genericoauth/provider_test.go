@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/db"
+	"github.com/dgrijalva/jwt-go"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -59,6 +60,52 @@ var _ = Describe("Generic OAuth Provider", func() {
 		Expect(verifyResult).To(Equal(true))
 	})
 
+	Describe("UserName", func() {
+		var accessToken string
+
+		userName := func() (string, error) {
+			c := &oauth2.Config{}
+			httpClient := c.Client(oauth2.NoContext, &oauth2.Token{AccessToken: accessToken})
+			return goaProvider.UserName(lagertest.NewTestLogger("test"), httpClient)
+		}
+
+		signedClaims := func(claims jwt.MapClaims) string {
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SigningString()
+			Expect(err).NotTo(HaveOccurred())
+			return token
+		}
+
+		Context("when the access token has a user_name claim", func() {
+			BeforeEach(func() {
+				accessToken = signedClaims(jwt.MapClaims{"user_name": "some-user", "sub": "some-subject"})
+			})
+
+			It("returns it", func() {
+				Expect(userName()).To(Equal("some-user"))
+			})
+		})
+
+		Context("when the access token only has a subject", func() {
+			BeforeEach(func() {
+				accessToken = signedClaims(jwt.MapClaims{"sub": "some-subject"})
+			})
+
+			It("returns the subject", func() {
+				Expect(userName()).To(Equal("some-subject"))
+			})
+		})
+
+		Context("when the access token is opaque", func() {
+			BeforeEach(func() {
+				accessToken = "some-opaque-token"
+			})
+
+			It("does not identify anyone", func() {
+				Expect(userName()).To(BeEmpty())
+			})
+		})
+	})
+
 	Context("Auth URL params are configured", func() {
 		BeforeEach(func() {
 			redirectURI = "redirect-uri"
@@ -1,18 +1,24 @@
 package auth
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
 
 type Team interface {
 	Name() string
 	ID() int
 	IsAdmin() bool
 	IsAuthorized(teamName string) bool
+	Role() atc.Role
 }
 
 type team struct {
 	name    string
 	teamID  int
 	isAdmin bool
+	role    atc.Role
 }
 
 func (t *team) Name() string {
@@ -31,6 +37,10 @@ func (t *team) IsAuthorized(teamName string) bool {
 	return t.name == teamName
 }
 
+func (t *team) Role() atc.Role {
+	return t.role
+}
+
 func GetTeam(r *http.Request) (Team, bool) {
 	teamName, namePresent := r.Context().Value(teamNameKey).(string)
 	teamID, teamIDPresent := r.Context().Value(teamIDKey).(int)
@@ -40,9 +50,17 @@ func GetTeam(r *http.Request) (Team, bool) {
 		return nil, false
 	}
 
+	// tokens without a role (e.g. those issued before roles were introduced)
+	// are only trusted to read; their holders must log in again for more
+	role, rolePresent := r.Context().Value(roleKey).(atc.Role)
+	if !rolePresent {
+		role = atc.RoleViewer
+	}
+
 	return &team{
 		name:    teamName,
 		teamID:  teamID,
 		isAdmin: isAdmin,
+		role:    role,
 	}, true
 }
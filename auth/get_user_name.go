@@ -0,0 +1,25 @@
+package auth
+
+import "net/http"
+
+// GetUserName returns the name of the user the request was made by, if their
+// token identifies one.
+func GetUserName(r *http.Request) (string, bool) {
+	userName, found := r.Context().Value(userNameKey).(string)
+	return userName, found
+}
+
+// BasicAuthProviderName qualifies the names of users who logged in with a
+// team's basic auth credentials.
+const BasicAuthProviderName = "basic"
+
+// QualifiedUserName identifies a user by the provider they logged in with and
+// the name it knows them by, e.g. 'github:some-user'. The same name may belong
+// to different people with different providers, so neither is enough alone.
+func QualifiedUserName(providerName string, userName string) string {
+	if userName == "" {
+		return ""
+	}
+
+	return providerName + ":" + userName
+}
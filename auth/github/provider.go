@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"golang.org/x/net/context"
@@ -21,7 +22,8 @@ type Provider interface {
 	PreTokenClient() (*http.Client, error)
 
 	OAuthClient
-	Verifier
+	RoleVerifier
+	UserIdentifier
 }
 
 type OAuthClient interface {
@@ -34,6 +36,16 @@ type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
 
+type RoleVerifier interface {
+	Verifier
+
+	VerifyRole(lager.Logger, *http.Client) (atc.Role, bool, error)
+}
+
+type UserIdentifier interface {
+	UserName(lager.Logger, *http.Client) (string, error)
+}
+
 func NewProvider(
 	gitHubAuth *db.GitHubAuth,
 	redirectURL string,
@@ -46,12 +58,28 @@ func NewProvider(
 		endpoint.TokenURL = gitHubAuth.TokenURL
 	}
 
+	grants := []verifier.RoleGrant{}
+	for _, role := range gitHubAuth.Roles {
+		grants = append(grants, verifier.RoleGrant{
+			Role: role.Role,
+			Verifier: verifier.NewVerifierBasket(
+				NewTeamVerifier(dbTeamsToGitHubTeams(role.Teams), client),
+				NewOrganizationVerifier(role.Organizations, client),
+				NewUserVerifier(role.Users, client),
+			),
+		})
+	}
+
 	return gitHubProvider{
-		Verifier: verifier.NewVerifierBasket(
-			NewTeamVerifier(dbTeamsToGitHubTeams(gitHubAuth.Teams), client),
-			NewOrganizationVerifier(gitHubAuth.Organizations, client),
-			NewUserVerifier(gitHubAuth.Users, client),
+		RoleVerifier: verifier.NewRoleBasket(
+			verifier.NewVerifierBasket(
+				NewTeamVerifier(dbTeamsToGitHubTeams(gitHubAuth.Teams), client),
+				NewOrganizationVerifier(gitHubAuth.Organizations, client),
+				NewUserVerifier(gitHubAuth.Users, client),
+			),
+			grants...,
 		),
+		client: client,
 		Config: &oauth2.Config{
 			ClientID:     gitHubAuth.ClientID,
 			ClientSecret: gitHubAuth.ClientSecret,
@@ -69,7 +97,9 @@ type gitHubProvider struct {
 	// Exchange(context.Context, string) (*oauth2.Token, error)
 	// Client(context.Context, *oauth2.Token) *http.Client
 
-	verifier.Verifier
+	verifier.RoleVerifier
+
+	client Client
 }
 
 func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
@@ -83,6 +113,10 @@ func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
 	return teams
 }
 
+func (p gitHubProvider) UserName(logger lager.Logger, httpClient *http.Client) (string, error) {
+	return p.client.CurrentUser(httpClient)
+}
+
 func (gitHubProvider) PreTokenClient() (*http.Client, error) {
 	return &http.Client{
 		Transport: &http.Transport{
@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"net/http"
 
+	"github.com/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -47,3 +48,43 @@ func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
 
 	return isSystemInterface.(bool), true
 }
+
+func (jr JWTReader) GetRole(r *http.Request) (atc.Role, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	roleInterface, roleOK := claims[roleClaimKey]
+	if !roleOK {
+		return "", false
+	}
+
+	role, roleOK := roleInterface.(string)
+	if !roleOK || role == "" {
+		return "", false
+	}
+
+	return atc.Role(role), true
+}
+
+func (jr JWTReader) GetUserName(r *http.Request) (string, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	userNameInterface, userNameOK := claims[userNameClaimKey]
+	if !userNameOK {
+		return "", false
+	}
+
+	userName, userNameOK := userNameInterface.(string)
+	if !userNameOK || userName == "" {
+		return "", false
+	}
+
+	return userName, true
+}
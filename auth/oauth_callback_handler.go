@@ -145,7 +145,7 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 
 	httpClient := provider.Client(ctx, token)
 
-	verified, err := provider.Verify(hLog.Session("verify"), httpClient)
+	role, verified, err := provider.VerifyRole(hLog.Session("verify"), httpClient)
 	if err != nil {
 		hLog.Error("failed-to-verify-token", err)
 		http.Error(w, "failed to verify token", http.StatusInternalServerError)
@@ -158,9 +158,18 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	userName, err := provider.UserName(hLog.Session("identify"), httpClient)
+	if err != nil {
+		hLog.Error("failed-to-identify-user", err)
+		http.Error(w, "failed to identify user", http.StatusInternalServerError)
+		return
+	}
+
+	userName = QualifiedUserName(providerName, userName)
+
 	exp := time.Now().Add(handler.expire)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin)
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin, role, userName)
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/auth/provider"
@@ -230,7 +231,8 @@ var _ = Describe("OAuthCallbackHandler", func() {
 
 					Context("when the token is verified", func() {
 						BeforeEach(func() {
-							fakeProvider.VerifyReturns(true, nil)
+							fakeProvider.VerifyRoleReturns(atc.RoleViewer, true, nil)
+							fakeProvider.UserNameReturns("some-user", nil)
 						})
 
 						It("responds OK", func() {
@@ -242,8 +244,8 @@ var _ = Describe("OAuthCallbackHandler", func() {
 							_, clientToken := fakeProvider.ClientArgsForCall(0)
 							Expect(clientToken).To(Equal(token))
 
-							Expect(fakeProvider.VerifyCallCount()).To(Equal(1))
-							_, client := fakeProvider.VerifyArgsForCall(0)
+							Expect(fakeProvider.VerifyRoleCallCount()).To(Equal(1))
+							_, client := fakeProvider.VerifyRoleArgsForCall(0)
 							Expect(client).To(Equal(httpClient))
 						})
 
@@ -279,6 +281,40 @@ var _ = Describe("OAuthCallbackHandler", func() {
 								Expect(claims["teamID"]).To(BeNumerically("==", team.ID))
 								Expect(token.Valid).To(BeTrue())
 							})
+
+							It("contains the role the provider verified", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["role"]).To(Equal("viewer"))
+							})
+
+							It("contains the name of the user the provider identified, qualified by the provider", func() {
+								Expect(fakeProvider.UserNameCallCount()).To(Equal(1))
+								_, client := fakeProvider.UserNameArgsForCall(0)
+								Expect(client).To(Equal(httpClient))
+
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["userName"]).To(Equal("some-provider:some-user"))
+							})
+						})
+
+						Context("when the provider cannot identify the user", func() {
+							BeforeEach(func() {
+								fakeProvider.UserNameReturns("", errors.New("nope"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+
+							It("does not set a cookie", func() {
+								Expect(response.Cookies()).To(BeEmpty())
+							})
 						})
 
 						It("does not redirect", func() {
@@ -301,7 +337,7 @@ var _ = Describe("OAuthCallbackHandler", func() {
 
 					Context("when the token is not verified", func() {
 						BeforeEach(func() {
-							fakeProvider.VerifyReturns(false, nil)
+							fakeProvider.VerifyRoleReturns("", false, nil)
 						})
 
 						It("returns Unauthorized", func() {
@@ -315,7 +351,7 @@ var _ = Describe("OAuthCallbackHandler", func() {
 
 					Context("when the token cannot be verified", func() {
 						BeforeEach(func() {
-							fakeProvider.VerifyReturns(false, errors.New("nope"))
+							fakeProvider.VerifyRoleReturns("", false, errors.New("nope"))
 						})
 
 						It("returns Internal Server Error", func() {
@@ -389,7 +425,7 @@ var _ = Describe("OAuthCallbackHandler", func() {
 
 					Context("when the token is verified", func() {
 						BeforeEach(func() {
-							fakeProvider.VerifyReturns(true, nil)
+							fakeProvider.VerifyRoleReturns(atc.RoleViewer, true, nil)
 						})
 
 						It("redirects to the redirect uri", func() {
@@ -400,7 +436,7 @@ var _ = Describe("OAuthCallbackHandler", func() {
 
 					Context("when the token is not verified", func() {
 						BeforeEach(func() {
-							fakeProvider.VerifyReturns(false, nil)
+							fakeProvider.VerifyRoleReturns("", false, nil)
 						})
 
 						It("returns Unauthorized", func() {
@@ -414,7 +450,7 @@ var _ = Describe("OAuthCallbackHandler", func() {
 
 					Context("when the token cannot be verified", func() {
 						BeforeEach(func() {
-							fakeProvider.VerifyReturns(false, errors.New("nope"))
+							fakeProvider.VerifyRoleReturns("", false, errors.New("nope"))
 						})
 
 						It("returns Internal Server Error", func() {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
@@ -15,7 +16,8 @@ type Provider interface {
 	PreTokenClient() (*http.Client, error)
 
 	OAuthClient
-	Verifier
+	RoleVerifier
+	UserIdentifier
 }
 
 type OAuthClient interface {
@@ -29,3 +31,15 @@ type OAuthClient interface {
 type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
+
+type RoleVerifier interface {
+	Verifier
+
+	VerifyRole(lager.Logger, *http.Client) (atc.Role, bool, error)
+}
+
+// UserIdentifier determines the name of the user who logged in. The name is
+// empty if the provider cannot tell who they are.
+type UserIdentifier interface {
+	UserName(lager.Logger, *http.Client) (string, error)
+}
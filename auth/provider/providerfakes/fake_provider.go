@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/provider"
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
@@ -57,6 +58,27 @@ type FakeProvider struct {
 		result1 bool
 		result2 error
 	}
+	VerifyRoleStub        func(lager.Logger, *http.Client) (atc.Role, bool, error)
+	verifyRoleMutex       sync.RWMutex
+	verifyRoleArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	verifyRoleReturns struct {
+		result1 atc.Role
+		result2 bool
+		result3 error
+	}
+	UserNameStub        func(lager.Logger, *http.Client) (string, error)
+	userNameMutex       sync.RWMutex
+	userNameArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	userNameReturns struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -225,6 +247,77 @@ func (fake *FakeProvider) VerifyReturns(result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeProvider) VerifyRole(arg1 lager.Logger, arg2 *http.Client) (atc.Role, bool, error) {
+	fake.verifyRoleMutex.Lock()
+	fake.verifyRoleArgsForCall = append(fake.verifyRoleArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.recordInvocation("VerifyRole", []interface{}{arg1, arg2})
+	fake.verifyRoleMutex.Unlock()
+	if fake.VerifyRoleStub != nil {
+		return fake.VerifyRoleStub(arg1, arg2)
+	} else {
+		return fake.verifyRoleReturns.result1, fake.verifyRoleReturns.result2, fake.verifyRoleReturns.result3
+	}
+}
+
+func (fake *FakeProvider) VerifyRoleCallCount() int {
+	fake.verifyRoleMutex.RLock()
+	defer fake.verifyRoleMutex.RUnlock()
+	return len(fake.verifyRoleArgsForCall)
+}
+
+func (fake *FakeProvider) VerifyRoleArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.verifyRoleMutex.RLock()
+	defer fake.verifyRoleMutex.RUnlock()
+	return fake.verifyRoleArgsForCall[i].arg1, fake.verifyRoleArgsForCall[i].arg2
+}
+
+func (fake *FakeProvider) VerifyRoleReturns(result1 atc.Role, result2 bool, result3 error) {
+	fake.VerifyRoleStub = nil
+	fake.verifyRoleReturns = struct {
+		result1 atc.Role
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeProvider) UserName(arg1 lager.Logger, arg2 *http.Client) (string, error) {
+	fake.userNameMutex.Lock()
+	fake.userNameArgsForCall = append(fake.userNameArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.recordInvocation("UserName", []interface{}{arg1, arg2})
+	fake.userNameMutex.Unlock()
+	if fake.UserNameStub != nil {
+		return fake.UserNameStub(arg1, arg2)
+	} else {
+		return fake.userNameReturns.result1, fake.userNameReturns.result2
+	}
+}
+
+func (fake *FakeProvider) UserNameCallCount() int {
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
+	return len(fake.userNameArgsForCall)
+}
+
+func (fake *FakeProvider) UserNameArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
+	return fake.userNameArgsForCall[i].arg1, fake.userNameArgsForCall[i].arg2
+}
+
+func (fake *FakeProvider) UserNameReturns(result1 string, result2 error) {
+	fake.UserNameStub = nil
+	fake.userNameReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -238,6 +331,10 @@ func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	defer fake.clientMutex.RUnlock()
 	fake.verifyMutex.RLock()
 	defer fake.verifyMutex.RUnlock()
+	fake.verifyRoleMutex.RLock()
+	defer fake.verifyRoleMutex.RUnlock()
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
 	return fake.invocations
 }
 
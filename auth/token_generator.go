@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/dgrijalva/jwt-go"
 )
 
@@ -17,9 +18,11 @@ const expClaimKey = "exp"
 const teamNameClaimKey = "teamName"
 const teamIDClaimKey = "teamID"
 const isAdminClaimKey = "isAdmin"
+const roleClaimKey = "role"
+const userNameClaimKey = "userName"
 
 type TokenGenerator interface {
-	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error)
+	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.Role, userName string) (TokenType, TokenValue, error)
 }
 
 type tokenGenerator struct {
@@ -32,13 +35,22 @@ func NewTokenGenerator(privateKey *rsa.PrivateKey) TokenGenerator {
 	}
 }
 
-func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error) {
-	jwtToken := jwt.NewWithClaims(SigningMethod, jwt.MapClaims{
+func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.Role, userName string) (TokenType, TokenValue, error) {
+	claims := jwt.MapClaims{
 		expClaimKey:      expiration.Unix(),
 		teamNameClaimKey: teamName,
 		teamIDClaimKey:   teamID,
 		isAdminClaimKey:  isAdmin,
-	})
+		roleClaimKey:     string(role),
+	}
+
+	// not every way of logging in identifies a user, e.g. generic OAuth
+	// providers may hand out opaque access tokens
+	if userName != "" {
+		claims[userNameClaimKey] = userName
+	}
+
+	jwtToken := jwt.NewWithClaims(SigningMethod, claims)
 
 	signed, err := jwtToken.SignedString(generator.privateKey)
 	if err != nil {
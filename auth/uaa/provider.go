@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"golang.org/x/net/context"
@@ -22,7 +23,8 @@ type Provider interface {
 	PreTokenClient() (*http.Client, error)
 
 	OAuthClient
-	Verifier
+	RoleVerifier
+	UserIdentifier
 }
 
 type OAuthClient interface {
@@ -35,6 +37,16 @@ type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
 
+type RoleVerifier interface {
+	Verifier
+
+	VerifyRole(lager.Logger, *http.Client) (atc.Role, bool, error)
+}
+
+type UserIdentifier interface {
+	UserName(lager.Logger, *http.Client) (string, error)
+}
+
 func NewProvider(
 	uaaAuth *db.UAAAuth,
 	redirectURL string,
@@ -45,11 +57,25 @@ func NewProvider(
 		endpoint.TokenURL = uaaAuth.TokenURL
 	}
 
+	grants := []verifier.RoleGrant{}
+	for _, role := range uaaAuth.Roles {
+		grants = append(grants, verifier.RoleGrant{
+			Role: role.Role,
+			Verifier: SpaceVerifier{
+				spaceGUIDs: role.CFSpaces,
+				cfAPIURL:   uaaAuth.CFURL,
+			},
+		})
+	}
+
 	return uaaProvider{
-		Verifier: SpaceVerifier{
-			spaceGUIDs: uaaAuth.CFSpaces,
-			cfAPIURL:   uaaAuth.CFURL,
-		},
+		RoleVerifier: verifier.NewRoleBasket(
+			SpaceVerifier{
+				spaceGUIDs: uaaAuth.CFSpaces,
+				cfAPIURL:   uaaAuth.CFURL,
+			},
+			grants...,
+		),
 		Config: &oauth2.Config{
 			ClientID:     uaaAuth.ClientID,
 			ClientSecret: uaaAuth.ClientSecret,
@@ -68,10 +94,19 @@ type uaaProvider struct {
 	// Exchange(context.Context, string) (*oauth2.Token, error)
 	// Client(context.Context, *oauth2.Token) *http.Client
 
-	verifier.Verifier
+	verifier.RoleVerifier
 	CFCACert string
 }
 
+func (uaaProvider) UserName(logger lager.Logger, httpClient *http.Client) (string, error) {
+	uaaToken, err := decodeUAAToken(httpClient)
+	if err != nil {
+		return "", err
+	}
+
+	return uaaToken.UserName, nil
+}
+
 func (p uaaProvider) PreTokenClient() (*http.Client, error) {
 	transport := &http.Transport{
 		DisableKeepAlives: true,
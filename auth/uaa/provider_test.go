@@ -5,11 +5,14 @@ import (
 	"encoding/pem"
 	"net/http"
 
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
+	"github.com/dgrijalva/jwt-go"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
 )
 
 var _ = Describe("Provider", func() {
@@ -122,4 +125,25 @@ K4iijxtW0XYe5R1Od6lWOEKZ6un9Ag==
 			})
 		})
 	})
+
+	Describe("UserName", func() {
+		BeforeEach(func() {
+			dbUAAAuth = &db.UAAAuth{}
+		})
+
+		It("returns the user_name from the access token", func() {
+			accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"user_id":   "some-user-id",
+				"user_name": "some-user",
+			}).SigningString()
+			Expect(err).NotTo(HaveOccurred())
+
+			c := &oauth2.Config{}
+			httpClient := c.Client(oauth2.NoContext, &oauth2.Token{AccessToken: accessToken})
+
+			userName, err := uaaProvider.UserName(lagertest.NewTestLogger("test"), httpClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userName).To(Equal("some-user"))
+		})
+	})
 })
@@ -0,0 +1,71 @@
+package verifier
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/hashicorp/go-multierror"
+)
+
+type RoleGrant struct {
+	Role     atc.Role
+	Verifier Verifier
+}
+
+// RoleBasket verifies users who satisfy the owner verifier as owners, and
+// otherwise grants them the most permissive role whose verifier they satisfy.
+type RoleBasket struct {
+	owner  Verifier
+	grants []RoleGrant
+}
+
+var grantableRoles = []atc.Role{
+	atc.RoleOwner,
+	atc.RoleMember,
+	atc.RolePipelineOperator,
+	atc.RoleViewer,
+}
+
+func NewRoleBasket(owner Verifier, grants ...RoleGrant) RoleBasket {
+	sorted := []RoleGrant{}
+	for _, role := range grantableRoles {
+		for _, grant := range grants {
+			if grant.Role == role {
+				sorted = append(sorted, grant)
+			}
+		}
+	}
+
+	return RoleBasket{owner: owner, grants: sorted}
+}
+
+func (rb RoleBasket) Verify(logger lager.Logger, client *http.Client) (bool, error) {
+	_, verified, err := rb.VerifyRole(logger, client)
+	return verified, err
+}
+
+func (rb RoleBasket) VerifyRole(logger lager.Logger, client *http.Client) (atc.Role, bool, error) {
+	var errors error
+
+	verified, err := rb.owner.Verify(logger, client)
+	if err != nil {
+		errors = multierror.Append(errors, err)
+	} else if verified {
+		return atc.RoleOwner, true, nil
+	}
+
+	for _, grant := range rb.grants {
+		verified, err := grant.Verifier.Verify(logger.Session("role", lager.Data{"role": grant.Role}), client)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+
+		if verified {
+			return grant.Role, true, nil
+		}
+	}
+
+	return "", false, errors
+}
@@ -0,0 +1,114 @@
+package verifier_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/auth/provider/providerfakes"
+
+	. "github.com/concourse/atc/auth/verifier"
+)
+
+var _ = Describe("RoleBasket", func() {
+	var (
+		fakeOwnerVerifier    *providerfakes.FakeVerifier
+		fakeViewerVerifier   *providerfakes.FakeVerifier
+		fakeOperatorVerifier *providerfakes.FakeVerifier
+
+		httpClient *http.Client
+		roleBasket RoleVerifier
+
+		role     atc.Role
+		verified bool
+		err      error
+	)
+
+	BeforeEach(func() {
+		fakeOwnerVerifier = new(providerfakes.FakeVerifier)
+		fakeViewerVerifier = new(providerfakes.FakeVerifier)
+		fakeOperatorVerifier = new(providerfakes.FakeVerifier)
+
+		httpClient = &http.Client{}
+		roleBasket = NewRoleBasket(
+			fakeOwnerVerifier,
+			RoleGrant{Role: atc.RoleViewer, Verifier: fakeViewerVerifier},
+			RoleGrant{Role: atc.RolePipelineOperator, Verifier: fakeOperatorVerifier},
+		)
+	})
+
+	JustBeforeEach(func() {
+		role, verified, err = roleBasket.VerifyRole(lagertest.NewTestLogger("test"), httpClient)
+	})
+
+	Context("when the owner verifier verifies", func() {
+		BeforeEach(func() {
+			fakeOwnerVerifier.VerifyReturns(true, nil)
+			fakeViewerVerifier.VerifyReturns(true, nil)
+		})
+
+		It("verifies the user as an owner", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(verified).To(BeTrue())
+			Expect(role).To(Equal(atc.RoleOwner))
+		})
+
+		It("does not check the role grants", func() {
+			Expect(fakeViewerVerifier.VerifyCallCount()).To(BeZero())
+			Expect(fakeOperatorVerifier.VerifyCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when only role grants verify", func() {
+		BeforeEach(func() {
+			fakeOwnerVerifier.VerifyReturns(false, nil)
+			fakeViewerVerifier.VerifyReturns(true, nil)
+			fakeOperatorVerifier.VerifyReturns(true, nil)
+		})
+
+		It("grants the most permissive role", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(verified).To(BeTrue())
+			Expect(role).To(Equal(atc.RolePipelineOperator))
+		})
+	})
+
+	Context("when the owner verifier errors but a role grant verifies", func() {
+		BeforeEach(func() {
+			fakeOwnerVerifier.VerifyReturns(false, errors.New("nope"))
+			fakeViewerVerifier.VerifyReturns(true, nil)
+		})
+
+		It("grants the role", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(verified).To(BeTrue())
+			Expect(role).To(Equal(atc.RoleViewer))
+		})
+	})
+
+	Context("when nothing verifies", func() {
+		BeforeEach(func() {
+			fakeOwnerVerifier.VerifyReturns(false, nil)
+			fakeViewerVerifier.VerifyReturns(false, errors.New("viewer error"))
+		})
+
+		It("fails to verify, returning the errors", func() {
+			Expect(verified).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("viewer error"))
+		})
+	})
+
+	It("verifies through Verify as well", func() {
+		fakeOwnerVerifier.VerifyReturns(false, nil)
+		fakeOperatorVerifier.VerifyReturns(true, nil)
+
+		result, err := roleBasket.Verify(lagertest.NewTestLogger("test"), httpClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeTrue())
+	})
+})
@@ -4,8 +4,15 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 )
 
 type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
+
+type RoleVerifier interface {
+	Verifier
+
+	VerifyRole(lager.Logger, *http.Client) (atc.Role, bool, error)
+}
@@ -10,6 +10,8 @@ var teamNameKey = "teamName"
 var teamIDKey = "teamID"
 var isAdminKey = "isAdmin"
 var isSystemKey = "system"
+var roleKey = "role"
+var userNameKey = "userName"
 
 func WrapHandler(
 	handler http.Handler,
@@ -38,6 +40,16 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, isAdminKey, isAdmin)
 	}
 
+	role, found := h.userContextReader.GetRole(r)
+	if found {
+		ctx = context.WithValue(ctx, roleKey, role)
+	}
+
+	userName, found := h.userContextReader.GetUserName(r)
+	if found {
+		ctx = context.WithValue(ctx, userNameKey, userName)
+	}
+
 	isSystem, found := h.userContextReader.GetSystem(r)
 	if found {
 		ctx = context.WithValue(ctx, isSystemKey, isSystem)
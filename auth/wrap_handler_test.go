@@ -26,6 +26,7 @@ var _ = Describe("WrapHandler", func() {
 		isSystemChan    <-chan bool
 		foundChan       <-chan bool
 		systemFoundChan <-chan bool
+		userNameChan    <-chan string
 	)
 
 	BeforeEach(func() {
@@ -38,6 +39,7 @@ var _ = Describe("WrapHandler", func() {
 		is := make(chan bool, 1)
 		f := make(chan bool, 1)
 		sf := make(chan bool, 1)
+		un := make(chan string, 1)
 
 		authenticated = a
 		teamNameChan = tn
@@ -45,6 +47,7 @@ var _ = Describe("WrapHandler", func() {
 		isSystemChan = is
 		foundChan = f
 		systemFoundChan = sf
+		userNameChan = un
 		simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			a <- auth.IsAuthenticated(r)
 			authTeam, authTeamFound := auth.GetTeam(r)
@@ -59,6 +62,9 @@ var _ = Describe("WrapHandler", func() {
 			if systemFound {
 				is <- isSystem
 			}
+			if userName, found := auth.GetUserName(r); found {
+				un <- userName
+			}
 		})
 
 		server = httptest.NewServer(auth.WrapHandler(
@@ -129,6 +135,16 @@ var _ = Describe("WrapHandler", func() {
 			})
 		})
 
+		Context("when the userContextReader finds a user name", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetUserNameReturns("some-user", true)
+			})
+
+			It("passes the user name along in the request object", func() {
+				Expect(<-userNameChan).To(Equal("some-user"))
+			})
+		})
+
 		Context("when the userContextReader finds system information", func() {
 			BeforeEach(func() {
 				fakeUserContextReader.GetSystemReturns(true, true)
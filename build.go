@@ -9,6 +9,10 @@ const (
 	StatusFailed    BuildStatus = "failed"
 	StatusErrored   BuildStatus = "errored"
 	StatusAborted   BuildStatus = "aborted"
+
+	// StatusPendingApproval is the status of a started build while it waits
+	// for a user to approve it.
+	StatusPendingApproval BuildStatus = "pending-approval"
 )
 
 type Build struct {
@@ -27,7 +31,7 @@ type Build struct {
 
 func (b Build) IsRunning() bool {
 	switch BuildStatus(b.Status) {
-	case StatusPending, StatusStarted:
+	case StatusPending, StatusStarted, StatusPendingApproval:
 		return true
 	default:
 		return false
@@ -9,12 +9,24 @@ import (
 )
 
 func main() {
-	cmd := &atccmd.ATCCommand{}
+	var cmd flags.Commander = &atccmd.ATCCommand{}
+
+	args := os.Args[1:]
+	if len(args) > 0 {
+		switch args[0] {
+		case "export-pipeline":
+			cmd = &atccmd.ExportPipelineCommand{}
+			args = args[1:]
+		case "import-pipeline":
+			cmd = &atccmd.ImportPipelineCommand{}
+			args = args[1:]
+		}
+	}
 
 	parser := flags.NewParser(cmd, flags.Default)
 	parser.NamespaceDelimiter = "-"
 
-	args, err := parser.Parse()
+	args, err := parser.ParseArgs(args)
 	if err != nil {
 		os.Exit(1)
 	}
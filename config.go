@@ -19,6 +19,15 @@ type ConfigResponse struct {
 	RawConfig RawConfig `json:"raw_config"`
 }
 
+// A ConfigVersion is one of the configs saved for a pipeline. The config
+// itself is only included when a specific version is requested.
+type ConfigVersion struct {
+	Version int     `json:"version"`
+	SavedBy string  `json:"saved_by,omitempty"`
+	SavedAt int64   `json:"saved_at"`
+	Config  *Config `json:"config,omitempty"`
+}
+
 type Config struct {
 	Groups        GroupConfigs    `yaml:"groups" json:"groups" mapstructure:"groups"`
 	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
@@ -56,12 +65,19 @@ type ResourceConfig struct {
 	Type       string `yaml:"type" json:"type" mapstructure:"type"`
 	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
 	CheckEvery string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+	Tags       Tags   `yaml:"tags,omitempty" json:"tags,omitempty" mapstructure:"tags"`
+
+	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token,omitempty" mapstructure:"webhook_token"`
 }
 
 type ResourceType struct {
-	Name   string `yaml:"name" json:"name" mapstructure:"name"`
-	Type   string `yaml:"type" json:"type" mapstructure:"type"`
-	Source Source `yaml:"source" json:"source" mapstructure:"source"`
+	Name       string `yaml:"name" json:"name" mapstructure:"name"`
+	Type       string `yaml:"type" json:"type" mapstructure:"type"`
+	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
+	Privileged *bool  `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
+	CheckEvery string `yaml:"check_every,omitempty" json:"check_every,omitempty" mapstructure:"check_every"`
+	Tags       Tags   `yaml:"tags,omitempty" json:"tags,omitempty" mapstructure:"tags"`
+	Params     Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 }
 
 type ResourceTypes []ResourceType
@@ -89,6 +105,8 @@ func (types ResourceTypes) Lookup(name string) (ResourceType, bool) {
 
 type Hooks struct {
 	Failure *PlanConfig
+	Error   *PlanConfig
+	Abort   *PlanConfig
 	Ensure  *PlanConfig
 	Success *PlanConfig
 }
@@ -103,15 +121,49 @@ type JobConfig struct {
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
+	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
+
+	Schedule []ScheduleConfig `yaml:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Failure *PlanConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
+	Error   *PlanConfig `yaml:"on_error,omitempty" json:"on_error,omitempty" mapstructure:"on_error"`
+	Abort   *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
 	Ensure  *PlanConfig `yaml:"ensure,omitempty" json:"ensure,omitempty" mapstructure:"ensure"`
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
 }
 
+// ScheduleConfig triggers a job at the times matching a cron expression,
+// evaluated in Location (UTC if empty). Scheduled builds use the latest
+// versions of the job's inputs. Ticks that pass while builds are not being
+// scheduled, e.g. while the ATC is down, result in a single build, or in a
+// build for each of them (up to a limit) if CatchUp is set. Ticks that pass
+// while the job or its pipeline is paused are skipped.
+type ScheduleConfig struct {
+	Cron     string `yaml:"cron" json:"cron" mapstructure:"cron"`
+	Location string `yaml:"location,omitempty" json:"location,omitempty" mapstructure:"location"`
+	CatchUp  bool   `yaml:"catch_up,omitempty" json:"catch_up,omitempty" mapstructure:"catch_up"`
+}
+
+// BuildLogRetention configures when the logs of a job's builds are reaped.
+// Logs are reaped once they are older than Days, or once there are more than
+// Builds newer builds, whichever comes first. The logs of the latest
+// MinimumSucceededBuilds succeeded builds are always kept.
+type BuildLogRetention struct {
+	Builds                 int `yaml:"builds,omitempty" json:"builds,omitempty" mapstructure:"builds"`
+	Days                   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+	MinimumSucceededBuilds int `yaml:"minimum_succeeded_builds,omitempty" json:"minimum_succeeded_builds,omitempty" mapstructure:"minimum_succeeded_builds"`
+}
+
 func (config JobConfig) Hooks() Hooks {
-	return Hooks{config.Failure, config.Ensure, config.Success}
+	return Hooks{
+		Failure: config.Failure,
+		Error:   config.Error,
+		Abort:   config.Abort,
+		Ensure:  config.Ensure,
+		Success: config.Success,
+	}
 }
 
 func (config JobConfig) MaxInFlight() int {
@@ -142,6 +194,25 @@ func (config JobConfig) GetSerialGroups() []string {
 // `on: [success]` after every Task plan.
 type PlanSequence []PlanConfig
 
+// An InParallelConfig runs its steps in parallel, running at most Limit of
+// them at once if Limit is set. With FailFast, the first step to fail
+// interrupts the rest.
+type InParallelConfig struct {
+	Steps    PlanSequence `yaml:"steps,omitempty" json:"steps,omitempty" mapstructure:"steps"`
+	Limit    int          `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+	FailFast bool         `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
+// An AcrossVarConfig is one dimension of an across step's matrix: the step
+// is run once for each of its Values, which are available to the step as
+// ((.:var)). At most MaxInFlight of them run at once; by default they run
+// one at a time.
+type AcrossVarConfig struct {
+	Var         string        `yaml:"var" json:"var" mapstructure:"var"`
+	Values      []interface{} `yaml:"values,omitempty" json:"values,omitempty" mapstructure:"values"`
+	MaxInFlight int           `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+}
+
 // A VersionConfig represents the choice to include every version of a
 // resource, the latest version of a resource, or a pinned (specific) one.
 type VersionConfig struct {
@@ -256,6 +327,9 @@ type PlanConfig struct {
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
 
+	// corresponds to an InParallel plan
+	InParallel *InParallelConfig `yaml:"in_parallel,omitempty" json:"in_parallel,omitempty" mapstructure:"in_parallel"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
@@ -275,11 +349,25 @@ type PlanConfig struct {
 	Task string `yaml:"task,omitempty" json:"task,omitempty" mapstructure:"task"`
 	// run task privileged
 	Privileged bool `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
-	// task config path, e.g. foo/build.yml
+	// task config path, e.g. foo/build.yml; also used by SetPipeline for the
+	// pipeline config path, and by LoadVar for the file to load
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a SetPipeline plan
+	// name of the pipeline to configure, e.g. self
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+
+	// corresponds to a LoadVar plan
+	// name of the build-local var to load, e.g. version
+	LoadVar string `yaml:"load_var,omitempty" json:"load_var,omitempty" mapstructure:"load_var"`
+	// format of the file to load, e.g. json, yaml, or raw
+	Format string `yaml:"format,omitempty" json:"format,omitempty" mapstructure:"format"`
+
+	// corresponds to an Approval plan
+	Approval *ApprovalConfig `yaml:"approval,omitempty" json:"approval,omitempty" mapstructure:"approval"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -299,6 +387,14 @@ type PlanConfig struct {
 	// used by any step to run something when the step reports a failure
 	Failure *PlanConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
 
+	// used by any step to run something when the step errors, e.g. because a
+	// worker went away
+	Error *PlanConfig `yaml:"on_error,omitempty" json:"on_error,omitempty" mapstructure:"on_error"`
+
+	// used by any step to run something when the build is aborted while the
+	// step is running
+	Abort *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
+
 	// used on any step to always execute regardless of the step's completed state
 	Ensure *PlanConfig `yaml:"ensure,omitempty" json:"ensure,omitempty" mapstructure:"ensure"`
 
@@ -317,9 +413,27 @@ type PlanConfig struct {
 	// repeat the step up to N times, until it works
 	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
 
+	// run the step once for every combination of the vars' values
+	Across []AcrossVarConfig `yaml:"across,omitempty" json:"across,omitempty" mapstructure:"across"`
+	// used by Across to interrupt the remaining combinations when one fails
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
+// ApprovalConfig configures a step that waits for a user to approve the
+// build before it continues. Approvers are named along with the provider they
+// log in with, e.g. 'github:some-user' or 'basic:some-user', as the same name
+// may belong to different people with different providers. If Approvers is
+// empty, any user who can operate the pipeline may approve it, as long as they
+// logged in with a provider that identifies them. If Timeout elapses first,
+// the step fails.
+type ApprovalConfig struct {
+	Approvers []string `yaml:"approvers,omitempty" json:"approvers,omitempty" mapstructure:"approvers"`
+	Timeout   string   `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
+	Message   string   `yaml:"message,omitempty" json:"message,omitempty" mapstructure:"message"`
+}
+
 func (config PlanConfig) Name() string {
 	if config.RawName != "" {
 		return config.RawName
@@ -360,7 +474,13 @@ func (config PlanConfig) ResourceName() string {
 }
 
 func (config PlanConfig) Hooks() Hooks {
-	return Hooks{config.Failure, config.Ensure, config.Success}
+	return Hooks{
+		Failure: config.Failure,
+		Error:   config.Error,
+		Abort:   config.Abort,
+		Ensure:  config.Ensure,
+		Success: config.Success,
+	}
 }
 
 type ResourceConfigs []ResourceConfig
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+type DiffAction string
+
+const (
+	DiffActionAdded   DiffAction = "added"
+	DiffActionRemoved DiffAction = "removed"
+	DiffActionChanged DiffAction = "changed"
+)
+
+// A ConfigDiff describes the changes that saving a new config over an
+// existing one would make. Each list is sorted by name and only contains the
+// items that were added, removed or changed.
+type ConfigDiff struct {
+	Groups        []ItemDiff `json:"groups,omitempty"`
+	Resources     []ItemDiff `json:"resources,omitempty"`
+	ResourceTypes []ItemDiff `json:"resource_types,omitempty"`
+	Jobs          []ItemDiff `json:"jobs,omitempty"`
+}
+
+// An ItemDiff describes how a single group, resource, resource type or job
+// changed. Fields is only set for changed items.
+type ItemDiff struct {
+	Name   string      `json:"name"`
+	Action DiffAction  `json:"action"`
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// A FieldDiff is a single changed field of an item, named as in the config.
+type FieldDiff struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+// DiffConfigs compares every group, resource, resource type and job of the
+// two configs by name.
+func DiffConfigs(oldConfig atc.Config, newConfig atc.Config) ConfigDiff {
+	oldGroups := map[string]interface{}{}
+	for _, group := range oldConfig.Groups {
+		oldGroups[group.Name] = group
+	}
+
+	newGroups := map[string]interface{}{}
+	for _, group := range newConfig.Groups {
+		newGroups[group.Name] = group
+	}
+
+	oldResources := map[string]interface{}{}
+	for _, resource := range oldConfig.Resources {
+		oldResources[resource.Name] = resource
+	}
+
+	newResources := map[string]interface{}{}
+	for _, resource := range newConfig.Resources {
+		newResources[resource.Name] = resource
+	}
+
+	oldResourceTypes := map[string]interface{}{}
+	for _, resourceType := range oldConfig.ResourceTypes {
+		oldResourceTypes[resourceType.Name] = resourceType
+	}
+
+	newResourceTypes := map[string]interface{}{}
+	for _, resourceType := range newConfig.ResourceTypes {
+		newResourceTypes[resourceType.Name] = resourceType
+	}
+
+	oldJobs := map[string]interface{}{}
+	for _, job := range oldConfig.Jobs {
+		oldJobs[job.Name] = job
+	}
+
+	newJobs := map[string]interface{}{}
+	for _, job := range newConfig.Jobs {
+		newJobs[job.Name] = job
+	}
+
+	return ConfigDiff{
+		Groups:        diffItems(oldGroups, newGroups),
+		Resources:     diffItems(oldResources, newResources),
+		ResourceTypes: diffItems(oldResourceTypes, newResourceTypes),
+		Jobs:          diffItems(oldJobs, newJobs),
+	}
+}
+
+// IsEmpty returns true if nothing would change.
+func (diff ConfigDiff) IsEmpty() bool {
+	return len(diff.Groups) == 0 &&
+		len(diff.Resources) == 0 &&
+		len(diff.ResourceTypes) == 0 &&
+		len(diff.Jobs) == 0
+}
+
+// ResourcesLosingVersionHistory returns the names of the resources whose
+// existing versions would no longer apply: those that are removed, and those
+// whose type or source changes.
+func (diff ConfigDiff) ResourcesLosingVersionHistory() []string {
+	names := []string{}
+
+	for _, item := range diff.Resources {
+		switch item.Action {
+		case DiffActionRemoved:
+			names = append(names, item.Name)
+
+		case DiffActionChanged:
+			for _, field := range item.Fields {
+				if field.Field == "type" || field.Field == "source" {
+					names = append(names, item.Name)
+					break
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+func diffItems(oldItems map[string]interface{}, newItems map[string]interface{}) []ItemDiff {
+	names := []string{}
+	for name := range oldItems {
+		names = append(names, name)
+	}
+
+	for name := range newItems {
+		if _, found := oldItems[name]; !found {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	diffs := []ItemDiff{}
+	for _, name := range names {
+		oldItem, inOld := oldItems[name]
+		newItem, inNew := newItems[name]
+
+		switch {
+		case !inOld:
+			diffs = append(diffs, ItemDiff{Name: name, Action: DiffActionAdded})
+		case !inNew:
+			diffs = append(diffs, ItemDiff{Name: name, Action: DiffActionRemoved})
+		case !reflect.DeepEqual(oldItem, newItem):
+			diffs = append(diffs, ItemDiff{
+				Name:   name,
+				Action: DiffActionChanged,
+				Fields: diffFields(oldItem, newItem),
+			})
+		}
+	}
+
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	return diffs
+}
+
+// diffFields compares the exported fields of two structs of the same type,
+// naming them by their JSON keys.
+func diffFields(oldItem interface{}, newItem interface{}) []FieldDiff {
+	oldVal := reflect.ValueOf(oldItem)
+	newVal := reflect.ValueOf(newItem)
+
+	fields := []FieldDiff{}
+
+	itemType := oldVal.Type()
+	for i := 0; i < itemType.NumField(); i++ {
+		field := itemType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		oldField := oldVal.Field(i).Interface()
+		newField := newVal.Field(i).Interface()
+
+		if !reflect.DeepEqual(oldField, newField) {
+			fields = append(fields, FieldDiff{
+				Field: name,
+				Old:   oldField,
+				New:   newField,
+			})
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,104 @@
+package config_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffConfigs", func() {
+	var oldConfig atc.Config
+
+	BeforeEach(func() {
+		oldConfig = atc.Config{
+			Groups: atc.GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+			},
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+				{Name: "some-other-resource", Type: "git", Source: atc.Source{"uri": "some-other-uri"}},
+			},
+			ResourceTypes: atc.ResourceTypes{
+				{Name: "some-type", Type: "docker-image", Source: atc.Source{"repository": "some-repo"}},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "some-job", Public: true},
+			},
+		}
+	})
+
+	It("is empty when nothing changed", func() {
+		diff := DiffConfigs(oldConfig, oldConfig)
+		Expect(diff.IsEmpty()).To(BeTrue())
+		Expect(diff.ResourcesLosingVersionHistory()).To(BeEmpty())
+	})
+
+	It("reports everything as added when there is no existing config", func() {
+		diff := DiffConfigs(atc.Config{}, oldConfig)
+		Expect(diff.Groups).To(Equal([]ItemDiff{{Name: "some-group", Action: DiffActionAdded}}))
+		Expect(diff.Resources).To(Equal([]ItemDiff{
+			{Name: "some-other-resource", Action: DiffActionAdded},
+			{Name: "some-resource", Action: DiffActionAdded},
+		}))
+		Expect(diff.ResourceTypes).To(Equal([]ItemDiff{{Name: "some-type", Action: DiffActionAdded}}))
+		Expect(diff.Jobs).To(Equal([]ItemDiff{{Name: "some-job", Action: DiffActionAdded}}))
+	})
+
+	Context("when items are added, removed and changed", func() {
+		var diff ConfigDiff
+
+		BeforeEach(func() {
+			newConfig := atc.Config{
+				Groups: oldConfig.Groups,
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-new-uri"}, CheckEvery: "1m"},
+					{Name: "some-new-resource", Type: "git"},
+				},
+				ResourceTypes: oldConfig.ResourceTypes,
+				Jobs: atc.JobConfigs{
+					{Name: "some-job", Public: false},
+				},
+			}
+
+			diff = DiffConfigs(oldConfig, newConfig)
+		})
+
+		It("reports each item with the changed fields", func() {
+			Expect(diff.IsEmpty()).To(BeFalse())
+			Expect(diff.Groups).To(BeEmpty())
+			Expect(diff.ResourceTypes).To(BeEmpty())
+
+			Expect(diff.Resources).To(Equal([]ItemDiff{
+				{Name: "some-new-resource", Action: DiffActionAdded},
+				{Name: "some-other-resource", Action: DiffActionRemoved},
+				{
+					Name:   "some-resource",
+					Action: DiffActionChanged,
+					Fields: []FieldDiff{
+						{Field: "source", Old: atc.Source{"uri": "some-uri"}, New: atc.Source{"uri": "some-new-uri"}},
+						{Field: "check_every", Old: "", New: "1m"},
+					},
+				},
+			}))
+
+			Expect(diff.Jobs).To(Equal([]ItemDiff{
+				{
+					Name:   "some-job",
+					Action: DiffActionChanged,
+					Fields: []FieldDiff{
+						{Field: "public", Old: true, New: false},
+					},
+				},
+			}))
+		})
+
+		It("reports the removed resources and those with a new source as losing version history", func() {
+			Expect(diff.ResourcesLosingVersionHistory()).To(Equal([]string{
+				"some-other-resource",
+				"some-resource",
+			}))
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/concourse/atc"
+import (
+	"strings"
+
+	"github.com/concourse/atc"
+)
 
 // these are expressly tucked away so as to avoid accidental use in public API
 // endpoints as that could leak credentials
@@ -20,11 +24,25 @@ type JobOutput struct {
 	Resource string
 }
 
+// CrossPipelineJob splits a job referenced by a passed constraint into the
+// names of the pipeline and job, if it refers to a job in another pipeline of
+// the same team (i.e. 'some-pipeline/some-job').
+func CrossPipelineJob(passed string) (string, string, bool) {
+	segments := strings.SplitN(passed, "/", 2)
+	if len(segments) != 2 {
+		return "", "", false
+	}
+
+	return segments[0], segments[1], true
+}
+
 func JobInputs(config atc.JobConfig) []JobInput {
 	return collectInputs(atc.PlanConfig{
 		Do:      &config.Plan,
 		Ensure:  config.Ensure,
 		Failure: config.Failure,
+		Error:   config.Error,
+		Abort:   config.Abort,
 		Success: config.Success,
 	})
 }
@@ -34,6 +52,8 @@ func JobOutputs(config atc.JobConfig) []JobOutput {
 		Do:      &config.Plan,
 		Ensure:  config.Ensure,
 		Failure: config.Failure,
+		Error:   config.Error,
+		Abort:   config.Abort,
 		Success: config.Success,
 	})
 }
@@ -49,6 +69,14 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 		inputs = append(inputs, collectInputs(*plan.Failure)...)
 	}
 
+	if plan.Error != nil {
+		inputs = append(inputs, collectInputs(*plan.Error)...)
+	}
+
+	if plan.Abort != nil {
+		inputs = append(inputs, collectInputs(*plan.Abort)...)
+	}
+
 	if plan.Ensure != nil {
 		inputs = append(inputs, collectInputs(*plan.Ensure)...)
 	}
@@ -69,6 +97,12 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			inputs = append(inputs, collectInputs(p)...)
+		}
+	}
+
 	if plan.Get != "" {
 		get := plan.Get
 
@@ -102,6 +136,14 @@ func collectOutputs(plan atc.PlanConfig) []JobOutput {
 		outputs = append(outputs, collectOutputs(*plan.Failure)...)
 	}
 
+	if plan.Error != nil {
+		outputs = append(outputs, collectOutputs(*plan.Error)...)
+	}
+
+	if plan.Abort != nil {
+		outputs = append(outputs, collectOutputs(*plan.Abort)...)
+	}
+
 	if plan.Ensure != nil {
 		outputs = append(outputs, collectOutputs(*plan.Ensure)...)
 	}
@@ -122,6 +164,12 @@ func collectOutputs(plan atc.PlanConfig) []JobOutput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			outputs = append(outputs, collectOutputs(p)...)
+		}
+	}
+
 	if plan.Put != "" {
 		put := plan.Put
 
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -8,6 +9,8 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/cron"
 )
 
 func formatErr(groupName string, err error) string {
@@ -58,9 +61,27 @@ func ValidateConfig(c atc.Config) ([]Warning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	varsErr := validateVars(c)
+	if varsErr != nil {
+		errorMessages = append(errorMessages, formatErr("vars", varsErr))
+	}
+
 	return warnings, errorMessages
 }
 
+// validateVars rejects placeholders whose names could be used to read
+// credentials belonging to other pipelines or teams.
+func validateVars(c atc.Config) error {
+	errorMessages := []string{}
+
+	for _, name := range creds.InvalidVarNames(c) {
+		errorMessages = append(errorMessages,
+			fmt.Sprintf("var '%s' must not contain '/' or '..'", name))
+	}
+
+	return compositeErr(errorMessages)
+}
+
 func validateGroups(c atc.Config) error {
 	errorMessages := []string{}
 
@@ -114,6 +135,12 @@ func validateResources(c atc.Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		// the source is hashed as JSON to find the resource in the team's
+		// other pipelines
+		if _, err := json.Marshal(resource.Source); err != nil {
+			errorMessages = append(errorMessages, identifier+" has a source that can not be encoded as JSON: "+err.Error())
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -150,6 +177,13 @@ func validateResourceTypes(c atc.Config) error {
 		if resourceType.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resourceType.CheckEvery != "" {
+			_, err := time.ParseDuration(resourceType.CheckEvery)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".check_every refers to a duration that could not be parsed ('%s')", resourceType.CheckEvery))
+			}
+		}
 	}
 
 	return compositeErr(errorMessages)
@@ -218,6 +252,20 @@ func validateJobs(c atc.Config) ([]Warning, error) {
 			)
 		}
 
+		if job.BuildLogRetention != nil {
+			errorMessages = append(errorMessages, validateBuildLogRetention(identifier, job)...)
+		}
+
+		for j, schedule := range job.Schedule {
+			_, err := cron.Parse(schedule.Cron, schedule.Location)
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s.schedule[%d] is invalid: %s", identifier, j, err),
+				)
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -226,6 +274,53 @@ func validateJobs(c atc.Config) ([]Warning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateBuildLogRetention(identifier string, job atc.JobConfig) []string {
+	errorMessages := []string{}
+
+	retention := job.BuildLogRetention
+
+	if job.BuildLogsToRetain != 0 {
+		errorMessages = append(
+			errorMessages,
+			identifier+" specifies both build_logs_to_retain and build_log_retention",
+		)
+	}
+
+	if retention.Builds < 0 {
+		errorMessages = append(
+			errorMessages,
+			identifier+fmt.Sprintf(" has negative build_log_retention.builds: %d", retention.Builds),
+		)
+	}
+
+	if retention.Days < 0 {
+		errorMessages = append(
+			errorMessages,
+			identifier+fmt.Sprintf(" has negative build_log_retention.days: %d", retention.Days),
+		)
+	}
+
+	if retention.MinimumSucceededBuilds < 0 {
+		errorMessages = append(
+			errorMessages,
+			identifier+fmt.Sprintf(" has negative build_log_retention.minimum_succeeded_builds: %d", retention.MinimumSucceededBuilds),
+		)
+	}
+
+	if retention.Builds > 0 && retention.MinimumSucceededBuilds > retention.Builds {
+		errorMessages = append(
+			errorMessages,
+			identifier+fmt.Sprintf(
+				" has build_log_retention.minimum_succeeded_builds (%d) greater than build_log_retention.builds (%d)",
+				retention.MinimumSucceededBuilds,
+				retention.Builds,
+			),
+		)
+	}
+
+	return errorMessages
+}
+
 type foundTypes struct {
 	identifier string
 	found      map[string]bool
@@ -273,6 +368,18 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("task")
 	}
 
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
+	if plan.LoadVar != "" {
+		foundTypes.Find("load_var")
+	}
+
+	if plan.Approval != nil {
+		foundTypes.Find("approval")
+	}
+
 	if plan.Do != nil {
 		foundTypes.Find("do")
 	}
@@ -281,6 +388,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("aggregate")
 	}
 
+	if plan.InParallel != nil {
+		foundTypes.Find("in_parallel")
+	}
+
 	if plan.Try != nil {
 		foundTypes.Find("try")
 	}
@@ -309,6 +420,21 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+	case plan.InParallel != nil:
+		if plan.InParallel.Limit < 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s.in_parallel.limit must not be negative", identifier),
+			)
+		}
+
+		for i, plan := range plan.InParallel.Steps {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.steps[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
 	case plan.Get != "":
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
@@ -342,9 +468,33 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			}
 		}
 
+		if resource, found := c.Resources.Lookup(plan.ResourceName()); found && len(plan.Tags) > 0 && !sameTags(plan.Tags, resource.Tags) {
+			warnings = append(warnings, Warning{
+				Type:    "pipeline",
+				Message: fmt.Sprintf("%s specifies tags that differ from those of its resource ('%s'); the resource may be checked on workers that the step cannot run on", identifier, resource.Name),
+			})
+		}
+
 		for _, job := range plan.Passed {
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
+				if pipelineName, jobName, isCrossPipeline := CrossPipelineJob(job); isCrossPipeline {
+					// jobs in other pipelines are matched up when the pipeline is
+					// scheduled, as they can be configured independently
+					if pipelineName == "" || jobName == "" {
+						errorMessages = append(
+							errorMessages,
+							fmt.Sprintf(
+								"%s.passed references an invalid job in another pipeline ('%s')",
+								identifier,
+								job,
+							),
+						)
+					}
+
+					continue
+				}
+
 				errorMessages = append(
 					errorMessages,
 					fmt.Sprintf(
@@ -440,6 +590,66 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			plan, identifier)...,
 		)
 
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a pipeline config file")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
+	case plan.LoadVar != "":
+		identifier = fmt.Sprintf("%s.load_var.%s", identifier, plan.LoadVar)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a file to load")
+		}
+
+		switch plan.Format {
+		case "", "json", "yaml", "raw":
+		default:
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s has an unknown format ('%s')", identifier, plan.Format),
+			)
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
+	case plan.Approval != nil:
+		identifier = fmt.Sprintf("%s.approval", identifier)
+
+		for _, approver := range plan.Approval.Approvers {
+			parts := strings.SplitN(approver, ":", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s has an approver that does not name its provider ('%s'); use 'provider:name', e.g. 'github:some-user'", identifier, approver),
+				)
+			}
+		}
+
+		if plan.Approval.Timeout != "" {
+			if _, err := time.ParseDuration(plan.Approval.Timeout); err != nil {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s has an invalid timeout ('%s')", identifier, plan.Approval.Timeout),
+				)
+			}
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "file"},
+			plan, identifier)...,
+		)
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -468,6 +678,20 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
+	if plan.Error != nil {
+		subIdentifier := fmt.Sprintf("%s.error", identifier)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Error)
+		warnings = append(warnings, planWarnings...)
+		errorMessages = append(errorMessages, planErrMessages...)
+	}
+
+	if plan.Abort != nil {
+		subIdentifier := fmt.Sprintf("%s.abort", identifier)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Abort)
+		warnings = append(warnings, planWarnings...)
+		errorMessages = append(errorMessages, planErrMessages...)
+	}
+
 	if plan.Timeout != "" {
 		_, err := time.ParseDuration(plan.Timeout)
 		if err != nil {
@@ -481,6 +705,27 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	seenVars := map[string]bool{}
+	for i, acrossVar := range plan.Across {
+		if acrossVar.Var == "" {
+			subIdentifier := fmt.Sprintf("%s.across[%d]", identifier, i)
+			errorMessages = append(errorMessages, subIdentifier+" does not specify a var")
+			continue
+		}
+
+		subIdentifier := fmt.Sprintf("%s.across.%s", identifier, acrossVar.Var)
+
+		if seenVars[acrossVar.Var] {
+			errorMessages = append(errorMessages, subIdentifier+" is specified more than once")
+		}
+
+		seenVars[acrossVar.Var] = true
+
+		if acrossVar.MaxInFlight < 0 {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid max_in_flight (%d)", acrossVar.MaxInFlight))
+		}
+	}
+
 	return warnings, errorMessages
 }
 
@@ -538,3 +783,25 @@ func compositeErr(errorMessages []string) error {
 
 	return errors.New(strings.Join(errorMessages, "\n"))
 }
+
+func sameTags(a atc.Tags, b atc.Tags) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, tag := range a {
+		found := false
+		for _, other := range b {
+			if tag == other {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"math"
+
 	"github.com/concourse/atc"
 	. "github.com/concourse/atc/config"
 
@@ -167,6 +169,22 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource's source can not be encoded as JSON", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, atc.ResourceConfig{
+					Name:   "bogus-resource",
+					Type:   "some-type",
+					Source: atc.Source{"not-a-number": math.NaN()},
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.bogus-resource has a source that can not be encoded as JSON"))
+			})
+		})
+
 		Context("when two resources have the same name", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, config.Resources...)
@@ -387,6 +405,69 @@ var _ = Describe("ValidateConfig", func() {
 				Expect(errorMessages[0]).To(ContainSubstring("resource_types[0] and resource_types[1] have the same name ('some-resource-type')"))
 			})
 		})
+
+		Context("when a resource type has an invalid check_every", func() {
+			BeforeEach(func() {
+				config.ResourceTypes = append(config.ResourceTypes, atc.ResourceType{
+					Name:       "some-other-resource-type",
+					Type:       "some-type",
+					CheckEvery: "bogus",
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resource types:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resource_types.some-other-resource-type.check_every refers to a duration that could not be parsed ('bogus')"))
+			})
+		})
+
+		Context("when a resource type has a valid check_every, tags, params, and is privileged", func() {
+			BeforeEach(func() {
+				privileged := true
+				config.ResourceTypes = append(config.ResourceTypes, atc.ResourceType{
+					Name:       "some-other-resource-type",
+					Type:       "some-type",
+					CheckEvery: "10m",
+					Tags:       atc.Tags{"some-tag"},
+					Params:     atc.Params{"some": "param"},
+					Privileged: &privileged,
+				})
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("validating vars", func() {
+		Context("when a var name would address another team's credentials", func() {
+			BeforeEach(func() {
+				config.Resources[0].Source = atc.Source{
+					"private_key": "((../../other-team/private-key))",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid vars:"))
+				Expect(errorMessages[0]).To(ContainSubstring("var '../../other-team/private-key' must not contain '/' or '..'"))
+			})
+		})
+
+		Context("when a var name is nested", func() {
+			BeforeEach(func() {
+				config.Resources[0].Source = atc.Source{
+					"private_key": "((some/private-key))",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("var 'some/private-key' must not contain '/' or '..'"))
+			})
+		})
 	})
 
 	Describe("validating a job", func() {
@@ -424,6 +505,107 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a schedule", func() {
+			BeforeEach(func() {
+				job.Schedule = []atc.ScheduleConfig{
+					{Cron: "0 2 * * 1-5", Location: "Europe/London"},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns no error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+
+			Context("when the cron expression is invalid", func() {
+				BeforeEach(func() {
+					config.Jobs[len(config.Jobs)-1].Schedule = append(
+						config.Jobs[len(config.Jobs)-1].Schedule,
+						atc.ScheduleConfig{Cron: "0 25 * * *"},
+					)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule[1] is invalid: hour field value 25 is out of range (0-23)"))
+				})
+			})
+
+			Context("when the location is unknown", func() {
+				BeforeEach(func() {
+					config.Jobs[len(config.Jobs)-1].Schedule[0].Location = "Europe/Atlantis"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule[0] is invalid: unknown location 'Europe/Atlantis'"))
+				})
+			})
+		})
+
+		Context("when a job has a build_log_retention", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &atc.BuildLogRetention{
+					Builds:                 10,
+					Days:                   7,
+					MinimumSucceededBuilds: 2,
+				}
+			})
+
+			Context("when it is valid", func() {
+				BeforeEach(func() {
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns no error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when build_logs_to_retain is also specified", func() {
+				BeforeEach(func() {
+					job.BuildLogsToRetain = 10
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job specifies both build_logs_to_retain and build_log_retention"))
+				})
+			})
+
+			Context("when it has negative values", func() {
+				BeforeEach(func() {
+					job.BuildLogRetention = &atc.BuildLogRetention{
+						Builds:                 -1,
+						Days:                   -2,
+						MinimumSucceededBuilds: -3,
+					}
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.builds: -1"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.days: -2"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.minimum_succeeded_builds: -3"))
+				})
+			})
+
+			Context("when minimum_succeeded_builds is greater than builds", func() {
+				BeforeEach(func() {
+					job.BuildLogRetention.MinimumSucceededBuilds = 11
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has build_log_retention.minimum_succeeded_builds (11) greater than build_log_retention.builds (10)"))
+				})
+			})
+		})
+
 		Describe("plans", func() {
 			Context("when multiple actions are specified in the same plan", func() {
 				Context("when it's not just Get and Put", func() {
@@ -467,6 +649,45 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when an in_parallel plan has a negative limit", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{{Get: "some-resource"}},
+							Limit: -1,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.limit must not be negative"))
+				})
+			})
+
+			Context("when an in_parallel plan has an invalid step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps:    atc.PlanSequence{{Get: "some-resource"}, {}},
+							Limit:    1,
+							FailFast: true,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.steps[1] has no action specified"))
+				})
+			})
+
 			Context("when no actions are specified in the plan", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{})
@@ -534,6 +755,129 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a set_pipeline plan has no file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						SetPipeline: "some-pipeline",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline does not specify a pipeline config file"))
+				})
+			})
+
+			Context("when a set_pipeline plan has inapplicable fields", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-resource/pipeline.yml",
+						Privileged:     true,
+						Trigger:        true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has invalid fields specified (trigger, privileged)"))
+				})
+			})
+
+			Context("when a load_var plan has no file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						LoadVar: "some-var",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var does not specify a file to load"))
+				})
+			})
+
+			Context("when a load_var plan has an unknown format", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-resource/version",
+						Format:         "toml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has an unknown format ('toml')"))
+				})
+			})
+
+			Context("when an approval plan has an invalid timeout", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Approval: &atc.ApprovalConfig{
+							Timeout: "a while",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].approval has an invalid timeout ('a while')"))
+				})
+			})
+
+			Context("when an approval plan has an approver without a provider", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Approval: &atc.ApprovalConfig{
+							Approvers: []string{"some-user", ":some-user", "github:"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error for each of them", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].approval has an approver that does not name its provider ('some-user')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].approval has an approver that does not name its provider (':some-user')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].approval has an approver that does not name its provider ('github:')"))
+				})
+			})
+
+			Context("when an approval plan has a valid timeout", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Approval: &atc.ApprovalConfig{
+							Approvers: []string{"github:some-user"},
+							Timeout:   "1h",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
 			Context("when a task plan has config path and config specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -557,6 +901,47 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a get plan specifies tags that differ from its resource's", func() {
+				BeforeEach(func() {
+					config.Resources[0].Tags = atc.Tags{"private"}
+
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:  "some-resource",
+						Tags: atc.Tags{"public"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns a warning", func() {
+					Expect(errorMessages).To(BeEmpty())
+					Expect(configWarnings).To(ContainElement(Warning{
+						Type:    "pipeline",
+						Message: "jobs.some-other-job.plan[0].get.some-resource specifies tags that differ from those of its resource ('some-resource'); the resource may be checked on workers that the step cannot run on",
+					}))
+				})
+			})
+
+			Context("when a get plan specifies the same tags as its resource", func() {
+				BeforeEach(func() {
+					config.Resources[0].Tags = atc.Tags{"private", "network"}
+
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:  "some-resource",
+						Tags: atc.Tags{"network", "private"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return a warning", func() {
+					Expect(errorMessages).To(BeEmpty())
+					for _, warning := range configWarnings {
+						Expect(warning.Message).NotTo(ContainSubstring("differ from those of its resource"))
+					}
+				})
+			})
+
 			Context("when a put plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -859,6 +1244,44 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid step within an error", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+						Error: &atc.PlanConfig{
+							Put:      "custom-name",
+							Resource: "some-missing-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.error.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+				})
+			})
+
+			Context("when a plan has an invalid step within an abort", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+						Abort: &atc.PlanConfig{
+							Put:      "custom-name",
+							Resource: "some-missing-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.abort.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+				})
+			})
+
 			Context("when a plan has an invalid timeout in a step", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -909,6 +1332,79 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a step has an across modifier", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						Across: []atc.AcrossVarConfig{
+							{Var: "tag", Values: []interface{}{"a", "b"}, MaxInFlight: 2},
+						},
+						FailFast: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when an across var has no name", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						Across: []atc.AcrossVarConfig{
+							{Values: []interface{}{"a", "b"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[0] does not specify a var"))
+				})
+			})
+
+			Context("when an across var is specified more than once", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						Across: []atc.AcrossVarConfig{
+							{Var: "tag", Values: []interface{}{"a"}},
+							{Var: "tag", Values: []interface{}{"b"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across.tag is specified more than once"))
+				})
+			})
+
+			Context("when an across var has a negative max_in_flight", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						Across: []atc.AcrossVarConfig{
+							{Var: "tag", Values: []interface{}{"a"}, MaxInFlight: -1},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across.tag has an invalid max_in_flight (-1)"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -941,6 +1437,37 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/some-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a job in another pipeline without naming the job", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:    "lol",
+						Passed: []string{"other-pipeline/"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol.passed references an invalid job in another pipeline ('other-pipeline/')"))
+				})
+			})
+
 			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
 				BeforeEach(func() {
 					config.Jobs[0].Plan = append(config.Jobs[0].Plan, atc.PlanConfig{
@@ -0,0 +1,186 @@
+package creds
+
+import (
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+// EvaluateAcrossVars returns a copy of the step's config with the given
+// values of an across step's vars interpolated wherever they are referenced
+// as ((.:name)): in params, in the task config and its path, and in any
+// nested steps and hooks. All other placeholders are left untouched to be
+// evaluated when the step runs.
+func EvaluateAcrossVars(vals map[string]interface{}, planConfig atc.PlanConfig) (atc.PlanConfig, error) {
+	e := &evaluator{variables: acrossVariables(vals)}
+	return e.evaluatePlanConfig(planConfig)
+}
+
+type acrossVariables map[string]interface{}
+
+func (vals acrossVariables) Get(varName string) (interface{}, bool, error) {
+	if !strings.HasPrefix(varName, LocalVarPrefix) {
+		return nil, false, nil
+	}
+
+	val, found := vals[strings.TrimPrefix(varName, LocalVarPrefix)]
+	return val, found, nil
+}
+
+func (e *evaluator) evaluatePlanConfig(planConfig atc.PlanConfig) (atc.PlanConfig, error) {
+	var err error
+
+	if planConfig.Params != nil {
+		params, err := e.evaluate(map[string]interface{}(planConfig.Params))
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.Params = atc.Params(params.(map[string]interface{}))
+	}
+
+	if planConfig.GetParams != nil {
+		params, err := e.evaluate(map[string]interface{}(planConfig.GetParams))
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.GetParams = atc.Params(params.(map[string]interface{}))
+	}
+
+	planConfig.TaskConfigPath, err = e.interpolateString(planConfig.TaskConfigPath)
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	if planConfig.TaskConfig != nil {
+		taskConfig, err := e.evaluateTaskConfig(*planConfig.TaskConfig)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.TaskConfig = &taskConfig
+	}
+
+	if planConfig.Do != nil {
+		do, err := e.evaluatePlanSequence(*planConfig.Do)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.Do = &do
+	}
+
+	if planConfig.Aggregate != nil {
+		aggregate, err := e.evaluatePlanSequence(*planConfig.Aggregate)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.Aggregate = &aggregate
+	}
+
+	if planConfig.InParallel != nil {
+		steps, err := e.evaluatePlanSequence(planConfig.InParallel.Steps)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		inParallel := *planConfig.InParallel
+		inParallel.Steps = steps
+		planConfig.InParallel = &inParallel
+	}
+
+	for _, hook := range []**atc.PlanConfig{
+		&planConfig.Failure,
+		&planConfig.Error,
+		&planConfig.Abort,
+		&planConfig.Ensure,
+		&planConfig.Success,
+		&planConfig.Try,
+	} {
+		if *hook == nil {
+			continue
+		}
+
+		evaluated, err := e.evaluatePlanConfig(**hook)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		*hook = &evaluated
+	}
+
+	return planConfig, nil
+}
+
+func (e *evaluator) evaluatePlanSequence(planSequence atc.PlanSequence) (atc.PlanSequence, error) {
+	evaluated := make(atc.PlanSequence, len(planSequence))
+	for i, planConfig := range planSequence {
+		step, err := e.evaluatePlanConfig(planConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		evaluated[i] = step
+	}
+
+	return evaluated, nil
+}
+
+func (e *evaluator) evaluateTaskConfig(config atc.TaskConfig) (atc.TaskConfig, error) {
+	var err error
+
+	if config.Params != nil {
+		params := make(map[string]string, len(config.Params))
+		for k, v := range config.Params {
+			params[k], err = e.interpolateString(v)
+			if err != nil {
+				return atc.TaskConfig{}, err
+			}
+		}
+
+		config.Params = params
+	}
+
+	config.Image, err = e.interpolateString(config.Image)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	if config.ImageResource != nil {
+		source, err := e.evaluate(map[string]interface{}(config.ImageResource.Source))
+		if err != nil {
+			return atc.TaskConfig{}, err
+		}
+
+		config.ImageResource = &atc.ImageResource{
+			Type:   config.ImageResource.Type,
+			Source: atc.Source(source.(map[string]interface{})),
+		}
+	}
+
+	config.Run.Path, err = e.interpolateString(config.Run.Path)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	if config.Run.Args != nil {
+		args := make([]string, len(config.Run.Args))
+		for i, arg := range config.Run.Args {
+			args[i], err = e.interpolateString(arg)
+			if err != nil {
+				return atc.TaskConfig{}, err
+			}
+		}
+
+		config.Run.Args = args
+	}
+
+	config.Run.Dir, err = e.interpolateString(config.Run.Dir)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,100 @@
+package creds_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EvaluateAcrossVars", func() {
+	var vals map[string]interface{}
+
+	BeforeEach(func() {
+		vals = map[string]interface{}{
+			"go_version": "1.8",
+			"flags":      []interface{}{"-race"},
+		}
+	})
+
+	It("interpolates the values into params, keeping whole-value placeholders raw", func() {
+		planConfig, err := creds.EvaluateAcrossVars(vals, atc.PlanConfig{
+			Put: "some-resource",
+			Params: atc.Params{
+				"tag":   "go-((.:go_version))",
+				"flags": "((.:flags))",
+			},
+			GetParams: atc.Params{
+				"version": "((.:go_version))",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(planConfig.Params).To(Equal(atc.Params{
+			"tag":   "go-1.8",
+			"flags": []interface{}{"-race"},
+		}))
+		Expect(planConfig.GetParams).To(Equal(atc.Params{
+			"version": "1.8",
+		}))
+	})
+
+	It("interpolates the values into the task config and its path", func() {
+		planConfig, err := creds.EvaluateAcrossVars(vals, atc.PlanConfig{
+			Task:           "some-task",
+			TaskConfigPath: "some-input/go-((.:go_version)).yml",
+			TaskConfig: &atc.TaskConfig{
+				ImageResource: &atc.ImageResource{
+					Type:   "docker-image",
+					Source: atc.Source{"tag": "((.:go_version))"},
+				},
+				Params: map[string]string{"FLAGS": "((.:flags))"},
+				Run: atc.TaskRunConfig{
+					Path: "go",
+					Args: []string{"test", "((.:go_version))"},
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(planConfig.TaskConfigPath).To(Equal("some-input/go-1.8.yml"))
+		Expect(planConfig.TaskConfig.ImageResource.Source).To(Equal(atc.Source{"tag": "1.8"}))
+		Expect(planConfig.TaskConfig.Params).To(Equal(map[string]string{"FLAGS": `["-race"]`}))
+		Expect(planConfig.TaskConfig.Run.Args).To(Equal([]string{"test", "1.8"}))
+	})
+
+	It("interpolates the values into nested steps and hooks", func() {
+		planConfig, err := creds.EvaluateAcrossVars(vals, atc.PlanConfig{
+			Do: &atc.PlanSequence{
+				{Put: "some-resource", Params: atc.Params{"tag": "((.:go_version))"}},
+			},
+			Failure: &atc.PlanConfig{
+				Put: "some-alert", Params: atc.Params{"text": "go ((.:go_version)) failed"},
+			},
+			Error: &atc.PlanConfig{
+				Put: "some-alert", Params: atc.Params{"text": "go ((.:go_version)) errored"},
+			},
+			Abort: &atc.PlanConfig{
+				Put: "some-alert", Params: atc.Params{"text": "go ((.:go_version)) aborted"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect((*planConfig.Do)[0].Params).To(Equal(atc.Params{"tag": "1.8"}))
+		Expect(planConfig.Failure.Params).To(Equal(atc.Params{"text": "go 1.8 failed"}))
+		Expect(planConfig.Error.Params).To(Equal(atc.Params{"text": "go 1.8 errored"}))
+		Expect(planConfig.Abort.Params).To(Equal(atc.Params{"text": "go 1.8 aborted"}))
+	})
+
+	It("leaves other placeholders untouched", func() {
+		original := atc.PlanConfig{
+			Put: "some-resource",
+			Params: atc.Params{
+				"password": "((password))",
+				"loaded":   "((.:some-loaded-var))",
+			},
+		}
+
+		planConfig, err := creds.EvaluateAcrossVars(vals, original)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(planConfig.Params).To(Equal(original.Params))
+	})
+})
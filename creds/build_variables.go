@@ -0,0 +1,140 @@
+package creds
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LocalVarPrefix marks a placeholder as referring to a variable loaded by an
+// earlier step in the same build, e.g. ((.:version)).
+const LocalVarPrefix = ".:"
+
+// BuildVariables are the Variables available to the steps of a single build:
+// the pipeline's credentials, along with any local variables loaded into the
+// build as it runs.
+type BuildVariables struct {
+	parent Variables
+
+	localVars map[string]interface{}
+	lock      sync.RWMutex
+}
+
+// NewBuildVariables returns BuildVariables which look up local variables in
+// the build and delegate every other name to parent.
+func NewBuildVariables(parent Variables) *BuildVariables {
+	return &BuildVariables{
+		parent:    parent,
+		localVars: map[string]interface{}{},
+	}
+}
+
+func (v *BuildVariables) Get(varName string) (interface{}, bool, error) {
+	if !strings.HasPrefix(varName, LocalVarPrefix) {
+		return v.parent.Get(varName)
+	}
+
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	val, found := v.localVars[strings.TrimPrefix(varName, LocalVarPrefix)]
+	return val, found, nil
+}
+
+// SetLocalVar loads a variable into the build, to be referenced by later
+// steps as ((.:name)).
+func (v *BuildVariables) SetLocalVar(name string, val interface{}) {
+	v.lock.Lock()
+	v.localVars[name] = val
+	v.lock.Unlock()
+}
+
+// Redact replaces the value of every local variable in the text, so that
+// they do not show up in the build's output.
+func (v *BuildVariables) Redact(text string) string {
+	values := v.redactableValues()
+
+	for _, str := range values {
+		text = strings.Replace(text, str, "((redacted))", -1)
+	}
+
+	return text
+}
+
+// RedactPartial is like Redact, but for text which is streamed in chunks. Any
+// text at the end which could be the start of a value is held back and
+// returned separately, so that it can be redacted along with the chunk that
+// follows it.
+func (v *BuildVariables) RedactPartial(text string) (string, string) {
+	values := v.redactableValues()
+
+	held := len(text)
+	for _, str := range values {
+		start := len(text) - len(str) + 1
+		if start < 0 {
+			start = 0
+		}
+
+		for i := start; i < held; i++ {
+			if strings.HasPrefix(str, text[i:]) {
+				held = i
+				break
+			}
+		}
+	}
+
+	// hold back any value that spans the boundary, too, so that it is
+	// redacted in full rather than left partially in the redacted text
+	for moved := true; moved; {
+		moved = false
+
+		for _, str := range values {
+			for start := 0; start < held; {
+				i := strings.Index(text[start:], str)
+				if i == -1 {
+					break
+				}
+
+				i += start
+				if i < held && i+len(str) > held {
+					held = i
+					moved = true
+					break
+				}
+
+				start = i + 1
+			}
+		}
+	}
+
+	redacted := text[:held]
+	for _, str := range values {
+		redacted = strings.Replace(redacted, str, "((redacted))", -1)
+	}
+
+	return redacted, text[held:]
+}
+
+func (v *BuildVariables) redactableValues() []string {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	values := []string{}
+	for _, val := range v.localVars {
+		if str := stringify(val); str != "" {
+			values = append(values, str)
+		}
+	}
+
+	// replace longer values first, so that values containing others are
+	// redacted in full
+	sort.Sort(byLengthDesc(values))
+
+	return values
+}
+
+type byLengthDesc []string
+
+func (s byLengthDesc) Len() int           { return len(s) }
+func (s byLengthDesc) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byLengthDesc) Less(i, j int) bool { return len(s[i]) > len(s[j]) }
@@ -0,0 +1,111 @@
+package creds_test
+
+import (
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildVariables", func() {
+	var (
+		fakeVariables *credsfakes.FakeVariables
+		variables     *creds.BuildVariables
+	)
+
+	BeforeEach(func() {
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariables.GetReturns("parent-value", true, nil)
+
+		variables = creds.NewBuildVariables(fakeVariables)
+	})
+
+	It("looks up other variables in the parent", func() {
+		val, found, err := variables.Get("some-var")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("parent-value"))
+
+		Expect(fakeVariables.GetArgsForCall(0)).To(Equal("some-var"))
+	})
+
+	Context("when a local var has been set", func() {
+		BeforeEach(func() {
+			variables.SetLocalVar("some-var", "local-value")
+		})
+
+		It("finds it by its local name", func() {
+			val, found, err := variables.Get(".:some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("local-value"))
+
+			Expect(fakeVariables.GetCallCount()).To(BeZero())
+		})
+
+		It("does not shadow the parent's variable", func() {
+			val, _, _ := variables.Get("some-var")
+			Expect(val).To(Equal("parent-value"))
+		})
+
+		It("is interpolated into sources", func() {
+			source, err := creds.EvaluateSource(variables, map[string]interface{}{
+				"version": "v((.:some-var))",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(source).To(HaveKeyWithValue("version", "vlocal-value"))
+		})
+	})
+
+	Context("when a local var has not been set", func() {
+		It("is not found", func() {
+			_, found, err := variables.Get(".:some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("Redact", func() {
+		BeforeEach(func() {
+			variables.SetLocalVar("short", "secret")
+			variables.SetLocalVar("long", "top-secret")
+			variables.SetLocalVar("structured", map[string]interface{}{"a": 1})
+		})
+
+		It("replaces the value of every local var", func() {
+			Expect(variables.Redact("the top-secret secret is {\"a\":1}")).To(Equal("the ((redacted)) ((redacted)) is ((redacted))"))
+		})
+	})
+
+	Describe("RedactPartial", func() {
+		BeforeEach(func() {
+			variables.SetLocalVar("short", "secret")
+			variables.SetLocalVar("long", "top-secret")
+		})
+
+		It("replaces the value of every local var", func() {
+			redacted, held := variables.RedactPartial("the top-secret secret is out\n")
+			Expect(redacted).To(Equal("the ((redacted)) ((redacted)) is out\n"))
+			Expect(held).To(BeEmpty())
+		})
+
+		It("holds back text which could be the start of a value", func() {
+			redacted, held := variables.RedactPartial("the value is top-sec")
+			Expect(redacted).To(Equal("the value is "))
+			Expect(held).To(Equal("top-sec"))
+
+			redacted, held = variables.RedactPartial(held + "ret\n")
+			Expect(redacted).To(Equal("((redacted))\n"))
+			Expect(held).To(BeEmpty())
+		})
+
+		It("holds back a value which spans the start of held back text", func() {
+			variables.SetLocalVar("overlapping", "etop-s3cr3t")
+
+			redacted, held := variables.RedactPartial("the secretop-s3")
+			Expect(redacted).To(Equal("the "))
+			Expect(held).To(Equal("secretop-s3"))
+		})
+	})
+})
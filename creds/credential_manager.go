@@ -0,0 +1,17 @@
+package creds
+
+//go:generate counterfeiter . CredentialManager
+
+// CredentialManager looks up the values of ((var)) placeholders in a secrets
+// backend. A lookup with an empty pipeline name is scoped to the whole team.
+type CredentialManager interface {
+	Get(teamName string, pipelineName string, varName string) (interface{}, bool, error)
+}
+
+// NoopCredentialManager is used when no secrets backend is configured. It
+// never finds anything, so any placeholder in a pipeline fails to evaluate.
+type NoopCredentialManager struct{}
+
+func (NoopCredentialManager) Get(string, string, string) (interface{}, bool, error) {
+	return nil, false, nil
+}
@@ -0,0 +1,371 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\(\(([^()\s]+)\)\)`)
+
+// UndefinedVariablesError is returned when one or more placeholders could not
+// be found in the secrets backend.
+type UndefinedVariablesError struct {
+	Vars []string
+}
+
+func (err UndefinedVariablesError) Error() string {
+	return fmt.Sprintf("undefined vars: %s", strings.Join(err.Vars, ", "))
+}
+
+// EvaluateSource returns a copy of the source with all placeholders replaced by
+// their values. The given source is left untouched.
+func EvaluateSource(variables Variables, source atc.Source) (atc.Source, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	evaluated, err := evaluate(variables, map[string]interface{}(source))
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Source(evaluated.(map[string]interface{})), nil
+}
+
+// EvaluateParams returns a copy of the params with all placeholders replaced
+// by their values. The given params are left untouched.
+func EvaluateParams(variables Variables, params atc.Params) (atc.Params, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	evaluated, err := evaluate(variables, map[string]interface{}(params))
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Params(evaluated.(map[string]interface{})), nil
+}
+
+// EvaluateString returns the string with all placeholders interpolated.
+func EvaluateString(variables Variables, str string) (string, error) {
+	e := &evaluator{variables: variables}
+
+	evaluated, err := e.interpolateString(str)
+	if err != nil {
+		return "", err
+	}
+
+	if err := e.undefinedErr(); err != nil {
+		return "", err
+	}
+
+	return evaluated, nil
+}
+
+// EvaluateResourceTypes evaluates the source and params of every resource
+// type.
+func EvaluateResourceTypes(variables Variables, resourceTypes atc.ResourceTypes) (atc.ResourceTypes, error) {
+	if resourceTypes == nil {
+		return nil, nil
+	}
+
+	evaluatedTypes := make(atc.ResourceTypes, len(resourceTypes))
+	for i, resourceType := range resourceTypes {
+		source, err := EvaluateSource(variables, resourceType.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		params, err := EvaluateParams(variables, resourceType.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceType.Source = source
+		resourceType.Params = params
+		evaluatedTypes[i] = resourceType
+	}
+
+	return evaluatedTypes, nil
+}
+
+// EvaluateTaskConfig evaluates the parts of a task config which may carry
+// credentials: its params, its image, and the source and params of its image
+// resource.
+// Placeholders in params are always interpolated as strings, as that is all
+// that can be passed to the task's environment.
+func EvaluateTaskConfig(variables Variables, config atc.TaskConfig) (atc.TaskConfig, error) {
+	e := &evaluator{variables: variables}
+
+	if config.Params != nil {
+		params := make(map[string]string, len(config.Params))
+		for k, v := range config.Params {
+			evaluated, err := e.interpolateString(v)
+			if err != nil {
+				return atc.TaskConfig{}, err
+			}
+
+			params[k] = evaluated
+		}
+
+		config.Params = params
+	}
+
+	image, err := e.interpolateString(config.Image)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	config.Image = image
+
+	if err := e.undefinedErr(); err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	if config.ImageResource != nil {
+		source, err := EvaluateSource(variables, config.ImageResource.Source)
+		if err != nil {
+			return atc.TaskConfig{}, err
+		}
+
+		params, err := EvaluateParams(variables, config.ImageResource.Params)
+		if err != nil {
+			return atc.TaskConfig{}, err
+		}
+
+		config.ImageResource = &atc.ImageResource{
+			Type:   config.ImageResource.Type,
+			Source: source,
+			Params: params,
+		}
+	}
+
+	return config, nil
+}
+
+// ValidVarName returns false for names which could address credentials
+// outside of the pipeline's or team's own, i.e. names containing a path
+// separator or a parent directory reference, or naming the current one.
+func ValidVarName(name string) bool {
+	return name != "." && !strings.Contains(name, "/") && !strings.Contains(name, "..")
+}
+
+// InvalidVarNames returns the names of the placeholders in the given values
+// which are not valid var names, in order and without duplicates.
+func InvalidVarNames(vals ...interface{}) []string {
+	invalid := map[string]struct{}{}
+
+	for _, val := range vals {
+		payload, err := json.Marshal(val)
+		if err != nil {
+			continue
+		}
+
+		for _, match := range placeholderRegexp.FindAllStringSubmatch(string(payload), -1) {
+			name := strings.TrimPrefix(match[1], LocalVarPrefix)
+			if !ValidVarName(name) {
+				invalid[match[1]] = struct{}{}
+			}
+		}
+	}
+
+	names := []string{}
+	for name := range invalid {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ReferencesLocalVars returns true if any of the given values contains a
+// ((.:name)) placeholder, which can only be evaluated once an earlier step in
+// the build has loaded the variable.
+func ReferencesLocalVars(vals ...interface{}) bool {
+	for _, val := range vals {
+		payload, err := json.Marshal(val)
+		if err != nil {
+			continue
+		}
+
+		for _, match := range placeholderRegexp.FindAllStringSubmatch(string(payload), -1) {
+			if strings.HasPrefix(match[1], LocalVarPrefix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func evaluate(variables Variables, val interface{}) (interface{}, error) {
+	e := &evaluator{variables: variables}
+
+	evaluated, err := e.evaluate(val)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.undefinedErr(); err != nil {
+		return nil, err
+	}
+
+	return evaluated, nil
+}
+
+type evaluator struct {
+	variables Variables
+	undefined map[string]struct{}
+}
+
+func (e *evaluator) evaluate(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case string:
+		return e.evaluateString(v)
+
+	case map[string]interface{}:
+		evaluated := make(map[string]interface{}, len(v))
+		for k, sub := range v {
+			evaluatedSub, err := e.evaluate(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[k] = evaluatedSub
+		}
+
+		return evaluated, nil
+
+	case map[interface{}]interface{}:
+		evaluated := make(map[interface{}]interface{}, len(v))
+		for k, sub := range v {
+			evaluatedSub, err := e.evaluate(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[k] = evaluatedSub
+		}
+
+		return evaluated, nil
+
+	case []interface{}:
+		evaluated := make([]interface{}, len(v))
+		for i, sub := range v {
+			evaluatedSub, err := e.evaluate(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[i] = evaluatedSub
+		}
+
+		return evaluated, nil
+
+	default:
+		return val, nil
+	}
+}
+
+// evaluateString replaces a string consisting of exactly one placeholder with
+// the variable's value as-is, so that structured credentials (e.g. a map of
+// keys) can be used. Placeholders embedded in a longer string are
+// interpolated.
+func (e *evaluator) evaluateString(str string) (interface{}, error) {
+	match := placeholderRegexp.FindStringSubmatch(str)
+	if match != nil && match[0] == str {
+		val, found, err := e.lookup(match[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return str, nil
+		}
+
+		return val, nil
+	}
+
+	return e.interpolateString(str)
+}
+
+func (e *evaluator) interpolateString(str string) (string, error) {
+	var lookupErr error
+
+	interpolated := placeholderRegexp.ReplaceAllStringFunc(str, func(placeholder string) string {
+		if lookupErr != nil {
+			return placeholder
+		}
+
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+
+		val, found, err := e.lookup(name)
+		if err != nil {
+			lookupErr = err
+			return placeholder
+		}
+
+		if !found {
+			return placeholder
+		}
+
+		return stringify(val)
+	})
+	if lookupErr != nil {
+		return "", lookupErr
+	}
+
+	return interpolated, nil
+}
+
+func (e *evaluator) lookup(name string) (interface{}, bool, error) {
+	val, found, err := e.variables.Get(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		if e.undefined == nil {
+			e.undefined = map[string]struct{}{}
+		}
+
+		e.undefined[name] = struct{}{}
+	}
+
+	return val, found, nil
+}
+
+func (e *evaluator) undefinedErr() error {
+	if len(e.undefined) == 0 {
+		return nil
+	}
+
+	names := []string{}
+	for name := range e.undefined {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return UndefinedVariablesError{Vars: names}
+}
+
+func stringify(val interface{}) string {
+	if str, ok := val.(string); ok {
+		return str
+	}
+
+	payload, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+
+	return string(payload)
+}
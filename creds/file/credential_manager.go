@@ -0,0 +1,94 @@
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/concourse/atc/creds"
+)
+
+// Credentials is the format of a credentials file, e.g.:
+//
+//	teams:
+//	  main:
+//	    vars:
+//	      docker-password: hunter2
+//	    pipelines:
+//	      my-pipeline:
+//	        docker-password: something-else
+type Credentials struct {
+	Teams map[string]TeamCredentials `yaml:"teams"`
+}
+
+type TeamCredentials struct {
+	Vars      map[string]interface{}            `yaml:"vars"`
+	Pipelines map[string]map[string]interface{} `yaml:"pipelines"`
+}
+
+type credentialManager struct {
+	credentials Credentials
+}
+
+// NewCredentialManager loads the credentials file at the given path. The file
+// is only read once; the ATC must be restarted to pick up changes.
+func NewCredentialManager(path string) (creds.CredentialManager, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %s", err)
+	}
+
+	var credentials Credentials
+	err = yaml.Unmarshal(payload, &credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file: %s", err)
+	}
+
+	return credentialManager{credentials: credentials}, nil
+}
+
+func (manager credentialManager) Get(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+	team, found := manager.credentials.Teams[teamName]
+	if !found {
+		return nil, false, nil
+	}
+
+	vars := team.Vars
+	if pipelineName != "" {
+		vars = team.Pipelines[pipelineName]
+	}
+
+	val, found := vars[varName]
+	if !found {
+		return nil, false, nil
+	}
+
+	return sanitize(val), true, nil
+}
+
+// sanitize converts YAML's map[interface{}]interface{} into JSON-friendly
+// map[string]interface{}, so that structured credentials can be passed along
+// to resources.
+func sanitize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		sanitized := make(map[string]interface{}, len(v))
+		for k, sub := range v {
+			sanitized[fmt.Sprintf("%v", k)] = sanitize(sub)
+		}
+
+		return sanitized
+
+	case []interface{}:
+		sanitized := make([]interface{}, len(v))
+		for i, sub := range v {
+			sanitized[i] = sanitize(sub)
+		}
+
+		return sanitized
+
+	default:
+		return val
+	}
+}
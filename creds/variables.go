@@ -0,0 +1,39 @@
+package creds
+
+//go:generate counterfeiter . Variables
+
+// Variables resolves placeholder names for a single pipeline.
+type Variables interface {
+	Get(varName string) (interface{}, bool, error)
+}
+
+type pipelineVariables struct {
+	manager      CredentialManager
+	teamName     string
+	pipelineName string
+}
+
+// NewVariables returns Variables which look up each name under the pipeline
+// first, falling back to the pipeline's team.
+func NewVariables(manager CredentialManager, teamName string, pipelineName string) Variables {
+	return pipelineVariables{
+		manager:      manager,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+func (v pipelineVariables) Get(varName string) (interface{}, bool, error) {
+	if v.pipelineName != "" {
+		val, found, err := v.manager.Get(v.teamName, v.pipelineName, varName)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if found {
+			return val, true, nil
+		}
+	}
+
+	return v.manager.Get(v.teamName, "", varName)
+}
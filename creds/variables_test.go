@@ -0,0 +1,86 @@
+package creds_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Variables", func() {
+	var (
+		fakeManager *credsfakes.FakeCredentialManager
+		variables   creds.Variables
+	)
+
+	BeforeEach(func() {
+		fakeManager = new(credsfakes.FakeCredentialManager)
+		variables = creds.NewVariables(fakeManager, "some-team", "some-pipeline")
+	})
+
+	Context("when the variable is scoped to the pipeline", func() {
+		BeforeEach(func() {
+			fakeManager.GetStub = func(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+				if pipelineName == "some-pipeline" {
+					return "pipeline-value", true, nil
+				}
+
+				return "team-value", true, nil
+			}
+		})
+
+		It("prefers the pipeline's value", func() {
+			val, found, err := variables.Get("some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("pipeline-value"))
+
+			Expect(fakeManager.GetCallCount()).To(Equal(1))
+			teamName, pipelineName, varName := fakeManager.GetArgsForCall(0)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(Equal("some-pipeline"))
+			Expect(varName).To(Equal("some-var"))
+		})
+	})
+
+	Context("when the variable is only scoped to the team", func() {
+		BeforeEach(func() {
+			fakeManager.GetStub = func(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+				if pipelineName == "" {
+					return "team-value", true, nil
+				}
+
+				return nil, false, nil
+			}
+		})
+
+		It("falls back to the team's value", func() {
+			val, found, err := variables.Get("some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("team-value"))
+
+			Expect(fakeManager.GetCallCount()).To(Equal(2))
+			teamName, pipelineName, _ := fakeManager.GetArgsForCall(1)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(BeEmpty())
+		})
+	})
+
+	Context("when looking up the pipeline's value fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeManager.GetReturns(nil, false, disaster)
+		})
+
+		It("returns the error without falling back", func() {
+			_, _, err := variables.Get("some-var")
+			Expect(err).To(Equal(disaster))
+			Expect(fakeManager.GetCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,103 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/concourse/atc/creds"
+)
+
+// credentialManager reads credentials from Vault's generic secret backend
+// over its HTTP API. Pipeline-scoped credentials live under
+// PREFIX/TEAM/PIPELINE/VAR, and team-scoped credentials under
+// PREFIX/TEAM/VAR.
+//
+// A secret's "value" field is used if present; otherwise the secret's entire
+// data is used, which allows for structured credentials.
+type credentialManager struct {
+	client     *http.Client
+	url        string
+	token      string
+	pathPrefix string
+}
+
+func NewCredentialManager(url string, token string, pathPrefix string) creds.CredentialManager {
+	return credentialManager{
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		url:        url,
+		token:      token,
+		pathPrefix: pathPrefix,
+	}
+}
+
+// InvalidPathSegmentError is returned for team, pipeline or var names which
+// could address secrets outside of their own path, e.g.
+// ((../other-team/secret)).
+type InvalidPathSegmentError struct {
+	Segment string
+}
+
+func (err InvalidPathSegmentError) Error() string {
+	return fmt.Sprintf("invalid secret path segment '%s': must not contain '/' or '..'", err.Segment)
+}
+
+type secretResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+func (manager credentialManager) Get(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+	segments := []string{"/v1", manager.pathPrefix}
+	for _, segment := range []string{teamName, pipelineName, varName} {
+		if segment == "" {
+			continue
+		}
+
+		if !creds.ValidVarName(segment) {
+			return nil, false, InvalidPathSegmentError{Segment: segment}
+		}
+
+		segments = append(segments, url.PathEscape(segment))
+	}
+
+	secretPath := path.Join(segments...)
+
+	req, err := http.NewRequest("GET", manager.url+secretPath, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	req.Header.Set("X-Vault-Token", manager.token)
+
+	resp, err := manager.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected response from vault for '%s': %s", secretPath, resp.Status)
+	}
+
+	var secret secretResponse
+	err = json.NewDecoder(resp.Body).Decode(&secret)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if val, found := secret.Data["value"]; found {
+		return val, true, nil
+	}
+
+	return secret.Data, true, nil
+}
@@ -0,0 +1,102 @@
+package vault_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/vault"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("CredentialManager", func() {
+	var vaultServer *ghttp.Server
+	var manager creds.CredentialManager
+
+	BeforeEach(func() {
+		vaultServer = ghttp.NewServer()
+		manager = vault.NewCredentialManager(vaultServer.URL(), "some-token", "/concourse")
+	})
+
+	AfterEach(func() {
+		vaultServer.Close()
+	})
+
+	Describe("Get", func() {
+		It("reads the secret's value from the pipeline's path", func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/some-pipeline/some-var"),
+					ghttp.VerifyHeader(http.Header{"X-Vault-Token": []string{"some-token"}}),
+					ghttp.RespondWith(http.StatusOK, `{"data":{"value":"some-value"}}`),
+				),
+			)
+
+			val, found, err := manager.Get("some-team", "some-pipeline", "some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("some-value"))
+		})
+
+		It("reads team-scoped secrets from the team's path", func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/some-var"),
+					ghttp.RespondWith(http.StatusOK, `{"data":{"value":"some-value"}}`),
+				),
+			)
+
+			_, found, err := manager.Get("some-team", "", "some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("escapes each segment of the path", func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/some-pipeline/some?var"),
+					ghttp.RespondWith(http.StatusOK, `{"data":{"value":"some-value"}}`),
+				),
+			)
+
+			_, found, err := manager.Get("some-team", "some-pipeline", "some?var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("returns not found when vault has no such secret", func() {
+			vaultServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, ""),
+			)
+
+			_, found, err := manager.Get("some-team", "some-pipeline", "some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		for _, name := range []string{"../../other-team/secret", "../../../sys/mounts", "nested/var", "..", "."} {
+			name := name
+
+			Context("when the var name is '"+name+"'", func() {
+				It("refuses to look it up", func() {
+					_, found, err := manager.Get("some-team", "some-pipeline", name)
+					Expect(err).To(Equal(vault.InvalidPathSegmentError{Segment: name}))
+					Expect(found).To(BeFalse())
+
+					Expect(vaultServer.ReceivedRequests()).To(BeEmpty())
+				})
+			})
+		}
+
+		Context("when the pipeline name is '..'", func() {
+			It("refuses to look anything up", func() {
+				_, _, err := manager.Get("some-team", "..", "some-var")
+				Expect(err).To(Equal(vault.InvalidPathSegmentError{Segment: ".."}))
+
+				Expect(vaultServer.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+})
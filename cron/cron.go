@@ -0,0 +1,233 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression (minute, hour, day of
+// month, month and day of week), evaluated in a particular location.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// as with cron(8), if both the day of month and the day of week are
+	// restricted, a day matches if either of them does
+	domRestricted bool
+	dowRestricted bool
+
+	location *time.Location
+}
+
+// searchLimit bounds how far ahead Next looks for a match, so that
+// expressions that can never match (e.g. "0 0 30 2 *") terminate.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+
+	// 7 is accepted as Sunday in addition to 0
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Parse parses a cron expression, to be evaluated in the named location
+// (e.g. "Europe/London"). An empty location means UTC.
+func Parse(expression string, location string) (Schedule, error) {
+	loc, err := time.LoadLocation(location)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("unknown location '%s'", location)
+	}
+
+	expression = strings.TrimSpace(expression)
+	if descriptor, found := descriptors[expression]; found {
+		expression = descriptor
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	schedule := Schedule{
+		domRestricted: !strings.HasPrefix(fields[2], "*"),
+		dowRestricted: !strings.HasPrefix(fields[4], "*"),
+		location:      loc,
+	}
+
+	specs := []field{minuteField, hourField, domField, monthField, dowField}
+	dests := []*uint64{&schedule.minute, &schedule.hour, &schedule.dom, &schedule.month, &schedule.dow}
+
+	for i, spec := range specs {
+		bits, err := parseField(fields[i], spec)
+		if err != nil {
+			return Schedule{}, err
+		}
+
+		*dests[i] = bits
+	}
+
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+
+	return schedule, nil
+}
+
+// Next returns the first time after the given time that matches the
+// schedule, or the zero time if there is none.
+func (s Schedule) Next(after time.Time) time.Time {
+	t := after.In(s.location)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, s.location).Add(time.Minute)
+
+	limit := t.Add(searchLimit)
+
+	for t.Before(limit) {
+		var next time.Time
+
+		switch {
+		case !has(s.month, int(t.Month())):
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		case !s.dayMatches(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		case !has(s.hour, t.Hour()):
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+		case !has(s.minute, t.Minute()):
+			next = t.Add(time.Minute)
+		default:
+			return t
+		}
+
+		// normalizing wall clock times around daylight saving transitions can
+		// land on or before where we started; always make progress
+		if !next.After(t) {
+			next = t.Add(time.Minute)
+		}
+
+		t = next
+	}
+
+	return time.Time{}
+}
+
+func (s Schedule) dayMatches(t time.Time) bool {
+	domMatches := has(s.dom, t.Day())
+	dowMatches := has(s.dow, int(t.Weekday()))
+
+	if s.domRestricted && s.dowRestricted {
+		return domMatches || dowMatches
+	}
+
+	return domMatches && dowMatches
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+func parseField(expression string, f field) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(expression, ",") {
+		rangeExpression := part
+		step := 1
+
+		if i := strings.Index(part, "/"); i != -1 {
+			rangeExpression = part[:i]
+
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field ('%s')", f.name, part)
+			}
+		}
+
+		var low, high int
+		var err error
+
+		switch {
+		case rangeExpression == "*":
+			low, high = f.min, f.max
+
+		case strings.Contains(rangeExpression, "-"):
+			bounds := strings.SplitN(rangeExpression, "-", 2)
+
+			low, err = f.value(bounds[0])
+			if err != nil {
+				return 0, err
+			}
+
+			high, err = f.value(bounds[1])
+			if err != nil {
+				return 0, err
+			}
+
+		default:
+			low, err = f.value(rangeExpression)
+			if err != nil {
+				return 0, err
+			}
+
+			high = low
+			if step != 1 {
+				high = f.max
+			}
+		}
+
+		if low > high {
+			return 0, fmt.Errorf("invalid range in %s field ('%s')", f.name, part)
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+func (f field) value(expression string) (int, error) {
+	if value, found := f.names[strings.ToLower(expression)]; found {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(expression)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value in %s field ('%s')", f.name, expression)
+	}
+
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("%s field value %d is out of range (%d-%d)", f.name, value, f.min, f.max)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,57 @@
+package cron_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/cron"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedule", func() {
+	DescribeTable("Next",
+		func(expression string, location string, after string, expected string) {
+			schedule, err := cron.Parse(expression, location)
+			Expect(err).NotTo(HaveOccurred())
+
+			afterTime, err := time.Parse(time.RFC3339, after)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedTime, err := time.Parse(time.RFC3339, expected)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(schedule.Next(afterTime)).To(BeTemporally("==", expectedTime))
+		},
+		Entry("every minute", "* * * * *", "", "2017-01-01T00:00:30Z", "2017-01-01T00:01:00Z"),
+		Entry("steps", "*/15 * * * *", "", "2017-01-01T00:07:30Z", "2017-01-01T00:15:00Z"),
+		Entry("a match is strictly after the given time", "0 * * * *", "", "2017-01-01T01:00:00Z", "2017-01-01T02:00:00Z"),
+		Entry("weekday ranges", "0 2 * * 1-5", "", "2017-03-24T12:00:00Z", "2017-03-27T02:00:00Z"),
+		Entry("named days and months", "0 0 * jan-feb sat", "", "2017-02-26T00:00:00Z", "2018-01-06T00:00:00Z"),
+		Entry("day of month or day of week", "0 0 13 * fri", "", "2017-01-01T00:00:00Z", "2017-01-06T00:00:00Z"),
+		Entry("leap days", "0 0 29 2 *", "", "2017-01-01T00:00:00Z", "2020-02-29T00:00:00Z"),
+		Entry("descriptors", "@daily", "", "2017-01-01T12:00:00Z", "2017-01-02T00:00:00Z"),
+		Entry("locations", "0 2 * * *", "Europe/London", "2017-03-26T00:00:00Z", "2017-03-26T01:00:00Z"),
+		Entry("times skipped by daylight saving", "30 2 * * *", "America/New_York", "2017-03-12T05:00:00Z", "2017-03-13T06:30:00Z"),
+	)
+
+	It("returns the zero time when the expression can never match", func() {
+		schedule, err := cron.Parse("0 0 30 2 *", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(schedule.Next(time.Now()).IsZero()).To(BeTrue())
+	})
+
+	DescribeTable("invalid expressions",
+		func(expression string, location string, message string) {
+			_, err := cron.Parse(expression, location)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("too few fields", "* * * *", "", "expected 5 fields, got 4"),
+		Entry("out of range values", "60 * * * *", "", "minute field value 60 is out of range (0-59)"),
+		Entry("bogus values", "* * * bogus *", "", "invalid value in month field ('bogus')"),
+		Entry("bogus steps", "*/0 * * * *", "", "invalid step in minute field ('*/0')"),
+		Entry("backwards ranges", "* * * * 5-1", "", "invalid range in day of week field ('5-1')"),
+		Entry("unknown locations", "* * * * *", "Mars/Olympus_Mons", "unknown location 'Mars/Olympus_Mons'"),
+	)
+})
@@ -8,6 +8,7 @@ type VersionsDB struct {
 	BuildInputs      []BuildInput
 	JobIDs           map[string]int
 	ResourceIDs      map[string]int
+	PinnedVersionIDs map[int]int
 	CachedAt         time.Time
 }
 
@@ -19,15 +19,22 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 	for _, inputConfig := range configs {
 		versionCandidates := VersionCandidates{}
 
+		// a version pinned on the resource itself takes precedence over the
+		// version configured for the input
+		pinnedVersionID := inputConfig.PinnedVersionID
+		if resourcePinnedVersionID, found := db.PinnedVersionIDs[inputConfig.ResourceID]; found {
+			pinnedVersionID = resourcePinnedVersionID
+		}
+
 		if len(inputConfig.Passed) == 0 {
-			if inputConfig.UseEveryVersion {
+			if inputConfig.UseEveryVersion && pinnedVersionID == 0 {
 				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
 			} else {
 				var versionCandidate VersionCandidate
 				var found bool
 
-				if inputConfig.PinnedVersionID != 0 {
-					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
+				if pinnedVersionID != 0 {
+					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, pinnedVersionID)
 				} else {
 					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
 				}
@@ -63,7 +70,7 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			Input:                 inputConfig.Name,
 			Passed:                inputConfig.Passed,
 			UseEveryVersion:       inputConfig.UseEveryVersion,
-			PinnedVersionID:       inputConfig.PinnedVersionID,
+			PinnedVersionID:       pinnedVersionID,
 			VersionCandidates:     versionCandidates,
 			ExistingBuildResolver: existingBuildResolver,
 		})
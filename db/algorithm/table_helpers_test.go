@@ -14,6 +14,9 @@ type DB struct {
 	BuildInputs  []DBRow
 	BuildOutputs []DBRow
 	Resources    []DBRow
+
+	// resource name to the name of the version pinned on it
+	PinnedVersions map[string]string
 }
 
 type DBRow struct {
@@ -138,6 +141,13 @@ func (example Example) Run() {
 				JobID:           jobIDs.ID(row.Job),
 			})
 		}
+		for resource, version := range example.DB.PinnedVersions {
+			if db.PinnedVersionIDs == nil {
+				db.PinnedVersionIDs = map[int]int{}
+			}
+
+			db.PinnedVersionIDs[resourceIDs.ID(resource)] = versionIDs.ID(version)
+		}
 	}
 
 	inputConfigs := make(algorithm.InputConfigs, len(example.Inputs))
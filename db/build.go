@@ -15,16 +15,17 @@ import (
 type Status string
 
 const (
-	StatusPending   Status = "pending"
-	StatusStarted   Status = "started"
-	StatusAborted   Status = "aborted"
-	StatusSucceeded Status = "succeeded"
-	StatusFailed    Status = "failed"
-	StatusErrored   Status = "errored"
+	StatusPending         Status = "pending"
+	StatusStarted         Status = "started"
+	StatusPendingApproval Status = "pending-approval"
+	StatusAborted         Status = "aborted"
+	StatusSucceeded       Status = "succeeded"
+	StatusFailed          Status = "failed"
+	StatusErrored         Status = "errored"
 )
 
-const buildColumns = "id, name, job_id, team_id, status, scheduled, engine, engine_metadata, start_time, end_time, reap_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
+const buildColumns = "id, name, job_id, team_id, status, scheduled, engine, engine_metadata, engine_metadata_nonce, start_time, end_time, reap_time"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.engine_metadata_nonce, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
 
 //go:generate counterfeiter . Build
 
@@ -59,6 +60,13 @@ type Build interface {
 	Abort() error
 	AbortNotifier() (Notifier, error)
 
+	RequestApproval(planID atc.PlanID, approvers []string, message string) (BuildApproval, error)
+	GetApproval(planID atc.PlanID) (BuildApproval, bool, error)
+	GetPendingApprovals() ([]BuildApproval, error)
+	Approve(planID atc.PlanID, approver string) (bool, error)
+	CloseApproval(planID atc.PlanID) error
+	ApprovalNotifier(planID atc.PlanID) (Notifier, error)
+
 	AcquireTrackingLock(logger lager.Logger, interval time.Duration) (Lock, bool, error)
 
 	GetPreparation() (BuildPreparation, bool, error)
@@ -159,7 +167,7 @@ func (b *build) IsScheduled() bool {
 
 func (b *build) IsRunning() bool {
 	switch b.status {
-	case StatusPending, StatusStarted:
+	case StatusPending, StatusStarted, StatusPendingApproval:
 		return true
 	default:
 		return false
@@ -224,6 +232,11 @@ func (b *build) Events(from uint) (EventSource, error) {
 }
 
 func (b *build) Start(engine, metadata string) (bool, error) {
+	encryptedMetadata, nonce, err := b.conn.EncryptionStrategy().Encrypt([]byte(metadata))
+	if err != nil {
+		return false, err
+	}
+
 	tx, err := b.conn.Begin()
 	if err != nil {
 		return false, err
@@ -235,11 +248,11 @@ func (b *build) Start(engine, metadata string) (bool, error) {
 
 	err = tx.QueryRow(`
 		UPDATE builds
-		SET status = 'started', start_time = now(), engine = $2, engine_metadata = $3
+		SET status = 'started', start_time = now(), engine = $2, engine_metadata = $3, engine_metadata_nonce = $4
 		WHERE id = $1
 		AND status = 'pending'
 		RETURNING start_time
-	`, b.id, engine, metadata).Scan(&startTime)
+	`, b.id, engine, encryptedMetadata, nonce).Scan(&startTime)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -300,6 +313,219 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+func (b *build) RequestApproval(planID atc.PlanID, approvers []string, message string) (BuildApproval, error) {
+	if approvers == nil {
+		approvers = []string{}
+	}
+
+	approversJSON, err := json.Marshal(approvers)
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	defer tx.Rollback()
+
+	// the approval may already exist if the build is being resumed, in which
+	// case it keeps its original request time
+	_, err = tx.Exec(`
+		INSERT INTO build_approvals (build_id, plan_id, approvers, message)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM build_approvals
+			WHERE build_id = $1
+			AND plan_id = $2
+		)
+	`, b.id, string(planID), string(approversJSON), message)
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	approval, err := scanBuildApproval(tx.QueryRow(`
+		SELECT `+buildApprovalColumns+`
+		FROM build_approvals
+		WHERE build_id = $1
+		AND plan_id = $2
+	`, b.id, string(planID)))
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	if approval.Pending() {
+		_, err = tx.Exec(`
+			UPDATE builds
+			SET status = 'pending-approval'
+			WHERE id = $1
+			AND status = 'started'
+		`, b.id)
+		if err != nil {
+			return BuildApproval{}, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	return approval, nil
+}
+
+func (b *build) GetApproval(planID atc.PlanID) (BuildApproval, bool, error) {
+	approval, err := scanBuildApproval(b.conn.QueryRow(`
+		SELECT `+buildApprovalColumns+`
+		FROM build_approvals
+		WHERE build_id = $1
+		AND plan_id = $2
+	`, b.id, string(planID)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildApproval{}, false, nil
+		}
+
+		return BuildApproval{}, false, err
+	}
+
+	return approval, true, nil
+}
+
+func (b *build) GetPendingApprovals() ([]BuildApproval, error) {
+	rows, err := b.conn.Query(`
+		SELECT `+buildApprovalColumns+`
+		FROM build_approvals
+		WHERE build_id = $1
+		AND approver IS NULL
+		AND NOT closed
+		ORDER BY id ASC
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	approvals := []BuildApproval{}
+
+	for rows.Next() {
+		approval, err := scanBuildApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, nil
+}
+
+func (b *build) Approve(planID atc.PlanID, approver string) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	approved, err := checkIfRowsUpdated(tx, `
+		UPDATE build_approvals
+		SET approver = $3, approved_at = now()
+		WHERE build_id = $1
+		AND plan_id = $2
+		AND approver IS NULL
+		AND NOT closed
+	`, b.id, string(planID), approver)
+	if err != nil {
+		return false, err
+	}
+
+	if !approved {
+		return false, nil
+	}
+
+	err = b.resumeIfNoPendingApprovals(tx)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	err = b.bus.Notify(buildApprovalChannel(b.id))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *build) CloseApproval(planID atc.PlanID) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE build_approvals
+		SET closed = true
+		WHERE build_id = $1
+		AND plan_id = $2
+		AND approver IS NULL
+	`, b.id, string(planID))
+	if err != nil {
+		return err
+	}
+
+	err = b.resumeIfNoPendingApprovals(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *build) ApprovalNotifier(planID atc.PlanID) (Notifier, error) {
+	return newConditionNotifier(b.bus, buildApprovalChannel(b.id), func() (bool, error) {
+		var approved bool
+		err := b.conn.QueryRow(`
+			SELECT approver IS NOT NULL
+			FROM build_approvals
+			WHERE build_id = $1
+			AND plan_id = $2
+		`, b.id, string(planID)).Scan(&approved)
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return approved, err
+	})
+}
+
+func (b *build) resumeIfNoPendingApprovals(tx Tx) error {
+	_, err := tx.Exec(`
+		UPDATE builds
+		SET status = 'started'
+		WHERE id = $1
+		AND status = 'pending-approval'
+		AND NOT EXISTS (
+			SELECT 1
+			FROM build_approvals
+			WHERE build_id = $1
+			AND approver IS NULL
+			AND NOT closed
+		)
+	`, b.id)
+	return err
+}
+
 func (b *build) Finish(status Status) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -390,7 +616,7 @@ func (b *build) GetResources() ([]BuildInput, []BuildOutput, error) {
 	outputs := []BuildOutput{}
 
 	rows, err := b.conn.Query(`
-		SELECT i.name, r.name, v.type, v.version, v.metadata, r.pipeline_id,
+		SELECT i.name, r.name, v.type, v.version, v.metadata, v.metadata_nonce, r.pipeline_id,
 		NOT EXISTS (
 			SELECT 1
 			FROM build_inputs ci, builds cb
@@ -424,7 +650,8 @@ func (b *build) GetResources() ([]BuildInput, []BuildOutput, error) {
 		var firstOccurrence bool
 
 		var version, metadata string
-		err := rows.Scan(&inputName, &vr.Resource, &vr.Type, &version, &metadata, &vr.PipelineID, &firstOccurrence)
+		var metadataNonce sql.NullString
+		err := rows.Scan(&inputName, &vr.Resource, &vr.Type, &version, &metadata, &metadataNonce, &vr.PipelineID, &firstOccurrence)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -434,7 +661,7 @@ func (b *build) GetResources() ([]BuildInput, []BuildOutput, error) {
 			return nil, nil, err
 		}
 
-		err = json.Unmarshal([]byte(metadata), &vr.Metadata)
+		err = decryptJSON(b.conn.EncryptionStrategy(), metadata, metadataNonce, &vr.Metadata)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -447,7 +674,7 @@ func (b *build) GetResources() ([]BuildInput, []BuildOutput, error) {
 	}
 
 	rows, err = b.conn.Query(`
-		SELECT r.name, v.type, v.version, v.metadata, r.pipeline_id
+		SELECT r.name, v.type, v.version, v.metadata, v.metadata_nonce, r.pipeline_id
 		FROM versioned_resources v, build_outputs o, builds b, resources r
 		WHERE b.id = $1
 		AND o.build_id = b.id
@@ -465,7 +692,8 @@ func (b *build) GetResources() ([]BuildInput, []BuildOutput, error) {
 		var vr VersionedResource
 
 		var version, metadata string
-		err := rows.Scan(&vr.Resource, &vr.Type, &version, &metadata, &vr.PipelineID)
+		var metadataNonce sql.NullString
+		err := rows.Scan(&vr.Resource, &vr.Type, &version, &metadata, &metadataNonce, &vr.PipelineID)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -475,7 +703,7 @@ func (b *build) GetResources() ([]BuildInput, []BuildOutput, error) {
 			return nil, nil, err
 		}
 
-		err = json.Unmarshal([]byte(metadata), &vr.Metadata)
+		err = decryptJSON(b.conn.EncryptionStrategy(), metadata, metadataNonce, &vr.Metadata)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -501,15 +729,16 @@ func (b *build) getVersionedResources(resourceRequest string) (SavedVersionedRes
 	for rows.Next() {
 		var versionedResource SavedVersionedResource
 		var versionJSON []byte
-		var metadataJSON []byte
-		err = rows.Scan(&versionedResource.ID, &versionedResource.Enabled, &versionJSON, &metadataJSON, &versionedResource.Type, &versionedResource.Resource, &versionedResource.PipelineID, &versionedResource.ModifiedTime)
+		var metadata string
+		var metadataNonce sql.NullString
+		err = rows.Scan(&versionedResource.ID, &versionedResource.Enabled, &versionJSON, &metadata, &metadataNonce, &versionedResource.Type, &versionedResource.Resource, &versionedResource.PipelineID, &versionedResource.ModifiedTime)
 
 		err = json.Unmarshal(versionJSON, &versionedResource.Version)
 		if err != nil {
 			return nil, err
 		}
 
-		err = json.Unmarshal(metadataJSON, &versionedResource.Metadata)
+		err = decryptJSON(b.conn.EncryptionStrategy(), metadata, metadataNonce, &versionedResource.Metadata)
 		if err != nil {
 			return nil, err
 		}
@@ -526,6 +755,7 @@ func (b *build) GetVersionedResources() (SavedVersionedResources, error) {
 			vr.enabled,
 			vr.version,
 			vr.metadata,
+			vr.metadata_nonce,
 			vr.type,
 			r.name,
 			r.pipeline_id,
@@ -543,6 +773,7 @@ func (b *build) GetVersionedResources() (SavedVersionedResources, error) {
 			vr.enabled,
 			vr.version,
 			vr.metadata,
+			vr.metadata_nonce,
 			vr.type,
 			r.name,
 			r.pipeline_id,
@@ -730,7 +961,7 @@ func (b *build) SaveInput(input BuildInput) (SavedVersionedResource, error) {
 		WHERE p.id = $1
 	`, input.VersionedResource.PipelineID)
 
-	savedPipeline, err := scanPipeline(row)
+	savedPipeline, err := scanPipeline(row, b.conn.EncryptionStrategy())
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
@@ -750,7 +981,7 @@ func (b *build) SaveOutput(vr VersionedResource, explicit bool) (SavedVersionedR
 		WHERE p.id = $1
 	`, vr.PipelineID)
 
-	savedPipeline, err := scanPipeline(row)
+	savedPipeline, err := scanPipeline(row, b.conn.EncryptionStrategy())
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
@@ -761,11 +992,16 @@ func (b *build) SaveOutput(vr VersionedResource, explicit bool) (SavedVersionedR
 }
 
 func (b *build) SaveEngineMetadata(engineMetadata string) error {
-	_, err := b.conn.Exec(`
+	encryptedMetadata, nonce, err := b.conn.EncryptionStrategy().Encrypt([]byte(engineMetadata))
+	if err != nil {
+		return err
+	}
+
+	_, err = b.conn.Exec(`
 		UPDATE builds
-		SET engine_metadata = $2
+		SET engine_metadata = $2, engine_metadata_nonce = $3
 		WHERE id = $1
-	`, b.id, engineMetadata)
+	`, b.id, encryptedMetadata, nonce)
 	if err != nil {
 		return err
 	}
@@ -888,15 +1124,16 @@ func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration)
 }
 
 func (b *build) GetConfig() (atc.Config, ConfigVersion, error) {
-	var configBlob []byte
+	var configText string
+	var nonce sql.NullString
 	var version int
 	err := b.conn.QueryRow(`
-			SELECT p.config, p.version
+			SELECT p.config, p.nonce, p.version
 			FROM builds b
 			INNER JOIN jobs j ON b.job_id = j.id
 			INNER JOIN pipelines p ON j.pipeline_id = p.id
 			WHERE b.id = $1
-		`, b.id).Scan(&configBlob, &version)
+		`, b.id).Scan(&configText, &nonce, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, 0, nil
@@ -906,7 +1143,7 @@ func (b *build) GetConfig() (atc.Config, ConfigVersion, error) {
 	}
 
 	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	err = decryptJSON(b.conn.EncryptionStrategy(), configText, nonce, &config)
 	if err != nil {
 		return atc.Config{}, 0, err
 	}
@@ -926,7 +1163,7 @@ func (b *build) GetPipeline() (SavedPipeline, error) {
 		WHERE p.id = $1
 	`, b.pipelineID)
 
-	return scanPipeline(row)
+	return scanPipeline(row, b.conn.EncryptionStrategy())
 }
 
 func newConditionNotifier(bus *notificationsBus, channel string, cond func() (bool, error)) (Notifier, error) {
@@ -977,6 +1214,10 @@ func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
 
+func buildApprovalChannel(buildID int) string {
+	return fmt.Sprintf("build_approval_%d", buildID)
+}
+
 func buildEventsChannel(buildID int) string {
 	return fmt.Sprintf("build_events_%d", buildID)
 }
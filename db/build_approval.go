@@ -0,0 +1,69 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/lib/pq"
+)
+
+const buildApprovalColumns = "plan_id, approvers, message, requested_at, approver, approved_at, closed"
+
+// BuildApproval is a request made by an approval step for a user to allow
+// the build to continue.
+type BuildApproval struct {
+	PlanID      atc.PlanID
+	Approvers   []string
+	Message     string
+	RequestedAt time.Time
+
+	// Approver is empty until the approval has been given.
+	Approver   string
+	ApprovedAt time.Time
+
+	// Closed is set once the step has stopped waiting, i.e. it timed out or
+	// was interrupted.
+	Closed bool
+}
+
+func (approval BuildApproval) Approved() bool {
+	return approval.Approver != ""
+}
+
+func (approval BuildApproval) Pending() bool {
+	return !approval.Approved() && !approval.Closed
+}
+
+func scanBuildApproval(row scannable) (BuildApproval, error) {
+	var (
+		approval   BuildApproval
+		planID     string
+		approvers  []byte
+		approver   sql.NullString
+		approvedAt pq.NullTime
+	)
+
+	err := row.Scan(&planID, &approvers, &approval.Message, &approval.RequestedAt, &approver, &approvedAt, &approval.Closed)
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	err = json.Unmarshal(approvers, &approval.Approvers)
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	approval.PlanID = atc.PlanID(planID)
+
+	if approver.Valid {
+		approval.Approver = approver.String
+	}
+
+	if approvedAt.Valid {
+		approval.ApprovedAt = approvedAt.Time
+	}
+
+	return approval, nil
+}
@@ -27,13 +27,13 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	var jobID, pipelineID, teamID sql.NullInt64
 	var status string
 	var scheduled bool
-	var engine, engineMetadata, jobName, pipelineName sql.NullString
+	var engine, engineMetadata, engineMetadataNonce, jobName, pipelineName sql.NullString
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 	var reapTime pq.NullTime
 	var teamName string
 
-	err := row.Scan(&id, &name, &jobID, &teamID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
+	err := row.Scan(&id, &name, &jobID, &teamID, &status, &scheduled, &engine, &engineMetadata, &engineMetadataNonce, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -42,6 +42,14 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		return nil, false, err
 	}
 
+	var decryptedMetadata []byte
+	if engineMetadata.Valid {
+		decryptedMetadata, err = decrypt(f.conn.EncryptionStrategy(), engineMetadata.String, engineMetadataNonce)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	build := &build{
 		conn:        f.conn,
 		bus:         f.bus,
@@ -53,7 +61,7 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		scheduled: scheduled,
 
 		engine:         engine.String,
-		engineMetadata: engineMetadata.String,
+		engineMetadata: string(decryptedMetadata),
 
 		startTime: startTime.Time,
 		endTime:   endTime.Time,
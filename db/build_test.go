@@ -462,6 +462,143 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("approvals", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start("engine", "metadata")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		Describe("RequestApproval", func() {
+			var approval db.BuildApproval
+
+			BeforeEach(func() {
+				var err error
+				approval, err = build.RequestApproval("some-plan", []string{"some-user"}, "ship it?")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the pending approval", func() {
+				Expect(approval.PlanID).To(Equal(atc.PlanID("some-plan")))
+				Expect(approval.Approvers).To(Equal([]string{"some-user"}))
+				Expect(approval.Message).To(Equal("ship it?"))
+				Expect(approval.RequestedAt).NotTo(BeZero())
+				Expect(approval.Pending()).To(BeTrue())
+			})
+
+			It("marks the build as pending approval", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.StatusPendingApproval))
+				Expect(build.IsRunning()).To(BeTrue())
+			})
+
+			It("lists it as pending", func() {
+				approvals, err := build.GetPendingApprovals()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvals).To(Equal([]db.BuildApproval{approval}))
+			})
+
+			Context("when requested again", func() {
+				It("returns the original approval", func() {
+					again, err := build.RequestApproval("some-plan", []string{"some-other-user"}, "really?")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(again).To(Equal(approval))
+				})
+			})
+
+			Describe("Approve", func() {
+				var notifier db.Notifier
+
+				BeforeEach(func() {
+					var err error
+					notifier, err = build.ApprovalNotifier("some-plan")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				AfterEach(func() {
+					notifier.Close()
+				})
+
+				It("records the approver, notifies, and resumes the build", func() {
+					Consistently(notifier.Notify()).ShouldNot(Receive())
+
+					approved, err := build.Approve("some-plan", "some-user")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(approved).To(BeTrue())
+
+					Eventually(notifier.Notify()).Should(Receive())
+
+					approval, found, err := build.GetApproval("some-plan")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(approval.Approver).To(Equal("some-user"))
+					Expect(approval.ApprovedAt).NotTo(BeZero())
+
+					approvals, err := build.GetPendingApprovals()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(approvals).To(BeEmpty())
+
+					found, err = build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.StatusStarted))
+				})
+
+				It("does not approve twice", func() {
+					approved, err := build.Approve("some-plan", "some-user")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(approved).To(BeTrue())
+
+					approved, err = build.Approve("some-plan", "some-other-user")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(approved).To(BeFalse())
+				})
+			})
+
+			Describe("CloseApproval", func() {
+				BeforeEach(func() {
+					err := build.CloseApproval("some-plan")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("is no longer pending and the build resumes", func() {
+					approval, found, err := build.GetApproval("some-plan")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(approval.Closed).To(BeTrue())
+					Expect(approval.Approved()).To(BeFalse())
+
+					approved, err := build.Approve("some-plan", "some-user")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(approved).To(BeFalse())
+
+					found, err = build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.StatusStarted))
+				})
+			})
+		})
+
+		Describe("GetApproval", func() {
+			Context("when no approval has been requested", func() {
+				It("returns false", func() {
+					_, found, err := build.GetApproval("some-plan")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+	})
+
 	Describe("GetBuildPreparation", func() {
 		var (
 			build             db.Build
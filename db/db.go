@@ -25,6 +25,7 @@ type Conn interface {
 	QueryRow(query string, args ...interface{}) *sql.Row
 	SetMaxIdleConns(n int)
 	SetMaxOpenConns(n int)
+	EncryptionStrategy() EncryptionStrategy
 }
 
 //go:generate counterfeiter . Tx
@@ -55,6 +56,29 @@ func (wrapped *wrappedDB) Begin() (Tx, error) {
 	return wrapped.DB.Begin()
 }
 
+func (wrapped *wrappedDB) EncryptionStrategy() EncryptionStrategy {
+	return NoEncryption{}
+}
+
+// WithEncryption returns a Conn which encrypts and decrypts sensitive data
+// using the given strategy.
+func WithEncryption(conn Conn, strategy EncryptionStrategy) Conn {
+	return &encryptedConn{
+		Conn:     conn,
+		strategy: strategy,
+	}
+}
+
+type encryptedConn struct {
+	Conn
+
+	strategy EncryptionStrategy
+}
+
+func (conn *encryptedConn) EncryptionStrategy() EncryptionStrategy {
+	return conn.strategy
+}
+
 func swallowUniqueViolation(err error) error {
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok {
@@ -85,6 +109,7 @@ type DB interface {
 
 	GetTaskLock(logger lager.Logger, taskName string) (Lock, bool, error)
 
+	GetUnreapedOneOffBuildsEndedBefore(endTime time.Time, limit int) ([]Build, error)
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
 	Workers() ([]SavedWorker, error) // auto-expires workers based on ttl
@@ -109,6 +134,7 @@ type DB interface {
 	SetVolumeTTL(string, time.Duration) error
 	GetVolumeTTL(volumeHandle string) (time.Duration, bool, error)
 	GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
+	MarkVolumeAsTaskCache(handle string, id TaskCacheIdentifier) error
 }
 
 //go:generate counterfeiter . Notifier
@@ -122,6 +148,7 @@ type Notifier interface {
 type ConfigVersion int
 
 var ErrConfigComparisonFailed = errors.New("comparison with existing config failed during save")
+var ErrPipelineAlreadyExists = errors.New("pipeline already exists")
 var ErrEndOfBuildEventStream = errors.New("end of build event stream")
 var ErrBuildEventStreamClosed = errors.New("build event stream closed")
 
@@ -227,6 +227,43 @@ var _ = Describe("Builds", func() {
 		})
 	})
 
+	Describe("GetUnreapedOneOffBuildsEndedBefore", func() {
+		It("returns the unreaped one-off builds that finished before the given time", func() {
+			finishedBuild, err := teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = finishedBuild.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			reapedBuild, err := teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = reapedBuild.Finish(db.StatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.DeleteBuildEventsByBuildIDs([]int{reapedBuild.ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			jobBuild, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = jobBuild.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := database.GetUnreapedOneOffBuildsEndedBefore(time.Now().Add(time.Hour), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(finishedBuild.ID()))
+
+			builds, err = database.GetUnreapedOneOffBuildsEndedBefore(time.Now().Add(-time.Hour), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+	})
+
 	Describe("DeleteBuildEventsByBuildIDs", func() {
 		It("deletes all build logs corresponding to the given build ids", func() {
 			build1DB, err := teamDB.CreateOneOffBuild()
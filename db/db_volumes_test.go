@@ -588,6 +588,104 @@ var _ = Describe("Keeping track of volumes", func() {
 				Expect(handles).To(ConsistOf([]string{"my-import-handle", "my-other-import-handle"}))
 			})
 		})
+
+		Describe("task cache volumes", func() {
+			var cacheIdentifier db.TaskCacheIdentifier
+
+			BeforeEach(func() {
+				cacheIdentifier = db.TaskCacheIdentifier{
+					PipelineID: pipelineDB.GetPipelineID(),
+					JobName:    "some-job",
+					StepName:   "some-task",
+					Path:       "some/cache",
+					WorkerName: "some-worker",
+				}
+
+				err := database.InsertVolume(db.Volume{
+					WorkerName: "some-worker",
+					TTL:        5 * time.Minute,
+					Handle:     "my-workspace-handle",
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			findCaches := func() []string {
+				volumes, err := database.GetVolumesByIdentifier(db.VolumeIdentifier{
+					TaskCache: &cacheIdentifier,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				handles := []string{}
+				for _, volume := range volumes {
+					handles = append(handles, volume.Handle)
+				}
+
+				return handles
+			}
+
+			It("are only found once marked as the cache", func() {
+				Expect(findCaches()).To(BeEmpty())
+
+				err := database.MarkVolumeAsTaskCache("my-workspace-handle", cacheIdentifier)
+				Expect(err).NotTo(HaveOccurred())
+
+				volumes, err := database.GetVolumesByIdentifier(db.VolumeIdentifier{
+					TaskCache: &cacheIdentifier,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(HaveLen(1))
+				Expect(volumes[0].Handle).To(Equal("my-workspace-handle"))
+				Expect(volumes[0].TTL).To(BeZero())
+				Expect(volumes[0].Volume.Identifier.TaskCache).To(Equal(&cacheIdentifier))
+			})
+
+			It("clears the previous cache when a new one is marked", func() {
+				err := database.MarkVolumeAsTaskCache("my-workspace-handle", cacheIdentifier)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = database.InsertVolume(db.Volume{
+					WorkerName: "some-worker",
+					TTL:        5 * time.Minute,
+					Handle:     "my-next-workspace-handle",
+					Identifier: db.VolumeIdentifier{
+						COW: &db.COWIdentifier{
+							ParentVolumeHandle: "my-workspace-handle",
+						},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = database.MarkVolumeAsTaskCache("my-next-workspace-handle", cacheIdentifier)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(findCaches()).To(Equal([]string{"my-next-workspace-handle"}))
+
+				clearedIdentifier := cacheIdentifier
+				clearedIdentifier.Cleared = true
+
+				volumes, err := database.GetVolumesByIdentifier(db.VolumeIdentifier{
+					TaskCache: &clearedIdentifier,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(HaveLen(1))
+				Expect(volumes[0].Handle).To(Equal("my-workspace-handle"))
+			})
+
+			It("returns an error when the volume does not exist", func() {
+				err := database.MarkVolumeAsTaskCache("bogus-handle", cacheIdentifier)
+				Expect(err).To(Equal(db.ErrVolumeNotFound))
+			})
+
+			It("can be cleared for a job", func() {
+				err := database.MarkVolumeAsTaskCache("my-workspace-handle", cacheIdentifier)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.ClearTaskCaches("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(findCaches()).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("GetVolumesForOneOffBuildImageResources", func() {
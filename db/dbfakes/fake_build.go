@@ -179,6 +179,61 @@ type FakeBuild struct {
 		result1 db.Notifier
 		result2 error
 	}
+	RequestApprovalStub        func(planID atc.PlanID, approvers []string, message string) (db.BuildApproval, error)
+	requestApprovalMutex       sync.RWMutex
+	requestApprovalArgsForCall []struct {
+		planID    atc.PlanID
+		approvers []string
+		message   string
+	}
+	requestApprovalReturns struct {
+		result1 db.BuildApproval
+		result2 error
+	}
+	GetApprovalStub        func(planID atc.PlanID) (db.BuildApproval, bool, error)
+	getApprovalMutex       sync.RWMutex
+	getApprovalArgsForCall []struct {
+		planID atc.PlanID
+	}
+	getApprovalReturns struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	GetPendingApprovalsStub        func() ([]db.BuildApproval, error)
+	getPendingApprovalsMutex       sync.RWMutex
+	getPendingApprovalsArgsForCall []struct{}
+	getPendingApprovalsReturns     struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	ApproveStub        func(planID atc.PlanID, approver string) (bool, error)
+	approveMutex       sync.RWMutex
+	approveArgsForCall []struct {
+		planID   atc.PlanID
+		approver string
+	}
+	approveReturns struct {
+		result1 bool
+		result2 error
+	}
+	CloseApprovalStub        func(planID atc.PlanID) error
+	closeApprovalMutex       sync.RWMutex
+	closeApprovalArgsForCall []struct {
+		planID atc.PlanID
+	}
+	closeApprovalReturns struct {
+		result1 error
+	}
+	ApprovalNotifierStub        func(planID atc.PlanID) (db.Notifier, error)
+	approvalNotifierMutex       sync.RWMutex
+	approvalNotifierArgsForCall []struct {
+		planID atc.PlanID
+	}
+	approvalNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
 	AcquireTrackingLockStub        func(logger lager.Logger, interval time.Duration) (db.Lock, bool, error)
 	acquireTrackingLockMutex       sync.RWMutex
 	acquireTrackingLockArgsForCall []struct {
@@ -933,6 +988,210 @@ func (fake *FakeBuild) AbortNotifierReturns(result1 db.Notifier, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RequestApproval(planID atc.PlanID, approvers []string, message string) (db.BuildApproval, error) {
+	var approversCopy []string
+	if approvers != nil {
+		approversCopy = make([]string, len(approvers))
+		copy(approversCopy, approvers)
+	}
+	fake.requestApprovalMutex.Lock()
+	fake.requestApprovalArgsForCall = append(fake.requestApprovalArgsForCall, struct {
+		planID    atc.PlanID
+		approvers []string
+		message   string
+	}{planID, approversCopy, message})
+	fake.recordInvocation("RequestApproval", []interface{}{planID, approversCopy, message})
+	fake.requestApprovalMutex.Unlock()
+	if fake.RequestApprovalStub != nil {
+		return fake.RequestApprovalStub(planID, approvers, message)
+	} else {
+		return fake.requestApprovalReturns.result1, fake.requestApprovalReturns.result2
+	}
+}
+
+func (fake *FakeBuild) RequestApprovalCallCount() int {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return len(fake.requestApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) RequestApprovalArgsForCall(i int) (atc.PlanID, []string, string) {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return fake.requestApprovalArgsForCall[i].planID, fake.requestApprovalArgsForCall[i].approvers, fake.requestApprovalArgsForCall[i].message
+}
+
+func (fake *FakeBuild) RequestApprovalReturns(result1 db.BuildApproval, result2 error) {
+	fake.RequestApprovalStub = nil
+	fake.requestApprovalReturns = struct {
+		result1 db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetApproval(planID atc.PlanID) (db.BuildApproval, bool, error) {
+	fake.getApprovalMutex.Lock()
+	fake.getApprovalArgsForCall = append(fake.getApprovalArgsForCall, struct {
+		planID atc.PlanID
+	}{planID})
+	fake.recordInvocation("GetApproval", []interface{}{planID})
+	fake.getApprovalMutex.Unlock()
+	if fake.GetApprovalStub != nil {
+		return fake.GetApprovalStub(planID)
+	} else {
+		return fake.getApprovalReturns.result1, fake.getApprovalReturns.result2, fake.getApprovalReturns.result3
+	}
+}
+
+func (fake *FakeBuild) GetApprovalCallCount() int {
+	fake.getApprovalMutex.RLock()
+	defer fake.getApprovalMutex.RUnlock()
+	return len(fake.getApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) GetApprovalArgsForCall(i int) atc.PlanID {
+	fake.getApprovalMutex.RLock()
+	defer fake.getApprovalMutex.RUnlock()
+	return fake.getApprovalArgsForCall[i].planID
+}
+
+func (fake *FakeBuild) GetApprovalReturns(result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.GetApprovalStub = nil
+	fake.getApprovalReturns = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) GetPendingApprovals() ([]db.BuildApproval, error) {
+	fake.getPendingApprovalsMutex.Lock()
+	fake.getPendingApprovalsArgsForCall = append(fake.getPendingApprovalsArgsForCall, struct{}{})
+	fake.recordInvocation("GetPendingApprovals", []interface{}{})
+	fake.getPendingApprovalsMutex.Unlock()
+	if fake.GetPendingApprovalsStub != nil {
+		return fake.GetPendingApprovalsStub()
+	} else {
+		return fake.getPendingApprovalsReturns.result1, fake.getPendingApprovalsReturns.result2
+	}
+}
+
+func (fake *FakeBuild) GetPendingApprovalsCallCount() int {
+	fake.getPendingApprovalsMutex.RLock()
+	defer fake.getPendingApprovalsMutex.RUnlock()
+	return len(fake.getPendingApprovalsArgsForCall)
+}
+
+func (fake *FakeBuild) GetPendingApprovalsReturns(result1 []db.BuildApproval, result2 error) {
+	fake.GetPendingApprovalsStub = nil
+	fake.getPendingApprovalsReturns = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Approve(planID atc.PlanID, approver string) (bool, error) {
+	fake.approveMutex.Lock()
+	fake.approveArgsForCall = append(fake.approveArgsForCall, struct {
+		planID   atc.PlanID
+		approver string
+	}{planID, approver})
+	fake.recordInvocation("Approve", []interface{}{planID, approver})
+	fake.approveMutex.Unlock()
+	if fake.ApproveStub != nil {
+		return fake.ApproveStub(planID, approver)
+	} else {
+		return fake.approveReturns.result1, fake.approveReturns.result2
+	}
+}
+
+func (fake *FakeBuild) ApproveCallCount() int {
+	fake.approveMutex.RLock()
+	defer fake.approveMutex.RUnlock()
+	return len(fake.approveArgsForCall)
+}
+
+func (fake *FakeBuild) ApproveArgsForCall(i int) (atc.PlanID, string) {
+	fake.approveMutex.RLock()
+	defer fake.approveMutex.RUnlock()
+	return fake.approveArgsForCall[i].planID, fake.approveArgsForCall[i].approver
+}
+
+func (fake *FakeBuild) ApproveReturns(result1 bool, result2 error) {
+	fake.ApproveStub = nil
+	fake.approveReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) CloseApproval(planID atc.PlanID) error {
+	fake.closeApprovalMutex.Lock()
+	fake.closeApprovalArgsForCall = append(fake.closeApprovalArgsForCall, struct {
+		planID atc.PlanID
+	}{planID})
+	fake.recordInvocation("CloseApproval", []interface{}{planID})
+	fake.closeApprovalMutex.Unlock()
+	if fake.CloseApprovalStub != nil {
+		return fake.CloseApprovalStub(planID)
+	} else {
+		return fake.closeApprovalReturns.result1
+	}
+}
+
+func (fake *FakeBuild) CloseApprovalCallCount() int {
+	fake.closeApprovalMutex.RLock()
+	defer fake.closeApprovalMutex.RUnlock()
+	return len(fake.closeApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) CloseApprovalArgsForCall(i int) atc.PlanID {
+	fake.closeApprovalMutex.RLock()
+	defer fake.closeApprovalMutex.RUnlock()
+	return fake.closeApprovalArgsForCall[i].planID
+}
+
+func (fake *FakeBuild) CloseApprovalReturns(result1 error) {
+	fake.CloseApprovalStub = nil
+	fake.closeApprovalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ApprovalNotifier(planID atc.PlanID) (db.Notifier, error) {
+	fake.approvalNotifierMutex.Lock()
+	fake.approvalNotifierArgsForCall = append(fake.approvalNotifierArgsForCall, struct {
+		planID atc.PlanID
+	}{planID})
+	fake.recordInvocation("ApprovalNotifier", []interface{}{planID})
+	fake.approvalNotifierMutex.Unlock()
+	if fake.ApprovalNotifierStub != nil {
+		return fake.ApprovalNotifierStub(planID)
+	} else {
+		return fake.approvalNotifierReturns.result1, fake.approvalNotifierReturns.result2
+	}
+}
+
+func (fake *FakeBuild) ApprovalNotifierCallCount() int {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	return len(fake.approvalNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalNotifierArgsForCall(i int) atc.PlanID {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	return fake.approvalNotifierArgsForCall[i].planID
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.ApprovalNotifierStub = nil
+	fake.approvalNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (db.Lock, bool, error) {
 	fake.acquireTrackingLockMutex.Lock()
 	fake.acquireTrackingLockArgsForCall = append(fake.acquireTrackingLockArgsForCall, struct {
@@ -1264,6 +1523,18 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortMutex.RUnlock()
 	fake.abortNotifierMutex.RLock()
 	defer fake.abortNotifierMutex.RUnlock()
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	fake.getApprovalMutex.RLock()
+	defer fake.getApprovalMutex.RUnlock()
+	fake.getPendingApprovalsMutex.RLock()
+	defer fake.getPendingApprovalsMutex.RUnlock()
+	fake.approveMutex.RLock()
+	defer fake.approveMutex.RUnlock()
+	fake.closeApprovalMutex.RLock()
+	defer fake.closeApprovalMutex.RUnlock()
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
 	fake.getPreparationMutex.RLock()
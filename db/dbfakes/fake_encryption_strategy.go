@@ -0,0 +1,132 @@
+// This file was generated by counterfeiter
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+)
+
+type FakeEncryptionStrategy struct {
+	EncryptStub        func(plaintext []byte) (string, *string, error)
+	encryptMutex       sync.RWMutex
+	encryptArgsForCall []struct {
+		plaintext []byte
+	}
+	encryptReturns struct {
+		result1 string
+		result2 *string
+		result3 error
+	}
+	DecryptStub        func(text string, nonce *string) ([]byte, error)
+	decryptMutex       sync.RWMutex
+	decryptArgsForCall []struct {
+		text  string
+		nonce *string
+	}
+	decryptReturns struct {
+		result1 []byte
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEncryptionStrategy) Encrypt(plaintext []byte) (string, *string, error) {
+	var plaintextCopy []byte
+	if plaintext != nil {
+		plaintextCopy = make([]byte, len(plaintext))
+		copy(plaintextCopy, plaintext)
+	}
+	fake.encryptMutex.Lock()
+	fake.encryptArgsForCall = append(fake.encryptArgsForCall, struct {
+		plaintext []byte
+	}{plaintextCopy})
+	fake.recordInvocation("Encrypt", []interface{}{plaintextCopy})
+	fake.encryptMutex.Unlock()
+	if fake.EncryptStub != nil {
+		return fake.EncryptStub(plaintext)
+	} else {
+		return fake.encryptReturns.result1, fake.encryptReturns.result2, fake.encryptReturns.result3
+	}
+}
+
+func (fake *FakeEncryptionStrategy) EncryptCallCount() int {
+	fake.encryptMutex.RLock()
+	defer fake.encryptMutex.RUnlock()
+	return len(fake.encryptArgsForCall)
+}
+
+func (fake *FakeEncryptionStrategy) EncryptArgsForCall(i int) []byte {
+	fake.encryptMutex.RLock()
+	defer fake.encryptMutex.RUnlock()
+	return fake.encryptArgsForCall[i].plaintext
+}
+
+func (fake *FakeEncryptionStrategy) EncryptReturns(result1 string, result2 *string, result3 error) {
+	fake.EncryptStub = nil
+	fake.encryptReturns = struct {
+		result1 string
+		result2 *string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeEncryptionStrategy) Decrypt(text string, nonce *string) ([]byte, error) {
+	fake.decryptMutex.Lock()
+	fake.decryptArgsForCall = append(fake.decryptArgsForCall, struct {
+		text  string
+		nonce *string
+	}{text, nonce})
+	fake.recordInvocation("Decrypt", []interface{}{text, nonce})
+	fake.decryptMutex.Unlock()
+	if fake.DecryptStub != nil {
+		return fake.DecryptStub(text, nonce)
+	} else {
+		return fake.decryptReturns.result1, fake.decryptReturns.result2
+	}
+}
+
+func (fake *FakeEncryptionStrategy) DecryptCallCount() int {
+	fake.decryptMutex.RLock()
+	defer fake.decryptMutex.RUnlock()
+	return len(fake.decryptArgsForCall)
+}
+
+func (fake *FakeEncryptionStrategy) DecryptArgsForCall(i int) (string, *string) {
+	fake.decryptMutex.RLock()
+	defer fake.decryptMutex.RUnlock()
+	return fake.decryptArgsForCall[i].text, fake.decryptArgsForCall[i].nonce
+}
+
+func (fake *FakeEncryptionStrategy) DecryptReturns(result1 []byte, result2 error) {
+	fake.DecryptStub = nil
+	fake.decryptReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEncryptionStrategy) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.encryptMutex.RLock()
+	defer fake.encryptMutex.RUnlock()
+	fake.decryptMutex.RLock()
+	defer fake.decryptMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeEncryptionStrategy) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.EncryptionStrategy = new(FakeEncryptionStrategy)
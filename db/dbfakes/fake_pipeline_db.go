@@ -2,6 +2,7 @@
 package dbfakes
 
 import (
+	"io"
 	"sync"
 	"time"
 
@@ -309,6 +310,16 @@ type FakePipelineDB struct {
 	updateFirstLoggedBuildIDReturns struct {
 		result1 error
 	}
+	UpdateJobScheduleCheckedTimeStub        func(job string, checkedTime time.Time) (time.Time, error)
+	updateJobScheduleCheckedTimeMutex       sync.RWMutex
+	updateJobScheduleCheckedTimeArgsForCall []struct {
+		job         string
+		checkedTime time.Time
+	}
+	updateJobScheduleCheckedTimeReturns struct {
+		result1 time.Time
+		result2 error
+	}
 	GetJobFinishedAndNextBuildStub        func(job string) (db.Build, db.Build, error)
 	getJobFinishedAndNextBuildMutex       sync.RWMutex
 	getJobFinishedAndNextBuildArgsForCall []struct {
@@ -555,6 +566,74 @@ type FakePipelineDB struct {
 	hideReturns     struct {
 		result1 error
 	}
+	TeamNameStub        func() string
+	teamNameMutex       sync.RWMutex
+	teamNameArgsForCall []struct{}
+	teamNameReturns     struct {
+		result1 string
+	}
+	ClearTaskCachesStub        func(job string) error
+	clearTaskCachesMutex       sync.RWMutex
+	clearTaskCachesArgsForCall []struct {
+		job string
+	}
+	clearTaskCachesReturns struct {
+		result1 error
+	}
+	PinVersionedResourceStub        func(resourceName string, versionedResourceID int) error
+	pinVersionedResourceMutex       sync.RWMutex
+	pinVersionedResourceArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+	}
+	pinVersionedResourceReturns struct {
+		result1 error
+	}
+	UnpinVersionedResourceStub        func(resourceName string, versionedResourceID int) error
+	unpinVersionedResourceMutex       sync.RWMutex
+	unpinVersionedResourceArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+	}
+	unpinVersionedResourceReturns struct {
+		result1 error
+	}
+	GetLatestSucceededJobBuildsStub        func(job string, limit int) ([]db.Build, error)
+	getLatestSucceededJobBuildsMutex       sync.RWMutex
+	getLatestSucceededJobBuildsArgsForCall []struct {
+		job   string
+		limit int
+	}
+	getLatestSucceededJobBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetUnreapedJobBuildsAfterStub        func(job string, buildID int, limit int) ([]db.Build, error)
+	getUnreapedJobBuildsAfterMutex       sync.RWMutex
+	getUnreapedJobBuildsAfterArgsForCall []struct {
+		job     string
+		buildID int
+		limit   int
+	}
+	getUnreapedJobBuildsAfterReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	ArchiveStub        func() error
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct{}
+	archiveReturns     struct {
+		result1 error
+	}
+	ExportStub        func(w io.Writer, includeEvents bool) error
+	exportMutex       sync.RWMutex
+	exportArgsForCall []struct {
+		w             io.Writer
+		includeEvents bool
+	}
+	exportReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1677,6 +1756,41 @@ func (fake *FakePipelineDB) UpdateFirstLoggedBuildIDReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) UpdateJobScheduleCheckedTime(job string, checkedTime time.Time) (time.Time, error) {
+	fake.updateJobScheduleCheckedTimeMutex.Lock()
+	fake.updateJobScheduleCheckedTimeArgsForCall = append(fake.updateJobScheduleCheckedTimeArgsForCall, struct {
+		job         string
+		checkedTime time.Time
+	}{job, checkedTime})
+	fake.recordInvocation("UpdateJobScheduleCheckedTime", []interface{}{job, checkedTime})
+	fake.updateJobScheduleCheckedTimeMutex.Unlock()
+	if fake.UpdateJobScheduleCheckedTimeStub != nil {
+		return fake.UpdateJobScheduleCheckedTimeStub(job, checkedTime)
+	} else {
+		return fake.updateJobScheduleCheckedTimeReturns.result1, fake.updateJobScheduleCheckedTimeReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) UpdateJobScheduleCheckedTimeCallCount() int {
+	fake.updateJobScheduleCheckedTimeMutex.RLock()
+	defer fake.updateJobScheduleCheckedTimeMutex.RUnlock()
+	return len(fake.updateJobScheduleCheckedTimeArgsForCall)
+}
+
+func (fake *FakePipelineDB) UpdateJobScheduleCheckedTimeArgsForCall(i int) (string, time.Time) {
+	fake.updateJobScheduleCheckedTimeMutex.RLock()
+	defer fake.updateJobScheduleCheckedTimeMutex.RUnlock()
+	return fake.updateJobScheduleCheckedTimeArgsForCall[i].job, fake.updateJobScheduleCheckedTimeArgsForCall[i].checkedTime
+}
+
+func (fake *FakePipelineDB) UpdateJobScheduleCheckedTimeReturns(result1 time.Time, result2 error) {
+	fake.UpdateJobScheduleCheckedTimeStub = nil
+	fake.updateJobScheduleCheckedTimeReturns = struct {
+		result1 time.Time
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) GetJobFinishedAndNextBuild(job string) (db.Build, db.Build, error) {
 	fake.getJobFinishedAndNextBuildMutex.Lock()
 	fake.getJobFinishedAndNextBuildArgsForCall = append(fake.getJobFinishedAndNextBuildArgsForCall, struct {
@@ -2583,6 +2697,262 @@ func (fake *FakePipelineDB) HideReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) TeamName() string {
+	fake.teamNameMutex.Lock()
+	fake.teamNameArgsForCall = append(fake.teamNameArgsForCall, struct{}{})
+	fake.recordInvocation("TeamName", []interface{}{})
+	fake.teamNameMutex.Unlock()
+	if fake.TeamNameStub != nil {
+		return fake.TeamNameStub()
+	} else {
+		return fake.teamNameReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) TeamNameCallCount() int {
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	return len(fake.teamNameArgsForCall)
+}
+
+func (fake *FakePipelineDB) TeamNameReturns(result1 string) {
+	fake.TeamNameStub = nil
+	fake.teamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipelineDB) ClearTaskCaches(job string) error {
+	fake.clearTaskCachesMutex.Lock()
+	fake.clearTaskCachesArgsForCall = append(fake.clearTaskCachesArgsForCall, struct {
+		job string
+	}{job})
+	fake.recordInvocation("ClearTaskCaches", []interface{}{job})
+	fake.clearTaskCachesMutex.Unlock()
+	if fake.ClearTaskCachesStub != nil {
+		return fake.ClearTaskCachesStub(job)
+	} else {
+		return fake.clearTaskCachesReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ClearTaskCachesCallCount() int {
+	fake.clearTaskCachesMutex.RLock()
+	defer fake.clearTaskCachesMutex.RUnlock()
+	return len(fake.clearTaskCachesArgsForCall)
+}
+
+func (fake *FakePipelineDB) ClearTaskCachesArgsForCall(i int) string {
+	fake.clearTaskCachesMutex.RLock()
+	defer fake.clearTaskCachesMutex.RUnlock()
+	return fake.clearTaskCachesArgsForCall[i].job
+}
+
+func (fake *FakePipelineDB) ClearTaskCachesReturns(result1 error) {
+	fake.ClearTaskCachesStub = nil
+	fake.clearTaskCachesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) PinVersionedResource(resourceName string, versionedResourceID int) error {
+	fake.pinVersionedResourceMutex.Lock()
+	fake.pinVersionedResourceArgsForCall = append(fake.pinVersionedResourceArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+	}{resourceName, versionedResourceID})
+	fake.recordInvocation("PinVersionedResource", []interface{}{resourceName, versionedResourceID})
+	fake.pinVersionedResourceMutex.Unlock()
+	if fake.PinVersionedResourceStub != nil {
+		return fake.PinVersionedResourceStub(resourceName, versionedResourceID)
+	} else {
+		return fake.pinVersionedResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) PinVersionedResourceCallCount() int {
+	fake.pinVersionedResourceMutex.RLock()
+	defer fake.pinVersionedResourceMutex.RUnlock()
+	return len(fake.pinVersionedResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinVersionedResourceArgsForCall(i int) (string, int) {
+	fake.pinVersionedResourceMutex.RLock()
+	defer fake.pinVersionedResourceMutex.RUnlock()
+	return fake.pinVersionedResourceArgsForCall[i].resourceName, fake.pinVersionedResourceArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipelineDB) PinVersionedResourceReturns(result1 error) {
+	fake.PinVersionedResourceStub = nil
+	fake.pinVersionedResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) UnpinVersionedResource(resourceName string, versionedResourceID int) error {
+	fake.unpinVersionedResourceMutex.Lock()
+	fake.unpinVersionedResourceArgsForCall = append(fake.unpinVersionedResourceArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+	}{resourceName, versionedResourceID})
+	fake.recordInvocation("UnpinVersionedResource", []interface{}{resourceName, versionedResourceID})
+	fake.unpinVersionedResourceMutex.Unlock()
+	if fake.UnpinVersionedResourceStub != nil {
+		return fake.UnpinVersionedResourceStub(resourceName, versionedResourceID)
+	} else {
+		return fake.unpinVersionedResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) UnpinVersionedResourceCallCount() int {
+	fake.unpinVersionedResourceMutex.RLock()
+	defer fake.unpinVersionedResourceMutex.RUnlock()
+	return len(fake.unpinVersionedResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinVersionedResourceArgsForCall(i int) (string, int) {
+	fake.unpinVersionedResourceMutex.RLock()
+	defer fake.unpinVersionedResourceMutex.RUnlock()
+	return fake.unpinVersionedResourceArgsForCall[i].resourceName, fake.unpinVersionedResourceArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipelineDB) UnpinVersionedResourceReturns(result1 error) {
+	fake.UnpinVersionedResourceStub = nil
+	fake.unpinVersionedResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetLatestSucceededJobBuilds(job string, limit int) ([]db.Build, error) {
+	fake.getLatestSucceededJobBuildsMutex.Lock()
+	fake.getLatestSucceededJobBuildsArgsForCall = append(fake.getLatestSucceededJobBuildsArgsForCall, struct {
+		job   string
+		limit int
+	}{job, limit})
+	fake.recordInvocation("GetLatestSucceededJobBuilds", []interface{}{job, limit})
+	fake.getLatestSucceededJobBuildsMutex.Unlock()
+	if fake.GetLatestSucceededJobBuildsStub != nil {
+		return fake.GetLatestSucceededJobBuildsStub(job, limit)
+	} else {
+		return fake.getLatestSucceededJobBuildsReturns.result1, fake.getLatestSucceededJobBuildsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetLatestSucceededJobBuildsCallCount() int {
+	fake.getLatestSucceededJobBuildsMutex.RLock()
+	defer fake.getLatestSucceededJobBuildsMutex.RUnlock()
+	return len(fake.getLatestSucceededJobBuildsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetLatestSucceededJobBuildsArgsForCall(i int) (string, int) {
+	fake.getLatestSucceededJobBuildsMutex.RLock()
+	defer fake.getLatestSucceededJobBuildsMutex.RUnlock()
+	return fake.getLatestSucceededJobBuildsArgsForCall[i].job, fake.getLatestSucceededJobBuildsArgsForCall[i].limit
+}
+
+func (fake *FakePipelineDB) GetLatestSucceededJobBuildsReturns(result1 []db.Build, result2 error) {
+	fake.GetLatestSucceededJobBuildsStub = nil
+	fake.getLatestSucceededJobBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetUnreapedJobBuildsAfter(job string, buildID int, limit int) ([]db.Build, error) {
+	fake.getUnreapedJobBuildsAfterMutex.Lock()
+	fake.getUnreapedJobBuildsAfterArgsForCall = append(fake.getUnreapedJobBuildsAfterArgsForCall, struct {
+		job     string
+		buildID int
+		limit   int
+	}{job, buildID, limit})
+	fake.recordInvocation("GetUnreapedJobBuildsAfter", []interface{}{job, buildID, limit})
+	fake.getUnreapedJobBuildsAfterMutex.Unlock()
+	if fake.GetUnreapedJobBuildsAfterStub != nil {
+		return fake.GetUnreapedJobBuildsAfterStub(job, buildID, limit)
+	} else {
+		return fake.getUnreapedJobBuildsAfterReturns.result1, fake.getUnreapedJobBuildsAfterReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetUnreapedJobBuildsAfterCallCount() int {
+	fake.getUnreapedJobBuildsAfterMutex.RLock()
+	defer fake.getUnreapedJobBuildsAfterMutex.RUnlock()
+	return len(fake.getUnreapedJobBuildsAfterArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetUnreapedJobBuildsAfterArgsForCall(i int) (string, int, int) {
+	fake.getUnreapedJobBuildsAfterMutex.RLock()
+	defer fake.getUnreapedJobBuildsAfterMutex.RUnlock()
+	return fake.getUnreapedJobBuildsAfterArgsForCall[i].job, fake.getUnreapedJobBuildsAfterArgsForCall[i].buildID, fake.getUnreapedJobBuildsAfterArgsForCall[i].limit
+}
+
+func (fake *FakePipelineDB) GetUnreapedJobBuildsAfterReturns(result1 []db.Build, result2 error) {
+	fake.GetUnreapedJobBuildsAfterStub = nil
+	fake.getUnreapedJobBuildsAfterReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) Archive() error {
+	fake.archiveMutex.Lock()
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct{}{})
+	fake.recordInvocation("Archive", []interface{}{})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub()
+	} else {
+		return fake.archiveReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakePipelineDB) ArchiveReturns(result1 error) {
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) Export(w io.Writer, includeEvents bool) error {
+	fake.exportMutex.Lock()
+	fake.exportArgsForCall = append(fake.exportArgsForCall, struct {
+		w             io.Writer
+		includeEvents bool
+	}{w, includeEvents})
+	fake.recordInvocation("Export", []interface{}{w, includeEvents})
+	fake.exportMutex.Unlock()
+	if fake.ExportStub != nil {
+		return fake.ExportStub(w, includeEvents)
+	} else {
+		return fake.exportReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ExportCallCount() int {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	return len(fake.exportArgsForCall)
+}
+
+func (fake *FakePipelineDB) ExportArgsForCall(i int) (io.Writer, bool) {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	return fake.exportArgsForCall[i].w, fake.exportArgsForCall[i].includeEvents
+}
+
+func (fake *FakePipelineDB) ExportReturns(result1 error) {
+	fake.ExportStub = nil
+	fake.exportReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2658,6 +3028,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
 	defer fake.updateFirstLoggedBuildIDMutex.RUnlock()
+	fake.updateJobScheduleCheckedTimeMutex.RLock()
+	defer fake.updateJobScheduleCheckedTimeMutex.RUnlock()
 	fake.getJobFinishedAndNextBuildMutex.RLock()
 	defer fake.getJobFinishedAndNextBuildMutex.RUnlock()
 	fake.getJobBuildsMutex.RLock()
@@ -2712,6 +3084,22 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.exposeMutex.RUnlock()
 	fake.hideMutex.RLock()
 	defer fake.hideMutex.RUnlock()
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	fake.clearTaskCachesMutex.RLock()
+	defer fake.clearTaskCachesMutex.RUnlock()
+	fake.pinVersionedResourceMutex.RLock()
+	defer fake.pinVersionedResourceMutex.RUnlock()
+	fake.unpinVersionedResourceMutex.RLock()
+	defer fake.unpinVersionedResourceMutex.RUnlock()
+	fake.getLatestSucceededJobBuildsMutex.RLock()
+	defer fake.getLatestSucceededJobBuildsMutex.RUnlock()
+	fake.getUnreapedJobBuildsAfterMutex.RLock()
+	defer fake.getUnreapedJobBuildsAfterMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
 	return fake.invocations
 }
 
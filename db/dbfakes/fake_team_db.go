@@ -166,6 +166,49 @@ type FakeTeamDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	SaveConfigByStub        func(savedBy string, pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.SavedPipeline, bool, error)
+	saveConfigByMutex       sync.RWMutex
+	saveConfigByArgsForCall []struct {
+		savedBy      string
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}
+	saveConfigByReturns struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}
+	GetConfigVersionsStub        func(pipelineName string) ([]db.PipelineConfigVersion, error)
+	getConfigVersionsMutex       sync.RWMutex
+	getConfigVersionsArgsForCall []struct {
+		pipelineName string
+	}
+	getConfigVersionsReturns struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}
+	GetConfigVersionStub        func(pipelineName string, version db.ConfigVersion) (db.PipelineConfigVersion, bool, error)
+	getConfigVersionMutex       sync.RWMutex
+	getConfigVersionArgsForCall []struct {
+		pipelineName string
+		version      db.ConfigVersion
+	}
+	getConfigVersionReturns struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	ImportPipelineStub        func(atc.PipelineExport) (db.SavedPipeline, error)
+	importPipelineMutex       sync.RWMutex
+	importPipelineArgsForCall []struct {
+		arg1 atc.PipelineExport
+	}
+	importPipelineReturns struct {
+		result1 db.SavedPipeline
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -740,6 +783,149 @@ func (fake *FakeTeamDB) GetVolumesReturns(result1 []db.SavedVolume, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) SaveConfigBy(savedBy string, pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.SavedPipeline, bool, error) {
+	fake.saveConfigByMutex.Lock()
+	fake.saveConfigByArgsForCall = append(fake.saveConfigByArgsForCall, struct {
+		savedBy      string
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}{savedBy, pipelineName, config, from, pausedState})
+	fake.recordInvocation("SaveConfigBy", []interface{}{savedBy, pipelineName, config, from, pausedState})
+	fake.saveConfigByMutex.Unlock()
+	if fake.SaveConfigByStub != nil {
+		return fake.SaveConfigByStub(savedBy, pipelineName, config, from, pausedState)
+	} else {
+		return fake.saveConfigByReturns.result1, fake.saveConfigByReturns.result2, fake.saveConfigByReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) SaveConfigByCallCount() int {
+	fake.saveConfigByMutex.RLock()
+	defer fake.saveConfigByMutex.RUnlock()
+	return len(fake.saveConfigByArgsForCall)
+}
+
+func (fake *FakeTeamDB) SaveConfigByArgsForCall(i int) (string, string, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+	fake.saveConfigByMutex.RLock()
+	defer fake.saveConfigByMutex.RUnlock()
+	return fake.saveConfigByArgsForCall[i].savedBy, fake.saveConfigByArgsForCall[i].pipelineName, fake.saveConfigByArgsForCall[i].config, fake.saveConfigByArgsForCall[i].from, fake.saveConfigByArgsForCall[i].pausedState
+}
+
+func (fake *FakeTeamDB) SaveConfigByReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
+	fake.SaveConfigByStub = nil
+	fake.saveConfigByReturns = struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) GetConfigVersions(pipelineName string) ([]db.PipelineConfigVersion, error) {
+	fake.getConfigVersionsMutex.Lock()
+	fake.getConfigVersionsArgsForCall = append(fake.getConfigVersionsArgsForCall, struct {
+		pipelineName string
+	}{pipelineName})
+	fake.recordInvocation("GetConfigVersions", []interface{}{pipelineName})
+	fake.getConfigVersionsMutex.Unlock()
+	if fake.GetConfigVersionsStub != nil {
+		return fake.GetConfigVersionsStub(pipelineName)
+	} else {
+		return fake.getConfigVersionsReturns.result1, fake.getConfigVersionsReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsCallCount() int {
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	return len(fake.getConfigVersionsArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsArgsForCall(i int) string {
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	return fake.getConfigVersionsArgsForCall[i].pipelineName
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsReturns(result1 []db.PipelineConfigVersion, result2 error) {
+	fake.GetConfigVersionsStub = nil
+	fake.getConfigVersionsReturns = struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetConfigVersion(pipelineName string, version db.ConfigVersion) (db.PipelineConfigVersion, bool, error) {
+	fake.getConfigVersionMutex.Lock()
+	fake.getConfigVersionArgsForCall = append(fake.getConfigVersionArgsForCall, struct {
+		pipelineName string
+		version      db.ConfigVersion
+	}{pipelineName, version})
+	fake.recordInvocation("GetConfigVersion", []interface{}{pipelineName, version})
+	fake.getConfigVersionMutex.Unlock()
+	if fake.GetConfigVersionStub != nil {
+		return fake.GetConfigVersionStub(pipelineName, version)
+	} else {
+		return fake.getConfigVersionReturns.result1, fake.getConfigVersionReturns.result2, fake.getConfigVersionReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigVersionCallCount() int {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	return len(fake.getConfigVersionArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigVersionArgsForCall(i int) (string, db.ConfigVersion) {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	return fake.getConfigVersionArgsForCall[i].pipelineName, fake.getConfigVersionArgsForCall[i].version
+}
+
+func (fake *FakeTeamDB) GetConfigVersionReturns(result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.GetConfigVersionStub = nil
+	fake.getConfigVersionReturns = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) ImportPipeline(arg1 atc.PipelineExport) (db.SavedPipeline, error) {
+	fake.importPipelineMutex.Lock()
+	fake.importPipelineArgsForCall = append(fake.importPipelineArgsForCall, struct {
+		arg1 atc.PipelineExport
+	}{arg1})
+	fake.recordInvocation("ImportPipeline", []interface{}{arg1})
+	fake.importPipelineMutex.Unlock()
+	if fake.ImportPipelineStub != nil {
+		return fake.ImportPipelineStub(arg1)
+	} else {
+		return fake.importPipelineReturns.result1, fake.importPipelineReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) ImportPipelineCallCount() int {
+	fake.importPipelineMutex.RLock()
+	defer fake.importPipelineMutex.RUnlock()
+	return len(fake.importPipelineArgsForCall)
+}
+
+func (fake *FakeTeamDB) ImportPipelineArgsForCall(i int) atc.PipelineExport {
+	fake.importPipelineMutex.RLock()
+	defer fake.importPipelineMutex.RUnlock()
+	return fake.importPipelineArgsForCall[i].arg1
+}
+
+func (fake *FakeTeamDB) ImportPipelineReturns(result1 db.SavedPipeline, result2 error) {
+	fake.ImportPipelineStub = nil
+	fake.importPipelineReturns = struct {
+		result1 db.SavedPipeline
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -779,6 +965,14 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.findContainersByDescriptorsMutex.RUnlock()
 	fake.getVolumesMutex.RLock()
 	defer fake.getVolumesMutex.RUnlock()
+	fake.saveConfigByMutex.RLock()
+	defer fake.saveConfigByMutex.RUnlock()
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	fake.importPipelineMutex.RLock()
+	defer fake.importPipelineMutex.RUnlock()
 	return fake.invocations
 }
 
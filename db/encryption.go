@@ -0,0 +1,112 @@
+package db
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+//go:generate counterfeiter . EncryptionStrategy
+
+// EncryptionStrategy encrypts sensitive data (pipeline configs, resource
+// metadata, build plans) before it is written to the database, and decrypts
+// it when it is read back.
+//
+// Encrypt returns the ciphertext along with the nonce used to produce it. A
+// nil nonce means the data was stored as plaintext.
+type EncryptionStrategy interface {
+	Encrypt(plaintext []byte) (string, *string, error)
+	Decrypt(text string, nonce *string) ([]byte, error)
+}
+
+// ErrDataIsEncrypted is returned when encrypted data is read without an
+// encryption key configured.
+var ErrDataIsEncrypted = errors.New("failed to decrypt data that is encrypted")
+
+// NoEncryption stores data as plaintext.
+type NoEncryption struct{}
+
+func (NoEncryption) Encrypt(plaintext []byte) (string, *string, error) {
+	return string(plaintext), nil, nil
+}
+
+func (NoEncryption) Decrypt(text string, nonce *string) ([]byte, error) {
+	if nonce != nil {
+		return nil, ErrDataIsEncrypted
+	}
+
+	return []byte(text), nil
+}
+
+type aesGCMEncryption struct {
+	aesgcm cipher.AEAD
+}
+
+// NewAESGCMEncryption returns an EncryptionStrategy which encrypts data using
+// the given AES-GCM cipher. Data which was written before encryption was
+// enabled (i.e. has no nonce) is read as plaintext.
+func NewAESGCMEncryption(aesgcm cipher.AEAD) EncryptionStrategy {
+	return aesGCMEncryption{aesgcm: aesgcm}
+}
+
+func (e aesGCMEncryption) Encrypt(plaintext []byte) (string, *string, error) {
+	nonce := make([]byte, e.aesgcm.NonceSize())
+	_, err := io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ciphertext := e.aesgcm.Seal(nil, nonce, plaintext, nil)
+
+	encodedNonce := hex.EncodeToString(nonce)
+
+	return hex.EncodeToString(ciphertext), &encodedNonce, nil
+}
+
+func (e aesGCMEncryption) Decrypt(text string, nonce *string) ([]byte, error) {
+	if nonce == nil {
+		return []byte(text), nil
+	}
+
+	decodedNonce, err := hex.DecodeString(*nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := hex.DecodeString(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.aesgcm.Open(nil, decodedNonce, ciphertext, nil)
+}
+
+func encryptJSON(strategy EncryptionStrategy, val interface{}) (string, *string, error) {
+	payload, err := json.Marshal(val)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return strategy.Encrypt(payload)
+}
+
+func decrypt(strategy EncryptionStrategy, text string, nonce sql.NullString) ([]byte, error) {
+	if nonce.Valid {
+		return strategy.Decrypt(text, &nonce.String)
+	}
+
+	return strategy.Decrypt(text, nil)
+}
+
+func decryptJSON(strategy EncryptionStrategy, text string, nonce sql.NullString, dest interface{}) error {
+	payload, err := decrypt(strategy, text, nonce)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, dest)
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type encryptedColumn struct {
+	table       string
+	column      string
+	nonceColumn string
+}
+
+var encryptedColumns = []encryptedColumn{
+	{"pipelines", "config", "nonce"},
+	{"pipeline_config_versions", "config", "nonce"},
+	{"jobs", "config", "nonce"},
+	{"resources", "config", "nonce"},
+	{"resource_types", "config", "nonce"},
+	{"versioned_resources", "metadata", "metadata_nonce"},
+	{"builds", "engine_metadata", "engine_metadata_nonce"},
+}
+
+// EncryptionRotator brings all encrypted columns in line with the
+// connection's encryption strategy. Plaintext data is encrypted, and data
+// encrypted with the old strategy (if any) is re-encrypted. If the connection
+// has no encryption, encrypted data is decrypted back to plaintext.
+type EncryptionRotator struct {
+	Logger      lager.Logger
+	Conn        Conn
+	OldStrategy EncryptionStrategy
+}
+
+func (rotator EncryptionRotator) Rotate() error {
+	for _, col := range encryptedColumns {
+		err := rotator.rotateColumn(col)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type encryptedRow struct {
+	id    int
+	text  string
+	nonce sql.NullString
+}
+
+func (rotator EncryptionRotator) rotateColumn(col encryptedColumn) error {
+	logger := rotator.Logger.Session("rotate", lager.Data{
+		"table":  col.table,
+		"column": col.column,
+	})
+
+	tx, err := rotator.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	newStrategy := rotator.Conn.EncryptionStrategy()
+	_, newIsNoop := newStrategy.(NoEncryption)
+
+	// only look at rows which may need to change, so that startup doesn't
+	// scan every build when nothing is being rotated
+	condition := ""
+	if newIsNoop {
+		condition = fmt.Sprintf("AND %s IS NOT NULL", col.nonceColumn)
+	} else if rotator.OldStrategy == nil {
+		condition = fmt.Sprintf("AND %s IS NULL", col.nonceColumn)
+	}
+
+	rows, err := tx.Query(fmt.Sprintf(`
+		SELECT id, %s, %s
+		FROM %s
+		WHERE %s IS NOT NULL
+		%s
+	`, col.column, col.nonceColumn, col.table, col.column, condition))
+	if err != nil {
+		return err
+	}
+
+	encryptedRows := []encryptedRow{}
+	for rows.Next() {
+		var row encryptedRow
+		err := rows.Scan(&row.id, &row.text, &row.nonce)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		encryptedRows = append(encryptedRows, row)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	rotated := 0
+	for _, row := range encryptedRows {
+		plaintext, current, err := rotator.decrypt(newStrategy, row)
+		if err != nil {
+			logger.Error("failed-to-decrypt", err, lager.Data{"id": row.id})
+			return err
+		}
+
+		if current {
+			continue
+		}
+
+		text, nonce, err := newStrategy.Encrypt(plaintext)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`
+			UPDATE %s
+			SET %s = $1, %s = $2
+			WHERE id = $3
+		`, col.table, col.column, col.nonceColumn), text, nonce, row.id)
+		if err != nil {
+			return err
+		}
+
+		rotated++
+	}
+
+	if rotated > 0 {
+		logger.Info("rotated", lager.Data{"rows": rotated})
+	}
+
+	return tx.Commit()
+}
+
+// decrypt returns the row's plaintext, or true if the row is already stored
+// using the new strategy and needs no changes.
+func (rotator EncryptionRotator) decrypt(newStrategy EncryptionStrategy, row encryptedRow) ([]byte, bool, error) {
+	if !row.nonce.Valid {
+		_, isNoop := newStrategy.(NoEncryption)
+		return []byte(row.text), isNoop, nil
+	}
+
+	if rotator.OldStrategy != nil {
+		plaintext, err := decrypt(rotator.OldStrategy, row.text, row.nonce)
+		if err == nil {
+			return plaintext, false, nil
+		}
+	}
+
+	_, err := decrypt(newStrategy, row.text, row.nonce)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return nil, true, nil
+}
@@ -0,0 +1,230 @@
+package db_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"database/sql"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+)
+
+func newAESGCM(key string) cipher.AEAD {
+	block, err := aes.NewCipher([]byte(key))
+	Expect(err).NotTo(HaveOccurred())
+
+	aesgcm, err := cipher.NewGCM(block)
+	Expect(err).NotTo(HaveOccurred())
+
+	return aesgcm
+}
+
+var _ = Describe("Encryption", func() {
+	Describe("NoEncryption", func() {
+		var strategy db.EncryptionStrategy
+
+		BeforeEach(func() {
+			strategy = db.NoEncryption{}
+		})
+
+		It("stores the data as-is, without a nonce", func() {
+			text, nonce, err := strategy.Encrypt([]byte("some-data"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(text).To(Equal("some-data"))
+			Expect(nonce).To(BeNil())
+
+			plaintext, err := strategy.Decrypt(text, nonce)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plaintext).To(Equal([]byte("some-data")))
+		})
+
+		It("fails to decrypt data which has a nonce", func() {
+			nonce := "some-nonce"
+			_, err := strategy.Decrypt("some-ciphertext", &nonce)
+			Expect(err).To(Equal(db.ErrDataIsEncrypted))
+		})
+	})
+
+	Describe("AES-GCM", func() {
+		var strategy db.EncryptionStrategy
+
+		BeforeEach(func() {
+			strategy = db.NewAESGCMEncryption(newAESGCM("AES256Key-32Characters1234567890"))
+		})
+
+		It("round-trips the data", func() {
+			text, nonce, err := strategy.Encrypt([]byte("some-data"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(text).NotTo(ContainSubstring("some-data"))
+			Expect(nonce).NotTo(BeNil())
+
+			plaintext, err := strategy.Decrypt(text, nonce)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plaintext).To(Equal([]byte("some-data")))
+		})
+
+		It("reads data without a nonce as plaintext", func() {
+			plaintext, err := strategy.Decrypt("some-data", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plaintext).To(Equal([]byte("some-data")))
+		})
+
+		It("fails to decrypt data encrypted with another key", func() {
+			otherStrategy := db.NewAESGCMEncryption(newAESGCM("another-key-which-is-32-bytes-!!"))
+
+			text, nonce, err := otherStrategy.Encrypt([]byte("some-data"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = strategy.Decrypt(text, nonce)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("storing pipeline configs", func() {
+		var sqlConn *sql.DB
+		var plainConn db.Conn
+		var encryptedConn db.Conn
+		var listener *pq.Listener
+		var lockFactory db.LockFactory
+
+		var config atc.Config
+
+		BeforeEach(func() {
+			postgresRunner.Truncate()
+
+			sqlConn = postgresRunner.Open()
+			plainConn = db.Wrap(sqlConn)
+			encryptedConn = db.WithEncryption(plainConn, db.NewAESGCMEncryption(newAESGCM("AES256Key-32Characters1234567890")))
+
+			listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+			Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+
+			pgxConn := postgresRunner.OpenPgx()
+			fakeConnector := new(dbfakes.FakeConnector)
+			retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+			lockFactory = db.NewLockFactory(retryableConn)
+
+			bus := db.NewNotificationsBus(listener, plainConn)
+			_, err := db.NewSQL(plainConn, bus, lockFactory).CreateTeam(db.Team{Name: "some-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			config = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"password": "super-secret"},
+					},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			Expect(sqlConn.Close()).To(Succeed())
+			Expect(listener.Close()).To(Succeed())
+		})
+
+		teamDBFor := func(conn db.Conn) db.TeamDB {
+			bus := db.NewNotificationsBus(listener, conn)
+			return db.NewTeamDBFactory(conn, bus, lockFactory).GetTeamDB("some-team")
+		}
+
+		rawColumns := func() []string {
+			var pipelineConfig, resourceConfig string
+			err := sqlConn.QueryRow(`SELECT config FROM pipelines`).Scan(&pipelineConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = sqlConn.QueryRow(`SELECT config FROM resources`).Scan(&resourceConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			return []string{pipelineConfig, resourceConfig}
+		}
+
+		It("encrypts the config, and decrypts it when read back", func() {
+			_, _, err := teamDBFor(encryptedConn).SaveConfig("some-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, raw := range rawColumns() {
+				Expect(raw).NotTo(ContainSubstring("super-secret"))
+			}
+
+			savedConfig, _, _, err := teamDBFor(encryptedConn).GetConfig("some-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedConfig).To(Equal(config))
+		})
+
+		It("fails to read encrypted data without the key", func() {
+			_, _, err := teamDBFor(encryptedConn).SaveConfig("some-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, err = teamDBFor(plainConn).GetConfig("some-pipeline")
+			Expect(err).To(Equal(db.ErrDataIsEncrypted))
+		})
+
+		Describe("EncryptionRotator", func() {
+			It("encrypts existing plaintext data", func() {
+				_, _, err := teamDBFor(plainConn).SaveConfig("some-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = db.EncryptionRotator{
+					Logger: lagertest.NewTestLogger("test"),
+					Conn:   encryptedConn,
+				}.Rotate()
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, raw := range rawColumns() {
+					Expect(raw).NotTo(ContainSubstring("super-secret"))
+				}
+
+				savedConfig, _, _, err := teamDBFor(encryptedConn).GetConfig("some-pipeline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedConfig).To(Equal(config))
+			})
+
+			It("re-encrypts data encrypted with the old key", func() {
+				oldStrategy := db.NewAESGCMEncryption(newAESGCM("the-old-key-which-is-32-bytes-!!"))
+				oldConn := db.WithEncryption(plainConn, oldStrategy)
+
+				_, _, err := teamDBFor(oldConn).SaveConfig("some-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = db.EncryptionRotator{
+					Logger:      lagertest.NewTestLogger("test"),
+					Conn:        encryptedConn,
+					OldStrategy: oldStrategy,
+				}.Rotate()
+				Expect(err).NotTo(HaveOccurred())
+
+				savedConfig, _, _, err := teamDBFor(encryptedConn).GetConfig("some-pipeline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedConfig).To(Equal(config))
+
+				_, _, _, err = teamDBFor(oldConn).GetConfig("some-pipeline")
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("decrypts data when encryption is removed", func() {
+				_, _, err := teamDBFor(encryptedConn).SaveConfig("some-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = db.EncryptionRotator{
+					Logger:      lagertest.NewTestLogger("test"),
+					Conn:        plainConn,
+					OldStrategy: encryptedConn.EncryptionStrategy(),
+				}.Rotate()
+				Expect(err).NotTo(HaveOccurred())
+
+				savedConfig, _, _, err := teamDBFor(plainConn).GetConfig("some-pipeline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedConfig).To(Equal(config))
+			})
+		})
+	})
+})
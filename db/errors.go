@@ -3,3 +3,7 @@ package db
 import "errors"
 
 var ErrMultipleContainersFound = errors.New("multiple containers found for given identifier")
+
+var ErrVolumeNotFound = errors.New("volume not found")
+
+var ErrVersionedResourceNotFound = errors.New("versioned resource not found")
@@ -0,0 +1,42 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddNonceToEncryptedColumns(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN nonce text;
+	`)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{"jobs", "resources", "resource_types"} {
+		_, err = tx.Exec(`
+			ALTER TABLE ` + table + `
+			ALTER COLUMN config TYPE text,
+			ADD COLUMN nonce text;
+		`)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE versioned_resources
+		ADD COLUMN metadata_nonce text;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN engine_metadata_nonce text;
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
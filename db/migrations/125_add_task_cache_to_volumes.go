@@ -0,0 +1,28 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddTaskCacheToVolumes(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN task_cache_pipeline_id integer,
+		ADD COLUMN task_cache_job_name text,
+		ADD COLUMN task_cache_step_name text,
+		ADD COLUMN task_cache_path text,
+		ADD COLUMN task_cache_cleared boolean NOT NULL DEFAULT false;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX volumes_task_cache_idx
+		ON volumes (task_cache_pipeline_id, task_cache_job_name)
+		WHERE task_cache_pipeline_id IS NOT NULL;
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
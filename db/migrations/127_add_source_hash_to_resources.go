@@ -0,0 +1,25 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+// AddSourceHashToResources adds a hash of each resource's type and source, so
+// that the same resource can be found across pipelines. Resources saved
+// before this migration have no hash until their pipeline is next configured.
+func AddSourceHashToResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE resources
+		ADD COLUMN source_hash text
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX resources_source_hash ON resources (source_hash)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+// AddPipelineConfigVersions adds a table recording every config saved for a
+// pipeline, along with who saved it and when. Each pipeline's current config
+// is recorded as its first version, with no author.
+func AddPipelineConfigVersions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE pipeline_config_versions (
+			id serial PRIMARY KEY,
+			pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+			version integer NOT NULL,
+			config text NOT NULL,
+			nonce text,
+			saved_by text,
+			saved_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (pipeline_id, version)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_versions (pipeline_id, version, config, nonce)
+		SELECT id, version, config, nonce
+		FROM pipelines
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddArchivedToPipelines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN archived boolean NOT NULL DEFAULT false
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddScheduleCheckedAtToJobs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN schedule_checked_at timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddBuildApprovals(tx migration.LimitedTx) error {
+	// values cannot be added to an enum within a transaction, so the type is
+	// replaced instead
+	_, err := tx.Exec(`
+		ALTER TYPE build_status RENAME TO build_status_old
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TYPE build_status AS ENUM (
+			'pending',
+			'started',
+			'pending-approval',
+			'aborted',
+			'succeeded',
+			'failed',
+			'errored'
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ALTER COLUMN status TYPE build_status USING status::text::build_status
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DROP TYPE build_status_old
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE build_approvals (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			plan_id text NOT NULL,
+			approvers text NOT NULL DEFAULT '[]',
+			message text NOT NULL DEFAULT '',
+			requested_at timestamp with time zone NOT NULL DEFAULT now(),
+			approver text,
+			approved_at timestamp with time zone,
+			closed boolean NOT NULL DEFAULT false,
+			UNIQUE (build_id, plan_id)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package migrations
 
 import (
 	"database/sql"
+	"fmt"
 	"hash/crc32"
 	"strings"
 	"time"
@@ -61,6 +62,49 @@ func LockDBAndMigrate(logger lager.Logger, sqlDriver string, sqlDataSource strin
 	return dbConn, nil
 }
 
+// ErrSchemaVersionMismatch is returned when opening a database whose schema
+// has not been migrated to the version this ATC expects.
+type ErrSchemaVersionMismatch struct {
+	Actual   int
+	Expected int
+}
+
+func (err ErrSchemaVersionMismatch) Error() string {
+	return fmt.Sprintf(
+		"database schema is at version %d, but version %d is expected; upgrade the ATCs using it to the same version first",
+		err.Actual,
+		err.Expected,
+	)
+}
+
+// OpenWithoutMigrating connects to a database which the ATCs of the cluster
+// have already migrated, for commands which run alongside them and must not
+// change its schema. It returns ErrSchemaVersionMismatch if the schema is not
+// at the version this ATC expects.
+func OpenWithoutMigrating(sqlDriver string, sqlDataSource string) (db.Conn, error) {
+	dbConn, err := db.WrapWithError(sql.Open(sqlDriver, sqlDataSource))
+	if err != nil {
+		return nil, err
+	}
+
+	var version int
+	err = dbConn.QueryRow("SELECT version FROM migration_version").Scan(&version)
+	if err != nil {
+		dbConn.Close()
+		return nil, err
+	}
+
+	if version != len(Migrations) {
+		dbConn.Close()
+		return nil, ErrSchemaVersionMismatch{
+			Actual:   version,
+			Expected: len(Migrations),
+		}
+	}
+
+	return dbConn, nil
+}
+
 func safeGetVersion(tx migration.LimitedTx) (int, error) {
 	v, err := getVersion(tx)
 	if err != nil {
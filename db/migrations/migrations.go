@@ -124,4 +124,12 @@ var Migrations = []migration.Migrator{
 	MigrateFromLeasesToLocks,
 	AddTeamNameToPipe,
 	AddConfigToJobsResources,
+	AddNonceToEncryptedColumns,
+	AddTaskCacheToVolumes,
+	AddPinnedVersionToResources,
+	AddSourceHashToResources,
+	AddPipelineConfigVersions,
+	AddArchivedToPipelines,
+	AddScheduleCheckedAtToJobs,
+	AddBuildApprovals,
 }
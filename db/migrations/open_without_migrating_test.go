@@ -0,0 +1,92 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"os"
+	"time"
+
+	"github.com/BurntSushi/migration"
+	. "github.com/concourse/atc/db/migrations"
+	"github.com/concourse/atc/postgresrunner"
+	_ "github.com/lib/pq"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpenWithoutMigrating", func() {
+	var postgresRunner postgresrunner.Runner
+
+	var dbProcess ifrit.Process
+
+	BeforeEach(func() {
+		postgresRunner = postgresrunner.Runner{
+			Port: 5433 + GinkgoParallelNode(),
+		}
+
+		dbProcess = ifrit.Invoke(postgresRunner)
+
+		postgresRunner.CreateTestDB()
+	})
+
+	AfterEach(func() {
+		postgresRunner.DropTestDB()
+
+		dbProcess.Signal(os.Interrupt)
+		Eventually(dbProcess.Wait(), 10*time.Second).Should(Receive())
+	})
+
+	migrateTo := func(migrations []migration.Migrator) {
+		dbConn, err := migration.Open("postgres", postgresRunner.DataSourceName(), migrations)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Context("when the database has been fully migrated", func() {
+		BeforeEach(func() {
+			migrateTo(Migrations)
+		})
+
+		It("opens it", func() {
+			dbConn, err := OpenWithoutMigrating("postgres", postgresRunner.DataSourceName())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dbConn.Ping()).To(Succeed())
+			Expect(dbConn.Close()).To(Succeed())
+		})
+	})
+
+	Context("when the database has not been migrated to the latest version", func() {
+		BeforeEach(func() {
+			migrateTo(Migrations[:len(Migrations)-1])
+		})
+
+		It("returns an error without migrating it", func() {
+			_, err := OpenWithoutMigrating("postgres", postgresRunner.DataSourceName())
+			Expect(err).To(Equal(ErrSchemaVersionMismatch{
+				Actual:   len(Migrations) - 1,
+				Expected: len(Migrations),
+			}))
+
+			sqlDB, err := sql.Open("postgres", postgresRunner.DataSourceName())
+			Expect(err).NotTo(HaveOccurred())
+
+			defer sqlDB.Close()
+
+			var version int
+			err = sqlDB.QueryRow("SELECT version FROM migration_version").Scan(&version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(len(Migrations) - 1))
+		})
+	})
+
+	Context("when the database has never been migrated", func() {
+		It("returns an error", func() {
+			_, err := OpenWithoutMigrating("postgres", postgresRunner.DataSourceName())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
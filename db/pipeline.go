@@ -1,6 +1,10 @@
 package db
 
-import "github.com/concourse/atc"
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
 
 type Pipeline struct {
 	Name    string
@@ -12,8 +16,18 @@ type SavedPipeline struct {
 	ID       int
 	Paused   bool
 	Public   bool
+	Archived bool
 	TeamID   int
 	TeamName string
 
 	Pipeline
 }
+
+// A PipelineConfigVersion is a config that was saved for a pipeline, along
+// with who saved it, if known, and when.
+type PipelineConfigVersion struct {
+	Version ConfigVersion
+	Config  atc.Config
+	SavedBy string
+	SavedAt time.Time
+}
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . PipelineDB
@@ -21,6 +25,7 @@ type PipelineDB interface {
 	GetPipelineID() int
 	ScopedName(string) string
 	TeamID() int
+	TeamName() string
 	Config() atc.Config
 	ConfigVersion() ConfigVersion
 
@@ -30,6 +35,7 @@ type PipelineDB interface {
 	Unpause() error
 	IsPaused() (bool, error)
 	IsPublic() bool
+	Archive() error
 	UpdateName(string) error
 	Destroy() error
 
@@ -49,6 +55,8 @@ type PipelineDB interface {
 	GetLatestEnabledVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
 	EnableVersionedResource(versionedResourceID int) error
 	DisableVersionedResource(versionedResourceID int) error
+	PinVersionedResource(resourceName string, versionedResourceID int) error
+	UnpinVersionedResource(resourceName string, versionedResourceID int) error
 	SetResourceCheckError(resource SavedResource, err error) error
 	AcquireResourceCheckingLock(logger lager.Logger, resource SavedResource, length time.Duration, immediate bool) (Lock, bool, error)
 	AcquireResourceTypeCheckingLock(logger lager.Logger, resourceType SavedResourceType, length time.Duration, immediate bool) (Lock, bool, error)
@@ -57,13 +65,17 @@ type PipelineDB interface {
 	GetJob(job string) (SavedJob, bool, error)
 	PauseJob(job string) error
 	UnpauseJob(job string) error
+	ClearTaskCaches(job string) error
 	SetMaxInFlightReached(string, bool) error
 	UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error
+	UpdateJobScheduleCheckedTime(job string, checkedTime time.Time) (time.Time, error)
 
 	GetJobFinishedAndNextBuild(job string) (Build, Build, error)
 
 	GetJobBuilds(job string, page Page) ([]Build, Pagination, error)
 	GetAllJobBuilds(job string) ([]Build, error)
+	GetLatestSucceededJobBuilds(job string, limit int) ([]Build, error)
+	GetUnreapedJobBuildsAfter(job string, buildID int, limit int) ([]Build, error)
 
 	GetJobBuild(job string, build string) (Build, bool, error)
 	CreateJobBuild(job string) (Build, error)
@@ -94,6 +106,8 @@ type PipelineDB interface {
 
 	Expose() error
 	Hide() error
+
+	Export(w io.Writer, includeEvents bool) error
 }
 
 type pipelineDB struct {
@@ -154,6 +168,10 @@ func (pdb *pipelineDB) TeamID() int {
 	return pdb.SavedPipeline.TeamID
 }
 
+func (pdb *pipelineDB) TeamName() string {
+	return pdb.SavedPipeline.TeamName
+}
+
 func (pdb *pipelineDB) Config() atc.Config {
 	return pdb.SavedPipeline.Config
 }
@@ -167,12 +185,28 @@ func (pdb *pipelineDB) IsPublic() bool {
 }
 
 func (pdb *pipelineDB) Unpause() error {
-	_, err := pdb.conn.Exec(`
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = resetPausedScheduleCheckedTimes(tx, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
 		UPDATE pipelines
 		SET paused = false
 		WHERE id = $1
 	`, pdb.ID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (pdb *pipelineDB) Pause() error {
@@ -184,6 +218,19 @@ func (pdb *pipelineDB) Pause() error {
 	return err
 }
 
+// Archive pauses the pipeline and marks it as archived, leaving its builds
+// and versions in place. Like pausing, it does not abort builds that are
+// already running, and their pending approvals can still be approved, so they
+// can finish. It is unarchived, and unpaused, by saving a new config.
+func (pdb *pipelineDB) Archive() error {
+	_, err := pdb.conn.Exec(`
+		UPDATE pipelines
+		SET archived = true, paused = true
+		WHERE id = $1
+	`, pdb.ID)
+	return err
+}
+
 func (pdb *pipelineDB) UpdateName(newName string) error {
 	_, err := pdb.conn.Exec(`
 		UPDATE pipelines
@@ -226,7 +273,7 @@ func (pdb *pipelineDB) Reload() (bool, error) {
 		WHERE p.id = $1
 	`, pdb.ID)
 
-	savedPipeline, err := scanPipeline(row)
+	savedPipeline, err := scanPipeline(row, pdb.conn.EncryptionStrategy())
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -262,10 +309,11 @@ func (pdb *pipelineDB) GetResource(resourceName string) (SavedResource, bool, er
 
 func (pdb *pipelineDB) GetResources() ([]SavedResource, bool, error) {
 	rows, err := pdb.conn.Query(`
-			SELECT id, name, config, check_error, paused
-			FROM resources
-			WHERE pipeline_id = $1
-				AND active = true
+			SELECT `+resourceColumns+`
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources pv ON pv.id = r.pinned_version_id
+			WHERE r.pipeline_id = $1
+				AND r.active = true
 		`, pdb.ID)
 
 	if err != nil {
@@ -533,7 +581,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 	}
 
 	query := `
-		SELECT v.id, v.enabled, v.type, v.version, v.metadata, r.name, v.check_order
+		SELECT v.id, v.enabled, v.type, v.version, v.metadata, v.metadata_nonce, r.name, v.check_order
 		FROM versioned_resources v
 		INNER JOIN resources r ON v.resource_id = r.id
 		WHERE v.resource_id = $1
@@ -606,6 +654,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 		var savedVersionedResource SavedVersionedResource
 
 		var versionString, metadataString string
+		var metadataNonce sql.NullString
 
 		err := rows.Scan(
 			&savedVersionedResource.ID,
@@ -613,6 +662,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 			&savedVersionedResource.Type,
 			&versionString,
 			&metadataString,
+			&metadataNonce,
 			&savedVersionedResource.Resource,
 			&savedVersionedResource.CheckOrder,
 		)
@@ -625,7 +675,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 			return nil, Pagination{}, false, err
 		}
 
-		err = json.Unmarshal([]byte(metadataString), &savedVersionedResource.Metadata)
+		err = decryptJSON(pdb.conn.EncryptionStrategy(), metadataString, metadataNonce, &savedVersionedResource.Metadata)
 		if err != nil {
 			return nil, Pagination{}, false, err
 		}
@@ -674,22 +724,28 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 	return savedVersionedResources, pagination, true, nil
 }
 
+const resourceColumns = "r.id, r.name, r.config, r.nonce, r.check_error, r.paused, r.pinned_version_id, pv.version"
+
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, error) {
 	return pdb.scanResource(tx.QueryRow(`
-			SELECT id, name, config, check_error, paused
-			FROM resources
-			WHERE name = $1
-				AND pipeline_id = $2
-				AND active = true
+			SELECT `+resourceColumns+`
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources pv ON pv.id = r.pinned_version_id
+			WHERE r.name = $1
+				AND r.pipeline_id = $2
+				AND r.active = true
 		`, name, pdb.ID))
 }
 
 func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error) {
 	var checkErr sql.NullString
 	var resource SavedResource
-	var configBlob []byte
+	var configText string
+	var nonce sql.NullString
+	var pinnedVersionID sql.NullInt64
+	var pinnedVersion sql.NullString
 
-	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused)
+	err := row.Scan(&resource.ID, &resource.Name, &configText, &nonce, &checkErr, &resource.Paused, &pinnedVersionID, &pinnedVersion)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResource{}, false, nil
@@ -701,7 +757,7 @@ func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error)
 	resource.PipelineName = pdb.GetPipelineName()
 
 	var config atc.ResourceConfig
-	err = json.Unmarshal(configBlob, &config)
+	err = decryptJSON(pdb.conn.EncryptionStrategy(), configText, nonce, &config)
 	if err != nil {
 		return SavedResource{}, false, err
 	}
@@ -711,6 +767,15 @@ func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error)
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
+	if pinnedVersionID.Valid {
+		resource.PinnedVersionID = int(pinnedVersionID.Int64)
+
+		err = json.Unmarshal([]byte(pinnedVersion.String), &resource.PinnedVersion)
+		if err != nil {
+			return SavedResource{}, false, err
+		}
+	}
+
 	return resource, true, nil
 }
 
@@ -738,14 +803,15 @@ func (pdb *pipelineDB) GetResourceType(name string) (SavedResourceType, bool, er
 func (pdb *pipelineDB) getResourceType(tx Tx, name string) (SavedResourceType, bool, error) {
 	var savedResourceType SavedResourceType
 	var versionJSON []byte
-	var configBlob []byte
+	var configText string
+	var nonce sql.NullString
 	err := tx.QueryRow(`
-			SELECT id, name, type, version, config
+			SELECT id, name, type, version, config, nonce
 			FROM resource_types
 			WHERE name = $1
 				AND pipeline_id = $2
 				AND active = true
-		`, name, pdb.ID).Scan(&savedResourceType.ID, &savedResourceType.Name, &savedResourceType.Type, &versionJSON, &configBlob)
+		`, name, pdb.ID).Scan(&savedResourceType.ID, &savedResourceType.Name, &savedResourceType.Type, &versionJSON, &configText, &nonce)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResourceType{}, false, nil
@@ -754,7 +820,7 @@ func (pdb *pipelineDB) getResourceType(tx Tx, name string) (SavedResourceType, b
 	}
 
 	var config atc.ResourceType
-	err = json.Unmarshal(configBlob, &config)
+	err = decryptJSON(pdb.conn.EncryptionStrategy(), configText, nonce, &config)
 	if err != nil {
 		return SavedResourceType{}, false, err
 	}
@@ -922,8 +988,99 @@ func (pdb *pipelineDB) toggleVersionedResource(versionedResourceID int, enable b
 	return nil
 }
 
+func (pdb *pipelineDB) PinVersionedResource(resourceName string, versionedResourceID int) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = pdb.touchPinnedVersion(tx, resourceName)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE resources r
+		SET pinned_version_id = v.id
+		FROM versioned_resources v
+		WHERE v.id = $1
+			AND v.resource_id = r.id
+			AND r.name = $2
+			AND r.pipeline_id = $3
+	`, versionedResourceID, resourceName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrVersionedResourceNotFound
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	err = pdb.touchPinnedVersion(tx, resourceName)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (pdb *pipelineDB) UnpinVersionedResource(resourceName string, versionedResourceID int) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = pdb.touchPinnedVersion(tx, resourceName)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE resources
+		SET pinned_version_id = NULL
+		WHERE name = $1
+			AND pipeline_id = $2
+			AND pinned_version_id = $3
+	`, resourceName, pdb.ID, versionedResourceID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// touchPinnedVersion bumps the modified time of the resource's pinned version
+// so that the cached versions DB is reloaded with the new pin
+func (pdb *pipelineDB) touchPinnedVersion(tx Tx, resourceName string) error {
+	_, err := tx.Exec(`
+		UPDATE versioned_resources
+		SET modified_time = now()
+		WHERE id = (
+			SELECT pinned_version_id
+			FROM resources
+			WHERE name = $1
+				AND pipeline_id = $2
+		)
+	`, resourceName, pdb.ID)
+	return err
+}
+
 func (pdb *pipelineDB) GetLatestEnabledVersionedResource(resourceName string) (SavedVersionedResource, bool, error) {
 	var versionBytes, metadataBytes string
+	var metadataNonce sql.NullString
 
 	svr := SavedVersionedResource{
 		VersionedResource: VersionedResource{
@@ -933,7 +1090,7 @@ func (pdb *pipelineDB) GetLatestEnabledVersionedResource(resourceName string) (S
 	}
 
 	err := pdb.conn.QueryRow(`
-		SELECT v.id, v.enabled, v.type, v.version, v.metadata, v.modified_time
+		SELECT v.id, v.enabled, v.type, v.version, v.metadata, v.metadata_nonce, v.modified_time
 		FROM versioned_resources v, resources r
 		WHERE v.resource_id = r.id
 			AND r.name = $1
@@ -941,7 +1098,7 @@ func (pdb *pipelineDB) GetLatestEnabledVersionedResource(resourceName string) (S
 			AND r.pipeline_id = $2
 		ORDER BY check_order DESC
 		LIMIT 1
-	`, resourceName, pdb.ID).Scan(&svr.ID, &svr.Enabled, &svr.Type, &versionBytes, &metadataBytes, &svr.ModifiedTime)
+	`, resourceName, pdb.ID).Scan(&svr.ID, &svr.Enabled, &svr.Type, &versionBytes, &metadataBytes, &metadataNonce, &svr.ModifiedTime)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedVersionedResource{}, false, nil
@@ -955,7 +1112,7 @@ func (pdb *pipelineDB) GetLatestEnabledVersionedResource(resourceName string) (S
 		return SavedVersionedResource{}, false, err
 	}
 
-	err = json.Unmarshal([]byte(metadataBytes), &svr.Metadata)
+	err = decryptJSON(pdb.conn.EncryptionStrategy(), metadataBytes, metadataNonce, &svr.Metadata)
 	if err != nil {
 		return SavedVersionedResource{}, false, err
 	}
@@ -965,6 +1122,7 @@ func (pdb *pipelineDB) GetLatestEnabledVersionedResource(resourceName string) (S
 
 func (pdb *pipelineDB) GetLatestVersionedResource(resourceName string) (SavedVersionedResource, bool, error) {
 	var versionBytes, metadataBytes string
+	var metadataNonce sql.NullString
 
 	svr := SavedVersionedResource{
 		VersionedResource: VersionedResource{
@@ -974,7 +1132,7 @@ func (pdb *pipelineDB) GetLatestVersionedResource(resourceName string) (SavedVer
 	}
 
 	err := pdb.conn.QueryRow(`
-		SELECT v.id, v.enabled, v.type, v.version, v.metadata, v.modified_time, v.check_order
+		SELECT v.id, v.enabled, v.type, v.version, v.metadata, v.metadata_nonce, v.modified_time, v.check_order
 		FROM versioned_resources v, resources r
 		WHERE v.resource_id = r.id
 			AND r.name = $1
@@ -987,6 +1145,7 @@ func (pdb *pipelineDB) GetLatestVersionedResource(resourceName string) (SavedVer
 		&svr.Type,
 		&versionBytes,
 		&metadataBytes,
+		&metadataNonce,
 		&svr.ModifiedTime,
 		&svr.CheckOrder,
 	)
@@ -1003,7 +1162,7 @@ func (pdb *pipelineDB) GetLatestVersionedResource(resourceName string) (SavedVer
 		return SavedVersionedResource{}, false, err
 	}
 
-	err = json.Unmarshal([]byte(metadataBytes), &svr.Metadata)
+	err = decryptJSON(pdb.conn.EncryptionStrategy(), metadataBytes, metadataNonce, &svr.Metadata)
 	if err != nil {
 		return SavedVersionedResource{}, false, err
 	}
@@ -1060,7 +1219,7 @@ func (pdb *pipelineDB) saveVersionedResource(tx Tx, savedResource SavedResource,
 		return SavedVersionedResource{}, false, err
 	}
 
-	metadataJSON, err := json.Marshal(vr.Metadata)
+	metadata, metadataNonce, err := encryptJSON(pdb.conn.EncryptionStrategy(), vr.Metadata)
 	if err != nil {
 		return SavedVersionedResource{}, false, err
 	}
@@ -1071,8 +1230,8 @@ func (pdb *pipelineDB) saveVersionedResource(tx Tx, savedResource SavedResource,
 	var check_order int
 
 	result, err := tx.Exec(`
-		INSERT INTO versioned_resources (resource_id, type, version, metadata, modified_time)
-		SELECT $1, $2, $3, $4, now()
+		INSERT INTO versioned_resources (resource_id, type, version, metadata, metadata_nonce, modified_time)
+		SELECT $1, $2, $3, $4, $5, now()
 		WHERE NOT EXISTS (
 			SELECT 1
 			FROM versioned_resources
@@ -1080,7 +1239,7 @@ func (pdb *pipelineDB) saveVersionedResource(tx Tx, savedResource SavedResource,
 			AND type = $2
 			AND version = $3
 		)
-	`, savedResource.ID, vr.Type, string(versionJSON), string(metadataJSON))
+	`, savedResource.ID, vr.Type, string(versionJSON), metadata, metadataNonce)
 
 	var rowsAffected int64
 	if err == nil {
@@ -1096,31 +1255,32 @@ func (pdb *pipelineDB) saveVersionedResource(tx Tx, savedResource SavedResource,
 	}
 
 	var savedMetadata string
+	var savedMetadataNonce sql.NullString
 
 	// separate from above, as it conditionally inserts (can't use RETURNING)
 	if len(vr.Metadata) > 0 {
 		err = tx.QueryRow(`
 			UPDATE versioned_resources
-			SET metadata = $4, modified_time = now()
+			SET metadata = $4, metadata_nonce = $5, modified_time = now()
 			WHERE resource_id = $1
 			AND type = $2
 			AND version = $3
-			RETURNING id, enabled, metadata, modified_time, check_order
-		`, savedResource.ID, vr.Type, string(versionJSON), string(metadataJSON)).Scan(&id, &enabled, &savedMetadata, &modified_time, &check_order)
+			RETURNING id, enabled, metadata, metadata_nonce, modified_time, check_order
+		`, savedResource.ID, vr.Type, string(versionJSON), metadata, metadataNonce).Scan(&id, &enabled, &savedMetadata, &savedMetadataNonce, &modified_time, &check_order)
 	} else {
 		err = tx.QueryRow(`
-			SELECT id, enabled, metadata, modified_time, check_order
+			SELECT id, enabled, metadata, metadata_nonce, modified_time, check_order
 			FROM versioned_resources
 			WHERE resource_id = $1
 			AND type = $2
 			AND version = $3
-		`, savedResource.ID, vr.Type, string(versionJSON)).Scan(&id, &enabled, &savedMetadata, &modified_time, &check_order)
+		`, savedResource.ID, vr.Type, string(versionJSON)).Scan(&id, &enabled, &savedMetadata, &savedMetadataNonce, &modified_time, &check_order)
 	}
 	if err != nil {
 		return SavedVersionedResource{}, false, err
 	}
 
-	err = json.Unmarshal([]byte(savedMetadata), &vr.Metadata)
+	err = decryptJSON(pdb.conn.EncryptionStrategy(), savedMetadata, savedMetadataNonce, &vr.Metadata)
 	if err != nil {
 		return SavedVersionedResource{}, false, err
 	}
@@ -1547,7 +1707,7 @@ func (pdb *pipelineDB) GetAllPendingBuilds() (map[string][]Build, error) {
 	builds := map[string][]Build{}
 
 	rows, err := pdb.conn.Query(`
-		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name
+		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.engine_metadata_nonce, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name
 		FROM builds b
 		JOIN jobs j ON b.job_id = j.id
 		JOIN pipelines p ON j.pipeline_id = p.id
@@ -1663,7 +1823,7 @@ func (pdb *pipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroup
 		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
 				AND jsg.serial_group IN (`+strings.Join(refs, ",")+`)
 		WHERE (
-				b.status = 'started'
+				b.status IN ('started', 'pending-approval')
 				OR
 				(b.scheduled = true AND b.status = 'pending')
 			)
@@ -1724,7 +1884,7 @@ func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 	return rows == 1, nil
 }
 
-func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
+func (pdb *pipelineDB) getLatestModifiedTime(crossPipelineJobIDs map[string]int) (time.Time, error) {
 	var max_modified_time time.Time
 
 	err := pdb.conn.QueryRow(`
@@ -1756,12 +1916,99 @@ func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
 			WHERE r.pipeline_id = $1
 		) vr
 	`, pdb.ID).Scan(&max_modified_time)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(crossPipelineJobIDs) == 0 {
+		return max_modified_time, nil
+	}
+
+	var cross_modified_time time.Time
+	err = pdb.conn.QueryRow(`
+		SELECT COALESCE(MAX(bo.modified_time), 'epoch')
+		FROM build_outputs bo, builds b
+		WHERE b.id = bo.build_id
+		AND b.job_id IN (` + joinJobIDs(crossPipelineJobIDs) + `)
+	`).Scan(&cross_modified_time)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if cross_modified_time.After(max_modified_time) {
+		return cross_modified_time, nil
+	}
+
+	return max_modified_time, nil
+}
+
+func (pdb *pipelineDB) getCrossPipelineJobIDs() (map[string]int, error) {
+	jobIDs := map[string]int{}
+
+	localJobs := map[string]bool{}
+	for _, job := range pdb.Config().Jobs {
+		localJobs[job.Name] = true
+	}
+
+	for _, job := range pdb.Config().Jobs {
+		for _, input := range config.JobInputs(job) {
+			for _, passed := range input.Passed {
+				if localJobs[passed] {
+					continue
+				}
+
+				if _, found := jobIDs[passed]; found {
+					continue
+				}
+
+				pipelineName, jobName, ok := config.CrossPipelineJob(passed)
+				if !ok {
+					continue
+				}
 
-	return max_modified_time, err
+				var id int
+				err := pdb.conn.QueryRow(`
+					SELECT j.id
+					FROM jobs j, pipelines p
+					WHERE p.id = j.pipeline_id
+					AND p.team_id = $1
+					AND p.name = $2
+					AND p.id != $3
+					AND j.name = $4
+					AND j.active = true
+				`, pdb.TeamID(), pipelineName, pdb.ID, jobName).Scan(&id)
+				if err != nil {
+					if err == sql.ErrNoRows {
+						continue
+					}
+
+					return nil, err
+				}
+
+				jobIDs[passed] = id
+			}
+		}
+	}
+
+	return jobIDs, nil
+}
+
+func joinJobIDs(jobIDs map[string]int) string {
+	ids := []string{}
+	for _, id := range jobIDs {
+		ids = append(ids, strconv.Itoa(id))
+	}
+
+	return strings.Join(ids, ",")
 }
 
 func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := pdb.getLatestModifiedTime()
+	crossPipelineJobIDs, err := pdb.getCrossPipelineJobIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	latestModifiedTime, err := pdb.getLatestModifiedTime(crossPipelineJobIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -1776,6 +2023,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		ResourceVersions: []algorithm.ResourceVersion{},
 		JobIDs:           map[string]int{},
 		ResourceIDs:      map[string]int{},
+		PinnedVersionIDs: map[int]int{},
 		CachedAt:         latestModifiedTime,
 	}
 
@@ -1806,6 +2054,42 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.BuildOutputs = append(db.BuildOutputs, output)
 	}
 
+	if len(crossPipelineJobIDs) > 0 {
+		// outputs of jobs in other pipelines are matched to this pipeline's
+		// versions of any resource with the same type and source
+		rows, err = pdb.conn.Query(`
+			SELECT v.id, v.check_order, r.id, o.build_id, j.id
+			FROM build_outputs o, builds b, versioned_resources ov, resources ors, jobs j, resources r, versioned_resources v
+			WHERE ov.id = o.versioned_resource_id
+			AND b.id = o.build_id
+			AND j.id = b.job_id
+			AND ors.id = ov.resource_id
+			AND ors.source_hash = r.source_hash
+			AND v.resource_id = r.id
+			AND v.version = ov.version
+			AND v.enabled
+			AND ov.enabled
+			AND b.status = 'succeeded'
+			AND r.pipeline_id = $1
+			AND j.id IN (`+joinJobIDs(crossPipelineJobIDs)+`)
+		`, pdb.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var output algorithm.BuildOutput
+			err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+			if err != nil {
+				return nil, err
+			}
+
+			output.ResourceVersion.CheckOrder = output.CheckOrder
+
+			db.BuildOutputs = append(db.BuildOutputs, output)
+		}
+	}
+
 	rows, err = pdb.conn.Query(`
     SELECT v.id, v.check_order, r.id, i.build_id, i.name, j.id
     FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
@@ -1873,8 +2157,12 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.JobIDs[name] = id
 	}
 
+	for name, id := range crossPipelineJobIDs {
+		db.JobIDs[name] = id
+	}
+
 	rows, err = pdb.conn.Query(`
-    SELECT r.name, r.id
+    SELECT r.name, r.id, r.pinned_version_id
     FROM resources r
     WHERE r.pipeline_id = $1
   `, pdb.ID)
@@ -1885,12 +2173,17 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	for rows.Next() {
 		var name string
 		var id int
-		err := rows.Scan(&name, &id)
+		var pinnedVersionID sql.NullInt64
+		err := rows.Scan(&name, &id, &pinnedVersionID)
 		if err != nil {
 			return nil, err
 		}
 
 		db.ResourceIDs[name] = id
+
+		if pinnedVersionID.Valid {
+			db.PinnedVersionIDs[id] = int(pinnedVersionID.Int64)
+		}
 	}
 
 	pdb.versionsDB = db
@@ -1900,6 +2193,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 
 func (pdb *pipelineDB) GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error) {
 	var versionBytes, metadataBytes string
+	var metadataNonce sql.NullString
 
 	versionJSON, err := json.Marshal(atcVersion)
 	if err != nil {
@@ -1914,14 +2208,14 @@ func (pdb *pipelineDB) GetVersionedResourceByVersion(atcVersion atc.Version, res
 	}
 
 	err = pdb.conn.QueryRow(`
-		SELECT v.id, v.enabled, v.type, v.version, v.metadata, v.check_order
+		SELECT v.id, v.enabled, v.type, v.version, v.metadata, v.metadata_nonce, v.check_order
 		FROM versioned_resources v
 		JOIN resources r ON r.id = v.resource_id
 		WHERE v.version = $1
 			AND r.name = $2
 			AND r.pipeline_id = $3
 			AND enabled = true
-	`, string(versionJSON), resourceName, pdb.ID).Scan(&svr.ID, &svr.Enabled, &svr.Type, &versionBytes, &metadataBytes, &svr.CheckOrder)
+	`, string(versionJSON), resourceName, pdb.ID).Scan(&svr.ID, &svr.Enabled, &svr.Type, &versionBytes, &metadataBytes, &metadataNonce, &svr.CheckOrder)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedVersionedResource{}, false, nil
@@ -1935,7 +2229,7 @@ func (pdb *pipelineDB) GetVersionedResourceByVersion(atcVersion atc.Version, res
 		return SavedVersionedResource{}, false, err
 	}
 
-	err = json.Unmarshal([]byte(metadataBytes), &svr.Metadata)
+	err = decryptJSON(pdb.conn.EncryptionStrategy(), metadataBytes, metadataNonce, &svr.Metadata)
 	if err != nil {
 		return SavedVersionedResource{}, false, err
 	}
@@ -2083,7 +2377,7 @@ func (pdb *pipelineDB) saveJobInputMapping(table string, inputMapping algorithm.
 
 func (pdb *pipelineDB) getJobBuildInputs(table string, jobName string) ([]BuildInput, error) {
 	rows, err := pdb.conn.Query(`
-		SELECT i.input_name, i.first_occurrence, r.name, v.type, v.version, v.metadata
+		SELECT i.input_name, i.first_occurrence, r.name, v.type, v.version, v.metadata, v.metadata_nonce
 		FROM `+table+` i
 		JOIN jobs j ON i.job_id = j.id
 		JOIN versioned_resources v ON v.id = i.version_id
@@ -2105,11 +2399,12 @@ func (pdb *pipelineDB) getJobBuildInputs(table string, jobName string) ([]BuildI
 			resourceType    string
 			versionBlob     string
 			metadataBlob    string
+			metadataNonce   sql.NullString
 			version         Version
 			metadata        []MetadataField
 		)
 
-		err := rows.Scan(&inputName, &firstOccurrence, &resourceName, &resourceType, &versionBlob, &metadataBlob)
+		err := rows.Scan(&inputName, &firstOccurrence, &resourceName, &resourceType, &versionBlob, &metadataBlob, &metadataNonce)
 		if err != nil {
 			return nil, err
 		}
@@ -2119,7 +2414,7 @@ func (pdb *pipelineDB) getJobBuildInputs(table string, jobName string) ([]BuildI
 			return nil, err
 		}
 
-		err = json.Unmarshal([]byte(metadataBlob), &metadata)
+		err = decryptJSON(pdb.conn.EncryptionStrategy(), metadataBlob, metadataNonce, &metadata)
 		if err != nil {
 			return nil, err
 		}
@@ -2147,6 +2442,16 @@ func (pdb *pipelineDB) UnpauseJob(job string) error {
 	return pdb.updatePausedJob(job, false)
 }
 
+func (pdb *pipelineDB) ClearTaskCaches(job string) error {
+	_, err := pdb.conn.Exec(`
+		UPDATE volumes
+		SET task_cache_cleared = true
+		WHERE task_cache_pipeline_id = $1
+		AND task_cache_job_name = $2
+	`, pdb.ID, job)
+	return err
+}
+
 func (pdb *pipelineDB) SetMaxInFlightReached(jobName string, reached bool) error {
 	result, err := pdb.conn.Exec(`
 		UPDATE jobs
@@ -2211,6 +2516,77 @@ func (pdb *pipelineDB) UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildI
 	return tx.Commit()
 }
 
+// UpdateJobScheduleCheckedTime records the time up to which the job's
+// schedule has been checked, returning the previously recorded time, or the
+// zero time if the schedule has never been checked.
+//
+// While the job is paused its schedule is not checked, and the zero time is
+// returned, so that the ticks that pass while it is paused do not trigger
+// builds once it is unpaused.
+func (pdb *pipelineDB) UpdateJobScheduleCheckedTime(job string, checkedTime time.Time) (time.Time, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	defer tx.Rollback()
+
+	var jobID int
+	var paused bool
+	var previousTime pq.NullTime
+	err = tx.QueryRow(`
+		SELECT id, paused, schedule_checked_at
+		FROM jobs
+		WHERE name = $1 AND pipeline_id = $2
+		FOR UPDATE
+	`, job, pdb.ID).Scan(&jobID, &paused, &previousTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	newTime := pq.NullTime{Time: checkedTime, Valid: true}
+	if paused {
+		newTime = pq.NullTime{}
+		previousTime = pq.NullTime{}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE jobs
+		SET schedule_checked_at = $1
+		WHERE id = $2
+	`, newTime, jobID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !previousTime.Valid {
+		return time.Time{}, nil
+	}
+
+	return previousTime.Time, nil
+}
+
+// resetPausedScheduleCheckedTimes forgets when the schedules of the jobs of
+// the pipeline were last checked if it is paused, as they are not checked
+// while it is, so that the ticks that passed in the meantime do not trigger
+// builds once it is unpaused.
+func resetPausedScheduleCheckedTimes(tx Tx, pipelineID int) error {
+	_, err := tx.Exec(`
+		UPDATE jobs
+		SET schedule_checked_at = NULL
+		WHERE pipeline_id = $1
+		AND EXISTS (
+			SELECT 1 FROM pipelines WHERE id = $1 AND paused
+		)
+	`, pipelineID)
+	return err
+}
+
 func (pdb *pipelineDB) updatePausedJob(job string, pause bool) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -2381,6 +2757,77 @@ func (pdb *pipelineDB) GetAllJobBuilds(job string) ([]Build, error) {
 	return bs, nil
 }
 
+func (pdb *pipelineDB) GetLatestSucceededJobBuilds(job string, limit int) ([]Build, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN pipelines p ON j.pipeline_id = p.id
+		INNER JOIN teams t ON b.team_id = t.id
+		WHERE j.name = $1
+			AND j.pipeline_id = $2
+			AND b.status = 'succeeded'
+		ORDER BY b.id DESC
+		LIMIT $3
+	`, job, pdb.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := pdb.buildFactory.ScanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
+func (pdb *pipelineDB) GetUnreapedJobBuildsAfter(job string, buildID int, limit int) ([]Build, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT sub.*
+		FROM (
+			SELECT `+qualifiedBuildColumns+`
+			FROM builds b
+			INNER JOIN jobs j ON b.job_id = j.id
+			INNER JOIN pipelines p ON j.pipeline_id = p.id
+			INNER JOIN teams t ON b.team_id = t.id
+			WHERE j.name = $1
+				AND j.pipeline_id = $2
+				AND b.id > $3
+				AND b.reap_time IS NULL
+			ORDER BY b.id ASC
+			LIMIT $4
+		) sub
+		ORDER BY sub.id DESC
+	`, job, pdb.ID, buildID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := pdb.buildFactory.ScanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
 func (pdb *pipelineDB) GetJobFinishedAndNextBuild(job string) (Build, Build, error) {
 	finished, _, err := pdb.buildFactory.ScanBuild(pdb.conn.QueryRow(`
 		SELECT `+qualifiedBuildColumns+`
@@ -2390,7 +2837,7 @@ func (pdb *pipelineDB) GetJobFinishedAndNextBuild(job string) (Build, Build, err
 			INNER JOIN teams t ON b.team_id = t.id
  		WHERE j.name = $1
 			AND j.pipeline_id = $2
-			AND b.status NOT IN ('pending', 'started')
+			AND b.status NOT IN ('pending', 'started', 'pending-approval')
 		ORDER BY b.id DESC
 		LIMIT 1
 	`, job, pdb.ID))
@@ -2406,7 +2853,7 @@ func (pdb *pipelineDB) GetJobFinishedAndNextBuild(job string) (Build, Build, err
 			INNER JOIN teams t ON b.team_id = t.id
  		WHERE j.name = $1
 			AND j.pipeline_id = $2
-			AND status IN ('pending', 'started')
+			AND status IN ('pending', 'started', 'pending-approval')
 		ORDER BY b.id ASC
 		LIMIT 1
 	`, job, pdb.ID))
@@ -2429,7 +2876,7 @@ func (pdb *pipelineDB) GetDashboard() (Dashboard, atc.GroupConfigs, error) {
 		return nil, nil, err
 	}
 
-	startedBuilds, err := pdb.getLastJobBuildsSatisfying("b.status = 'started'")
+	startedBuilds, err := pdb.getLastJobBuildsSatisfying("b.status IN ('started', 'pending-approval')")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2439,7 +2886,7 @@ func (pdb *pipelineDB) GetDashboard() (Dashboard, atc.GroupConfigs, error) {
 		return nil, nil, err
 	}
 
-	finishedBuilds, err := pdb.getLastJobBuildsSatisfying("b.status NOT IN ('pending', 'started')")
+	finishedBuilds, err := pdb.getLastJobBuildsSatisfying("b.status NOT IN ('pending', 'started', 'pending-approval')")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2485,7 +2932,7 @@ func (pdb *pipelineDB) Hide() error {
 
 func (pdb *pipelineDB) getJobs() ([]SavedJob, error) {
 	rows, err := pdb.conn.Query(`
-		SELECT j.id, j.name, j.config, j.paused, j.first_logged_build_id, p.team_id
+		SELECT j.id, j.name, j.config, j.nonce, j.paused, j.first_logged_build_id, p.team_id
 		FROM jobs j, pipelines p
 		WHERE j.pipeline_id = p.id
 		AND pipeline_id = $1
@@ -2556,7 +3003,7 @@ func (pdb *pipelineDB) getLastJobBuildsSatisfying(bRequirement string) (map[stri
 
 func (pdb *pipelineDB) getJob(tx Tx, name string) (SavedJob, error) {
 	return pdb.scanJob(tx.QueryRow(`
- 	SELECT j.id, j.name, j.config, j.paused, j.first_logged_build_id, p.team_id
+ 	SELECT j.id, j.name, j.config, j.nonce, j.paused, j.first_logged_build_id, p.team_id
   	FROM jobs j, pipelines p
   	WHERE j.active = true
 			AND j.pipeline_id = p.id
@@ -2567,9 +3014,10 @@ func (pdb *pipelineDB) getJob(tx Tx, name string) (SavedJob, error) {
 
 func (pdb *pipelineDB) scanJob(row scannable) (SavedJob, error) {
 	var job SavedJob
-	var configBlob []byte
+	var configText string
+	var nonce sql.NullString
 
-	err := row.Scan(&job.ID, &job.Name, &configBlob, &job.Paused, &job.FirstLoggedBuildID, &job.TeamID)
+	err := row.Scan(&job.ID, &job.Name, &configText, &nonce, &job.Paused, &job.FirstLoggedBuildID, &job.TeamID)
 	if err != nil {
 		return SavedJob{}, err
 	}
@@ -2577,7 +3025,7 @@ func (pdb *pipelineDB) scanJob(row scannable) (SavedJob, error) {
 	job.PipelineName = pdb.Name
 
 	var config atc.JobConfig
-	err = json.Unmarshal(configBlob, &config)
+	err = decryptJSON(pdb.conn.EncryptionStrategy(), configText, nonce, &config)
 	if err != nil {
 		return SavedJob{}, err
 	}
@@ -1,7 +1,10 @@
 package db_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/concourse/atc"
@@ -412,6 +415,318 @@ var _ = Describe("PipelineDB", func() {
 		})
 	})
 
+	Describe("archiving a pipeline", func() {
+		It("starts out as unarchived", func() {
+			Expect(savedPipeline.Archived).To(BeFalse())
+		})
+
+		It("can be archived, which pauses it", func() {
+			err := pipelineDB.Archive()
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := pipelineDB.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipelineDB.Pipeline().Archived).To(BeTrue())
+			Expect(pipelineDB.Pipeline().Paused).To(BeTrue())
+
+			found, err = otherPipelineDB.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(otherPipelineDB.Pipeline().Archived).To(BeFalse())
+		})
+
+		It("is unarchived and unpaused by saving a config", func() {
+			err := pipelineDB.Archive()
+			Expect(err).NotTo(HaveOccurred())
+
+			resavedPipeline, _, err := teamDB.SaveConfig("a-pipeline-name", pipelineConfig, pipelineDB.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resavedPipeline.Archived).To(BeFalse())
+			Expect(resavedPipeline.Paused).To(BeFalse())
+		})
+
+		It("stays paused when unarchived by saving a config that pauses it", func() {
+			err := pipelineDB.Archive()
+			Expect(err).NotTo(HaveOccurred())
+
+			resavedPipeline, _, err := teamDB.SaveConfig("a-pipeline-name", pipelineConfig, pipelineDB.ConfigVersion(), db.PipelinePaused)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resavedPipeline.Archived).To(BeFalse())
+			Expect(resavedPipeline.Paused).To(BeTrue())
+		})
+
+		It("stays paused when saving a config for a paused pipeline that was never archived", func() {
+			err := pipelineDB.Pause()
+			Expect(err).NotTo(HaveOccurred())
+
+			resavedPipeline, _, err := teamDB.SaveConfig("a-pipeline-name", pipelineConfig, pipelineDB.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resavedPipeline.Paused).To(BeTrue())
+		})
+
+		It("leaves running builds and their pending approvals in place", func() {
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start("engine", "metadata")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = build.RequestApproval("some-plan", []string{"github:some-user"}, "ship it?")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = pipelineDB.Archive()
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.StatusPendingApproval))
+
+			approvals, err := build.GetPendingApprovals()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(HaveLen(1))
+
+			approved, err := build.Approve("some-plan", "github:some-user")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approved).To(BeTrue())
+		})
+	})
+
+	Describe("exporting and importing a pipeline", func() {
+		var otherTeamDB db.TeamDB
+
+		var importableConfig atc.Config
+
+		var input db.VersionedResource
+		var output db.VersionedResource
+
+		exportPipeline := func(includeEvents bool) atc.PipelineExport {
+			buf := new(bytes.Buffer)
+			err := pipelineDB.Export(buf, includeEvents)
+			Expect(err).NotTo(HaveOccurred())
+
+			var export atc.PipelineExport
+			err = json.NewDecoder(buf).Decode(&export)
+			Expect(err).NotTo(HaveOccurred())
+
+			return export
+		}
+
+		BeforeEach(func() {
+			// only valid configs can be imported
+			importableConfig = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"source-config": "some-value"},
+					},
+					{
+						Name:   "some-other-resource",
+						Type:   "some-type",
+						Source: atc.Source{"source-config": "some-value"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{Get: "some-input", Resource: "some-resource"},
+							{Put: "some-other-resource"},
+						},
+					},
+					{
+						Name: "some-other-job",
+						Plan: atc.PlanSequence{
+							{Get: "some-resource"},
+						},
+					},
+				},
+			}
+
+			var err error
+			savedPipeline, _, err = teamDB.SaveConfig("a-pipeline-name", importableConfig, pipelineDB.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			pipelineDB = pipelineDBFactory.Build(savedPipeline)
+
+			_, err = sqlDB.CreateTeam(db.Team{Name: "other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+
+			input = db.VersionedResource{
+				Resource: "some-resource",
+				Type:     "some-type",
+				Version:  db.Version{"ver": "1"},
+				Metadata: []db.MetadataField{{Name: "some", Value: "metadata"}},
+			}
+
+			output = db.VersionedResource{
+				Resource: "some-other-resource",
+				Type:     "some-type",
+				Version:  db.Version{"ver": "2"},
+			}
+
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = build.SaveInput(db.BuildInput{Name: "some-input", VersionedResource: input})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = build.SaveOutput(output, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = pipelineDB.CreateJobBuild("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = pipelineDB.PauseJob("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("leaves out builds that have not finished", func() {
+			export := exportPipeline(false)
+			Expect(export.Builds).To(HaveLen(1))
+			Expect(export.Builds[0].JobName).To(Equal("some-job"))
+			Expect(export.Builds[0].Events).To(BeEmpty())
+		})
+
+		It("includes build events if asked to", func() {
+			export := exportPipeline(true)
+			Expect(export.Builds).To(HaveLen(1))
+			Expect(export.Builds[0].Events).NotTo(BeEmpty())
+		})
+
+		It("exports each build with its own inputs, outputs, and events", func() {
+			otherInput := db.VersionedResource{
+				Resource: "some-resource",
+				Type:     "some-type",
+				Version:  db.Version{"ver": "3"},
+			}
+
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = build.SaveInput(db.BuildInput{Name: "some-input", VersionedResource: otherInput})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some-log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.StatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			export := exportPipeline(true)
+			Expect(export.Builds).To(HaveLen(2))
+
+			versionIDs := map[string]int{}
+			for _, version := range export.Versions {
+				versionIDs[version.Version["ver"]] = version.ID
+			}
+
+			Expect(export.Builds[0].Name).To(Equal("1"))
+			Expect(export.Builds[0].Inputs).To(Equal([]atc.ExportedBuildInput{{Name: "some-input", VersionID: versionIDs["1"]}}))
+			Expect(export.Builds[0].Outputs).To(Equal([]atc.ExportedBuildOutput{{VersionID: versionIDs["2"], Explicit: true}}))
+
+			Expect(export.Builds[1].ID).To(Equal(build.ID()))
+			Expect(export.Builds[1].Name).To(Equal("2"))
+			Expect(export.Builds[1].Status).To(Equal("failed"))
+			Expect(export.Builds[1].Inputs).To(Equal([]atc.ExportedBuildInput{{Name: "some-input", VersionID: versionIDs["3"]}}))
+			Expect(export.Builds[1].Outputs).To(BeEmpty())
+
+			eventTypes := []atc.EventType{}
+			for _, ev := range export.Builds[1].Events {
+				eventTypes = append(eventTypes, ev.Type)
+			}
+
+			Expect(eventTypes).To(Equal([]atc.EventType{event.EventTypeLog, event.EventTypeStatus}))
+		})
+
+		It("imports the pipeline with its versions and builds under new IDs", func() {
+			export := exportPipeline(true)
+
+			importedPipeline, err := otherTeamDB.ImportPipeline(export)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(importedPipeline.ID).NotTo(Equal(savedPipeline.ID))
+			Expect(importedPipeline.Name).To(Equal("a-pipeline-name"))
+			Expect(importedPipeline.Config).To(Equal(importableConfig))
+
+			importedPipelineDB := pipelineDBFactory.Build(importedPipeline)
+
+			build, found, err := importedPipelineDB.GetJobBuild("some-job", "1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.StatusSucceeded))
+
+			input.PipelineID = importedPipeline.ID
+			output.PipelineID = importedPipeline.ID
+
+			inputs, outputs, err := build.GetResources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(ConsistOf(db.BuildInput{Name: "some-input", VersionedResource: input, FirstOccurrence: true}))
+			Expect(outputs).To(ConsistOf(db.BuildOutput{VersionedResource: output}))
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer events.Close()
+
+			ev, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev).To(Equal(envelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   build.EndTime().Unix(),
+			})))
+
+			job, found, err := importedPipelineDB.GetJob("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.Paused).To(BeTrue())
+
+			nextBuild, err := importedPipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nextBuild.Name()).To(Equal("2"))
+		})
+
+		It("does not import over an existing pipeline", func() {
+			export := exportPipeline(false)
+
+			_, err := teamDB.ImportPipeline(export)
+			Expect(err).To(Equal(db.ErrPipelineAlreadyExists))
+		})
+
+		It("does not import an invalid config", func() {
+			export := exportPipeline(false)
+
+			export.Config.Jobs[0].Plan = append(export.Config.Jobs[0].Plan, atc.PlanConfig{Get: "bogus-resource"})
+
+			_, err := otherTeamDB.ImportPipeline(export)
+			Expect(err).To(BeAssignableToTypeOf(db.InvalidExportError{}))
+
+			_, found, err := otherTeamDB.GetPipelineByName("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not import builds of jobs that are not in the config", func() {
+			export := exportPipeline(false)
+
+			export.Builds[0].JobName = "bogus-job"
+
+			_, err := otherTeamDB.ImportPipeline(export)
+			Expect(err).To(Equal(db.InvalidExportError{
+				Errors: []string{
+					fmt.Sprintf("build %d refers to job 'bogus-job', which is not in the config", export.Builds[0].ID),
+				},
+			}))
+		})
+	})
+
 	Describe("UpdateName", func() {
 		var teamDB db.TeamDB
 
@@ -937,6 +1252,184 @@ var _ = Describe("PipelineDB", func() {
 			}))
 		})
 
+		Context("when a job has passed constraints on a job in another pipeline", func() {
+			var downstreamPipelineDB db.PipelineDB
+
+			BeforeEach(func() {
+				downstreamPipeline, _, err := teamDB.SaveConfig("downstream-pipeline-name", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "downstream-resource",
+							Type: "some-type",
+							Source: atc.Source{
+								"source-config": "some-value",
+							},
+						},
+					},
+
+					Jobs: atc.JobConfigs{
+						{
+							Name: "downstream-job",
+							Plan: atc.PlanSequence{
+								{
+									Get:    "downstream-resource",
+									Passed: []string{"other-pipeline-name/a-job"},
+								},
+							},
+						},
+					},
+				}, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				downstreamPipelineDB = pipelineDBFactory.Build(downstreamPipeline)
+			})
+
+			It("includes the other pipeline's outputs of versions of resources with the same source", func() {
+				err := otherPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"source-config": "some-value"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamVR, found, err := otherPipelineDB.GetLatestVersionedResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = downstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "downstream-resource",
+					Type:   "some-type",
+					Source: atc.Source{"source-config": "some-value"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				downstreamVR, found, err := downstreamPipelineDB.GetLatestVersionedResource("downstream-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				downstreamResource, found, err := downstreamPipelineDB.GetResource("downstream-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.BuildOutputs).To(BeEmpty())
+
+				upstreamJob, found, err := otherPipelineDB.GetJob("a-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				downstreamJob, found, err := downstreamPipelineDB.GetJob("downstream-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(versions.JobIDs).To(Equal(map[string]int{
+					"downstream-job":            downstreamJob.ID,
+					"other-pipeline-name/a-job": upstreamJob.ID,
+				}))
+
+				upstreamBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveOutput(upstreamBuild.ID(), upstreamVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = upstreamBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err = downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.BuildOutputs).To(ConsistOf([]algorithm.BuildOutput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{
+							VersionID:  downstreamVR.ID,
+							ResourceID: downstreamResource.ID,
+							CheckOrder: downstreamVR.CheckOrder,
+						},
+						JobID:   upstreamJob.ID,
+						BuildID: upstreamBuild.ID(),
+					},
+				}))
+			})
+
+			Context("when the sources have vars", func() {
+				BeforeEach(func() {
+					_, _, err := teamDB.SaveConfig("other-pipeline-name", atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:   "some-resource",
+								Type:   "some-type",
+								Source: atc.Source{"source-config": "((some-var))"},
+							},
+						},
+						Jobs: atc.JobConfigs{{Name: "a-job"}},
+					}, otherPipelineDB.ConfigVersion(), db.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, _, err = teamDB.SaveConfig("downstream-pipeline-name", atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:   "downstream-resource",
+								Type:   "some-type",
+								Source: atc.Source{"source-config": "((some-var))"},
+							},
+						},
+						Jobs: atc.JobConfigs{
+							{
+								Name: "downstream-job",
+								Plan: atc.PlanSequence{
+									{
+										Get:    "downstream-resource",
+										Passed: []string{"other-pipeline-name/a-job"},
+									},
+								},
+							},
+						},
+					}, downstreamPipelineDB.ConfigVersion(), db.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("includes the other pipeline's outputs, as the sources are compared as configured", func() {
+					err := otherPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"source-config": "((some-var))"},
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					upstreamVR, found, err := otherPipelineDB.GetLatestVersionedResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = downstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "downstream-resource",
+						Type:   "some-type",
+						Source: atc.Source{"source-config": "((some-var))"},
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					downstreamVR, found, err := downstreamPipelineDB.GetLatestVersionedResource("downstream-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					upstreamBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = otherPipelineDB.SaveOutput(upstreamBuild.ID(), upstreamVR.VersionedResource, false)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = upstreamBuild.Finish(db.StatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					versions, err := downstreamPipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versions.BuildOutputs).To(HaveLen(1))
+					Expect(versions.BuildOutputs[0].VersionID).To(Equal(downstreamVR.ID))
+					Expect(versions.BuildOutputs[0].BuildID).To(Equal(upstreamBuild.ID()))
+				})
+			})
+		})
+
 		Context("when a version is disabled", func() {
 			It("omits the version from the versions DB", func() {
 				build1, err := pipelineDB.CreateJobBuild("a-job")
@@ -1294,6 +1787,89 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("pinning and unpinning versioned resources", func() {
+			var savedVR1, savedVR2 db.SavedVersionedResource
+
+			BeforeEach(func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   resource.Name,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}, {"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				savedVR1, _, err = pipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, resource.Name)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedVR2, _, err = pipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "2"}, resource.Name)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns ErrVersionedResourceNotFound if the version is bogus", func() {
+				err := pipelineDB.PinVersionedResource(resource.Name, 42)
+				Expect(err).To(Equal(db.ErrVersionedResourceNotFound))
+			})
+
+			It("returns ErrVersionedResourceNotFound if the version belongs to another resource", func() {
+				err := pipelineDB.PinVersionedResource(otherResource.Name, savedVR1.ID)
+				Expect(err).To(Equal(db.ErrVersionedResourceNotFound))
+			})
+
+			It("surfaces the pinned version on the resource", func() {
+				err := pipelineDB.PinVersionedResource(resource.Name, savedVR1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				pinnedResource, found, err := pipelineDB.GetResource(resource.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pinnedResource.PinnedVersionID).To(Equal(savedVR1.ID))
+				Expect(pinnedResource.PinnedVersion).To(Equal(db.Version{"version": "1"}))
+
+				err = pipelineDB.UnpinVersionedResource(resource.Name, savedVR1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				unpinnedResource, found, err := pipelineDB.GetResource(resource.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(unpinnedResource.PinnedVersionID).To(BeZero())
+				Expect(unpinnedResource.PinnedVersion).To(BeNil())
+			})
+
+			It("does not unpin the resource when a different version is given", func() {
+				err := pipelineDB.PinVersionedResource(resource.Name, savedVR1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.UnpinVersionedResource(resource.Name, savedVR2.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				pinnedResource, _, err := pipelineDB.GetResource(resource.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinnedResource.PinnedVersionID).To(Equal(savedVR1.ID))
+			})
+
+			It("includes the pin in the versions DB, invalidating the cached one", func() {
+				versionsDB, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.PinnedVersionIDs).To(BeEmpty())
+
+				err = pipelineDB.PinVersionedResource(resource.Name, savedVR1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err = pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.PinnedVersionIDs).To(Equal(map[int]int{
+					resource.ID: savedVR1.ID,
+				}))
+
+				err = pipelineDB.UnpinVersionedResource(resource.Name, savedVR1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err = pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.PinnedVersionIDs).To(BeEmpty())
+			})
+		})
+
 		Describe("enabling and disabling versioned resources", func() {
 			It("returns an error if the resource or version is bogus", func() {
 				err := pipelineDB.EnableVersionedResource(42)
@@ -1656,6 +2232,56 @@ var _ = Describe("PipelineDB", func() {
 			Expect(builds).To(BeEmpty())
 		})
 
+		It("returns the latest succeeded builds of a job", func() {
+			build1, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build1.Finish(db.StatusSucceeded)).To(Succeed())
+
+			build2, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build2.Finish(db.StatusSucceeded)).To(Succeed())
+
+			build3, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build3.Finish(db.StatusFailed)).To(Succeed())
+
+			build4, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build4.Finish(db.StatusSucceeded)).To(Succeed())
+
+			builds, err := pipelineDB.GetLatestSucceededJobBuilds("some-job", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+			Expect(builds[0].ID()).To(Equal(build4.ID()))
+			Expect(builds[1].ID()).To(Equal(build2.ID()))
+		})
+
+		It("returns the oldest unreaped builds of a job after a build, newest first", func() {
+			build1, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			build2, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			build3, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			build4, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = sqlDB.DeleteBuildEventsByBuildIDs([]int{build2.ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := pipelineDB.GetUnreapedJobBuildsAfter("some-job", build1.ID(), 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+			Expect(builds[0].ID()).To(Equal(build4.ID()))
+			Expect(builds[1].ID()).To(Equal(build3.ID()))
+		})
+
 		It("initially has no pending build for a job", func() {
 			pendingBuilds, err := pipelineDB.GetPendingBuildsForJob("some-job")
 			Expect(err).NotTo(HaveOccurred())
@@ -2386,6 +3012,157 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("UpdateJobScheduleCheckedTime", func() {
+			It("returns the previously checked time", func() {
+				By("starting out as the zero time")
+				firstCheck := time.Date(2017, time.March, 27, 2, 0, 0, 0, time.UTC)
+				lastChecked, err := pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lastChecked.IsZero()).To(BeTrue())
+
+				By("returning the time it was last updated to")
+				lastChecked, err = pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(time.Minute))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lastChecked).To(BeTemporally("==", firstCheck))
+			})
+
+			Context("with a job that has a schedule", func() {
+				var firstCheck time.Time
+
+				saveSchedule := func(schedule []atc.ScheduleConfig, pausedState db.PipelinePausedState) {
+					scheduledConfig := pipelineConfig
+					scheduledConfig.Jobs = make(atc.JobConfigs, len(pipelineConfig.Jobs))
+					copy(scheduledConfig.Jobs, pipelineConfig.Jobs)
+
+					for i, job := range scheduledConfig.Jobs {
+						if job.Name == "some-job" {
+							scheduledConfig.Jobs[i].Schedule = schedule
+						}
+					}
+
+					_, _, err := teamDB.SaveConfig("a-pipeline-name", scheduledConfig, pipelineDB.ConfigVersion(), pausedState)
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err := pipelineDB.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+				}
+
+				BeforeEach(func() {
+					saveSchedule([]atc.ScheduleConfig{{Cron: "0 2 * * 1-5"}}, db.PipelineNoChange)
+
+					firstCheck = time.Date(2017, time.March, 27, 2, 0, 0, 0, time.UTC)
+					_, err := pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("keeps the checked time when the config is saved again", func() {
+					saveSchedule([]atc.ScheduleConfig{{Cron: "0 2 * * 1-5"}}, db.PipelineNoChange)
+
+					lastChecked, err := pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(time.Minute))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lastChecked).To(BeTemporally("==", firstCheck))
+				})
+
+				It("forgets the checked time when the schedule is removed and added back", func() {
+					saveSchedule(nil, db.PipelineNoChange)
+					saveSchedule([]atc.ScheduleConfig{{Cron: "0 2 * * 1-5"}}, db.PipelineNoChange)
+
+					lastChecked, err := pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(time.Minute))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lastChecked.IsZero()).To(BeTrue())
+				})
+
+				It("forgets the checked time when the job is removed and added back", func() {
+					jobless := pipelineConfig
+					jobless.Jobs = atc.JobConfigs{}
+					for _, job := range pipelineConfig.Jobs {
+						if job.Name != "some-job" {
+							jobless.Jobs = append(jobless.Jobs, job)
+						}
+					}
+
+					_, _, err := teamDB.SaveConfig("a-pipeline-name", jobless, pipelineDB.ConfigVersion(), db.PipelineNoChange)
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err := pipelineDB.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					saveSchedule([]atc.ScheduleConfig{{Cron: "0 2 * * 1-5"}}, db.PipelineNoChange)
+
+					lastChecked, err := pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(time.Minute))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lastChecked.IsZero()).To(BeTrue())
+				})
+
+				Context("when the job is paused", func() {
+					BeforeEach(func() {
+						err := pipelineDB.PauseJob("some-job")
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("returns the zero time until it has been unpaused and checked", func() {
+						By("not counting ticks while it is paused")
+						lastChecked, err := pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(time.Minute))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(lastChecked.IsZero()).To(BeTrue())
+
+						lastChecked, err = pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(2*time.Minute))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(lastChecked.IsZero()).To(BeTrue())
+
+						err = pipelineDB.UnpauseJob("some-job")
+						Expect(err).NotTo(HaveOccurred())
+
+						By("starting to count ticks again once it is unpaused")
+						lastChecked, err = pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(time.Hour))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(lastChecked.IsZero()).To(BeTrue())
+
+						lastChecked, err = pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(2*time.Hour))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(lastChecked).To(BeTemporally("==", firstCheck.Add(time.Hour)))
+					})
+				})
+
+				Context("when the pipeline is paused", func() {
+					BeforeEach(func() {
+						err := pipelineDB.Pause()
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("forgets the checked time when it is unpaused", func() {
+						err := pipelineDB.Unpause()
+						Expect(err).NotTo(HaveOccurred())
+
+						lastChecked, err := pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(time.Hour))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(lastChecked.IsZero()).To(BeTrue())
+					})
+
+					It("forgets the checked time when it is unpaused by saving its config", func() {
+						saveSchedule([]atc.ScheduleConfig{{Cron: "0 2 * * 1-5"}}, db.PipelineUnpaused)
+
+						lastChecked, err := pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(time.Hour))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(lastChecked.IsZero()).To(BeTrue())
+					})
+				})
+
+				Context("when the pipeline is unpaused while it is not paused", func() {
+					It("keeps the checked time", func() {
+						err := pipelineDB.Unpause()
+						Expect(err).NotTo(HaveOccurred())
+
+						lastChecked, err := pipelineDB.UpdateJobScheduleCheckedTime("some-job", firstCheck.Add(time.Minute))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(lastChecked).To(BeTemporally("==", firstCheck))
+					})
+				})
+			})
+		})
+
 		Describe("GetJobBuild", func() {
 			var firstBuild db.Build
 			var job db.SavedJob
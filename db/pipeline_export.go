@@ -0,0 +1,700 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/lib/pq"
+)
+
+// InvalidExportError is returned when importing an export whose config is
+// invalid, or which refers to resources, jobs, or versions it does not
+// contain.
+type InvalidExportError struct {
+	Errors []string
+}
+
+func (e InvalidExportError) Error() string {
+	return fmt.Sprintf("invalid export:\n%s", strings.Join(e.Errors, "\n"))
+}
+
+// exportBuildBatchSize is how many builds Export reads, along with their
+// inputs, outputs, and events, before writing them out.
+const exportBuildBatchSize = 100
+
+// Export writes the pipeline's config along with the versions of its
+// resources and its jobs' finished builds, including their inputs and
+// outputs, to w as the JSON of an atc.PipelineExport. Build events are only
+// included if includeEvents is true. Builds are read and written in batches,
+// so that a long history, particularly with its events, is never held in
+// memory all at once. Nothing is written if reading the resources, jobs, or
+// versions fails, but a failure while writing the builds leaves the export
+// cut short.
+func (pdb *pipelineDB) Export(w io.Writer, includeEvents bool) error {
+	resources, err := pdb.exportResources()
+	if err != nil {
+		return err
+	}
+
+	jobs, err := pdb.exportJobs()
+	if err != nil {
+		return err
+	}
+
+	versions, err := pdb.exportVersions()
+	if err != nil {
+		return err
+	}
+
+	fields := []struct {
+		prefix string
+		value  interface{}
+	}{
+		{`{"name":`, pdb.Name},
+		{`,"paused":`, pdb.Paused},
+		{`,"public":`, pdb.Public},
+		{`,"config":`, pdb.SavedPipeline.Config},
+		{`,"resources":`, resources},
+		{`,"jobs":`, jobs},
+		{`,"versions":`, versions},
+	}
+
+	for _, field := range fields {
+		err := writeJSON(w, field.prefix, field.value)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = io.WriteString(w, `,"builds":[`)
+	if err != nil {
+		return err
+	}
+
+	prefix := ""
+	afterID := 0
+	for {
+		builds, err := pdb.exportBuilds(afterID, includeEvents)
+		if err != nil {
+			return err
+		}
+
+		if len(builds) == 0 {
+			break
+		}
+
+		for _, build := range builds {
+			err := writeJSON(w, prefix, build)
+			if err != nil {
+				return err
+			}
+
+			prefix = ","
+		}
+
+		afterID = builds[len(builds)-1].ID
+	}
+
+	_, err = io.WriteString(w, "]}\n")
+	return err
+}
+
+func writeJSON(w io.Writer, prefix string, value interface{}) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, prefix)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(payload)
+	return err
+}
+
+func (pdb *pipelineDB) exportResources() ([]atc.ExportedResource, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT r.name, r.paused, r.pinned_version_id
+		FROM resources r
+		WHERE r.pipeline_id = $1
+		AND r.active = true
+		ORDER BY r.id ASC
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	resources := []atc.ExportedResource{}
+	for rows.Next() {
+		var resource atc.ExportedResource
+		var pinnedVersionID sql.NullInt64
+		err := rows.Scan(&resource.Name, &resource.Paused, &pinnedVersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		resource.PinnedVersionID = int(pinnedVersionID.Int64)
+
+		resources = append(resources, resource)
+	}
+
+	return resources, rows.Err()
+}
+
+func (pdb *pipelineDB) exportJobs() ([]atc.ExportedJob, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT j.name, j.paused
+		FROM jobs j
+		WHERE j.pipeline_id = $1
+		AND j.active = true
+		ORDER BY j.id ASC
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	jobs := []atc.ExportedJob{}
+	for rows.Next() {
+		var job atc.ExportedJob
+		err := rows.Scan(&job.Name, &job.Paused)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+func (pdb *pipelineDB) exportVersions() ([]atc.ExportedVersion, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT v.id, r.name, v.type, v.version, v.metadata, v.metadata_nonce, v.enabled, v.check_order
+		FROM versioned_resources v, resources r
+		WHERE r.id = v.resource_id
+		AND r.pipeline_id = $1
+		AND r.active = true
+		ORDER BY v.id ASC
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := []atc.ExportedVersion{}
+	for rows.Next() {
+		var version atc.ExportedVersion
+		var versionString, metadataString string
+		var metadataNonce sql.NullString
+		err := rows.Scan(&version.ID, &version.Resource, &version.Type, &versionString, &metadataString, &metadataNonce, &version.Enabled, &version.CheckOrder)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(versionString), &version.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		err = decryptJSON(pdb.conn.EncryptionStrategy(), metadataString, metadataNonce, &version.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, rows.Err()
+}
+
+// exportBuilds returns the next batch of finished builds after the given
+// build ID, with their inputs, outputs, and, if includeEvents is true, their
+// events. Each query is read to the end before the next is made, so that an
+// export only ever needs one connection.
+func (pdb *pipelineDB) exportBuilds(afterID int, includeEvents bool) ([]atc.ExportedBuild, error) {
+	builds, err := pdb.exportBuildBatch(afterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(builds) == 0 {
+		return builds, nil
+	}
+
+	buildIndexes := map[int]int{}
+	for i, build := range builds {
+		buildIndexes[build.ID] = i
+	}
+
+	firstID := builds[0].ID
+	lastID := builds[len(builds)-1].ID
+
+	err = pdb.exportBuildInputs(builds, buildIndexes, firstID, lastID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pdb.exportBuildOutputs(builds, buildIndexes, firstID, lastID)
+	if err != nil {
+		return nil, err
+	}
+
+	if includeEvents {
+		err = pdb.exportBuildEvents(builds, buildIndexes, firstID, lastID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return builds, nil
+}
+
+func (pdb *pipelineDB) exportBuildBatch(afterID int) ([]atc.ExportedBuild, error) {
+	// running and pending builds are left behind, as they can't be resumed on
+	// another cluster
+	rows, err := pdb.conn.Query(`
+		SELECT b.id, j.name, b.name, b.status, b.start_time, b.end_time
+		FROM builds b, jobs j
+		WHERE j.id = b.job_id
+		AND j.pipeline_id = $1
+		AND j.active = true
+		AND b.completed = true
+		AND b.id > $2
+		ORDER BY b.id ASC
+		LIMIT $3
+	`, pdb.ID, afterID, exportBuildBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	builds := []atc.ExportedBuild{}
+	for rows.Next() {
+		var build atc.ExportedBuild
+		var startTime, endTime pq.NullTime
+		err := rows.Scan(&build.ID, &build.JobName, &build.Name, &build.Status, &startTime, &endTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if startTime.Valid {
+			build.StartTime = startTime.Time.Unix()
+		}
+
+		if endTime.Valid {
+			build.EndTime = endTime.Time.Unix()
+		}
+
+		build.Inputs = []atc.ExportedBuildInput{}
+		build.Outputs = []atc.ExportedBuildOutput{}
+
+		builds = append(builds, build)
+	}
+
+	return builds, rows.Err()
+}
+
+func (pdb *pipelineDB) exportBuildInputs(builds []atc.ExportedBuild, buildIndexes map[int]int, firstID int, lastID int) error {
+	rows, err := pdb.conn.Query(`
+		SELECT i.build_id, i.name, i.versioned_resource_id
+		FROM build_inputs i, versioned_resources v, resources r
+		WHERE v.id = i.versioned_resource_id
+		AND r.id = v.resource_id
+		AND r.pipeline_id = $1
+		AND r.active = true
+		AND i.build_id BETWEEN $2 AND $3
+		ORDER BY i.build_id ASC
+	`, pdb.ID, firstID, lastID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var input atc.ExportedBuildInput
+		err := rows.Scan(&buildID, &input.Name, &input.VersionID)
+		if err != nil {
+			return err
+		}
+
+		index, found := buildIndexes[buildID]
+		if !found {
+			continue
+		}
+
+		builds[index].Inputs = append(builds[index].Inputs, input)
+	}
+
+	return rows.Err()
+}
+
+func (pdb *pipelineDB) exportBuildOutputs(builds []atc.ExportedBuild, buildIndexes map[int]int, firstID int, lastID int) error {
+	rows, err := pdb.conn.Query(`
+		SELECT o.build_id, o.versioned_resource_id, o.explicit
+		FROM build_outputs o, versioned_resources v, resources r
+		WHERE v.id = o.versioned_resource_id
+		AND r.id = v.resource_id
+		AND r.pipeline_id = $1
+		AND r.active = true
+		AND o.build_id BETWEEN $2 AND $3
+		ORDER BY o.build_id ASC
+	`, pdb.ID, firstID, lastID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var output atc.ExportedBuildOutput
+		err := rows.Scan(&buildID, &output.VersionID, &output.Explicit)
+		if err != nil {
+			return err
+		}
+
+		index, found := buildIndexes[buildID]
+		if !found {
+			continue
+		}
+
+		builds[index].Outputs = append(builds[index].Outputs, output)
+	}
+
+	return rows.Err()
+}
+
+func (pdb *pipelineDB) exportBuildEvents(builds []atc.ExportedBuild, buildIndexes map[int]int, firstID int, lastID int) error {
+	rows, err := pdb.conn.Query(fmt.Sprintf(`
+		SELECT build_id, event_id, type, version, payload
+		FROM pipeline_build_events_%d
+		WHERE build_id BETWEEN $1 AND $2
+		ORDER BY build_id ASC, event_id ASC
+	`, pdb.ID), firstID, lastID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var event atc.ExportedBuildEvent
+		var eventType, version, payload string
+		err := rows.Scan(&buildID, &event.ID, &eventType, &version, &payload)
+		if err != nil {
+			return err
+		}
+
+		index, found := buildIndexes[buildID]
+		if !found {
+			continue
+		}
+
+		event.Type = atc.EventType(eventType)
+		event.Version = atc.EventVersion(version)
+		event.Payload = json.RawMessage(payload)
+
+		builds[index].Events = append(builds[index].Events, event)
+	}
+
+	return rows.Err()
+}
+
+// ImportPipeline creates a pipeline from an export, remapping the IDs of
+// its versions and builds so that scheduling carries on from the last
+// exported builds rather than re-running every job. The pipeline must not
+// already exist, and the export is validated before anything is written.
+func (db *teamDB) ImportPipeline(export atc.PipelineExport) (SavedPipeline, error) {
+	errorMessages := validateExport(export)
+	if len(errorMessages) > 0 {
+		return SavedPipeline{}, InvalidExportError{Errors: errorMessages}
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return SavedPipeline{}, err
+	}
+
+	defer tx.Rollback()
+
+	var existing int
+	err = tx.QueryRow(`
+		SELECT COUNT(1)
+		FROM pipelines
+		WHERE name = $1
+		AND team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($2)
+		)
+	`, export.Name, db.teamName).Scan(&existing)
+	if err != nil {
+		return SavedPipeline{}, err
+	}
+
+	if existing != 0 {
+		return SavedPipeline{}, ErrPipelineAlreadyExists
+	}
+
+	pausedState := PipelineUnpaused
+	if export.Paused {
+		pausedState = PipelinePaused
+	}
+
+	savedPipeline, _, err := db.saveConfig(tx, "", export.Name, export.Config, 0, pausedState)
+	if err != nil {
+		return SavedPipeline{}, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE pipelines
+		SET public = $2
+		WHERE id = $1
+	`, savedPipeline.ID, export.Public)
+	if err != nil {
+		return SavedPipeline{}, err
+	}
+
+	savedPipeline.Public = export.Public
+
+	for _, job := range export.Jobs {
+		_, err = tx.Exec(`
+			UPDATE jobs
+			SET paused = $3
+			WHERE name = $1
+			AND pipeline_id = $2
+		`, job.Name, savedPipeline.ID, job.Paused)
+		if err != nil {
+			return SavedPipeline{}, err
+		}
+	}
+
+	versionIDs := map[int]int{}
+	for _, version := range export.Versions {
+		versionJSON, err := json.Marshal(version.Version)
+		if err != nil {
+			return SavedPipeline{}, err
+		}
+
+		metadata, metadataNonce, err := encryptJSON(db.conn.EncryptionStrategy(), version.Metadata)
+		if err != nil {
+			return SavedPipeline{}, err
+		}
+
+		var id int
+		err = tx.QueryRow(`
+			INSERT INTO versioned_resources (resource_id, type, version, metadata, metadata_nonce, enabled, check_order, modified_time)
+			SELECT r.id, $3, $4, $5, $6, $7, $8, now()
+			FROM resources r
+			WHERE r.name = $1
+			AND r.pipeline_id = $2
+			RETURNING id
+		`, version.Resource, savedPipeline.ID, version.Type, string(versionJSON), metadata, metadataNonce, version.Enabled, version.CheckOrder).Scan(&id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return SavedPipeline{}, ResourceNotFoundError{Name: version.Resource}
+			}
+
+			return SavedPipeline{}, err
+		}
+
+		versionIDs[version.ID] = id
+	}
+
+	for _, resource := range export.Resources {
+		var pinnedVersionID sql.NullInt64
+		if resource.PinnedVersionID != 0 {
+			id, found := versionIDs[resource.PinnedVersionID]
+			if !found {
+				return SavedPipeline{}, fmt.Errorf("pinned version %d of resource '%s' not found in export", resource.PinnedVersionID, resource.Name)
+			}
+
+			pinnedVersionID = sql.NullInt64{Int64: int64(id), Valid: true}
+		}
+
+		_, err = tx.Exec(`
+			UPDATE resources
+			SET paused = $3, pinned_version_id = $4
+			WHERE name = $1
+			AND pipeline_id = $2
+		`, resource.Name, savedPipeline.ID, resource.Paused, pinnedVersionID)
+		if err != nil {
+			return SavedPipeline{}, err
+		}
+	}
+
+	buildNumbers := map[string]int{}
+	for _, build := range export.Builds {
+		var buildID int
+		err = tx.QueryRow(`
+			INSERT INTO builds (name, job_id, team_id, status, start_time, end_time, completed)
+			SELECT $1, j.id, $4, $5, $6, $7, true
+			FROM jobs j
+			WHERE j.name = $2
+			AND j.pipeline_id = $3
+			RETURNING id
+		`,
+			build.Name,
+			build.JobName,
+			savedPipeline.ID,
+			savedPipeline.TeamID,
+			build.Status,
+			exportedTime(build.StartTime),
+			exportedTime(build.EndTime),
+		).Scan(&buildID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return SavedPipeline{}, fmt.Errorf("job '%s' not found", build.JobName)
+			}
+
+			return SavedPipeline{}, err
+		}
+
+		for _, input := range build.Inputs {
+			versionID, found := versionIDs[input.VersionID]
+			if !found {
+				return SavedPipeline{}, fmt.Errorf("input '%s' of build %d refers to version %d, which is not in the export", input.Name, build.ID, input.VersionID)
+			}
+
+			_, err = tx.Exec(`
+				INSERT INTO build_inputs (build_id, versioned_resource_id, name)
+				VALUES ($1, $2, $3)
+			`, buildID, versionID, input.Name)
+			if err != nil {
+				return SavedPipeline{}, err
+			}
+		}
+
+		for _, output := range build.Outputs {
+			versionID, found := versionIDs[output.VersionID]
+			if !found {
+				return SavedPipeline{}, fmt.Errorf("output of build %d refers to version %d, which is not in the export", build.ID, output.VersionID)
+			}
+
+			_, err = tx.Exec(`
+				INSERT INTO build_outputs (build_id, versioned_resource_id, explicit)
+				VALUES ($1, $2, $3)
+			`, buildID, versionID, output.Explicit)
+			if err != nil {
+				return SavedPipeline{}, err
+			}
+		}
+
+		for _, event := range build.Events {
+			_, err = tx.Exec(fmt.Sprintf(`
+				INSERT INTO pipeline_build_events_%d (event_id, build_id, type, version, payload)
+				VALUES ($1, $2, $3, $4, $5)
+			`, savedPipeline.ID), event.ID, buildID, string(event.Type), string(event.Version), string(event.Payload))
+			if err != nil {
+				return SavedPipeline{}, err
+			}
+		}
+
+		number, err := strconv.Atoi(build.Name)
+		if err == nil && number > buildNumbers[build.JobName] {
+			buildNumbers[build.JobName] = number
+		}
+	}
+
+	for jobName, number := range buildNumbers {
+		_, err = tx.Exec(`
+			UPDATE jobs
+			SET build_number_seq = $3
+			WHERE name = $1
+			AND pipeline_id = $2
+		`, jobName, savedPipeline.ID, number)
+		if err != nil {
+			return SavedPipeline{}, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return SavedPipeline{}, err
+	}
+
+	return savedPipeline, nil
+}
+
+func exportedTime(unix int64) pq.NullTime {
+	if unix == 0 {
+		return pq.NullTime{}
+	}
+
+	return pq.NullTime{Time: time.Unix(unix, 0), Valid: true}
+}
+
+func validateExport(export atc.PipelineExport) []string {
+	_, errorMessages := config.ValidateConfig(export.Config)
+
+	resources := map[string]bool{}
+	for _, resource := range export.Config.Resources {
+		resources[resource.Name] = true
+	}
+
+	jobs := map[string]bool{}
+	for _, job := range export.Config.Jobs {
+		jobs[job.Name] = true
+	}
+
+	versions := map[int]bool{}
+	for _, version := range export.Versions {
+		if !resources[version.Resource] {
+			errorMessages = append(errorMessages, fmt.Sprintf("version %d refers to resource '%s', which is not in the config", version.ID, version.Resource))
+		}
+
+		versions[version.ID] = true
+	}
+
+	for _, resource := range export.Resources {
+		if !resources[resource.Name] {
+			errorMessages = append(errorMessages, fmt.Sprintf("resource '%s' is not in the config", resource.Name))
+		}
+
+		if resource.PinnedVersionID != 0 && !versions[resource.PinnedVersionID] {
+			errorMessages = append(errorMessages, fmt.Sprintf("pinned version %d of resource '%s' is not in the export", resource.PinnedVersionID, resource.Name))
+		}
+	}
+
+	for _, job := range export.Jobs {
+		if !jobs[job.Name] {
+			errorMessages = append(errorMessages, fmt.Sprintf("job '%s' is not in the config", job.Name))
+		}
+	}
+
+	for _, build := range export.Builds {
+		if !jobs[build.JobName] {
+			errorMessages = append(errorMessages, fmt.Sprintf("build %d refers to job '%s', which is not in the config", build.ID, build.JobName))
+		}
+
+		for _, input := range build.Inputs {
+			if !versions[input.VersionID] {
+				errorMessages = append(errorMessages, fmt.Sprintf("input '%s' of build %d refers to version %d, which is not in the export", input.Name, build.ID, input.VersionID))
+			}
+		}
+
+		for _, output := range build.Outputs {
+			if !versions[output.VersionID] {
+				errorMessages = append(errorMessages, fmt.Sprintf("output of build %d refers to version %d, which is not in the export", build.ID, output.VersionID))
+			}
+		}
+	}
+
+	return errorMessages
+}
@@ -1,6 +1,9 @@
 package db
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"time"
 
 	"github.com/concourse/atc"
@@ -11,11 +14,13 @@ type Resource struct {
 }
 
 type SavedResource struct {
-	ID           int
-	CheckError   error
-	Paused       bool
-	PipelineName string
-	Config       atc.ResourceConfig
+	ID              int
+	CheckError      error
+	Paused          bool
+	PinnedVersionID int
+	PinnedVersion   Version
+	PipelineName    string
+	Config          atc.ResourceConfig
 	Resource
 }
 
@@ -31,6 +36,31 @@ func (r SavedResource) FailingToCheck() bool {
 	return r.CheckError != nil
 }
 
+// sourceHash identifies the resource by its team, type and source, so that
+// versions of the same resource configured in different pipelines of the team
+// can be matched up.
+//
+// The source is hashed as configured, without evaluating any ((vars)), so a
+// placeholder is taken to refer to the same credential in each of the team's
+// pipelines. The pipeline graph links shared resources by the same rule.
+func sourceHash(teamID int, config atc.ResourceConfig) (string, error) {
+	payload, err := json.Marshal(struct {
+		TeamID int        `json:"team_id"`
+		Type   string     `json:"type"`
+		Source atc.Source `json:"source"`
+	}{
+		TeamID: teamID,
+		Type:   config.Type,
+		Source: config.Source,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:]), nil
+}
+
 type VersionedResource struct {
 	Resource   string
 	Type       string
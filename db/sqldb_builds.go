@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"strconv"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 )
@@ -55,7 +56,7 @@ func (db *SQLDB) GetAllStartedBuilds() ([]Build, error) {
 		LEFT OUTER JOIN jobs j ON b.job_id = j.id
 		LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id
 		LEFT OUTER JOIN teams t ON b.team_id = t.id
-		WHERE b.status = 'started'
+		WHERE b.status IN ('started', 'pending-approval')
 	`)
 	if err != nil {
 		return nil, err
@@ -77,6 +78,39 @@ func (db *SQLDB) GetAllStartedBuilds() ([]Build, error) {
 	return bs, nil
 }
 
+func (db *SQLDB) GetUnreapedOneOffBuildsEndedBefore(endTime time.Time, limit int) ([]Build, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		LEFT OUTER JOIN jobs j ON b.job_id = j.id
+		LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id
+		LEFT OUTER JOIN teams t ON b.team_id = t.id
+		WHERE b.job_id IS NULL
+			AND b.end_time < $1
+			AND b.reap_time IS NULL
+		ORDER BY b.id ASC
+		LIMIT $2
+	`, endTime, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := db.buildFactory.ScanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
 func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	if len(buildIDs) == 0 {
 		return nil
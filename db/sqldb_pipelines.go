@@ -6,8 +6,8 @@ type PipelinesDB interface {
 	GetAllPublicPipelines() ([]SavedPipeline, error)
 }
 
-const pipelineColumns = "p.id, p.name, p.config, p.version, p.paused, p.team_id, p.public, t.name as team_name"
-const unqualifiedPipelineColumns = "id, name, config, version, paused, team_id, public"
+const pipelineColumns = "p.id, p.name, p.config, p.nonce, p.version, p.paused, p.team_id, p.public, p.archived, t.name as team_name"
+const unqualifiedPipelineColumns = "id, name, config, nonce, version, paused, team_id, public, archived"
 
 func (db *SQLDB) GetAllPublicPipelines() ([]SavedPipeline, error) {
 	rows, err := db.conn.Query(`
@@ -23,7 +23,7 @@ func (db *SQLDB) GetAllPublicPipelines() ([]SavedPipeline, error) {
 
 	defer rows.Close()
 
-	return scanPipelines(rows)
+	return scanPipelines(rows, db.conn.EncryptionStrategy())
 }
 
 func (db *SQLDB) GetAllPipelines() ([]SavedPipeline, error) {
@@ -39,7 +39,7 @@ func (db *SQLDB) GetAllPipelines() ([]SavedPipeline, error) {
 
 	defer rows.Close()
 
-	return scanPipelines(rows)
+	return scanPipelines(rows, db.conn.EncryptionStrategy())
 }
 
 func (db *SQLDB) GetPipelineByID(pipelineID int) (SavedPipeline, error) {
@@ -50,5 +50,5 @@ func (db *SQLDB) GetPipelineByID(pipelineID int) (SavedPipeline, error) {
 		WHERE p.id = $1
 	`, pipelineID)
 
-	return scanPipeline(row)
+	return scanPipeline(row, db.conn.EncryptionStrategy())
 }
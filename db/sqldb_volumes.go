@@ -79,6 +79,14 @@ func (db *SQLDB) InsertVolume(data Volume) error {
 		columns = append(columns, "replicated_from")
 		params = append(params, data.Identifier.Replication.ReplicatedVolumeHandle)
 		values = append(values, fmt.Sprintf("$%d", len(params)))
+	case data.Identifier.TaskCache != nil:
+		taskCache := data.Identifier.TaskCache
+
+		columns = append(columns, "task_cache_pipeline_id", "task_cache_job_name", "task_cache_step_name", "task_cache_path", "task_cache_cleared")
+		for _, param := range []interface{}{taskCache.PipelineID, taskCache.JobName, taskCache.StepName, taskCache.Path, taskCache.Cleared} {
+			params = append(params, param)
+			values = append(values, fmt.Sprintf("$%d", len(params)))
+		}
 	}
 
 	_, err = tx.Exec(
@@ -129,7 +137,12 @@ func (db *SQLDB) GetVolumes() ([]SavedVolume, error) {
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path,
+			v.task_cache_cleared
 		FROM volumes v
 		` + volumeJoins + `
 		WHERE (v.expires_at IS NULL OR v.expires_at > NOW())
@@ -171,6 +184,13 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 		}
 	case id.Replication != nil:
 		addParam("replicated_from", id.Replication.ReplicatedVolumeHandle)
+	case id.TaskCache != nil:
+		addParam("task_cache_pipeline_id", id.TaskCache.PipelineID)
+		addParam("task_cache_job_name", id.TaskCache.JobName)
+		addParam("task_cache_step_name", id.TaskCache.StepName)
+		addParam("task_cache_path", id.TaskCache.Path)
+		addParam("task_cache_cleared", id.TaskCache.Cleared)
+		addParam("worker_name", id.TaskCache.WorkerName)
 	}
 
 	statement := `
@@ -189,7 +209,12 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path,
+			v.task_cache_cleared
 		FROM volumes v` + volumeJoins
 
 	statement += "WHERE " + strings.Join(conditions, " AND ")
@@ -227,7 +252,12 @@ func (db *SQLDB) GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path,
+			v.task_cache_cleared
 		FROM volumes v ` + volumeJoins + `
 			INNER JOIN image_resource_versions i
 				ON i.version = v.resource_version
@@ -309,6 +339,60 @@ func (db *SQLDB) GetVolumeTTL(handle string) (time.Duration, bool, error) {
 	return ttl, true, nil
 }
 
+// MarkVolumeAsTaskCache makes the volume the current cache for the given
+// task cache identifier, keeping it around until the baggage collector
+// decides otherwise. Any previous caches for the identifier are cleared,
+// leaving them to be expired by the baggage collector.
+func (db *SQLDB) MarkVolumeAsTaskCache(handle string, id TaskCacheIdentifier) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE volumes
+		SET task_cache_cleared = true
+		WHERE task_cache_pipeline_id = $1
+		AND task_cache_job_name = $2
+		AND task_cache_step_name = $3
+		AND task_cache_path = $4
+		AND worker_name = $5
+		AND handle != $6
+	`, id.PipelineID, id.JobName, id.StepName, id.Path, id.WorkerName, handle)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE volumes
+		SET task_cache_pipeline_id = $1,
+			task_cache_job_name = $2,
+			task_cache_step_name = $3,
+			task_cache_path = $4,
+			task_cache_cleared = false,
+			original_volume_handle = NULL,
+			ttl = 0,
+			expires_at = NULL
+		WHERE handle = $5
+	`, id.PipelineID, id.JobName, id.StepName, id.Path, handle)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrVolumeNotFound
+	}
+
+	return tx.Commit()
+}
+
 func (db *SQLDB) ReapExpiredVolumes() error {
 	_, err := db.conn.Exec(`
 		DELETE FROM volumes
@@ -335,6 +419,11 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			path                 sql.NullString
 			hostPathVersion      sql.NullString
 			teamID               sql.NullInt64
+			taskCachePipelineID  sql.NullInt64
+			taskCacheJobName     sql.NullString
+			taskCacheStepName    sql.NullString
+			taskCachePath        sql.NullString
+			taskCacheCleared     bool
 		)
 
 		err := rows.Scan(
@@ -353,6 +442,11 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			&volume.SizeInBytes,
 			&volume.ContainerTTL,
 			&teamID,
+			&taskCachePipelineID,
+			&taskCacheJobName,
+			&taskCacheStepName,
+			&taskCachePath,
+			&taskCacheCleared,
 		)
 		if err != nil {
 			return []SavedVolume{}, err
@@ -367,6 +461,15 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 		}
 
 		switch {
+		case taskCachePipelineID.Valid:
+			volume.Volume.Identifier.TaskCache = &TaskCacheIdentifier{
+				PipelineID: int(taskCachePipelineID.Int64),
+				JobName:    taskCacheJobName.String,
+				StepName:   taskCacheStepName.String,
+				Path:       taskCachePath.String,
+				WorkerName: volume.WorkerName,
+				Cleared:    taskCacheCleared,
+			}
 		case versionJSON.Valid && resourceHash.Valid:
 			var cacheID ResourceCacheIdentifier
 
@@ -3,6 +3,7 @@ package db
 import (
 	"encoding/json"
 
+	"github.com/concourse/atc"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -21,8 +22,9 @@ func (t Team) IsAuthConfigured() bool {
 }
 
 type BasicAuth struct {
-	BasicAuthUsername string `json:"basic_auth_username"`
-	BasicAuthPassword string `json:"basic_auth_password"`
+	BasicAuthUsername string   `json:"basic_auth_username"`
+	BasicAuthPassword string   `json:"basic_auth_password"`
+	Role              atc.Role `json:"role,omitempty"`
 }
 
 func (auth *BasicAuth) EncryptedJSON() (string, error) {
@@ -35,6 +37,7 @@ func (auth *BasicAuth) EncryptedJSON() (string, error) {
 		result = &BasicAuth{
 			BasicAuthPassword: string(encryptedPw),
 			BasicAuthUsername: auth.BasicAuthUsername,
+			Role:              auth.Role,
 		}
 	}
 
@@ -51,6 +54,14 @@ type GitHubAuth struct {
 	AuthURL       string       `json:"auth_url"`
 	TokenURL      string       `json:"token_url"`
 	APIURL        string       `json:"api_url"`
+	Roles         []GitHubRole `json:"roles,omitempty"`
+}
+
+type GitHubRole struct {
+	Role          atc.Role     `json:"role"`
+	Organizations []string     `json:"organizations"`
+	Teams         []GitHubTeam `json:"teams"`
+	Users         []string     `json:"users"`
 }
 
 type GitHubTeam struct {
@@ -64,21 +75,33 @@ type SavedTeam struct {
 }
 
 type UAAAuth struct {
-	ClientID     string   `json:"client_id"`
-	ClientSecret string   `json:"client_secret"`
-	AuthURL      string   `json:"auth_url"`
-	TokenURL     string   `json:"token_url"`
-	CFSpaces     []string `json:"cf_spaces"`
-	CFURL        string   `json:"cf_url"`
-	CFCACert     string   `json:"cf_ca_cert"`
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret"`
+	AuthURL      string    `json:"auth_url"`
+	TokenURL     string    `json:"token_url"`
+	CFSpaces     []string  `json:"cf_spaces"`
+	CFURL        string    `json:"cf_url"`
+	CFCACert     string    `json:"cf_ca_cert"`
+	Roles        []UAARole `json:"roles,omitempty"`
+}
+
+type UAARole struct {
+	Role     atc.Role `json:"role"`
+	CFSpaces []string `json:"cf_spaces"`
 }
 
 type GenericOAuth struct {
-	AuthURL       string            `json:"auth_url"`
-	AuthURLParams map[string]string `json:"auth_url_params"`
-	TokenURL      string            `json:"token_url"`
-	ClientID      string            `json:"client_id"`
-	ClientSecret  string            `json:"client_secret"`
-	DisplayName   string            `json:"display_name"`
-	Scope         string            `json:"scope"`
+	AuthURL       string             `json:"auth_url"`
+	AuthURLParams map[string]string  `json:"auth_url_params"`
+	TokenURL      string             `json:"token_url"`
+	ClientID      string             `json:"client_id"`
+	ClientSecret  string             `json:"client_secret"`
+	DisplayName   string             `json:"display_name"`
+	Scope         string             `json:"scope"`
+	Roles         []GenericOAuthRole `json:"roles,omitempty"`
+}
+
+type GenericOAuthRole struct {
+	Role  atc.Role `json:"role"`
+	Scope string   `json:"scope"`
 }
@@ -30,6 +30,12 @@ type TeamDB interface {
 
 	GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error)
 	SaveConfig(string, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
+	SaveConfigBy(savedBy string, pipelineName string, config atc.Config, from ConfigVersion, pausedState PipelinePausedState) (SavedPipeline, bool, error)
+
+	GetConfigVersions(pipelineName string) ([]PipelineConfigVersion, error)
+	GetConfigVersion(pipelineName string, version ConfigVersion) (PipelineConfigVersion, bool, error)
+
+	ImportPipeline(export atc.PipelineExport) (SavedPipeline, error)
 
 	CreateOneOffBuild() (Build, error)
 	GetPrivateAndPublicBuilds(page Page) ([]Build, Pagination, error)
@@ -58,7 +64,7 @@ func (db *teamDB) GetPipelineByName(pipelineName string) (SavedPipeline, bool, e
 			SELECT id FROM teams WHERE LOWER(name) = LOWER($2)
 		)
 	`, pipelineName, db.teamName)
-	pipeline, err := scanPipeline(row)
+	pipeline, err := scanPipeline(row, db.conn.EncryptionStrategy())
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedPipeline{}, false, nil
@@ -86,7 +92,7 @@ func (db *teamDB) GetPipelines() ([]SavedPipeline, error) {
 
 	defer rows.Close()
 
-	return scanPipelines(rows)
+	return scanPipelines(rows, db.conn.EncryptionStrategy())
 }
 
 func (db *teamDB) GetPublicPipelines() ([]SavedPipeline, error) {
@@ -106,7 +112,7 @@ func (db *teamDB) GetPublicPipelines() ([]SavedPipeline, error) {
 
 	defer rows.Close()
 
-	return scanPipelines(rows)
+	return scanPipelines(rows, db.conn.EncryptionStrategy())
 }
 
 func (db *teamDB) GetPrivateAndAllPublicPipelines() ([]SavedPipeline, error) {
@@ -123,7 +129,7 @@ func (db *teamDB) GetPrivateAndAllPublicPipelines() ([]SavedPipeline, error) {
 
 	defer rows.Close()
 
-	currentTeamPipelines, err := scanPipelines(rows)
+	currentTeamPipelines, err := scanPipelines(rows, db.conn.EncryptionStrategy())
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +148,7 @@ func (db *teamDB) GetPrivateAndAllPublicPipelines() ([]SavedPipeline, error) {
 
 	defer otherRows.Close()
 
-	otherTeamPipelines, err := scanPipelines(otherRows)
+	otherTeamPipelines, err := scanPipelines(otherRows, db.conn.EncryptionStrategy())
 	if err != nil {
 		return nil, err
 	}
@@ -203,17 +209,18 @@ func (db *teamDB) OrderPipelines(pipelineNames []string) error {
 }
 
 func (db *teamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error) {
-	var configBlob []byte
+	var configText string
+	var nonce sql.NullString
 	var version int
 	err := db.conn.QueryRow(`
-		SELECT config, version
+		SELECT config, nonce, version
 		FROM pipelines
 		WHERE name = $1 AND team_id = (
 			SELECT id
 			FROM teams
 			WHERE LOWER(name) = LOWER($2)
 		)
-	`, pipelineName, db.teamName).Scan(&configBlob, &version)
+	`, pipelineName, db.teamName).Scan(&configText, &nonce, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, atc.RawConfig(""), 0, nil
@@ -221,6 +228,11 @@ func (db *teamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, Con
 		return atc.Config{}, atc.RawConfig(""), 0, err
 	}
 
+	configBlob, err := decrypt(db.conn.EncryptionStrategy(), configText, nonce)
+	if err != nil {
+		return atc.Config{}, atc.RawConfig(""), 0, err
+	}
+
 	var config atc.Config
 	err = json.Unmarshal(configBlob, &config)
 	if err != nil {
@@ -236,17 +248,47 @@ func (db *teamDB) SaveConfig(
 	from ConfigVersion,
 	pausedState PipelinePausedState,
 ) (SavedPipeline, bool, error) {
-	payload, err := json.Marshal(config)
+	return db.SaveConfigBy("", pipelineName, config, from, pausedState)
+}
+
+// SaveConfigBy saves the config like SaveConfig, recording who saved it in
+// the pipeline's config history. savedBy may be empty if it is not known.
+// Saving a config for an archived pipeline unarchives it, and unpauses it
+// unless pausedState says to keep it paused.
+func (db *teamDB) SaveConfigBy(
+	savedBy string,
+	pipelineName string,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
+	tx, err := db.conn.Begin()
 	if err != nil {
 		return SavedPipeline{}, false, err
 	}
 
-	tx, err := db.conn.Begin()
+	defer tx.Rollback()
+
+	savedPipeline, created, err := db.saveConfig(tx, savedBy, pipelineName, config, from, pausedState)
 	if err != nil {
 		return SavedPipeline{}, false, err
 	}
 
-	defer tx.Rollback()
+	return savedPipeline, created, tx.Commit()
+}
+
+func (db *teamDB) saveConfig(
+	tx Tx,
+	savedBy string,
+	pipelineName string,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
+	payload, nonce, err := encryptJSON(db.conn.EncryptionStrategy(), config)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
 
 	var teamID int
 	err = tx.QueryRow(`SELECT id FROM teams WHERE LOWER(name) = LOWER($1)`, db.teamName).Scan(&teamID)
@@ -274,10 +316,11 @@ func (db *teamDB) SaveConfig(
 		}
 
 		savedPipeline, err = scanPipeline(tx.QueryRow(`
-		INSERT INTO pipelines (name, config, version, ordering, paused, team_id)
+		INSERT INTO pipelines (name, config, nonce, version, ordering, paused, team_id)
 		VALUES (
 			$1,
 			$2,
+			$5,
 			nextval('config_version_seq'),
 			(SELECT COUNT(1) + 1 FROM pipelines),
 			$3,
@@ -287,7 +330,7 @@ func (db *teamDB) SaveConfig(
 		(
 			SELECT t.name as team_name FROM teams t WHERE t.id = $4
 		)
-		`, pipelineName, payload, pausedState.Bool(), teamID))
+		`, pipelineName, payload, pausedState.Bool(), teamID, nonce), db.conn.EncryptionStrategy())
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
@@ -316,10 +359,35 @@ func (db *teamDB) SaveConfig(
 			return SavedPipeline{}, false, err
 		}
 	} else {
+		var pipelineID int
+		var archived bool
+		err = tx.QueryRow(`
+			SELECT id, archived
+			FROM pipelines
+			WHERE name = $1
+			AND team_id = $2
+		`, pipelineName, teamID).Scan(&pipelineID, &archived)
+		if err != nil {
+			return SavedPipeline{}, false, err
+		}
+
+		// archiving paused the pipeline, so unarchiving it unpauses it
+		// unless told otherwise
+		if archived && pausedState == PipelineNoChange {
+			pausedState = PipelineUnpaused
+		}
+
+		if pausedState == PipelineUnpaused {
+			err = resetPausedScheduleCheckedTimes(tx, pipelineID)
+			if err != nil {
+				return SavedPipeline{}, false, err
+			}
+		}
+
 		if pausedState == PipelineNoChange {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq')
+			SET config = $1, nonce = $5, version = nextval('config_version_seq'), archived = false
 			WHERE name = $2
 			AND version = $3
 			AND team_id = $4
@@ -327,11 +395,11 @@ func (db *teamDB) SaveConfig(
 			(
 				SELECT t.name as team_name FROM teams t WHERE t.id = $4
 			)
-			`, payload, pipelineName, from, teamID))
+			`, payload, pipelineName, from, teamID, nonce), db.conn.EncryptionStrategy())
 		} else {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq'), paused = $2
+			SET config = $1, nonce = $6, version = nextval('config_version_seq'), paused = $2, archived = false
 			WHERE name = $3
 			AND version = $4
 			AND team_id = $5
@@ -339,7 +407,7 @@ func (db *teamDB) SaveConfig(
 			(
 				SELECT t.name as team_name FROM teams t WHERE t.id = $4
 			)
-			`, payload, pausedState.Bool(), pipelineName, from, teamID))
+			`, payload, pausedState.Bool(), pipelineName, from, teamID, nonce), db.conn.EncryptionStrategy())
 		}
 
 		if err != nil && err != sql.ErrNoRows {
@@ -390,8 +458,16 @@ func (db *teamDB) SaveConfig(
 		}
 	}
 
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_versions (pipeline_id, version, config, nonce, saved_by)
+		VALUES ($1, $2, $3, $4, $5)
+	`, savedPipeline.ID, savedPipeline.Version, payload, nonce, sql.NullString{String: savedBy, Valid: savedBy != ""})
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
 	for _, resource := range config.Resources {
-		err = db.saveResource(tx, resource, savedPipeline.ID)
+		err = db.saveResource(tx, resource, savedPipeline.ID, teamID)
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
@@ -418,20 +494,35 @@ func (db *teamDB) SaveConfig(
 		}
 	}
 
-	return savedPipeline, created, tx.Commit()
+	// forget when the schedules of removed jobs were last checked, so that
+	// they are only counted from when the jobs are added back
+	_, err = tx.Exec(`
+		UPDATE jobs
+		SET schedule_checked_at = NULL
+		WHERE pipeline_id = $1
+		AND NOT active
+	`, savedPipeline.ID)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	return savedPipeline, created, nil
 }
 
 func (db *teamDB) saveJob(tx Tx, job atc.JobConfig, pipelineID int) error {
-	configPayload, err := json.Marshal(job)
+	configPayload, nonce, err := encryptJSON(db.conn.EncryptionStrategy(), job)
 	if err != nil {
 		return err
 	}
 
+	// a schedule is only counted from when it was added, so forget when the
+	// job's schedule was last checked if it no longer has one
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE jobs
-		SET config = $3, active = true
+		SET config = $3, nonce = $4, active = true,
+			schedule_checked_at = CASE WHEN $5 THEN schedule_checked_at END
 		WHERE name = $1 AND pipeline_id = $2
-	`, job.Name, pipelineID, configPayload)
+	`, job.Name, pipelineID, configPayload, nonce, len(job.Schedule) > 0)
 	if err != nil {
 		return err
 	}
@@ -441,9 +532,9 @@ func (db *teamDB) saveJob(tx Tx, job atc.JobConfig, pipelineID int) error {
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO jobs (name, pipeline_id, config, active)
-		VALUES ($1, $2, $3, true)
-	`, job.Name, pipelineID, configPayload)
+		INSERT INTO jobs (name, pipeline_id, config, nonce, active)
+		VALUES ($1, $2, $3, $4, true)
+	`, job.Name, pipelineID, configPayload, nonce)
 
 	return swallowUniqueViolation(err)
 }
@@ -464,17 +555,22 @@ func (db *teamDB) registerSerialGroup(tx Tx, jobName, serialGroup string, pipeli
 	return swallowUniqueViolation(err)
 }
 
-func (db *teamDB) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int) error {
-	configPayload, err := json.Marshal(resource)
+func (db *teamDB) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int, teamID int) error {
+	configPayload, nonce, err := encryptJSON(db.conn.EncryptionStrategy(), resource)
+	if err != nil {
+		return err
+	}
+
+	hash, err := sourceHash(teamID, resource)
 	if err != nil {
 		return err
 	}
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resources
-		SET config = $3, active = true
+		SET config = $3, nonce = $4, source_hash = $5, active = true
 		WHERE name = $1 AND pipeline_id = $2
-	`, resource.Name, pipelineID, configPayload)
+	`, resource.Name, pipelineID, configPayload, nonce, hash)
 	if err != nil {
 		return err
 	}
@@ -484,24 +580,24 @@ func (db *teamDB) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID in
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO resources (name, pipeline_id, config, active)
-		VALUES ($1, $2, $3, true)
-	`, resource.Name, pipelineID, configPayload)
+		INSERT INTO resources (name, pipeline_id, config, nonce, source_hash, active)
+		VALUES ($1, $2, $3, $4, $5, true)
+	`, resource.Name, pipelineID, configPayload, nonce, hash)
 
 	return swallowUniqueViolation(err)
 }
 
 func (db *teamDB) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelineID int) error {
-	configPayload, err := json.Marshal(resourceType)
+	configPayload, nonce, err := encryptJSON(db.conn.EncryptionStrategy(), resourceType)
 	if err != nil {
 		return err
 	}
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resource_types
-		SET config = $3, type = $4, active = true
+		SET config = $3, type = $4, nonce = $5, active = true
 		WHERE name = $1 AND pipeline_id = $2
-	`, resourceType.Name, pipelineID, configPayload, resourceType.Type)
+	`, resourceType.Name, pipelineID, configPayload, resourceType.Type, nonce)
 	if err != nil {
 		return err
 	}
@@ -511,9 +607,9 @@ func (db *teamDB) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelin
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO resource_types (name, type, pipeline_id, config, active)
-		VALUES ($1, $2, $3, $4, true)
-	`, resourceType.Name, resourceType.Type, pipelineID, configPayload)
+		INSERT INTO resource_types (name, type, pipeline_id, config, nonce, active)
+		VALUES ($1, $2, $3, $4, $5, true)
+	`, resourceType.Name, resourceType.Type, pipelineID, configPayload, nonce)
 
 	return swallowUniqueViolation(err)
 }
@@ -748,23 +844,25 @@ func (db *teamDB) GetPrivateAndPublicBuilds(page Page) ([]Build, Pagination, err
 	return getBuildsWithPagination(buildsQuery, page, db.conn, db.buildFactory)
 }
 
-func scanPipeline(rows scannable) (SavedPipeline, error) {
+func scanPipeline(rows scannable, strategy EncryptionStrategy) (SavedPipeline, error) {
 	var id int
 	var name string
-	var configBlob []byte
+	var configText string
+	var nonce sql.NullString
 	var version int
 	var paused bool
 	var public bool
+	var archived bool
 	var teamID int
 	var teamName string
 
-	err := rows.Scan(&id, &name, &configBlob, &version, &paused, &teamID, &public, &teamName)
+	err := rows.Scan(&id, &name, &configText, &nonce, &version, &paused, &teamID, &public, &archived, &teamName)
 	if err != nil {
 		return SavedPipeline{}, err
 	}
 
 	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	err = decryptJSON(strategy, configText, nonce, &config)
 	if err != nil {
 		return SavedPipeline{}, err
 	}
@@ -773,6 +871,7 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 		ID:       id,
 		Paused:   paused,
 		Public:   public,
+		Archived: archived,
 		TeamID:   teamID,
 		TeamName: teamName,
 		Pipeline: Pipeline{
@@ -783,11 +882,11 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 	}, nil
 }
 
-func scanPipelines(rows *sql.Rows) ([]SavedPipeline, error) {
+func scanPipelines(rows *sql.Rows, strategy EncryptionStrategy) ([]SavedPipeline, error) {
 	pipelines := []SavedPipeline{}
 
 	for rows.Next() {
-		pipeline, err := scanPipeline(rows)
+		pipeline, err := scanPipeline(rows, strategy)
 		if err != nil {
 			return nil, err
 		}
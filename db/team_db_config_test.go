@@ -516,6 +516,68 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 	})
 
+	Context("config history", func() {
+		var pipelineName string
+
+		BeforeEach(func() {
+			pipelineName = "a-pipeline-name"
+		})
+
+		It("records every saved config with who saved it, newest first", func() {
+			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			firstVersion := savedPipeline.Version
+
+			savedPipeline, _, err = teamDB.SaveConfigBy("some-user", pipelineName, otherConfig, firstVersion, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			secondVersion := savedPipeline.Version
+
+			versions, err := teamDB.GetConfigVersions(pipelineName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(2))
+
+			Expect(versions[0].Version).To(Equal(secondVersion))
+			Expect(versions[0].Config).To(Equal(otherConfig))
+			Expect(versions[0].SavedBy).To(Equal("some-user"))
+			Expect(versions[0].SavedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			Expect(versions[1].Version).To(Equal(firstVersion))
+			Expect(versions[1].Config).To(Equal(config))
+			Expect(versions[1].SavedBy).To(BeEmpty())
+		})
+
+		It("can look up a specific version", func() {
+			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfig(pipelineName, otherConfig, savedPipeline.Version, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			configVersion, found, err := teamDB.GetConfigVersion(pipelineName, savedPipeline.Version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(configVersion.Config).To(Equal(config))
+
+			_, found, err = teamDB.GetConfigVersion(pipelineName, db.ConfigVersion(0))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not record a version when the save fails", func() {
+			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfig(pipelineName, otherConfig, db.ConfigVersion(0), db.PipelineNoChange)
+			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+			versions, err := teamDB.GetConfigVersions(pipelineName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+		})
+	})
+
 	It("can lookup a pipeline by name", func() {
 		pipelineName := "a-pipeline-name"
 		otherPipelineName := "an-other-pipeline-name"
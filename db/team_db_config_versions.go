@@ -0,0 +1,89 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+)
+
+// GetConfigVersions returns every config saved for the pipeline, newest
+// first.
+func (db *teamDB) GetConfigVersions(pipelineName string) ([]PipelineConfigVersion, error) {
+	rows, err := db.conn.Query(`
+		SELECT v.version, v.config, v.nonce, v.saved_by, v.saved_at
+		FROM pipeline_config_versions v
+		INNER JOIN pipelines p ON p.id = v.pipeline_id
+		WHERE p.name = $1
+		AND p.team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($2)
+		)
+		ORDER BY v.version DESC
+	`, pipelineName, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := []PipelineConfigVersion{}
+	for rows.Next() {
+		version, err := db.scanConfigVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// GetConfigVersion returns a specific config saved for the pipeline.
+func (db *teamDB) GetConfigVersion(pipelineName string, version ConfigVersion) (PipelineConfigVersion, bool, error) {
+	configVersion, err := db.scanConfigVersion(db.conn.QueryRow(`
+		SELECT v.version, v.config, v.nonce, v.saved_by, v.saved_at
+		FROM pipeline_config_versions v
+		INNER JOIN pipelines p ON p.id = v.pipeline_id
+		WHERE p.name = $1
+		AND v.version = $2
+		AND p.team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($3)
+		)
+	`, pipelineName, version, db.teamName))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineConfigVersion{}, false, nil
+		}
+
+		return PipelineConfigVersion{}, false, err
+	}
+
+	return configVersion, true, nil
+}
+
+func (db *teamDB) scanConfigVersion(row scannable) (PipelineConfigVersion, error) {
+	var (
+		version    int
+		configText string
+		nonce      sql.NullString
+		savedBy    sql.NullString
+		savedAt    time.Time
+	)
+
+	err := row.Scan(&version, &configText, &nonce, &savedBy, &savedAt)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	configVersion := PipelineConfigVersion{
+		Version: ConfigVersion(version),
+		SavedBy: savedBy.String,
+		SavedAt: savedAt,
+	}
+
+	err = decryptJSON(db.conn.EncryptionStrategy(), configText, nonce, &configVersion.Config)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	return configVersion, nil
+}
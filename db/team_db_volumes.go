@@ -33,7 +33,12 @@ func (db *teamDB) GetVolumes() ([]SavedVolume, error) {
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path,
+			v.task_cache_cleared
 		FROM volumes v
 		LEFT JOIN containers c
 			ON v.container_id = c.id
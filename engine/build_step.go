@@ -4,6 +4,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
@@ -22,6 +23,67 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return step
 }
 
+func (build *execBuild) buildInParallelStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("in-parallel")
+
+	step := exec.InParallel{
+		Limit:    plan.InParallel.Limit,
+		FailFast: plan.InParallel.FailFast,
+	}
+
+	for _, innerPlan := range plan.InParallel.Steps {
+		innerPlan.Attempts = plan.Attempts
+		stepFactory := build.buildStepFactory(logger, innerPlan)
+		step.Steps = append(step.Steps, stepFactory)
+	}
+
+	return step
+}
+
+func (build *execBuild) buildAcrossStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("across")
+
+	steps := make([]exec.StepFactory, len(plan.Across.Steps))
+	for i, scopedPlan := range plan.Across.Steps {
+		innerPlan := scopedPlan.Step
+		innerPlan.Attempts = plan.Attempts
+		steps[i] = build.buildStepFactory(logger, innerPlan)
+	}
+
+	return nestAcrossSteps(plan.Across.Vars, steps, plan.Across.FailFast)
+}
+
+// nestAcrossSteps runs the steps for each of the first var's values in
+// parallel, up to the var's MaxInFlight, with each value's steps nested the
+// same way for the remaining vars. The steps must be ordered as constructed
+// by the build factory, with the first var varying the slowest.
+func nestAcrossSteps(vars []atc.AcrossVar, steps []exec.StepFactory, failFast bool) exec.StepFactory {
+	if len(vars) == 0 {
+		if len(steps) == 0 {
+			return exec.Identity{}
+		}
+
+		return steps[0]
+	}
+
+	step := exec.InParallel{
+		Limit:    vars[0].MaxInFlight,
+		FailFast: failFast,
+	}
+
+	values := len(vars[0].Values)
+	if values == 0 {
+		return step
+	}
+
+	chunk := len(steps) / values
+	for i := 0; i < values; i++ {
+		step.Steps = append(step.Steps, nestAcrossSteps(vars[1:], steps[i*chunk:(i+1)*chunk], failFast))
+	}
+
+	return step
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("do")
 
@@ -69,6 +131,22 @@ func (build *execBuild) buildOnFailureStep(logger lager.Logger, plan atc.Plan) e
 	return exec.OnFailure(step, next)
 }
 
+func (build *execBuild) buildOnErrorStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	plan.OnError.Step.Attempts = plan.Attempts
+	step := build.buildStepFactory(logger, plan.OnError.Step)
+	plan.OnError.Next.Attempts = plan.Attempts
+	next := build.buildStepFactory(logger, plan.OnError.Next)
+	return exec.OnError(step, next)
+}
+
+func (build *execBuild) buildOnAbortStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	plan.OnAbort.Step.Attempts = plan.Attempts
+	step := build.buildStepFactory(logger, plan.OnAbort.Step)
+	plan.OnAbort.Next.Attempts = plan.Attempts
+	next := build.buildStepFactory(logger, plan.OnAbort.Next)
+	return exec.OnAbort(step, next)
+}
+
 func (build *execBuild) buildEnsureStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	plan.Ensure.Step.Attempts = plan.Attempts
 	step := build.buildStepFactory(logger, plan.Ensure.Step)
@@ -94,7 +172,12 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		return exec.Identity{}
 	}
 
-	configSource = exec.ValidatingConfigSource{configSource}
+	configSource = exec.ValidatingConfigSource{
+		exec.EvaluatingConfigSource{
+			ConfigSource: configSource,
+			Variables:    build.variables,
+		},
+	}
 
 	workerID, workerMetadata := build.stepIdentifier(
 		logger.Session("taskIdentifier"),
@@ -107,23 +190,67 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 
 	clock := clock.NewClock()
 
-	return build.factory.Task(
+	return build.deferUntilLocalVarsLoaded(func() exec.StepFactory {
+		resourceTypes, err := creds.EvaluateResourceTypes(build.variables, plan.Task.ResourceTypes)
+		if err != nil {
+			return exec.Errored{Err: err}
+		}
+
+		return build.factory.Task(
+			logger,
+			exec.SourceName(plan.Task.Name),
+			workerID,
+			workerMetadata,
+			build.delegate.ExecutionDelegate(logger, *plan.Task, event.OriginID(plan.ID)),
+			exec.Privileged(plan.Task.Privileged),
+			plan.Task.Tags,
+			build.teamID,
+			configSource,
+			resourceTypes,
+			plan.Task.InputMapping,
+			plan.Task.OutputMapping,
+			plan.Task.ImageArtifactName,
+			clock,
+			build.containerSuccessTTL,
+			build.containerFailureTTL,
+		)
+	}, plan.Task.ResourceTypes)
+}
+
+func (build *execBuild) buildSetPipelineStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("set-pipeline", lager.Data{
+		"name": plan.SetPipeline.Name,
+	})
+
+	return build.factory.SetPipeline(
+		logger,
+		build.delegate.SetPipelineDelegate(logger, *plan.SetPipeline, event.OriginID(plan.ID)),
+		build.teamName,
+		*plan.SetPipeline,
+	)
+}
+
+func (build *execBuild) buildLoadVarStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("load-var", lager.Data{
+		"name": plan.LoadVar.Name,
+	})
+
+	return build.factory.LoadVar(
+		logger,
+		build.delegate.LoadVarDelegate(logger, *plan.LoadVar, event.OriginID(plan.ID)),
+		build.variables,
+		*plan.LoadVar,
+	)
+}
+
+func (build *execBuild) buildApprovalStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("approval")
+
+	return build.factory.Approval(
 		logger,
-		exec.SourceName(plan.Task.Name),
-		workerID,
-		workerMetadata,
-		build.delegate.ExecutionDelegate(logger, *plan.Task, event.OriginID(plan.ID)),
-		exec.Privileged(plan.Task.Privileged),
-		plan.Task.Tags,
-		build.teamID,
-		configSource,
-		plan.Task.ResourceTypes,
-		plan.Task.InputMapping,
-		plan.Task.OutputMapping,
-		plan.Task.ImageArtifactName,
-		clock,
-		build.containerSuccessTTL,
-		build.containerFailureTTL,
+		build.delegate.ApprovalDelegate(logger, *plan.Approval, event.OriginID(plan.ID)),
+		*plan.Approval,
+		clock.NewClock(),
 	)
 }
 
@@ -141,26 +268,33 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		"get",
 	)
 
-	return build.factory.Get(
-		logger,
-		build.stepMetadata,
-		exec.SourceName(plan.Get.Name),
-		workerID,
-		workerMetadata,
-		build.delegate.InputDelegate(logger, *plan.Get, event.OriginID(plan.ID)),
-		atc.ResourceConfig{
-			Name:   plan.Get.Resource,
-			Type:   plan.Get.Type,
-			Source: plan.Get.Source,
-		},
-		plan.Get.Tags,
-		build.teamID,
-		plan.Get.Params,
-		plan.Get.Version,
-		plan.Get.ResourceTypes,
-		build.containerSuccessTTL,
-		build.containerFailureTTL,
-	)
+	return build.deferUntilLocalVarsLoaded(func() exec.StepFactory {
+		source, params, resourceTypes, err := build.evaluateResourceConfig(plan.Get.Source, plan.Get.Params, plan.Get.ResourceTypes)
+		if err != nil {
+			return exec.Errored{Err: err}
+		}
+
+		return build.factory.Get(
+			logger,
+			build.stepMetadata,
+			exec.SourceName(plan.Get.Name),
+			workerID,
+			workerMetadata,
+			build.delegate.InputDelegate(logger, *plan.Get, event.OriginID(plan.ID)),
+			atc.ResourceConfig{
+				Name:   plan.Get.Resource,
+				Type:   plan.Get.Type,
+				Source: source,
+			},
+			plan.Get.Tags,
+			build.teamID,
+			params,
+			plan.Get.Version,
+			resourceTypes,
+			build.containerSuccessTTL,
+			build.containerFailureTTL,
+		)
+	}, plan.Get.Source, plan.Get.Params, plan.Get.ResourceTypes)
 }
 
 func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
@@ -177,24 +311,31 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		"put",
 	)
 
-	return build.factory.Put(
-		logger,
-		build.stepMetadata,
-		workerID,
-		workerMetadata,
-		build.delegate.OutputDelegate(logger, *plan.Put, event.OriginID(plan.ID)),
-		atc.ResourceConfig{
-			Name:   plan.Put.Resource,
-			Type:   plan.Put.Type,
-			Source: plan.Put.Source,
-		},
-		plan.Put.Tags,
-		build.teamID,
-		plan.Put.Params,
-		plan.Put.ResourceTypes,
-		build.containerSuccessTTL,
-		build.containerFailureTTL,
-	)
+	return build.deferUntilLocalVarsLoaded(func() exec.StepFactory {
+		source, params, resourceTypes, err := build.evaluateResourceConfig(plan.Put.Source, plan.Put.Params, plan.Put.ResourceTypes)
+		if err != nil {
+			return exec.Errored{Err: err}
+		}
+
+		return build.factory.Put(
+			logger,
+			build.stepMetadata,
+			workerID,
+			workerMetadata,
+			build.delegate.OutputDelegate(logger, *plan.Put, event.OriginID(plan.ID)),
+			atc.ResourceConfig{
+				Name:   plan.Put.Resource,
+				Type:   plan.Put.Type,
+				Source: source,
+			},
+			plan.Put.Tags,
+			build.teamID,
+			params,
+			resourceTypes,
+			build.containerSuccessTTL,
+			build.containerFailureTTL,
+		)
+	}, plan.Put.Source, plan.Put.Params, plan.Put.ResourceTypes)
 }
 
 func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
@@ -212,25 +353,32 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		"get",
 	)
 
-	return build.factory.DependentGet(
-		logger,
-		build.stepMetadata,
-		exec.SourceName(getPlan.Name),
-		workerID,
-		workerMetadata,
-		build.delegate.InputDelegate(logger, getPlan, event.OriginID(plan.ID)),
-		atc.ResourceConfig{
-			Name:   getPlan.Resource,
-			Type:   getPlan.Type,
-			Source: getPlan.Source,
-		},
-		getPlan.Tags,
-		build.teamID,
-		getPlan.Params,
-		getPlan.ResourceTypes,
-		build.containerSuccessTTL,
-		build.containerFailureTTL,
-	)
+	return build.deferUntilLocalVarsLoaded(func() exec.StepFactory {
+		source, params, resourceTypes, err := build.evaluateResourceConfig(getPlan.Source, getPlan.Params, getPlan.ResourceTypes)
+		if err != nil {
+			return exec.Errored{Err: err}
+		}
+
+		return build.factory.DependentGet(
+			logger,
+			build.stepMetadata,
+			exec.SourceName(getPlan.Name),
+			workerID,
+			workerMetadata,
+			build.delegate.InputDelegate(logger, getPlan, event.OriginID(plan.ID)),
+			atc.ResourceConfig{
+				Name:   getPlan.Resource,
+				Type:   getPlan.Type,
+				Source: source,
+			},
+			getPlan.Tags,
+			build.teamID,
+			params,
+			resourceTypes,
+			build.containerSuccessTTL,
+			build.containerFailureTTL,
+		)
+	}, getPlan.Source, getPlan.Params, getPlan.ResourceTypes)
 }
 
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
@@ -247,3 +395,43 @@ func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.
 
 	return step
 }
+
+// deferUntilLocalVarsLoaded constructs a step when it runs, rather than up
+// front, if any of its config refers to build-local variables, as they are
+// only loaded by earlier steps in the build.
+func (build *execBuild) deferUntilLocalVarsLoaded(
+	construct func() exec.StepFactory,
+	config ...interface{},
+) exec.StepFactory {
+	if !creds.ReferencesLocalVars(config...) {
+		return construct()
+	}
+
+	return exec.Deferred{Construct: construct}
+}
+
+// evaluateResourceConfig replaces any credential placeholders in a resource
+// step's source, params, and custom resource types. The plan itself is left
+// untouched so that credentials are never persisted with the build.
+func (build *execBuild) evaluateResourceConfig(
+	source atc.Source,
+	params atc.Params,
+	resourceTypes atc.ResourceTypes,
+) (atc.Source, atc.Params, atc.ResourceTypes, error) {
+	evaluatedSource, err := creds.EvaluateSource(build.variables, source)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	evaluatedParams, err := creds.EvaluateParams(build.variables, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	evaluatedResourceTypes, err := creds.EvaluateResourceTypes(build.variables, resourceTypes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return evaluatedSource, evaluatedParams, evaluatedResourceTypes, nil
+}
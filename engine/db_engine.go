@@ -238,6 +238,7 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 	}()
 
 	metric.BuildStarted{
+		TeamName:     build.build.TeamName(),
 		PipelineName: build.build.PipelineName(),
 		JobName:      build.build.JobName(),
 		BuildName:    build.build.Name(),
@@ -263,6 +264,7 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 	}
 
 	metric.BuildFinished{
+		TeamName:      build.build.TeamName(),
 		PipelineName:  build.build.PipelineName(),
 		JobName:       build.build.JobName(),
 		BuildName:     build.build.Name(),
@@ -50,6 +50,36 @@ type FakeBuildDelegate struct {
 		arg3 exec.Success
 		arg4 bool
 	}
+	SetPipelineDelegateStub        func(lager.Logger, atc.SetPipelinePlan, event.OriginID) exec.SetPipelineDelegate
+	setPipelineDelegateMutex       sync.RWMutex
+	setPipelineDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.SetPipelinePlan
+		arg3 event.OriginID
+	}
+	setPipelineDelegateReturns struct {
+		result1 exec.SetPipelineDelegate
+	}
+	LoadVarDelegateStub        func(lager.Logger, atc.LoadVarPlan, event.OriginID) exec.LoadVarDelegate
+	loadVarDelegateMutex       sync.RWMutex
+	loadVarDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.LoadVarPlan
+		arg3 event.OriginID
+	}
+	loadVarDelegateReturns struct {
+		result1 exec.LoadVarDelegate
+	}
+	ApprovalDelegateStub        func(lager.Logger, atc.ApprovalPlan, event.OriginID) exec.ApprovalDelegate
+	approvalDelegateMutex       sync.RWMutex
+	approvalDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.ApprovalPlan
+		arg3 event.OriginID
+	}
+	approvalDelegateReturns struct {
+		result1 exec.ApprovalDelegate
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -186,6 +216,111 @@ func (fake *FakeBuildDelegate) FinishArgsForCall(i int) (lager.Logger, error, ex
 	return fake.finishArgsForCall[i].arg1, fake.finishArgsForCall[i].arg2, fake.finishArgsForCall[i].arg3, fake.finishArgsForCall[i].arg4
 }
 
+func (fake *FakeBuildDelegate) SetPipelineDelegate(arg1 lager.Logger, arg2 atc.SetPipelinePlan, arg3 event.OriginID) exec.SetPipelineDelegate {
+	fake.setPipelineDelegateMutex.Lock()
+	fake.setPipelineDelegateArgsForCall = append(fake.setPipelineDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.SetPipelinePlan
+		arg3 event.OriginID
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SetPipelineDelegate", []interface{}{arg1, arg2, arg3})
+	fake.setPipelineDelegateMutex.Unlock()
+	if fake.SetPipelineDelegateStub != nil {
+		return fake.SetPipelineDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.setPipelineDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateCallCount() int {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return len(fake.setPipelineDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateArgsForCall(i int) (lager.Logger, atc.SetPipelinePlan, event.OriginID) {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return fake.setPipelineDelegateArgsForCall[i].arg1, fake.setPipelineDelegateArgsForCall[i].arg2, fake.setPipelineDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateReturns(result1 exec.SetPipelineDelegate) {
+	fake.SetPipelineDelegateStub = nil
+	fake.setPipelineDelegateReturns = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegate(arg1 lager.Logger, arg2 atc.LoadVarPlan, arg3 event.OriginID) exec.LoadVarDelegate {
+	fake.loadVarDelegateMutex.Lock()
+	fake.loadVarDelegateArgsForCall = append(fake.loadVarDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.LoadVarPlan
+		arg3 event.OriginID
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("LoadVarDelegate", []interface{}{arg1, arg2, arg3})
+	fake.loadVarDelegateMutex.Unlock()
+	if fake.LoadVarDelegateStub != nil {
+		return fake.LoadVarDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.loadVarDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegateCallCount() int {
+	fake.loadVarDelegateMutex.RLock()
+	defer fake.loadVarDelegateMutex.RUnlock()
+	return len(fake.loadVarDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegateArgsForCall(i int) (lager.Logger, atc.LoadVarPlan, event.OriginID) {
+	fake.loadVarDelegateMutex.RLock()
+	defer fake.loadVarDelegateMutex.RUnlock()
+	return fake.loadVarDelegateArgsForCall[i].arg1, fake.loadVarDelegateArgsForCall[i].arg2, fake.loadVarDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegateReturns(result1 exec.LoadVarDelegate) {
+	fake.LoadVarDelegateStub = nil
+	fake.loadVarDelegateReturns = struct {
+		result1 exec.LoadVarDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) ApprovalDelegate(arg1 lager.Logger, arg2 atc.ApprovalPlan, arg3 event.OriginID) exec.ApprovalDelegate {
+	fake.approvalDelegateMutex.Lock()
+	fake.approvalDelegateArgsForCall = append(fake.approvalDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.ApprovalPlan
+		arg3 event.OriginID
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ApprovalDelegate", []interface{}{arg1, arg2, arg3})
+	fake.approvalDelegateMutex.Unlock()
+	if fake.ApprovalDelegateStub != nil {
+		return fake.ApprovalDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.approvalDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) ApprovalDelegateCallCount() int {
+	fake.approvalDelegateMutex.RLock()
+	defer fake.approvalDelegateMutex.RUnlock()
+	return len(fake.approvalDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) ApprovalDelegateArgsForCall(i int) (lager.Logger, atc.ApprovalPlan, event.OriginID) {
+	fake.approvalDelegateMutex.RLock()
+	defer fake.approvalDelegateMutex.RUnlock()
+	return fake.approvalDelegateArgsForCall[i].arg1, fake.approvalDelegateArgsForCall[i].arg2, fake.approvalDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) ApprovalDelegateReturns(result1 exec.ApprovalDelegate) {
+	fake.ApprovalDelegateStub = nil
+	fake.approvalDelegateReturns = struct {
+		result1 exec.ApprovalDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -197,6 +332,12 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.outputDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	fake.loadVarDelegateMutex.RLock()
+	defer fake.loadVarDelegateMutex.RUnlock()
+	fake.approvalDelegateMutex.RLock()
+	defer fake.approvalDelegateMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -4,15 +4,17 @@ package enginefakes
 import (
 	"sync"
 
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 )
 
 type FakeBuildDelegateFactory struct {
-	DelegateStub        func(db.Build) engine.BuildDelegate
+	DelegateStub        func(db.Build, *creds.BuildVariables) engine.BuildDelegate
 	delegateMutex       sync.RWMutex
 	delegateArgsForCall []struct {
 		arg1 db.Build
+		arg2 *creds.BuildVariables
 	}
 	delegateReturns struct {
 		result1 engine.BuildDelegate
@@ -21,15 +23,16 @@ type FakeBuildDelegateFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildDelegateFactory) Delegate(arg1 db.Build) engine.BuildDelegate {
+func (fake *FakeBuildDelegateFactory) Delegate(arg1 db.Build, arg2 *creds.BuildVariables) engine.BuildDelegate {
 	fake.delegateMutex.Lock()
 	fake.delegateArgsForCall = append(fake.delegateArgsForCall, struct {
 		arg1 db.Build
-	}{arg1})
-	fake.recordInvocation("Delegate", []interface{}{arg1})
+		arg2 *creds.BuildVariables
+	}{arg1, arg2})
+	fake.recordInvocation("Delegate", []interface{}{arg1, arg2})
 	fake.delegateMutex.Unlock()
 	if fake.DelegateStub != nil {
-		return fake.DelegateStub(arg1)
+		return fake.DelegateStub(arg1, arg2)
 	} else {
 		return fake.delegateReturns.result1
 	}
@@ -41,10 +44,10 @@ func (fake *FakeBuildDelegateFactory) DelegateCallCount() int {
 	return len(fake.delegateArgsForCall)
 }
 
-func (fake *FakeBuildDelegateFactory) DelegateArgsForCall(i int) db.Build {
+func (fake *FakeBuildDelegateFactory) DelegateArgsForCall(i int) (db.Build, *creds.BuildVariables) {
 	fake.delegateMutex.RLock()
 	defer fake.delegateMutex.RUnlock()
-	return fake.delegateArgsForCall[i].arg1
+	return fake.delegateArgsForCall[i].arg1, fake.delegateArgsForCall[i].arg2
 }
 
 func (fake *FakeBuildDelegateFactory) DelegateReturns(result1 engine.BuildDelegate) {
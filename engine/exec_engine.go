@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
@@ -25,23 +26,26 @@ const successTTL = 5 * time.Minute
 const failureTTL = 5 * time.Minute
 
 type execEngine struct {
-	factory         exec.Factory
-	delegateFactory BuildDelegateFactory
-	teamDBFactory   db.TeamDBFactory
-	externalURL     string
+	factory           exec.Factory
+	delegateFactory   BuildDelegateFactory
+	teamDBFactory     db.TeamDBFactory
+	credentialManager creds.CredentialManager
+	externalURL       string
 }
 
 func NewExecEngine(
 	factory exec.Factory,
 	delegateFactory BuildDelegateFactory,
 	teamDBFactory db.TeamDBFactory,
+	credentialManager creds.CredentialManager,
 	externalURL string,
 ) Engine {
 	return &execEngine{
-		factory:         factory,
-		delegateFactory: delegateFactory,
-		teamDBFactory:   teamDBFactory,
-		externalURL:     externalURL,
+		factory:           factory,
+		delegateFactory:   delegateFactory,
+		teamDBFactory:     teamDBFactory,
+		credentialManager: credentialManager,
+		externalURL:       externalURL,
 	}
 }
 
@@ -50,14 +54,19 @@ func (engine *execEngine) Name() string {
 }
 
 func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan atc.Plan) (Build, error) {
+	variables := creds.NewBuildVariables(
+		creds.NewVariables(engine.credentialManager, build.TeamName(), build.PipelineName()),
+	)
+
 	return &execBuild{
 		buildID:      build.ID(),
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    variables,
 
 		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(build),
+		delegate: engine.delegateFactory.Delegate(build, variables),
 		metadata: execMetadata{
 			Plan: plan,
 		},
@@ -82,14 +91,19 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		return nil, err
 	}
 
+	variables := creds.NewBuildVariables(
+		creds.NewVariables(engine.credentialManager, build.TeamName(), build.PipelineName()),
+	)
+
 	return &execBuild{
 		buildID:      build.ID(),
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    variables,
 
 		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(build),
+		delegate: engine.delegateFactory.Delegate(build, variables),
 		metadata: metadata,
 
 		signals: make(chan os.Signal, 1),
@@ -164,6 +178,7 @@ type execBuild struct {
 	stepMetadata StepMetadata
 	teamName     string
 	teamID       int
+	variables    *creds.BuildVariables
 
 	factory  exec.Factory
 	delegate BuildDelegate
@@ -238,6 +253,14 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildAggregateStep(logger, plan)
 	}
 
+	if plan.InParallel != nil {
+		return build.buildInParallelStep(logger, plan)
+	}
+
+	if plan.Across != nil {
+		return build.buildAcrossStep(logger, plan)
+	}
+
 	if plan.Do != nil {
 		return build.buildDoStep(logger, plan)
 	}
@@ -258,6 +281,14 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildOnFailureStep(logger, plan)
 	}
 
+	if plan.OnError != nil {
+		return build.buildOnErrorStep(logger, plan)
+	}
+
+	if plan.OnAbort != nil {
+		return build.buildOnAbortStep(logger, plan)
+	}
+
 	if plan.Ensure != nil {
 		return build.buildEnsureStep(logger, plan)
 	}
@@ -266,6 +297,18 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildTaskStep(logger, plan)
 	}
 
+	if plan.SetPipeline != nil {
+		return build.buildSetPipelineStep(logger, plan)
+	}
+
+	if plan.LoadVar != nil {
+		return build.buildLoadVarStep(logger, plan)
+	}
+
+	if plan.Approval != nil {
+		return build.buildApprovalStep(logger, plan)
+	}
+
 	if plan.Get != nil {
 		return build.buildGetStep(logger, plan)
 	}
@@ -303,6 +346,7 @@ func (build *execBuild) stepIdentifier(
 			PlanID:  planID,
 		},
 		worker.Metadata{
+			JobName:    build.stepMetadata.JobName,
 			StepName:   stepName,
 			Type:       stepType,
 			PipelineID: pipelineID,
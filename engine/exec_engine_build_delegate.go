@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
@@ -25,6 +26,9 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	SetPipelineDelegate(lager.Logger, atc.SetPipelinePlan, event.OriginID) exec.SetPipelineDelegate
+	LoadVarDelegate(lager.Logger, atc.LoadVarPlan, event.OriginID) exec.LoadVarDelegate
+	ApprovalDelegate(lager.Logger, atc.ApprovalPlan, event.OriginID) exec.ApprovalDelegate
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -32,7 +36,7 @@ type BuildDelegate interface {
 //go:generate counterfeiter . BuildDelegateFactory
 
 type BuildDelegateFactory interface {
-	Delegate(db.Build) BuildDelegate
+	Delegate(db.Build, *creds.BuildVariables) BuildDelegate
 }
 
 type buildDelegateFactory struct{}
@@ -41,23 +45,27 @@ func NewBuildDelegateFactory() BuildDelegateFactory {
 	return buildDelegateFactory{}
 }
 
-func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
-	return newBuildDelegate(build)
+func (factory buildDelegateFactory) Delegate(build db.Build, variables *creds.BuildVariables) BuildDelegate {
+	return newBuildDelegate(build, variables)
 }
 
 type delegate struct {
-	build db.Build
+	build     db.Build
+	variables *creds.BuildVariables
 
 	implicitOutputs map[string]implicitOutput
+	eventWriters    map[event.Origin]*dbEventWriter
 
 	lock sync.Mutex
 }
 
-func newBuildDelegate(build db.Build) BuildDelegate {
+func newBuildDelegate(build db.Build, variables *creds.BuildVariables) BuildDelegate {
 	return &delegate{
-		build: build,
+		build:     build,
+		variables: variables,
 
 		implicitOutputs: make(map[string]implicitOutput),
+		eventWriters:    make(map[event.Origin]*dbEventWriter),
 	}
 }
 
@@ -91,7 +99,39 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 	}
 }
 
+func (delegate *delegate) SetPipelineDelegate(logger lager.Logger, plan atc.SetPipelinePlan, id event.OriginID) exec.SetPipelineDelegate {
+	return &setPipelineDelegate{
+		logger: logger,
+
+		id:       id,
+		plan:     plan,
+		delegate: delegate,
+	}
+}
+
+func (delegate *delegate) LoadVarDelegate(logger lager.Logger, plan atc.LoadVarPlan, id event.OriginID) exec.LoadVarDelegate {
+	return &loadVarDelegate{
+		logger: logger,
+
+		id:       id,
+		plan:     plan,
+		delegate: delegate,
+	}
+}
+
+func (delegate *delegate) ApprovalDelegate(logger lager.Logger, plan atc.ApprovalPlan, id event.OriginID) exec.ApprovalDelegate {
+	return &approvalDelegate{
+		logger: logger,
+
+		id:       id,
+		plan:     plan,
+		delegate: delegate,
+	}
+}
+
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
+	delegate.flushEventWriters(logger, nil)
+
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
 
@@ -157,6 +197,74 @@ func (delegate *delegate) saveInitializePut(logger lager.Logger, origin event.Or
 	}
 }
 
+func (delegate *delegate) saveInitializeSetPipeline(logger lager.Logger, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.InitializeSetPipeline{
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-initialize-event", err)
+	}
+}
+
+func (delegate *delegate) saveFinishSetPipeline(logger lager.Logger, status exec.ExitStatus, origin event.Origin) {
+	delegate.flushEventWriters(logger, &origin.ID)
+
+	err := delegate.build.SaveEvent(event.FinishSetPipeline{
+		ExitStatus: int(status),
+		Time:       time.Now().Unix(),
+		Origin:     origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-event", err)
+	}
+}
+
+func (delegate *delegate) saveInitializeLoadVar(logger lager.Logger, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.InitializeLoadVar{
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-initialize-event", err)
+	}
+}
+
+func (delegate *delegate) saveFinishLoadVar(logger lager.Logger, status exec.ExitStatus, origin event.Origin) {
+	delegate.flushEventWriters(logger, &origin.ID)
+
+	err := delegate.build.SaveEvent(event.FinishLoadVar{
+		ExitStatus: int(status),
+		Time:       time.Now().Unix(),
+		Origin:     origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-event", err)
+	}
+}
+
+func (delegate *delegate) saveWaitingForApproval(logger lager.Logger, plan atc.ApprovalPlan, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.WaitingForApproval{
+		Time:      time.Now().Unix(),
+		Message:   plan.Message,
+		Approvers: plan.Approvers,
+		Origin:    origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-event", err)
+	}
+}
+
+func (delegate *delegate) saveFinishApproval(logger lager.Logger, approver string, approved bool, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.FinishApproval{
+		Approver: approver,
+		Approved: approved,
+		Time:     time.Now().Unix(),
+		Origin:   origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-event", err)
+	}
+}
+
 func (delegate *delegate) saveStart(logger lager.Logger, origin event.Origin) {
 	err := delegate.build.SaveEvent(event.StartTask{
 		Time:   time.Now().Unix(),
@@ -167,11 +275,14 @@ func (delegate *delegate) saveStart(logger lager.Logger, origin event.Origin) {
 	}
 }
 
-func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus, origin event.Origin) {
+func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus, outOfMemory bool, origin event.Origin) {
+	delegate.flushEventWriters(logger, &origin.ID)
+
 	err := delegate.build.SaveEvent(event.FinishTask{
-		ExitStatus: int(status),
-		Time:       time.Now().Unix(),
-		Origin:     origin,
+		ExitStatus:  int(status),
+		Time:        time.Now().Unix(),
+		Origin:      origin,
+		OutOfMemory: outOfMemory,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-finish-event", err)
@@ -186,6 +297,8 @@ func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus
 }
 
 func (delegate *delegate) saveErr(logger lager.Logger, errVal error, origin event.Origin) {
+	delegate.flushEventWriters(logger, &origin.ID)
+
 	err := delegate.build.SaveEvent(event.Error{
 		Message: errVal.Error(),
 		Origin:  origin,
@@ -196,6 +309,8 @@ func (delegate *delegate) saveErr(logger lager.Logger, errVal error, origin even
 }
 
 func (delegate *delegate) saveInput(logger lager.Logger, status exec.ExitStatus, plan atc.GetPlan, info *exec.VersionInfo, origin event.Origin) {
+	delegate.flushEventWriters(logger, &origin.ID)
+
 	var version atc.Version
 	var metadata []atc.MetadataField
 
@@ -232,6 +347,8 @@ func (delegate *delegate) saveInput(logger lager.Logger, status exec.ExitStatus,
 }
 
 func (delegate *delegate) saveOutput(logger lager.Logger, status exec.ExitStatus, plan atc.PutPlan, info *exec.VersionInfo, origin event.Origin) {
+	delegate.flushEventWriters(logger, &origin.ID)
+
 	var version atc.Version
 	var metadata []atc.MetadataField
 
@@ -294,9 +411,38 @@ func (delegate *delegate) saveImplicitOutput(logger lager.Logger, plan atc.GetPl
 }
 
 func (delegate *delegate) eventWriter(origin event.Origin) io.Writer {
-	return &dbEventWriter{
-		build:  delegate.build,
-		origin: origin,
+	delegate.lock.Lock()
+	defer delegate.lock.Unlock()
+
+	writer, found := delegate.eventWriters[origin]
+	if !found {
+		writer = &dbEventWriter{
+			build:     delegate.build,
+			variables: delegate.variables,
+			origin:    origin,
+		}
+
+		delegate.eventWriters[origin] = writer
+	}
+
+	return writer
+}
+
+// flushEventWriters saves any output which the event writers for the given
+// step, or for every step if id is nil, are holding back for redaction.
+func (delegate *delegate) flushEventWriters(logger lager.Logger, id *event.OriginID) {
+	delegate.lock.Lock()
+	defer delegate.lock.Unlock()
+
+	for origin, writer := range delegate.eventWriters {
+		if id != nil && origin.ID != *id {
+			continue
+		}
+
+		err := writer.Flush()
+		if err != nil {
+			logger.Error("failed-to-flush-output", err)
+		}
 	}
 }
 
@@ -424,13 +570,21 @@ func (execution *executionDelegate) Started() {
 }
 
 func (execution *executionDelegate) Finished(status exec.ExitStatus) {
-	execution.delegate.saveFinish(execution.logger, status, event.Origin{
+	execution.delegate.saveFinish(execution.logger, status, false, event.Origin{
 		ID: execution.id,
 	})
 
 	execution.logger.Info("finished", lager.Data{"exit-status": status})
 }
 
+func (execution *executionDelegate) FinishedOutOfMemory(status exec.ExitStatus) {
+	execution.delegate.saveFinish(execution.logger, status, true, event.Origin{
+		ID: execution.id,
+	})
+
+	execution.logger.Info("finished-out-of-memory", lager.Data{"exit-status": status})
+}
+
 func (execution *executionDelegate) Failed(err error) {
 	execution.delegate.saveErr(execution.logger, err, event.Origin{
 		ID: execution.id,
@@ -456,15 +610,167 @@ func (execution *executionDelegate) Stderr() io.Writer {
 	})
 }
 
+type setPipelineDelegate struct {
+	logger lager.Logger
+
+	plan atc.SetPipelinePlan
+	id   event.OriginID
+
+	delegate *delegate
+}
+
+func (setPipeline *setPipelineDelegate) Initializing() {
+	setPipeline.delegate.saveInitializeSetPipeline(setPipeline.logger, event.Origin{
+		ID: setPipeline.id,
+	})
+
+	setPipeline.logger.Info("initializing")
+}
+
+func (setPipeline *setPipelineDelegate) Finished(status exec.ExitStatus) {
+	setPipeline.delegate.saveFinishSetPipeline(setPipeline.logger, status, event.Origin{
+		ID: setPipeline.id,
+	})
+
+	setPipeline.logger.Info("finished", lager.Data{"exit-status": status})
+}
+
+func (setPipeline *setPipelineDelegate) Failed(err error) {
+	setPipeline.delegate.saveErr(setPipeline.logger, err, event.Origin{
+		ID: setPipeline.id,
+	})
+	setPipeline.logger.Info("errored", lager.Data{"error": err.Error()})
+}
+
+func (setPipeline *setPipelineDelegate) Stdout() io.Writer {
+	return setPipeline.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStdout,
+		ID:     setPipeline.id,
+	})
+}
+
+func (setPipeline *setPipelineDelegate) Stderr() io.Writer {
+	return setPipeline.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStderr,
+		ID:     setPipeline.id,
+	})
+}
+
+type loadVarDelegate struct {
+	logger lager.Logger
+
+	plan atc.LoadVarPlan
+	id   event.OriginID
+
+	delegate *delegate
+}
+
+func (loadVar *loadVarDelegate) Initializing() {
+	loadVar.delegate.saveInitializeLoadVar(loadVar.logger, event.Origin{
+		ID: loadVar.id,
+	})
+
+	loadVar.logger.Info("initializing")
+}
+
+func (loadVar *loadVarDelegate) Finished(status exec.ExitStatus) {
+	loadVar.delegate.saveFinishLoadVar(loadVar.logger, status, event.Origin{
+		ID: loadVar.id,
+	})
+
+	loadVar.logger.Info("finished", lager.Data{"exit-status": status})
+}
+
+func (loadVar *loadVarDelegate) Failed(err error) {
+	loadVar.delegate.saveErr(loadVar.logger, err, event.Origin{
+		ID: loadVar.id,
+	})
+	loadVar.logger.Info("errored", lager.Data{"error": err.Error()})
+}
+
+func (loadVar *loadVarDelegate) Stdout() io.Writer {
+	return loadVar.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStdout,
+		ID:     loadVar.id,
+	})
+}
+
+type approvalDelegate struct {
+	logger lager.Logger
+
+	plan atc.ApprovalPlan
+	id   event.OriginID
+
+	delegate *delegate
+}
+
+func (approval *approvalDelegate) RequestApproval() (db.BuildApproval, error) {
+	buildApproval, err := approval.delegate.build.RequestApproval(
+		atc.PlanID(approval.id),
+		approval.plan.Approvers,
+		approval.plan.Message,
+	)
+	if err != nil {
+		return db.BuildApproval{}, err
+	}
+
+	if buildApproval.Pending() {
+		approval.delegate.saveWaitingForApproval(approval.logger, approval.plan, event.Origin{
+			ID: approval.id,
+		})
+
+		approval.logger.Info("waiting")
+	}
+
+	return buildApproval, nil
+}
+
+func (approval *approvalDelegate) ApprovalNotifier() (db.Notifier, error) {
+	return approval.delegate.build.ApprovalNotifier(atc.PlanID(approval.id))
+}
+
+func (approval *approvalDelegate) Approval() (db.BuildApproval, bool, error) {
+	return approval.delegate.build.GetApproval(atc.PlanID(approval.id))
+}
+
+func (approval *approvalDelegate) Finished(approver string, approved bool) {
+	if !approved {
+		err := approval.delegate.build.CloseApproval(atc.PlanID(approval.id))
+		if err != nil {
+			approval.logger.Error("failed-to-close-approval", err)
+		}
+	}
+
+	approval.delegate.saveFinishApproval(approval.logger, approver, approved, event.Origin{
+		ID: approval.id,
+	})
+
+	approval.logger.Info("finished", lager.Data{"approver": approver, "approved": approved})
+}
+
+func (approval *approvalDelegate) Failed(err error) {
+	approval.delegate.saveErr(approval.logger, err, event.Origin{
+		ID: approval.id,
+	})
+	approval.logger.Info("errored", lager.Data{"error": err.Error()})
+}
+
 type dbEventWriter struct {
-	build db.Build
+	build     db.Build
+	variables *creds.BuildVariables
 
 	origin event.Origin
 
 	dangling []byte
+	held     string
+
+	lock sync.Mutex
 }
 
 func (writer *dbEventWriter) Write(data []byte) (int, error) {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
 	text := append(writer.dangling, data...)
 
 	checkEncoding, _ := utf8.DecodeLastRune(text)
@@ -475,8 +781,18 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 
 	writer.dangling = nil
 
+	payload := string(text)
+	if writer.variables != nil {
+		// a value may be split across writes, so hold back anything which
+		// could be the start of one until the next write
+		payload, writer.held = writer.variables.RedactPartial(writer.held + payload)
+		if payload == "" {
+			return len(data), nil
+		}
+	}
+
 	err := writer.build.SaveEvent(event.Log{
-		Payload: string(text),
+		Payload: payload,
 		Origin:  writer.origin,
 	})
 	if err != nil {
@@ -486,6 +802,24 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 	return len(data), nil
 }
 
+// Flush saves any output held back by Write, once no more is to come.
+func (writer *dbEventWriter) Flush() error {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	if writer.held == "" {
+		return nil
+	}
+
+	payload := writer.variables.Redact(writer.held)
+	writer.held = ""
+
+	return writer.build.SaveEvent(event.Log{
+		Payload: payload,
+		Origin:  writer.origin,
+	})
+}
+
 func vrFromInput(plan atc.GetPlan, fetchedInfo exec.VersionInfo) db.VersionedResource {
 	return db.VersionedResource{
 		Resource:   plan.Resource,
@@ -7,6 +7,8 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/engine"
@@ -23,6 +25,7 @@ var _ = Describe("BuildDelegate", func() {
 		factory BuildDelegateFactory
 
 		fakeBuild *dbfakes.FakeBuild
+		variables *creds.BuildVariables
 
 		delegate BuildDelegate
 
@@ -35,7 +38,8 @@ var _ = Describe("BuildDelegate", func() {
 		factory = NewBuildDelegateFactory()
 
 		fakeBuild = new(dbfakes.FakeBuild)
-		delegate = factory.Delegate(fakeBuild)
+		variables = creds.NewBuildVariables(new(credsfakes.FakeVariables))
+		delegate = factory.Delegate(fakeBuild, variables)
 
 		logger = lagertest.NewTestLogger("test")
 
@@ -689,11 +693,30 @@ var _ = Describe("BuildDelegate", func() {
 					Expect(savedEvent.(event.FinishTask).Origin).To(Equal(event.Origin{
 						ID: originID,
 					}))
+					Expect(savedEvent.(event.FinishTask).OutOfMemory).To(BeFalse())
 
 				})
 			})
 		})
 
+		Describe("FinishedOutOfMemory", func() {
+			JustBeforeEach(func() {
+				executionDelegate.FinishedOutOfMemory(137)
+			})
+
+			It("saves a finish event saying the task ran out of memory", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishTask{}))
+				Expect(savedEvent.(event.FinishTask).ExitStatus).To(Equal(137))
+				Expect(savedEvent.(event.FinishTask).OutOfMemory).To(BeTrue())
+				Expect(savedEvent.(event.FinishTask).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+
 		Describe("Failed", func() {
 			JustBeforeEach(func() {
 				executionDelegate.Failed(errors.New("nope"))
@@ -776,6 +799,67 @@ var _ = Describe("BuildDelegate", func() {
 				}))
 
 			})
+
+			Context("when a local var has been loaded into the build", func() {
+				BeforeEach(func() {
+					variables.SetLocalVar("some-var", "some-secret")
+				})
+
+				It("redacts its value", func() {
+					_, err := writer.Write([]byte("the value is some-secret"))
+					Expect(err).NotTo(HaveOccurred())
+
+					savedEvent := fakeBuild.SaveEventArgsForCall(0)
+					Expect(savedEvent).To(Equal(event.Log{
+						Origin: event.Origin{
+							Source: event.OriginSourceStdout,
+							ID:     originID,
+						},
+						Payload: "the value is ((redacted))",
+					}))
+				})
+
+				It("redacts its value when it is split across writes", func() {
+					_, err := writer.Write([]byte("the value is some-se"))
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = executionDelegate.Stdout().Write([]byte("cret\n"))
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+						Origin: event.Origin{
+							Source: event.OriginSourceStdout,
+							ID:     originID,
+						},
+						Payload: "the value is ",
+					}))
+					Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+						Origin: event.Origin{
+							Source: event.OriginSourceStdout,
+							ID:     originID,
+						},
+						Payload: "((redacted))\n",
+					}))
+				})
+
+				It("saves any held back output when the task finishes", func() {
+					_, err := writer.Write([]byte("the output ends with some"))
+					Expect(err).NotTo(HaveOccurred())
+
+					executionDelegate.Finished(0)
+
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
+					Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+						Origin: event.Origin{
+							Source: event.OriginSourceStdout,
+							ID:     originID,
+						},
+						Payload: "some",
+					}))
+					Expect(fakeBuild.SaveEventArgsForCall(2)).To(BeAssignableToTypeOf(event.FinishTask{}))
+				})
+			})
 		})
 
 		Describe("Stderr", func() {
@@ -804,6 +888,169 @@ var _ = Describe("BuildDelegate", func() {
 		})
 	})
 
+	Describe("LoadVarDelegate", func() {
+		var loadVarDelegate exec.LoadVarDelegate
+
+		BeforeEach(func() {
+			loadVarDelegate = delegate.LoadVarDelegate(logger, atc.LoadVarPlan{
+				Name: "some-var",
+				File: "some-artifact/version",
+			}, originID)
+		})
+
+		Describe("Initializing", func() {
+			It("saves an initialize event", func() {
+				loadVarDelegate.Initializing()
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.InitializeLoadVar{
+					Origin: event.Origin{
+						ID: originID,
+					},
+				}))
+			})
+		})
+
+		Describe("Finished", func() {
+			It("saves a finish event", func() {
+				loadVarDelegate.Finished(exec.ExitStatus(0))
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				savedEvent, ok := fakeBuild.SaveEventArgsForCall(0).(event.FinishLoadVar)
+				Expect(ok).To(BeTrue())
+				Expect(savedEvent.ExitStatus).To(Equal(0))
+				Expect(savedEvent.Origin).To(Equal(event.Origin{ID: originID}))
+			})
+		})
+
+		Describe("Failed", func() {
+			It("saves an error event", func() {
+				loadVarDelegate.Failed(errors.New("nope"))
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Error{
+					Message: "nope",
+					Origin: event.Origin{
+						ID: originID,
+					},
+				}))
+			})
+		})
+	})
+
+	Describe("ApprovalDelegate", func() {
+		var (
+			approvalPlan     atc.ApprovalPlan
+			approvalDelegate exec.ApprovalDelegate
+		)
+
+		BeforeEach(func() {
+			approvalPlan = atc.ApprovalPlan{
+				Approvers: []string{"some-user"},
+				Message:   "ship it?",
+			}
+
+			approvalDelegate = delegate.ApprovalDelegate(logger, approvalPlan, originID)
+		})
+
+		Describe("RequestApproval", func() {
+			var (
+				approval db.BuildApproval
+				err      error
+			)
+
+			JustBeforeEach(func() {
+				approval, err = approvalDelegate.RequestApproval()
+			})
+
+			Context("when the approval is pending", func() {
+				BeforeEach(func() {
+					fakeBuild.RequestApprovalReturns(db.BuildApproval{
+						PlanID:    atc.PlanID(originID),
+						Approvers: []string{"some-user"},
+					}, nil)
+				})
+
+				It("requests approval for the plan", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(approval.PlanID).To(Equal(atc.PlanID(originID)))
+
+					Expect(fakeBuild.RequestApprovalCallCount()).To(Equal(1))
+					planID, approvers, message := fakeBuild.RequestApprovalArgsForCall(0)
+					Expect(planID).To(Equal(atc.PlanID(originID)))
+					Expect(approvers).To(Equal([]string{"some-user"}))
+					Expect(message).To(Equal("ship it?"))
+				})
+
+				It("saves a waiting event", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+					savedEvent, ok := fakeBuild.SaveEventArgsForCall(0).(event.WaitingForApproval)
+					Expect(ok).To(BeTrue())
+					Expect(savedEvent.Message).To(Equal("ship it?"))
+					Expect(savedEvent.Approvers).To(Equal([]string{"some-user"}))
+					Expect(savedEvent.Origin).To(Equal(event.Origin{ID: originID}))
+				})
+			})
+
+			Context("when the approval has already been given", func() {
+				BeforeEach(func() {
+					fakeBuild.RequestApprovalReturns(db.BuildApproval{
+						PlanID:   atc.PlanID(originID),
+						Approver: "some-user",
+					}, nil)
+				})
+
+				It("does not save a waiting event", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when requesting approval fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeBuild.RequestApprovalReturns(db.BuildApproval{}, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(err).To(Equal(disaster))
+				})
+			})
+		})
+
+		Describe("Finished", func() {
+			Context("when approved", func() {
+				It("saves a finish event with the approver", func() {
+					approvalDelegate.Finished("some-user", true)
+
+					Expect(fakeBuild.CloseApprovalCallCount()).To(Equal(0))
+
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+					savedEvent, ok := fakeBuild.SaveEventArgsForCall(0).(event.FinishApproval)
+					Expect(ok).To(BeTrue())
+					Expect(savedEvent.Approver).To(Equal("some-user"))
+					Expect(savedEvent.Approved).To(BeTrue())
+					Expect(savedEvent.Origin).To(Equal(event.Origin{ID: originID}))
+				})
+			})
+
+			Context("when not approved", func() {
+				It("closes the approval and saves a finish event", func() {
+					approvalDelegate.Finished("", false)
+
+					Expect(fakeBuild.CloseApprovalCallCount()).To(Equal(1))
+					Expect(fakeBuild.CloseApprovalArgsForCall(0)).To(Equal(atc.PlanID(originID)))
+
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+					savedEvent, ok := fakeBuild.SaveEventArgsForCall(0).(event.FinishApproval)
+					Expect(ok).To(BeTrue())
+					Expect(savedEvent.Approved).To(BeFalse())
+				})
+			})
+		})
+	})
+
 	Describe("OutputDelegate", func() {
 		var (
 			putPlan atc.PutPlan
@@ -3,6 +3,7 @@ package engine_test
 import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -36,10 +37,12 @@ var _ = Describe("Exec Engine With Hooks", func() {
 		fakeDelegateFactory = new(enginefakes.FakeBuildDelegateFactory)
 
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
+		fakeCredentialManager := new(credsfakes.FakeCredentialManager)
 		execEngine = engine.NewExecEngine(
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			fakeCredentialManager,
 			"http://example.com",
 		)
 
@@ -191,6 +194,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 					Expect(sourceName).To(Equal(exec.SourceName("some-input")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-input",
 						Type:       db.ContainerTypeGet,
 					}))
@@ -211,6 +215,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 					Expect(sourceName).To(Equal(exec.SourceName("some-completion-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-completion-task",
 						Type:       db.ContainerTypeTask,
 					}))
@@ -232,6 +237,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 					Expect(sourceName).To(Equal(exec.SourceName("some-failure-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-failure-task",
 						Type:       db.ContainerTypeTask,
 					}))
@@ -253,6 +259,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 					Expect(sourceName).To(Equal(exec.SourceName("some-success-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-success-task",
 						Type:       db.ContainerTypeTask,
 					}))
@@ -274,6 +281,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 					Expect(sourceName).To(Equal(exec.SourceName("some-next-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-next-task",
 						Type:       db.ContainerTypeTask,
 					}))
@@ -10,6 +10,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -25,10 +27,11 @@ import (
 
 var _ = Describe("ExecEngine", func() {
 	var (
-		fakeFactory         *execfakes.FakeFactory
-		fakeTeamDB          *dbfakes.FakeTeamDB
-		fakeDelegateFactory *enginefakes.FakeBuildDelegateFactory
-		logger              *lagertest.TestLogger
+		fakeFactory           *execfakes.FakeFactory
+		fakeTeamDB            *dbfakes.FakeTeamDB
+		fakeDelegateFactory   *enginefakes.FakeBuildDelegateFactory
+		fakeCredentialManager *credsfakes.FakeCredentialManager
+		logger                *lagertest.TestLogger
 
 		execEngine engine.Engine
 		teamID     = 17
@@ -40,12 +43,14 @@ var _ = Describe("ExecEngine", func() {
 		logger = lagertest.NewTestLogger("test")
 
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
+		fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 		fakeTeamDB = new(dbfakes.FakeTeamDB)
 		fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
 		execEngine = engine.NewExecEngine(
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			fakeCredentialManager,
 			"http://example.com",
 		)
 	})
@@ -270,6 +275,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						ResourceName: "",
 						Type:         db.ContainerTypePut,
+						JobName:      "some-job",
 						StepName:     "some-put",
 						PipelineID:   57,
 						TeamID:       teamID,
@@ -293,6 +299,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						ResourceName: "",
 						Type:         db.ContainerTypePut,
+						JobName:      "some-job",
 						StepName:     "some-put-2",
 						PipelineID:   57,
 						TeamID:       teamID,
@@ -325,6 +332,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						ResourceName: "",
 						Type:         db.ContainerTypeGet,
+						JobName:      "some-job",
 						StepName:     "some-get",
 						PipelineID:   57,
 						TeamID:       teamID,
@@ -353,6 +361,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						ResourceName: "",
 						Type:         db.ContainerTypeGet,
+						JobName:      "some-job",
 						StepName:     "some-get-2",
 						PipelineID:   57,
 						TeamID:       teamID,
@@ -445,6 +454,7 @@ var _ = Describe("ExecEngine", func() {
 				Expect(workerMetadata).To(Equal(worker.Metadata{
 					ResourceName: "",
 					Type:         db.ContainerTypeGet,
+					JobName:      "some-job",
 					StepName:     "some-get",
 					PipelineID:   57,
 					Attempts:     []int{1},
@@ -473,6 +483,7 @@ var _ = Describe("ExecEngine", func() {
 				Expect(workerMetadata).To(Equal(worker.Metadata{
 					ResourceName: "",
 					Type:         db.ContainerTypeGet,
+					JobName:      "some-job",
 					StepName:     "some-get",
 					PipelineID:   57,
 					Attempts:     []int{3},
@@ -505,6 +516,7 @@ var _ = Describe("ExecEngine", func() {
 				Expect(workerMetadata).To(Equal(worker.Metadata{
 					ResourceName: "",
 					Type:         db.ContainerTypeTask,
+					JobName:      "some-job",
 					StepName:     "some-task",
 					PipelineID:   57,
 					Attempts:     []int{2, 1},
@@ -519,7 +531,9 @@ var _ = Describe("ExecEngine", func() {
 				Expect(privileged).To(Equal(exec.Privileged(false)))
 				Expect(tags).To(Equal(atc.Tags{"some", "task", "tags"}))
 				Expect(actualTeamID).To(Equal(teamID))
-				Expect(configSource).To(Equal(exec.ValidatingConfigSource{exec.FileConfigSource{"some-config-path"}}))
+				Expect(configSource).To(BeAssignableToTypeOf(exec.ValidatingConfigSource{}))
+				Expect(configSource.(exec.ValidatingConfigSource).ConfigSource).To(BeAssignableToTypeOf(exec.EvaluatingConfigSource{}))
+				Expect(configSource.(exec.ValidatingConfigSource).ConfigSource.(exec.EvaluatingConfigSource).ConfigSource).To(Equal(exec.FileConfigSource{"some-config-path"}))
 
 				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
@@ -527,6 +541,7 @@ var _ = Describe("ExecEngine", func() {
 				Expect(workerMetadata).To(Equal(worker.Metadata{
 					ResourceName: "",
 					Type:         db.ContainerTypeTask,
+					JobName:      "some-job",
 					StepName:     "some-task",
 					PipelineID:   57,
 					Attempts:     []int{2, 2},
@@ -541,7 +556,9 @@ var _ = Describe("ExecEngine", func() {
 				Expect(delegate).To(Equal(fakeExecutionDelegate))
 				Expect(privileged).To(Equal(exec.Privileged(false)))
 				Expect(tags).To(Equal(atc.Tags{"some", "task", "tags"}))
-				Expect(configSource).To(Equal(exec.ValidatingConfigSource{exec.FileConfigSource{"some-config-path"}}))
+				Expect(configSource).To(BeAssignableToTypeOf(exec.ValidatingConfigSource{}))
+				Expect(configSource.(exec.ValidatingConfigSource).ConfigSource).To(BeAssignableToTypeOf(exec.EvaluatingConfigSource{}))
+				Expect(configSource.(exec.ValidatingConfigSource).ConfigSource.(exec.EvaluatingConfigSource).ConfigSource).To(Equal(exec.FileConfigSource{"some-config-path"}))
 			})
 		})
 
@@ -670,6 +687,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						ResourceName: "",
 						Type:         db.ContainerTypeGet,
+						JobName:      "some-job",
 						StepName:     "some-input",
 						PipelineID:   57,
 						TeamID:       teamID,
@@ -694,6 +712,69 @@ var _ = Describe("ExecEngine", func() {
 					Expect(planID).To(Equal(event.OriginID(plan.ID)))
 				})
 
+				Context("when the source and params contain credential placeholders", func() {
+					BeforeEach(func() {
+						plan.Get.Source = atc.Source{"password": "((some-password))"}
+						plan.Get.Params = atc.Params{"token": "((some-token))"}
+					})
+
+					Context("when the credentials are found", func() {
+						BeforeEach(func() {
+							fakeCredentialManager.GetStub = func(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+								return "secret-" + varName, true, nil
+							}
+						})
+
+						It("constructs the get with the evaluated source and params", func() {
+							var err error
+							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+							Expect(fakeFactory.GetCallCount()).To(Equal(1))
+
+							_, _, _, _, _, _, resourceConfig, _, _, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+							Expect(resourceConfig.Source).To(Equal(atc.Source{"password": "secret-some-password"}))
+							Expect(params).To(Equal(atc.Params{"token": "secret-some-token"}))
+
+							teamName, pipelineName, _ := fakeCredentialManager.GetArgsForCall(0)
+							Expect(teamName).To(Equal("some-team"))
+							Expect(pipelineName).To(Equal("some-pipeline"))
+						})
+
+						It("does not store the credentials in the build's metadata", func() {
+							var err error
+							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+
+							Expect(build.Metadata()).To(ContainSubstring("((some-password))"))
+							Expect(build.Metadata()).NotTo(ContainSubstring("secret-some-password"))
+						})
+					})
+
+					Context("when the credentials are not found", func() {
+						BeforeEach(func() {
+							fakeCredentialManager.GetReturns(nil, false, nil)
+						})
+
+						It("errors the build without constructing the get", func() {
+							var err error
+							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+							Expect(fakeFactory.GetCallCount()).To(BeZero())
+
+							Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+							_, finishErr, _, _ := fakeDelegate.FinishArgsForCall(0)
+							Expect(finishErr).To(HaveOccurred())
+							Expect(finishErr.Error()).To(ContainSubstring("some-password"))
+						})
+					})
+				})
+
 				It("releases inputs correctly", func() {
 					inputStep.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
 						defer GinkgoRecover()
@@ -784,6 +865,7 @@ var _ = Describe("ExecEngine", func() {
 						Expect(workerMetadata).To(Equal(worker.Metadata{
 							ResourceName: "",
 							Type:         db.ContainerTypeTask,
+							JobName:      "some-job",
 							StepName:     "some-task",
 							PipelineID:   57,
 							TeamID:       teamID,
@@ -832,7 +914,7 @@ var _ = Describe("ExecEngine", func() {
 							}
 						})
 
-						It("creates the task with a MergedConfigSource wrapped in a ValidatingConfigSource", func() {
+						It("creates the task with a MergedConfigSource wrapped in an EvaluatingConfigSource and a ValidatingConfigSource", func() {
 							var err error
 							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
 							Expect(err).NotTo(HaveOccurred())
@@ -843,7 +925,9 @@ var _ = Describe("ExecEngine", func() {
 							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
-							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
+							ecs, ok := vcs.ConfigSource.(exec.EvaluatingConfigSource)
+							Expect(ok).To(BeTrue())
+							_, ok = ecs.ConfigSource.(exec.MergedConfigSource)
 							Expect(ok).To(BeTrue())
 						})
 					})
@@ -857,7 +941,7 @@ var _ = Describe("ExecEngine", func() {
 							}
 						})
 
-						It("creates the task with a MergedConfigSource wrapped in a ValidatingConfigSource", func() {
+						It("creates the task with a MergedConfigSource wrapped in an EvaluatingConfigSource and a ValidatingConfigSource", func() {
 							var err error
 							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
 							Expect(err).NotTo(HaveOccurred())
@@ -868,7 +952,9 @@ var _ = Describe("ExecEngine", func() {
 							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
-							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
+							ecs, ok := vcs.ConfigSource.(exec.EvaluatingConfigSource)
+							Expect(ok).To(BeTrue())
+							_, ok = ecs.ConfigSource.(exec.MergedConfigSource)
 							Expect(ok).To(BeTrue())
 						})
 					})
@@ -889,6 +975,321 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 
+			Context("that contains a set_pipeline step", func() {
+				var (
+					fakeSetPipelineDelegate *execfakes.FakeSetPipelineDelegate
+					setPipelinePlan         atc.SetPipelinePlan
+				)
+
+				BeforeEach(func() {
+					fakeSetPipelineDelegate = new(execfakes.FakeSetPipelineDelegate)
+					fakeDelegate.SetPipelineDelegateReturns(fakeSetPipelineDelegate)
+
+					setPipelineStepFactory := new(execfakes.FakeStepFactory)
+					setPipelineStep := new(execfakes.FakeStep)
+					setPipelineStep.ResultStub = successResult(true)
+					setPipelineStepFactory.UsingReturns(setPipelineStep)
+					fakeFactory.SetPipelineReturns(setPipelineStepFactory)
+
+					setPipelinePlan = atc.SetPipelinePlan{
+						Name: "some-pipeline",
+						File: "some-input/pipeline.yml",
+					}
+
+					plan = planFactory.NewPlan(setPipelinePlan)
+				})
+
+				It("constructs the step for the build's team", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.SetPipelineCallCount()).To(Equal(1))
+
+					_, delegate, teamName, actualPlan := fakeFactory.SetPipelineArgsForCall(0)
+					Expect(delegate).To(Equal(fakeSetPipelineDelegate))
+					Expect(teamName).To(Equal("some-team"))
+					Expect(actualPlan).To(Equal(setPipelinePlan))
+
+					_, _, planID := fakeDelegate.SetPipelineDelegateArgsForCall(0)
+					Expect(planID).To(Equal(event.OriginID(plan.ID)))
+				})
+			})
+
+			Context("that contains an approval step", func() {
+				var (
+					fakeApprovalDelegate *execfakes.FakeApprovalDelegate
+					approvalPlan         atc.ApprovalPlan
+				)
+
+				BeforeEach(func() {
+					fakeApprovalDelegate = new(execfakes.FakeApprovalDelegate)
+					fakeDelegate.ApprovalDelegateReturns(fakeApprovalDelegate)
+
+					approvalStepFactory := new(execfakes.FakeStepFactory)
+					approvalStep := new(execfakes.FakeStep)
+					approvalStep.ResultStub = successResult(true)
+					approvalStepFactory.UsingReturns(approvalStep)
+					fakeFactory.ApprovalReturns(approvalStepFactory)
+
+					approvalPlan = atc.ApprovalPlan{
+						Approvers: []string{"some-user"},
+						Timeout:   "1h",
+						Message:   "ship it?",
+					}
+
+					plan = planFactory.NewPlan(approvalPlan)
+				})
+
+				It("constructs the step with an approval delegate for the plan", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.ApprovalCallCount()).To(Equal(1))
+
+					_, delegate, actualPlan, _ := fakeFactory.ApprovalArgsForCall(0)
+					Expect(delegate).To(Equal(fakeApprovalDelegate))
+					Expect(actualPlan).To(Equal(approvalPlan))
+
+					_, delegatePlan, planID := fakeDelegate.ApprovalDelegateArgsForCall(0)
+					Expect(delegatePlan).To(Equal(approvalPlan))
+					Expect(planID).To(Equal(event.OriginID(plan.ID)))
+				})
+			})
+
+			Context("that contains a load_var step", func() {
+				var (
+					fakeLoadVarDelegate *execfakes.FakeLoadVarDelegate
+					loadVarPlan         atc.LoadVarPlan
+				)
+
+				BeforeEach(func() {
+					fakeLoadVarDelegate = new(execfakes.FakeLoadVarDelegate)
+					fakeDelegate.LoadVarDelegateReturns(fakeLoadVarDelegate)
+
+					loadVarStepFactory := new(execfakes.FakeStepFactory)
+					loadVarStep := new(execfakes.FakeStep)
+					loadVarStep.ResultStub = successResult(true)
+					loadVarStepFactory.UsingReturns(loadVarStep)
+					fakeFactory.LoadVarReturns(loadVarStepFactory)
+
+					loadVarPlan = atc.LoadVarPlan{
+						Name: "some-var",
+						File: "some-input/version",
+					}
+
+					plan = planFactory.NewPlan(loadVarPlan)
+				})
+
+				It("constructs the step with the build's variables", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.LoadVarCallCount()).To(Equal(1))
+
+					_, delegate, variables, actualPlan := fakeFactory.LoadVarArgsForCall(0)
+					Expect(delegate).To(Equal(fakeLoadVarDelegate))
+					Expect(actualPlan).To(Equal(loadVarPlan))
+
+					_, delegateVariables := fakeDelegateFactory.DelegateArgsForCall(0)
+					Expect(variables).To(BeIdenticalTo(delegateVariables))
+				})
+
+				Context("followed by a get which refers to the var", func() {
+					BeforeEach(func() {
+						fakeFactory.LoadVarStub = func(_ lager.Logger, _ exec.LoadVarDelegate, variables *creds.BuildVariables, plan atc.LoadVarPlan) exec.StepFactory {
+							variables.SetLocalVar(plan.Name, "some-version")
+
+							loadVarStepFactory := new(execfakes.FakeStepFactory)
+							loadVarStep := new(execfakes.FakeStep)
+							loadVarStep.ResultStub = successResult(true)
+							loadVarStepFactory.UsingReturns(loadVarStep)
+							return loadVarStepFactory
+						}
+
+						plan = planFactory.NewPlan(atc.OnSuccessPlan{
+							Step: plan,
+							Next: planFactory.NewPlan(atc.GetPlan{
+								Name:       "some-input",
+								Resource:   "some-input-resource",
+								Type:       "get",
+								Source:     atc.Source{"some": "source"},
+								Params:     atc.Params{"version": "((.:some-var))"},
+								PipelineID: 57,
+							}),
+						})
+					})
+
+					It("constructs the get with the var's value", func() {
+						var err error
+						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+						Expect(fakeFactory.GetCallCount()).To(Equal(1))
+
+						_, _, _, _, _, _, _, _, _, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+						Expect(params).To(Equal(atc.Params{"version": "some-version"}))
+					})
+				})
+
+				Context("followed by a task whose resource types refer to the var", func() {
+					BeforeEach(func() {
+						fakeFactory.LoadVarStub = func(_ lager.Logger, _ exec.LoadVarDelegate, variables *creds.BuildVariables, plan atc.LoadVarPlan) exec.StepFactory {
+							variables.SetLocalVar(plan.Name, "some-version")
+
+							loadVarStepFactory := new(execfakes.FakeStepFactory)
+							loadVarStep := new(execfakes.FakeStep)
+							loadVarStep.ResultStub = successResult(true)
+							loadVarStepFactory.UsingReturns(loadVarStep)
+							return loadVarStepFactory
+						}
+
+						plan = planFactory.NewPlan(atc.OnSuccessPlan{
+							Step: plan,
+							Next: planFactory.NewPlan(atc.TaskPlan{
+								Name:   "some-task",
+								Config: &atc.TaskConfig{Run: atc.TaskRunConfig{Path: "ls"}},
+								ResourceTypes: atc.ResourceTypes{
+									{
+										Name:   "custom-resource",
+										Type:   "custom-type",
+										Source: atc.Source{"tag": "((.:some-var))"},
+									},
+								},
+								PipelineID: 57,
+							}),
+						})
+					})
+
+					It("constructs the task with the var's value", func() {
+						var err error
+						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+						_, _, _, _, _, _, _, _, _, resourceTypes, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+						Expect(resourceTypes).To(Equal(atc.ResourceTypes{
+							{
+								Name:   "custom-resource",
+								Type:   "custom-type",
+								Source: atc.Source{"tag": "some-version"},
+							},
+						}))
+					})
+				})
+			})
+
+			Context("that contains an across step", func() {
+				BeforeEach(func() {
+					loadVarStepFactory := new(execfakes.FakeStepFactory)
+					loadVarStep := new(execfakes.FakeStep)
+					loadVarStep.ResultStub = successResult(true)
+					loadVarStepFactory.UsingReturns(loadVarStep)
+					fakeFactory.LoadVarReturns(loadVarStepFactory)
+
+					scopedPlan := func(values ...interface{}) atc.VarScopedPlan {
+						return atc.VarScopedPlan{
+							Step: planFactory.NewPlan(atc.LoadVarPlan{
+								Name: "some-var",
+								File: fmt.Sprintf("some-input/%s-%s", values...),
+							}),
+							Values: values,
+						}
+					}
+
+					plan = planFactory.NewPlan(atc.AcrossPlan{
+						Vars: []atc.AcrossVar{
+							{Var: "go_version", Values: []interface{}{"1.7", "1.8"}, MaxInFlight: 1},
+							{Var: "os", Values: []interface{}{"linux", "darwin"}, MaxInFlight: 1},
+						},
+						Steps: []atc.VarScopedPlan{
+							scopedPlan("1.7", "linux"),
+							scopedPlan("1.7", "darwin"),
+							scopedPlan("1.8", "linux"),
+							scopedPlan("1.8", "darwin"),
+						},
+					})
+				})
+
+				It("constructs a step for every combination, in order", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.LoadVarCallCount()).To(Equal(4))
+
+					files := []string{}
+					for i := 0; i < 4; i++ {
+						_, _, _, actualPlan := fakeFactory.LoadVarArgsForCall(i)
+						files = append(files, actualPlan.File)
+					}
+
+					Expect(files).To(Equal([]string{
+						"some-input/1.7-linux",
+						"some-input/1.7-darwin",
+						"some-input/1.8-linux",
+						"some-input/1.8-darwin",
+					}))
+				})
+			})
+
+			Context("that contains error and abort hooks", func() {
+				var steps map[string]*execfakes.FakeStep
+
+				BeforeEach(func() {
+					steps = map[string]*execfakes.FakeStep{}
+
+					fakeFactory.LoadVarStub = func(_ lager.Logger, _ exec.LoadVarDelegate, _ *creds.BuildVariables, plan atc.LoadVarPlan) exec.StepFactory {
+						step := new(execfakes.FakeStep)
+						step.ResultStub = successResult(true)
+						if plan.Name == "some-step" {
+							step.RunReturns(errors.New("worker went away"))
+						}
+
+						steps[plan.Name] = step
+
+						stepFactory := new(execfakes.FakeStepFactory)
+						stepFactory.UsingReturns(step)
+						return stepFactory
+					}
+
+					plan = planFactory.NewPlan(atc.OnAbortPlan{
+						Step: planFactory.NewPlan(atc.OnErrorPlan{
+							Step: planFactory.NewPlan(atc.LoadVarPlan{Name: "some-step", File: "some-input/step"}),
+							Next: planFactory.NewPlan(atc.LoadVarPlan{Name: "some-error-hook", File: "some-input/on-error"}),
+						}),
+						Next: planFactory.NewPlan(atc.LoadVarPlan{Name: "some-abort-hook", File: "some-input/on-abort"}),
+					})
+				})
+
+				It("runs only the error hook when the step errors", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.LoadVarCallCount()).To(Equal(3))
+
+					Expect(steps["some-step"].RunCallCount()).To(Equal(1))
+					Expect(steps["some-error-hook"].RunCallCount()).To(Equal(1))
+					Expect(steps["some-abort-hook"].RunCallCount()).To(BeZero())
+
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, finishErr, _, aborted := fakeDelegate.FinishArgsForCall(0)
+					Expect(finishErr).To(MatchError(ContainSubstring("worker went away")))
+					Expect(aborted).To(BeFalse())
+				})
+			})
+
 			Context("that contains outputs", func() {
 				var (
 					plan             atc.Plan
@@ -936,6 +1337,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						ResourceName: "",
 						Type:         db.ContainerTypePut,
+						JobName:      "some-job",
 						StepName:     "some-put",
 						PipelineID:   57,
 						TeamID:       teamID,
@@ -972,6 +1374,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						ResourceName: "",
 						Type:         db.ContainerTypeGet,
+						JobName:      "some-job",
 						StepName:     "some-get",
 						PipelineID:   57,
 						TeamID:       teamID,
@@ -1128,6 +1531,7 @@ var _ = Describe("ExecEngine", func() {
 				Expect(workerMetadata).To(Equal(worker.Metadata{
 					ResourceName: "",
 					Type:         db.ContainerTypeGet,
+					JobName:      "some-job",
 					StepName:     "some-get",
 					PipelineID:   57,
 					Attempts:     []int{1},
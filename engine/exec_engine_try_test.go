@@ -3,6 +3,7 @@ package engine_test
 import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
@@ -37,10 +38,12 @@ var _ = Describe("Exec Engine with Try", func() {
 		fakeDelegateFactory = new(enginefakes.FakeBuildDelegateFactory)
 
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
+		fakeCredentialManager := new(credsfakes.FakeCredentialManager)
 		execEngine = engine.NewExecEngine(
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			fakeCredentialManager,
 			"http://example.com",
 		)
 
@@ -129,6 +132,7 @@ var _ = Describe("Exec Engine with Try", func() {
 				Expect(sourceName).To(Equal(exec.SourceName("some-input")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
 					Type:       db.ContainerTypeGet,
+					JobName:    "some-job",
 					StepName:   "some-input",
 					PipelineID: 42,
 				}))
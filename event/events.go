@@ -14,6 +14,10 @@ type FinishTask struct {
 	Time       int64  `json:"time"`
 	ExitStatus int    `json:"exit_status"`
 	Origin     Origin `json:"origin"`
+
+	// OutOfMemory is set if the task's container was killed for exceeding its
+	// memory limit.
+	OutOfMemory bool `json:"out_of_memory,omitempty"`
 }
 
 func (FinishTask) EventType() atc.EventType  { return EventTypeFinishTask }
@@ -155,3 +159,55 @@ type InitializePut struct {
 
 func (InitializePut) EventType() atc.EventType  { return EventTypeInitializePut }
 func (InitializePut) Version() atc.EventVersion { return "1.0" }
+
+type InitializeSetPipeline struct {
+	Origin Origin `json:"origin"`
+}
+
+func (InitializeSetPipeline) EventType() atc.EventType  { return EventTypeInitializeSetPipeline }
+func (InitializeSetPipeline) Version() atc.EventVersion { return "1.0" }
+
+type FinishSetPipeline struct {
+	Origin     Origin `json:"origin"`
+	Time       int64  `json:"time"`
+	ExitStatus int    `json:"exit_status"`
+}
+
+func (FinishSetPipeline) EventType() atc.EventType  { return EventTypeFinishSetPipeline }
+func (FinishSetPipeline) Version() atc.EventVersion { return "1.0" }
+
+type InitializeLoadVar struct {
+	Origin Origin `json:"origin"`
+}
+
+func (InitializeLoadVar) EventType() atc.EventType  { return EventTypeInitializeLoadVar }
+func (InitializeLoadVar) Version() atc.EventVersion { return "1.0" }
+
+type FinishLoadVar struct {
+	Origin     Origin `json:"origin"`
+	Time       int64  `json:"time"`
+	ExitStatus int    `json:"exit_status"`
+}
+
+func (FinishLoadVar) EventType() atc.EventType  { return EventTypeFinishLoadVar }
+func (FinishLoadVar) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForApproval struct {
+	Origin    Origin   `json:"origin"`
+	Time      int64    `json:"time"`
+	Message   string   `json:"message,omitempty"`
+	Approvers []string `json:"approvers,omitempty"`
+}
+
+func (WaitingForApproval) EventType() atc.EventType  { return EventTypeWaitingForApproval }
+func (WaitingForApproval) Version() atc.EventVersion { return "1.0" }
+
+type FinishApproval struct {
+	Origin   Origin `json:"origin"`
+	Time     int64  `json:"time"`
+	Approver string `json:"approver,omitempty"`
+	Approved bool   `json:"approved"`
+}
+
+func (FinishApproval) EventType() atc.EventType  { return EventTypeFinishApproval }
+func (FinishApproval) Version() atc.EventVersion { return "1.0" }
@@ -40,6 +40,12 @@ func init() {
 	registerEvent(FinishGet{})
 	registerEvent(InitializePut{})
 	registerEvent(FinishPut{})
+	registerEvent(InitializeSetPipeline{})
+	registerEvent(FinishSetPipeline{})
+	registerEvent(InitializeLoadVar{})
+	registerEvent(FinishLoadVar{})
+	registerEvent(WaitingForApproval{})
+	registerEvent(FinishApproval{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
@@ -30,6 +30,24 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// set_pipeline step initializing
+	EventTypeInitializeSetPipeline atc.EventType = "initialize-set-pipeline"
+
+	// finished setting a pipeline
+	EventTypeFinishSetPipeline atc.EventType = "finish-set-pipeline"
+
+	// load_var step initializing
+	EventTypeInitializeLoadVar atc.EventType = "initialize-load-var"
+
+	// finished loading a var
+	EventTypeFinishLoadVar atc.EventType = "finish-load-var"
+
+	// approval step waiting for a user to approve
+	EventTypeWaitingForApproval atc.EventType = "waiting-for-approval"
+
+	// approval step approved or timed out
+	EventTypeFinishApproval atc.EventType = "finish-approval"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
@@ -0,0 +1,145 @@
+package exec
+
+import (
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+)
+
+// ApprovalStep waits for a user to approve the build before it continues.
+type ApprovalStep struct {
+	logger   lager.Logger
+	delegate ApprovalDelegate
+	plan     atc.ApprovalPlan
+	clock    clock.Clock
+
+	approved bool
+}
+
+func newApprovalStep(
+	logger lager.Logger,
+	delegate ApprovalDelegate,
+	plan atc.ApprovalPlan,
+	clock clock.Clock,
+) ApprovalStep {
+	return ApprovalStep{
+		logger:   logger,
+		delegate: delegate,
+		plan:     plan,
+		clock:    clock,
+	}
+}
+
+// Using finishes construction of the ApprovalStep and returns an
+// *ApprovalStep. If the *ApprovalStep errors, its error is reported to the
+// delegate.
+func (step ApprovalStep) Using(prev Step, repo *SourceRepository) Step {
+	return errorReporter{
+		Step:          &step,
+		ReportFailure: step.delegate.Failed,
+	}
+}
+
+// Run requests approval and waits until it is given.
+//
+// If the plan has a timeout and it elapses before approval is given, the step
+// fails. The timeout counts from when approval was first requested, so a build
+// that is resumed does not get to wait any longer.
+//
+// If the step is interrupted, it stops waiting and returns ErrInterrupted.
+func (step *ApprovalStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	var timeout time.Duration
+	if step.plan.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(step.plan.Timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	close(ready)
+
+	approval, err := step.delegate.RequestApproval()
+	if err != nil {
+		return err
+	}
+
+	if !approval.Pending() {
+		// already decided before the build was resumed
+		step.approved = approval.Approved()
+		return nil
+	}
+
+	notifier, err := step.delegate.ApprovalNotifier()
+	if err != nil {
+		return err
+	}
+
+	defer notifier.Close()
+
+	var timedOut <-chan time.Time
+	if timeout != 0 {
+		remaining := timeout - step.clock.Since(approval.RequestedAt)
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		timer := step.clock.NewTimer(remaining)
+		defer timer.Stop()
+
+		timedOut = timer.C()
+	}
+
+	for {
+		select {
+		case <-notifier.Notify():
+			approval, found, err := step.delegate.Approval()
+			if err != nil {
+				return err
+			}
+
+			if !found || !approval.Approved() {
+				continue
+			}
+
+			step.logger.Info("approved", lager.Data{"approver": approval.Approver})
+
+			step.approved = true
+			step.delegate.Finished(approval.Approver, true)
+
+			return nil
+
+		case <-timedOut:
+			step.logger.Info("timed-out")
+
+			step.delegate.Finished("", false)
+
+			return nil
+
+		case <-signals:
+			step.delegate.Finished("", false)
+
+			return ErrInterrupted
+		}
+	}
+}
+
+// Release does nothing, as the step has no resources to release.
+func (step *ApprovalStep) Release() {}
+
+// Result indicates Success as true if the build was approved.
+//
+// All other types are ignored.
+func (step *ApprovalStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.approved)
+		return true
+
+	default:
+		return false
+	}
+}
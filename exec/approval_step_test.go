@@ -0,0 +1,210 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("ApprovalStep", func() {
+	var (
+		fakeDelegate *execfakes.FakeApprovalDelegate
+		fakeNotifier *dbfakes.FakeNotifier
+		fakeClock    *fakeclock.FakeClock
+		notify       chan struct{}
+
+		factory Factory
+		plan    atc.ApprovalPlan
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		notify = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+
+		fakeDelegate = new(execfakes.FakeApprovalDelegate)
+		fakeDelegate.RequestApprovalReturns(db.BuildApproval{
+			PlanID:      "some-plan",
+			RequestedAt: fakeClock.Now(),
+		}, nil)
+		fakeDelegate.ApprovalNotifierReturns(fakeNotifier, nil)
+
+		factory = NewGardenFactory(nil, nil, nil, new(dbfakes.FakeTeamDBFactory), atc.ContainerLimits{}, atc.ContainerLimits{})
+
+		plan = atc.ApprovalPlan{
+			Approvers: []string{"some-user"},
+			Message:   "ship it?",
+		}
+	})
+
+	JustBeforeEach(func() {
+		step = factory.Approval(lagertest.NewTestLogger("test"), fakeDelegate, plan, fakeClock).Using(nil, nil)
+		process = ifrit.Background(step)
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Kill)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	succeeded := func() bool {
+		var success Success
+		Expect(step.Result(&success)).To(BeTrue())
+		return bool(success)
+	}
+
+	It("requests approval and waits", func() {
+		Eventually(fakeDelegate.RequestApprovalCallCount).Should(Equal(1))
+		Consistently(process.Wait()).ShouldNot(Receive())
+	})
+
+	Context("when approval is given", func() {
+		BeforeEach(func() {
+			fakeDelegate.ApprovalReturns(db.BuildApproval{
+				PlanID:   "some-plan",
+				Approver: "some-user",
+			}, true, nil)
+		})
+
+		It("succeeds and records the approver", func() {
+			notify <- struct{}{}
+
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(succeeded()).To(BeTrue())
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			approver, approved := fakeDelegate.FinishedArgsForCall(0)
+			Expect(approver).To(Equal("some-user"))
+			Expect(approved).To(BeTrue())
+
+			Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when notified but not yet approved", func() {
+		BeforeEach(func() {
+			fakeDelegate.ApprovalReturns(db.BuildApproval{PlanID: "some-plan"}, true, nil)
+		})
+
+		It("keeps waiting", func() {
+			notify <- struct{}{}
+
+			Eventually(fakeDelegate.ApprovalCallCount).Should(Equal(1))
+			Consistently(process.Wait()).ShouldNot(Receive())
+		})
+	})
+
+	Context("when the approval was already given before the build resumed", func() {
+		BeforeEach(func() {
+			fakeDelegate.RequestApprovalReturns(db.BuildApproval{
+				PlanID:   "some-plan",
+				Approver: "some-user",
+			}, nil)
+		})
+
+		It("succeeds without waiting or recording anything", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(succeeded()).To(BeTrue())
+			Expect(fakeDelegate.ApprovalNotifierCallCount()).To(Equal(0))
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the plan has a timeout", func() {
+		BeforeEach(func() {
+			plan.Timeout = "1h"
+		})
+
+		It("fails once the timeout elapses", func() {
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+			fakeClock.Increment(59 * time.Minute)
+			Consistently(process.Wait()).ShouldNot(Receive())
+
+			fakeClock.Increment(time.Minute)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(succeeded()).To(BeFalse())
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			approver, approved := fakeDelegate.FinishedArgsForCall(0)
+			Expect(approver).To(BeEmpty())
+			Expect(approved).To(BeFalse())
+		})
+
+		Context("when approval was requested before the build resumed", func() {
+			BeforeEach(func() {
+				fakeDelegate.RequestApprovalReturns(db.BuildApproval{
+					PlanID:      "some-plan",
+					RequestedAt: fakeClock.Now().Add(-50 * time.Minute),
+				}, nil)
+			})
+
+			It("only waits for the remainder of the timeout", func() {
+				Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+				fakeClock.Increment(10 * time.Minute)
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+				Expect(succeeded()).To(BeFalse())
+			})
+		})
+
+		Context("when the timeout is invalid", func() {
+			BeforeEach(func() {
+				plan.Timeout = "nope"
+			})
+
+			It("errors and reports the failure", func() {
+				var err error
+				Eventually(process.Wait()).Should(Receive(&err))
+				Expect(err).To(HaveOccurred())
+				Expect(fakeDelegate.FailedCallCount()).To(Equal(1))
+				Expect(fakeDelegate.RequestApprovalCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when interrupted", func() {
+		It("stops waiting and returns ErrInterrupted", func() {
+			Eventually(fakeDelegate.ApprovalNotifierCallCount).Should(Equal(1))
+
+			process.Signal(os.Interrupt)
+
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+			Expect(succeeded()).To(BeFalse())
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, approved := fakeDelegate.FinishedArgsForCall(0)
+			Expect(approved).To(BeFalse())
+		})
+	})
+
+	Context("when requesting approval fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.RequestApprovalReturns(db.BuildApproval{}, disaster)
+		})
+
+		It("errors and reports the failure", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+			Expect(fakeDelegate.FailedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FailedArgsForCall(0)).To(Equal(disaster))
+		})
+	})
+})
@@ -3,8 +3,11 @@ package exec
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"strings"
 
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
 )
 
 //go:generate counterfeiter . ArtifactSource
@@ -27,6 +30,11 @@ type ArtifactSource interface {
 	// given worker. If a volume can be found, it will be used directly. If not,
 	// `StreamTo` will be used to copy the data to the destination instead.
 	VolumeOn(worker.Worker) (worker.Volume, bool, error)
+
+	// VolumeRef refers to the volume holding this source, if there is one, so
+	// that a worker already holding it can be chosen for a container using
+	// the source.
+	VolumeRef() (worker.VolumeRef, bool)
 }
 
 //go:generate counterfeiter . ArtifactDestination
@@ -51,3 +59,36 @@ type FileNotFoundError struct {
 func (err FileNotFoundError) Error() string {
 	return fmt.Sprintf("file not found: %s", err.Path)
 }
+
+// readArtifactFile reads the file at a path in the format
+// SOURCE_NAME/FILE/PATH out of the SourceRepository.
+//
+// If the source name is missing, UnspecifiedArtifactSourceError is returned.
+// If the source cannot be found, UnknownArtifactSourceError is returned. If
+// the file cannot be found, FileNotFoundError is returned.
+func readArtifactFile(repo *SourceRepository, path string) ([]byte, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedArtifactSourceError{path}
+	}
+
+	sourceName := SourceName(segs[0])
+	filePath := segs[1]
+
+	source, found := repo.SourceFor(sourceName)
+	if !found {
+		return nil, UnknownArtifactSourceError{sourceName}
+	}
+
+	stream, err := source.StreamFile(filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, FileNotFoundError{path}
+		}
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return ioutil.ReadAll(stream)
+}
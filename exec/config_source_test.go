@@ -4,6 +4,8 @@ import (
 	"errors"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 	"github.com/concourse/baggageclaim"
@@ -547,4 +549,81 @@ run: {path: a/file}
 			})
 		})
 	})
+
+	Describe("EvaluatingConfigSource", func() {
+		var (
+			fakeConfigSource *execfakes.FakeTaskConfigSource
+			fakeVariables    *credsfakes.FakeVariables
+
+			configSource TaskConfigSource
+
+			fetchedConfig atc.TaskConfig
+			fetchErr      error
+		)
+
+		BeforeEach(func() {
+			fakeConfigSource = new(execfakes.FakeTaskConfigSource)
+			fakeVariables = new(credsfakes.FakeVariables)
+
+			configSource = EvaluatingConfigSource{
+				ConfigSource: fakeConfigSource,
+				Variables:    fakeVariables,
+			}
+		})
+
+		JustBeforeEach(func() {
+			fetchedConfig, fetchErr = configSource.FetchConfig(repo)
+		})
+
+		Context("when the config has placeholders", func() {
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{
+					Platform: "some-platform",
+					Params:   map[string]string{"SECRET": "((some-secret))"},
+					Run:      atc.TaskRunConfig{Path: "echo"},
+				}, nil)
+			})
+
+			Context("when the variables are defined", func() {
+				BeforeEach(func() {
+					fakeVariables.GetReturns("shh", true, nil)
+				})
+
+				It("returns the config with the placeholders replaced", func() {
+					Expect(fetchErr).ToNot(HaveOccurred())
+					Expect(fetchedConfig).To(Equal(atc.TaskConfig{
+						Platform: "some-platform",
+						Params:   map[string]string{"SECRET": "shh"},
+						Run:      atc.TaskRunConfig{Path: "echo"},
+					}))
+
+					Expect(fakeVariables.GetArgsForCall(0)).To(Equal("some-secret"))
+				})
+			})
+
+			Context("when the variables are not defined", func() {
+				BeforeEach(func() {
+					fakeVariables.GetReturns(nil, false, nil)
+				})
+
+				It("returns an error", func() {
+					Expect(fetchErr).To(Equal(creds.UndefinedVariablesError{
+						Vars: []string{"some-secret"},
+					}))
+				})
+			})
+		})
+
+		Context("when fetching the config fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{}, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(fetchErr).To(Equal(disaster))
+			})
+		})
+	})
 })
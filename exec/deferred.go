@@ -0,0 +1,49 @@
+package exec
+
+import "os"
+
+// Deferred constructs its StepFactory when the step runs, rather than when
+// the build's steps are constructed. It is used for steps whose config can
+// only be evaluated once earlier steps have run, e.g. because it refers to
+// variables loaded into the build.
+type Deferred struct {
+	Construct func() StepFactory
+}
+
+// Using returns a step which constructs the StepFactory and delegates to its
+// step when run.
+func (deferred Deferred) Using(prev Step, repo *SourceRepository) Step {
+	return &deferredStep{
+		construct: deferred.Construct,
+		prev:      prev,
+		repo:      repo,
+	}
+}
+
+type deferredStep struct {
+	construct func() StepFactory
+
+	prev Step
+	repo *SourceRepository
+
+	step Step
+}
+
+func (step *deferredStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	step.step = step.construct().Using(step.prev, step.repo)
+	return step.step.Run(signals, ready)
+}
+
+func (step *deferredStep) Release() {
+	if step.step != nil {
+		step.step.Release()
+	}
+}
+
+func (step *deferredStep) Result(x interface{}) bool {
+	if step.step == nil {
+		return false
+	}
+
+	return step.step.Result(x)
+}
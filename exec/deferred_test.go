@@ -0,0 +1,87 @@
+package exec_test
+
+import (
+	"errors"
+
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Deferred", func() {
+	var (
+		fakeStepFactory *execfakes.FakeStepFactory
+		fakeStep        *execfakes.FakeStep
+		constructed     int
+
+		inStep *execfakes.FakeStep
+		repo   *SourceRepository
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		fakeStepFactory = new(execfakes.FakeStepFactory)
+		fakeStep = new(execfakes.FakeStep)
+		fakeStepFactory.UsingReturns(fakeStep)
+		constructed = 0
+
+		inStep = new(execfakes.FakeStep)
+		repo = NewSourceRepository()
+
+		step = Deferred{
+			Construct: func() StepFactory {
+				constructed++
+				return fakeStepFactory
+			},
+		}.Using(inStep, repo)
+	})
+
+	It("does not construct the step until it runs", func() {
+		Expect(constructed).To(BeZero())
+		Expect(fakeStepFactory.UsingCallCount()).To(BeZero())
+	})
+
+	It("does not indicate a result before running", func() {
+		var success Success
+		Expect(step.Result(&success)).To(BeFalse())
+	})
+
+	It("does not release anything before running", func() {
+		step.Release()
+		Expect(fakeStep.ReleaseCallCount()).To(BeZero())
+	})
+
+	Context("when run", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStep.RunReturns(disaster)
+			fakeStep.ResultStub = successResult(true)
+		})
+
+		It("constructs the step with the previous step and repository, and runs it", func() {
+			Eventually(ifrit.Invoke(step).Wait()).Should(Receive(Equal(disaster)))
+
+			Expect(constructed).To(Equal(1))
+			prev, actualRepo := fakeStepFactory.UsingArgsForCall(0)
+			Expect(prev).To(Equal(inStep))
+			Expect(actualRepo).To(Equal(repo))
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+
+		It("delegates its result and release to the step", func() {
+			Eventually(ifrit.Invoke(step).Wait()).Should(Receive())
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(bool(success)).To(BeTrue())
+
+			step.Release()
+			Expect(fakeStep.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+})
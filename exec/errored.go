@@ -0,0 +1,28 @@
+package exec
+
+import "os"
+
+// Errored constructs a step that fails with the given error as soon as it
+// runs. It is used in place of a step that could not be constructed, e.g.
+// because its credentials could not be evaluated.
+type Errored struct {
+	Err error
+}
+
+// Using returns the Errored step itself.
+func (step Errored) Using(Step, *SourceRepository) Step {
+	return step
+}
+
+// Run returns the error immediately.
+func (step Errored) Run(<-chan os.Signal, chan<- struct{}) error {
+	return step.Err
+}
+
+// Release does nothing.
+func (Errored) Release() {}
+
+// Result returns false, as the step never produces a result.
+func (Errored) Result(interface{}) bool {
+	return false
+}
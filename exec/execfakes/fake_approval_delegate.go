@@ -0,0 +1,205 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/exec"
+)
+
+type FakeApprovalDelegate struct {
+	RequestApprovalStub        func() (db.BuildApproval, error)
+	requestApprovalMutex       sync.RWMutex
+	requestApprovalArgsForCall []struct{}
+	requestApprovalReturns     struct {
+		result1 db.BuildApproval
+		result2 error
+	}
+	ApprovalNotifierStub        func() (db.Notifier, error)
+	approvalNotifierMutex       sync.RWMutex
+	approvalNotifierArgsForCall []struct{}
+	approvalNotifierReturns     struct {
+		result1 db.Notifier
+		result2 error
+	}
+	ApprovalStub        func() (db.BuildApproval, bool, error)
+	approvalMutex       sync.RWMutex
+	approvalArgsForCall []struct{}
+	approvalReturns     struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	FinishedStub        func(approver string, approved bool)
+	finishedMutex       sync.RWMutex
+	finishedArgsForCall []struct {
+		approver string
+		approved bool
+	}
+	FailedStub        func(error)
+	failedMutex       sync.RWMutex
+	failedArgsForCall []struct {
+		arg1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeApprovalDelegate) RequestApproval() (db.BuildApproval, error) {
+	fake.requestApprovalMutex.Lock()
+	fake.requestApprovalArgsForCall = append(fake.requestApprovalArgsForCall, struct{}{})
+	fake.recordInvocation("RequestApproval", []interface{}{})
+	fake.requestApprovalMutex.Unlock()
+	if fake.RequestApprovalStub != nil {
+		return fake.RequestApprovalStub()
+	} else {
+		return fake.requestApprovalReturns.result1, fake.requestApprovalReturns.result2
+	}
+}
+
+func (fake *FakeApprovalDelegate) RequestApprovalCallCount() int {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return len(fake.requestApprovalArgsForCall)
+}
+
+func (fake *FakeApprovalDelegate) RequestApprovalReturns(result1 db.BuildApproval, result2 error) {
+	fake.RequestApprovalStub = nil
+	fake.requestApprovalReturns = struct {
+		result1 db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApprovalDelegate) ApprovalNotifier() (db.Notifier, error) {
+	fake.approvalNotifierMutex.Lock()
+	fake.approvalNotifierArgsForCall = append(fake.approvalNotifierArgsForCall, struct{}{})
+	fake.recordInvocation("ApprovalNotifier", []interface{}{})
+	fake.approvalNotifierMutex.Unlock()
+	if fake.ApprovalNotifierStub != nil {
+		return fake.ApprovalNotifierStub()
+	} else {
+		return fake.approvalNotifierReturns.result1, fake.approvalNotifierReturns.result2
+	}
+}
+
+func (fake *FakeApprovalDelegate) ApprovalNotifierCallCount() int {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	return len(fake.approvalNotifierArgsForCall)
+}
+
+func (fake *FakeApprovalDelegate) ApprovalNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.ApprovalNotifierStub = nil
+	fake.approvalNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApprovalDelegate) Approval() (db.BuildApproval, bool, error) {
+	fake.approvalMutex.Lock()
+	fake.approvalArgsForCall = append(fake.approvalArgsForCall, struct{}{})
+	fake.recordInvocation("Approval", []interface{}{})
+	fake.approvalMutex.Unlock()
+	if fake.ApprovalStub != nil {
+		return fake.ApprovalStub()
+	} else {
+		return fake.approvalReturns.result1, fake.approvalReturns.result2, fake.approvalReturns.result3
+	}
+}
+
+func (fake *FakeApprovalDelegate) ApprovalCallCount() int {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	return len(fake.approvalArgsForCall)
+}
+
+func (fake *FakeApprovalDelegate) ApprovalReturns(result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.ApprovalStub = nil
+	fake.approvalReturns = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeApprovalDelegate) Finished(approver string, approved bool) {
+	fake.finishedMutex.Lock()
+	fake.finishedArgsForCall = append(fake.finishedArgsForCall, struct {
+		approver string
+		approved bool
+	}{approver, approved})
+	fake.recordInvocation("Finished", []interface{}{approver, approved})
+	fake.finishedMutex.Unlock()
+	if fake.FinishedStub != nil {
+		fake.FinishedStub(approver, approved)
+	}
+}
+
+func (fake *FakeApprovalDelegate) FinishedCallCount() int {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	return len(fake.finishedArgsForCall)
+}
+
+func (fake *FakeApprovalDelegate) FinishedArgsForCall(i int) (string, bool) {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	return fake.finishedArgsForCall[i].approver, fake.finishedArgsForCall[i].approved
+}
+
+func (fake *FakeApprovalDelegate) Failed(arg1 error) {
+	fake.failedMutex.Lock()
+	fake.failedArgsForCall = append(fake.failedArgsForCall, struct {
+		arg1 error
+	}{arg1})
+	fake.recordInvocation("Failed", []interface{}{arg1})
+	fake.failedMutex.Unlock()
+	if fake.FailedStub != nil {
+		fake.FailedStub(arg1)
+	}
+}
+
+func (fake *FakeApprovalDelegate) FailedCallCount() int {
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	return len(fake.failedArgsForCall)
+}
+
+func (fake *FakeApprovalDelegate) FailedArgsForCall(i int) error {
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	return fake.failedArgsForCall[i].arg1
+}
+
+func (fake *FakeApprovalDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeApprovalDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.ApprovalDelegate = new(FakeApprovalDelegate)
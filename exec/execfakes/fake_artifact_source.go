@@ -37,6 +37,13 @@ type FakeArtifactSource struct {
 		result2 bool
 		result3 error
 	}
+	VolumeRefStub        func() (worker.VolumeRef, bool)
+	volumeRefMutex       sync.RWMutex
+	volumeRefArgsForCall []struct{}
+	volumeRefReturns     struct {
+		result1 worker.VolumeRef
+		result2 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -143,6 +150,32 @@ func (fake *FakeArtifactSource) VolumeOnReturns(result1 worker.Volume, result2 b
 	}{result1, result2, result3}
 }
 
+func (fake *FakeArtifactSource) VolumeRef() (worker.VolumeRef, bool) {
+	fake.volumeRefMutex.Lock()
+	fake.volumeRefArgsForCall = append(fake.volumeRefArgsForCall, struct{}{})
+	fake.recordInvocation("VolumeRef", []interface{}{})
+	fake.volumeRefMutex.Unlock()
+	if fake.VolumeRefStub != nil {
+		return fake.VolumeRefStub()
+	} else {
+		return fake.volumeRefReturns.result1, fake.volumeRefReturns.result2
+	}
+}
+
+func (fake *FakeArtifactSource) VolumeRefCallCount() int {
+	fake.volumeRefMutex.RLock()
+	defer fake.volumeRefMutex.RUnlock()
+	return len(fake.volumeRefArgsForCall)
+}
+
+func (fake *FakeArtifactSource) VolumeRefReturns(result1 worker.VolumeRef, result2 bool) {
+	fake.VolumeRefStub = nil
+	fake.volumeRefReturns = struct {
+		result1 worker.VolumeRef
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeArtifactSource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -152,6 +185,8 @@ func (fake *FakeArtifactSource) Invocations() map[string][][]interface{} {
 	defer fake.streamFileMutex.RUnlock()
 	fake.volumeOnMutex.RLock()
 	defer fake.volumeOnMutex.RUnlock()
+	fake.volumeRefMutex.RLock()
+	defer fake.volumeRefMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
 )
@@ -96,6 +97,39 @@ type FakeFactory struct {
 	taskReturns struct {
 		result1 exec.StepFactory
 	}
+	SetPipelineStub        func(lager.Logger, exec.SetPipelineDelegate, string, atc.SetPipelinePlan) exec.StepFactory
+	setPipelineMutex       sync.RWMutex
+	setPipelineArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.SetPipelineDelegate
+		arg3 string
+		arg4 atc.SetPipelinePlan
+	}
+	setPipelineReturns struct {
+		result1 exec.StepFactory
+	}
+	LoadVarStub        func(lager.Logger, exec.LoadVarDelegate, *creds.BuildVariables, atc.LoadVarPlan) exec.StepFactory
+	loadVarMutex       sync.RWMutex
+	loadVarArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.LoadVarDelegate
+		arg3 *creds.BuildVariables
+		arg4 atc.LoadVarPlan
+	}
+	loadVarReturns struct {
+		result1 exec.StepFactory
+	}
+	ApprovalStub        func(lager.Logger, exec.ApprovalDelegate, atc.ApprovalPlan, clock.Clock) exec.StepFactory
+	approvalMutex       sync.RWMutex
+	approvalArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.ApprovalDelegate
+		arg3 atc.ApprovalPlan
+		arg4 clock.Clock
+	}
+	approvalReturns struct {
+		result1 exec.StepFactory
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -283,6 +317,114 @@ func (fake *FakeFactory) TaskReturns(result1 exec.StepFactory) {
 	}{result1}
 }
 
+func (fake *FakeFactory) SetPipeline(arg1 lager.Logger, arg2 exec.SetPipelineDelegate, arg3 string, arg4 atc.SetPipelinePlan) exec.StepFactory {
+	fake.setPipelineMutex.Lock()
+	fake.setPipelineArgsForCall = append(fake.setPipelineArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.SetPipelineDelegate
+		arg3 string
+		arg4 atc.SetPipelinePlan
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SetPipeline", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setPipelineMutex.Unlock()
+	if fake.SetPipelineStub != nil {
+		return fake.SetPipelineStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.setPipelineReturns.result1
+	}
+}
+
+func (fake *FakeFactory) SetPipelineCallCount() int {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return len(fake.setPipelineArgsForCall)
+}
+
+func (fake *FakeFactory) SetPipelineArgsForCall(i int) (lager.Logger, exec.SetPipelineDelegate, string, atc.SetPipelinePlan) {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return fake.setPipelineArgsForCall[i].arg1, fake.setPipelineArgsForCall[i].arg2, fake.setPipelineArgsForCall[i].arg3, fake.setPipelineArgsForCall[i].arg4
+}
+
+func (fake *FakeFactory) SetPipelineReturns(result1 exec.StepFactory) {
+	fake.SetPipelineStub = nil
+	fake.setPipelineReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) LoadVar(arg1 lager.Logger, arg2 exec.LoadVarDelegate, arg3 *creds.BuildVariables, arg4 atc.LoadVarPlan) exec.StepFactory {
+	fake.loadVarMutex.Lock()
+	fake.loadVarArgsForCall = append(fake.loadVarArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.LoadVarDelegate
+		arg3 *creds.BuildVariables
+		arg4 atc.LoadVarPlan
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("LoadVar", []interface{}{arg1, arg2, arg3, arg4})
+	fake.loadVarMutex.Unlock()
+	if fake.LoadVarStub != nil {
+		return fake.LoadVarStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.loadVarReturns.result1
+	}
+}
+
+func (fake *FakeFactory) LoadVarCallCount() int {
+	fake.loadVarMutex.RLock()
+	defer fake.loadVarMutex.RUnlock()
+	return len(fake.loadVarArgsForCall)
+}
+
+func (fake *FakeFactory) LoadVarArgsForCall(i int) (lager.Logger, exec.LoadVarDelegate, *creds.BuildVariables, atc.LoadVarPlan) {
+	fake.loadVarMutex.RLock()
+	defer fake.loadVarMutex.RUnlock()
+	return fake.loadVarArgsForCall[i].arg1, fake.loadVarArgsForCall[i].arg2, fake.loadVarArgsForCall[i].arg3, fake.loadVarArgsForCall[i].arg4
+}
+
+func (fake *FakeFactory) LoadVarReturns(result1 exec.StepFactory) {
+	fake.LoadVarStub = nil
+	fake.loadVarReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) Approval(arg1 lager.Logger, arg2 exec.ApprovalDelegate, arg3 atc.ApprovalPlan, arg4 clock.Clock) exec.StepFactory {
+	fake.approvalMutex.Lock()
+	fake.approvalArgsForCall = append(fake.approvalArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.ApprovalDelegate
+		arg3 atc.ApprovalPlan
+		arg4 clock.Clock
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("Approval", []interface{}{arg1, arg2, arg3, arg4})
+	fake.approvalMutex.Unlock()
+	if fake.ApprovalStub != nil {
+		return fake.ApprovalStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.approvalReturns.result1
+	}
+}
+
+func (fake *FakeFactory) ApprovalCallCount() int {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	return len(fake.approvalArgsForCall)
+}
+
+func (fake *FakeFactory) ApprovalArgsForCall(i int) (lager.Logger, exec.ApprovalDelegate, atc.ApprovalPlan, clock.Clock) {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	return fake.approvalArgsForCall[i].arg1, fake.approvalArgsForCall[i].arg2, fake.approvalArgsForCall[i].arg3, fake.approvalArgsForCall[i].arg4
+}
+
+func (fake *FakeFactory) ApprovalReturns(result1 exec.StepFactory) {
+	fake.ApprovalStub = nil
+	fake.approvalReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
 func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -294,6 +436,12 @@ func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	defer fake.dependentGetMutex.RUnlock()
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	fake.loadVarMutex.RLock()
+	defer fake.loadVarMutex.RUnlock()
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -24,6 +24,11 @@ type FakeTaskDelegate struct {
 	finishedArgsForCall []struct {
 		arg1 exec.ExitStatus
 	}
+	FinishedOutOfMemoryStub        func(exec.ExitStatus)
+	finishedOutOfMemoryMutex       sync.RWMutex
+	finishedOutOfMemoryArgsForCall []struct {
+		arg1 exec.ExitStatus
+	}
 	FailedStub        func(error)
 	failedMutex       sync.RWMutex
 	failedArgsForCall []struct {
@@ -117,6 +122,30 @@ func (fake *FakeTaskDelegate) FinishedArgsForCall(i int) exec.ExitStatus {
 	return fake.finishedArgsForCall[i].arg1
 }
 
+func (fake *FakeTaskDelegate) FinishedOutOfMemory(arg1 exec.ExitStatus) {
+	fake.finishedOutOfMemoryMutex.Lock()
+	fake.finishedOutOfMemoryArgsForCall = append(fake.finishedOutOfMemoryArgsForCall, struct {
+		arg1 exec.ExitStatus
+	}{arg1})
+	fake.recordInvocation("FinishedOutOfMemory", []interface{}{arg1})
+	fake.finishedOutOfMemoryMutex.Unlock()
+	if fake.FinishedOutOfMemoryStub != nil {
+		fake.FinishedOutOfMemoryStub(arg1)
+	}
+}
+
+func (fake *FakeTaskDelegate) FinishedOutOfMemoryCallCount() int {
+	fake.finishedOutOfMemoryMutex.RLock()
+	defer fake.finishedOutOfMemoryMutex.RUnlock()
+	return len(fake.finishedOutOfMemoryArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) FinishedOutOfMemoryArgsForCall(i int) exec.ExitStatus {
+	fake.finishedOutOfMemoryMutex.RLock()
+	defer fake.finishedOutOfMemoryMutex.RUnlock()
+	return fake.finishedOutOfMemoryArgsForCall[i].arg1
+}
+
 func (fake *FakeTaskDelegate) Failed(arg1 error) {
 	fake.failedMutex.Lock()
 	fake.failedArgsForCall = append(fake.failedArgsForCall, struct {
@@ -233,6 +262,8 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.startedMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.finishedOutOfMemoryMutex.RLock()
+	defer fake.finishedOutOfMemoryMutex.RUnlock()
 	fake.failedMutex.RLock()
 	defer fake.failedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
@@ -7,6 +7,8 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 )
 
@@ -85,6 +87,30 @@ type Factory interface {
 		time.Duration,
 		time.Duration,
 	) StepFactory
+
+	// SetPipeline constructs a SetPipelineStep factory.
+	SetPipeline(
+		lager.Logger,
+		SetPipelineDelegate,
+		string,
+		atc.SetPipelinePlan,
+	) StepFactory
+
+	// LoadVar constructs a LoadVarStep factory.
+	LoadVar(
+		lager.Logger,
+		LoadVarDelegate,
+		*creds.BuildVariables,
+		atc.LoadVarPlan,
+	) StepFactory
+
+	// Approval constructs an ApprovalStep factory.
+	Approval(
+		lager.Logger,
+		ApprovalDelegate,
+		atc.ApprovalPlan,
+		clock.Clock,
+	) StepFactory
 }
 
 // StepMetadata is used to inject metadata to make available to the step when
@@ -102,6 +128,11 @@ type TaskDelegate interface {
 	Started()
 
 	Finished(ExitStatus)
+
+	// FinishedOutOfMemory is called instead of Finished when the task's
+	// container was killed for exceeding its memory limit.
+	FinishedOutOfMemory(ExitStatus)
+
 	Failed(error)
 
 	ImageVersionDetermined(worker.VolumeIdentifier) error
@@ -110,6 +141,46 @@ type TaskDelegate interface {
 	Stderr() io.Writer
 }
 
+//go:generate counterfeiter . SetPipelineDelegate
+
+// SetPipelineDelegate is used to record events related to a SetPipelineStep's
+// runtime behavior.
+type SetPipelineDelegate interface {
+	Initializing()
+
+	Finished(ExitStatus)
+	Failed(error)
+
+	Stdout() io.Writer
+	Stderr() io.Writer
+}
+
+//go:generate counterfeiter . LoadVarDelegate
+
+// LoadVarDelegate is used to record events related to a LoadVarStep's runtime
+// behavior.
+type LoadVarDelegate interface {
+	Initializing()
+
+	Finished(ExitStatus)
+	Failed(error)
+
+	Stdout() io.Writer
+}
+
+//go:generate counterfeiter . ApprovalDelegate
+
+// ApprovalDelegate is used to request approval for an ApprovalStep and to
+// record events related to its runtime behavior.
+type ApprovalDelegate interface {
+	RequestApproval() (db.BuildApproval, error)
+	ApprovalNotifier() (db.Notifier, error)
+	Approval() (db.BuildApproval, bool, error)
+
+	Finished(approver string, approved bool)
+	Failed(error)
+}
+
 // ResourceDelegate is used to record events related to a resource's runtime
 // behavior.
 type ResourceDelegate interface {
@@ -10,6 +10,8 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -18,6 +20,10 @@ type gardenFactory struct {
 	workerClient    worker.Client
 	tracker         resource.Tracker
 	resourceFetcher resource.Fetcher
+	teamDBFactory   db.TeamDBFactory
+
+	defaultLimits atc.ContainerLimits
+	maxLimits     atc.ContainerLimits
 }
 
 //go:generate counterfeiter . TrackerFactory
@@ -26,15 +32,28 @@ type TrackerFactory interface {
 	TrackerFor(client worker.Client) resource.Tracker
 }
 
+// NewGardenFactory constructs a Factory whose steps run in containers on the
+// workers.
+//
+// The default limits apply to any task which does not configure its container
+// limits, and the max limits cap whatever a task configures. A zero value for
+// any limit means no limit.
 func NewGardenFactory(
 	workerClient worker.Client,
 	tracker resource.Tracker,
 	resourceFetcher resource.Fetcher,
+	teamDBFactory db.TeamDBFactory,
+	defaultLimits atc.ContainerLimits,
+	maxLimits atc.ContainerLimits,
 ) Factory {
 	return &gardenFactory{
 		workerClient:    workerClient,
 		tracker:         tracker,
 		resourceFetcher: resourceFetcher,
+		teamDBFactory:   teamDBFactory,
+
+		defaultLimits: defaultLimits,
+		maxLimits:     maxLimits,
 	}
 }
 
@@ -193,6 +212,50 @@ func (factory *gardenFactory) Task(
 		clock,
 		containerSuccessTTL,
 		containerFailureTTL,
+		factory.defaultLimits,
+		factory.maxLimits,
+	)
+}
+
+func (factory *gardenFactory) SetPipeline(
+	logger lager.Logger,
+	delegate SetPipelineDelegate,
+	teamName string,
+	plan atc.SetPipelinePlan,
+) StepFactory {
+	return newSetPipelineStep(
+		logger,
+		delegate,
+		factory.teamDBFactory.GetTeamDB(teamName),
+		plan,
+	)
+}
+
+func (factory *gardenFactory) LoadVar(
+	logger lager.Logger,
+	delegate LoadVarDelegate,
+	variables *creds.BuildVariables,
+	plan atc.LoadVarPlan,
+) StepFactory {
+	return newLoadVarStep(
+		logger,
+		delegate,
+		variables,
+		plan,
+	)
+}
+
+func (factory *gardenFactory) Approval(
+	logger lager.Logger,
+	delegate ApprovalDelegate,
+	plan atc.ApprovalPlan,
+	clock clock.Clock,
+) StepFactory {
+	return newApprovalStep(
+		logger,
+		delegate,
+		plan,
+		clock,
 	)
 }
 
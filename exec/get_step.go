@@ -201,6 +201,21 @@ func (step *GetStep) VolumeOn(worker worker.Worker) (worker.Volume, bool, error)
 	return step.cacheIdentifier.FindOn(step.logger.Session("volume-on"), worker)
 }
 
+// VolumeRef refers to any cache for the GetStep's resource and version.
+func (step *GetStep) VolumeRef() (worker.VolumeRef, bool) {
+	cache := step.cacheIdentifier.VolumeIdentifier().ResourceCache
+	if cache == nil {
+		return worker.VolumeRef{}, false
+	}
+
+	return worker.VolumeRef{
+		Strategy: worker.ResourceCacheStrategy{
+			ResourceHash:    cache.ResourceHash,
+			ResourceVersion: cache.ResourceVersion,
+		},
+	}, true
+}
+
 // StreamTo streams the resource's data to the destination.
 func (step *GetStep) StreamTo(destination ArtifactDestination) error {
 	out, err := step.fetchSource.VersionedSource().StreamOut(".")
@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 	"github.com/concourse/atc/resource"
@@ -118,7 +119,7 @@ var _ = Describe("Get", func() {
 		fakeVersionedSource = new(rfakes.FakeVersionedSource)
 		fakeFetchSource.VersionedSourceReturns(fakeVersionedSource)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, new(dbfakes.FakeTeamDBFactory), atc.ContainerLimits{}, atc.ContainerLimits{})
 	})
 
 	JustBeforeEach(func() {
@@ -387,6 +388,25 @@ var _ = Describe("Get", func() {
 		})
 	})
 
+	Context("when fetching the resource is interrupted", func() {
+		BeforeEach(func() {
+			fakeResourceFetcher.FetchReturns(nil, resource.ErrInterrupted)
+		})
+
+		It("exits with ErrInterrupted", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+		})
+
+		It("invokes the delegate's Failed callback without completing", func() {
+			Eventually(process.Wait()).Should(Receive())
+
+			Expect(getDelegate.CompletedCallCount()).To(BeZero())
+
+			Expect(getDelegate.FailedCallCount()).To(Equal(1))
+			Expect(getDelegate.FailedArgsForCall(0)).To(Equal(ErrInterrupted))
+		})
+	})
+
 	Context("when the tracker fails to initialize the resource", func() {
 		disaster := errors.New("nope")
 
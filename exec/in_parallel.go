@@ -0,0 +1,202 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// failedFast is sent to the steps still running in a fail_fast InParallel
+// step once one of them fails. They are interrupted as though the build was
+// aborted, but do not run their on_abort hooks, as it was not.
+var failedFast os.Signal = failedFastSignal{}
+
+type failedFastSignal struct{}
+
+func (failedFastSignal) String() string { return "failed fast" }
+func (failedFastSignal) Signal()        {}
+
+// InParallel constructs a Step that will run its steps in parallel, running
+// at most Limit of them at once. A Limit of zero runs them all at once.
+//
+// If FailFast is set, the first step to fail or error interrupts the steps
+// that are still running, and the steps that have not yet started are
+// skipped.
+type InParallel struct {
+	Steps    []StepFactory
+	Limit    int
+	FailFast bool
+}
+
+// Using delegates to each StepFactory and returns an InParallelStep.
+func (p InParallel) Using(prev Step, repo *SourceRepository) Step {
+	steps := []Step{}
+
+	for _, step := range p.Steps {
+		steps = append(steps, step.Using(prev, repo))
+	}
+
+	return &InParallelStep{
+		steps:    steps,
+		limit:    p.Limit,
+		failFast: p.FailFast,
+		started:  make([]bool, len(steps)),
+	}
+}
+
+// InParallelStep is a step of steps to run in parallel.
+type InParallelStep struct {
+	steps    []Step
+	limit    int
+	failFast bool
+
+	started []bool
+}
+
+type inParallelExit struct {
+	index int
+	err   error
+}
+
+// Run executes the steps in parallel, starting each one in order as soon as
+// fewer than the limit are running. It indicates that it's ready
+// immediately, as steps past the limit may not start until others finish,
+// and propagates any signal received to all running steps.
+//
+// After all started steps finish, their errors (if any) will be aggregated
+// and returned as a single error. Steps interrupted because a sibling failed
+// do not contribute errors.
+func (step *InParallelStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	limit := step.limit
+	if limit <= 0 || limit > len(step.steps) {
+		limit = len(step.steps)
+	}
+
+	exited := make(chan inParallelExit, len(step.steps))
+	running := map[int]ifrit.Process{}
+	next := 0
+
+	startSteps := func() {
+		for next < len(step.steps) && len(running) < limit {
+			index := next
+			process := ifrit.Background(step.steps[index])
+
+			step.started[index] = true
+			running[index] = process
+
+			go func() {
+				exited <- inParallelExit{index: index, err: <-process.Wait()}
+			}()
+
+			next++
+		}
+	}
+
+	stopStarting := func(sig os.Signal) {
+		next = len(step.steps)
+
+		for _, process := range running {
+			process.Signal(sig)
+		}
+	}
+
+	var errorMessages []string
+	interrupted := false
+	stoppedFast := false
+
+	startSteps()
+
+	for len(running) > 0 {
+		select {
+		case sig := <-signals:
+			interrupted = true
+			stopStarting(sig)
+
+		case exit := <-exited:
+			delete(running, exit.index)
+
+			if exit.err != nil && !(stoppedFast && exit.err == ErrInterrupted) {
+				errorMessages = append(errorMessages, exit.err.Error())
+			}
+
+			if step.failFast && !stoppedFast && !interrupted && step.failed(exit) {
+				stoppedFast = true
+				stopStarting(failedFast)
+			}
+
+			startSteps()
+		}
+	}
+
+	if interrupted {
+		return ErrInterrupted
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("steps failed:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+func (step *InParallelStep) failed(exit inParallelExit) bool {
+	if exit.err != nil {
+		return true
+	}
+
+	var succeeded Success
+	return step.steps[exit.index].Result(&succeeded) && !bool(succeeded)
+}
+
+// Release iterates over the steps and Releases them individually.
+func (step *InParallelStep) Release() {
+	for _, src := range step.steps {
+		src.Release()
+	}
+}
+
+// Result indicates Success as true if all of the steps indicate Success as
+// true, or if there were no steps at all. Steps that were never started
+// count as failed. If none of the started steps can indicate Success, it
+// will return false and not indicate success itself.
+//
+// All other result types are ignored, and Result will return false.
+func (step *InParallelStep) Result(x interface{}) bool {
+	if success, ok := x.(*Success); ok {
+		if len(step.steps) == 0 {
+			*success = Success(true)
+			return true
+		}
+
+		succeeded := true
+		anyIndicated := false
+		for i, src := range step.steps {
+			if !step.started[i] {
+				succeeded = false
+				continue
+			}
+
+			var s Success
+			if !src.Result(&s) {
+				continue
+			}
+
+			anyIndicated = true
+			succeeded = succeeded && bool(s)
+		}
+
+		if !anyIndicated {
+			return false
+		}
+
+		*success = Success(succeeded)
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,374 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("InParallel", func() {
+	var (
+		fakeStepA *execfakes.FakeStepFactory
+		fakeStepB *execfakes.FakeStepFactory
+		fakeStepC *execfakes.FakeStepFactory
+
+		inParallel InParallel
+
+		inStep *execfakes.FakeStep
+		repo   *SourceRepository
+
+		outStepA *execfakes.FakeStep
+		outStepB *execfakes.FakeStep
+		outStepC *execfakes.FakeStep
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeStepA = new(execfakes.FakeStepFactory)
+		fakeStepB = new(execfakes.FakeStepFactory)
+		fakeStepC = new(execfakes.FakeStepFactory)
+
+		inParallel = InParallel{
+			Steps: []StepFactory{
+				fakeStepA,
+				fakeStepB,
+				fakeStepC,
+			},
+		}
+
+		inStep = new(execfakes.FakeStep)
+		repo = NewSourceRepository()
+
+		outStepA = new(execfakes.FakeStep)
+		fakeStepA.UsingReturns(outStepA)
+
+		outStepB = new(execfakes.FakeStep)
+		fakeStepB.UsingReturns(outStepB)
+
+		outStepC = new(execfakes.FakeStep)
+		fakeStepC.UsingReturns(outStepC)
+	})
+
+	JustBeforeEach(func() {
+		step = inParallel.Using(inStep, repo)
+		process = ifrit.Invoke(step)
+	})
+
+	It("uses the input source for all steps", func() {
+		for _, fakeStep := range []*execfakes.FakeStepFactory{fakeStepA, fakeStepB, fakeStepC} {
+			Expect(fakeStep.UsingCallCount()).To(Equal(1))
+			step, repo := fakeStep.UsingArgsForCall(0)
+			Expect(step).To(Equal(inStep))
+			Expect(repo).To(Equal(repo))
+		}
+	})
+
+	It("exits successfully", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	Describe("executing each step", func() {
+		BeforeEach(func() {
+			wg := new(sync.WaitGroup)
+			wg.Add(3)
+
+			runStub := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				wg.Done()
+				wg.Wait()
+				close(ready)
+				return nil
+			}
+
+			outStepA.RunStub = runStub
+			outStepB.RunStub = runStub
+			outStepC.RunStub = runStub
+		})
+
+		It("happens concurrently", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(outStepA.RunCallCount()).To(Equal(1))
+			Expect(outStepB.RunCallCount()).To(Equal(1))
+			Expect(outStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("with a limit", func() {
+		var finishA chan struct{}
+		var finishB chan struct{}
+
+		BeforeEach(func() {
+			inParallel.Limit = 2
+
+			finishA = make(chan struct{})
+			finishB = make(chan struct{})
+
+			outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				<-finishA
+				return nil
+			}
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				<-finishB
+				return nil
+			}
+		})
+
+		It("runs at most that many steps at once, in order", func() {
+			Eventually(outStepA.RunCallCount).Should(Equal(1))
+			Eventually(outStepB.RunCallCount).Should(Equal(1))
+			Consistently(outStepC.RunCallCount).Should(BeZero())
+
+			close(finishB)
+
+			Eventually(outStepC.RunCallCount).Should(Equal(1))
+
+			close(finishA)
+
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Describe("signalling", func() {
+		var receivedSignals chan os.Signal
+		var actuallyExit chan struct{}
+
+		BeforeEach(func() {
+			receivedSignals = make(chan os.Signal, 3)
+			actuallyExit = make(chan struct{})
+
+			runStub := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				<-actuallyExit
+				return ErrInterrupted
+			}
+
+			outStepA.RunStub = runStub
+			outStepB.RunStub = runStub
+			outStepC.RunStub = runStub
+		})
+
+		It("forwards the signal to the running steps and returns ErrInterrupted", func() {
+			process.Signal(os.Interrupt)
+
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Consistently(process.Wait()).ShouldNot(Receive())
+			close(actuallyExit)
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+		})
+
+		Context("with a limit", func() {
+			BeforeEach(func() {
+				inParallel.Limit = 2
+			})
+
+			It("does not start the remaining steps", func() {
+				Eventually(outStepB.RunCallCount).Should(Equal(1))
+
+				process.Signal(os.Interrupt)
+				close(actuallyExit)
+
+				Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when steps error", func() {
+		disasterA := errors.New("nope A")
+		disasterB := errors.New("nope B")
+
+		BeforeEach(func() {
+			outStepA.RunReturns(disasterA)
+			outStepB.RunReturns(disasterB)
+		})
+
+		It("exits with an error including each message", func() {
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Expect(err.Error()).To(ContainSubstring("nope A"))
+			Expect(err.Error()).To(ContainSubstring("nope B"))
+		})
+
+		It("still runs every step", func() {
+			Eventually(process.Wait()).Should(Receive())
+			Expect(outStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("with fail_fast", func() {
+		var receivedSignals chan os.Signal
+
+		BeforeEach(func() {
+			inParallel.FailFast = true
+			inParallel.Limit = 2
+
+			receivedSignals = make(chan os.Signal, 1)
+
+			outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				receivedSignals <- <-signals
+				return ErrInterrupted
+			}
+		})
+
+		Context("when a step errors", func() {
+			BeforeEach(func() {
+				outStepB.RunReturns(errors.New("nope B"))
+			})
+
+			It("interrupts the running steps and skips the rest", func() {
+				Eventually(receivedSignals).Should(Receive(Not(Equal(os.Interrupt))))
+
+				var err error
+				Eventually(process.Wait()).Should(Receive(&err))
+				Expect(err.Error()).To(ContainSubstring("nope B"))
+				Expect(err.Error()).NotTo(ContainSubstring(ErrInterrupted.Error()))
+
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when a step fails", func() {
+			BeforeEach(func() {
+				outStepB.ResultStub = successResult(false)
+			})
+
+			It("interrupts the running steps and skips the rest", func() {
+				Eventually(receivedSignals).Should(Receive(Not(Equal(os.Interrupt))))
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+
+			It("does not indicate success", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(bool(success)).To(BeFalse())
+			})
+		})
+
+		Context("when an interrupted step has an on_abort hook", func() {
+			var abortHook *execfakes.FakeStep
+
+			BeforeEach(func() {
+				abortHook = new(execfakes.FakeStep)
+
+				abortHookFactory := new(execfakes.FakeStepFactory)
+				abortHookFactory.UsingReturns(abortHook)
+
+				inParallel.Steps[0] = OnAbort(fakeStepA, abortHookFactory)
+
+				outStepB.RunReturns(errors.New("nope B"))
+			})
+
+			It("does not run the hook, as the build was not aborted", func() {
+				Eventually(receivedSignals).Should(Receive())
+
+				var err error
+				Eventually(process.Wait()).Should(Receive(&err))
+				Expect(err.Error()).To(ContainSubstring("nope B"))
+				Expect(err.Error()).NotTo(ContainSubstring(ErrInterrupted.Error()))
+
+				Expect(abortHook.RunCallCount()).To(BeZero())
+			})
+
+			Context("when the build is aborted instead", func() {
+				BeforeEach(func() {
+					outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+						<-signals
+						return ErrInterrupted
+					}
+				})
+
+				It("runs the hook", func() {
+					process.Signal(os.Interrupt)
+
+					Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+					Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+					Expect(abortHook.RunCallCount()).To(Equal(1))
+				})
+			})
+		})
+	})
+
+	Describe("releasing", func() {
+		It("releases all steps", func() {
+			Eventually(process.Wait()).Should(Receive())
+
+			step.Release()
+			Expect(outStepA.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepB.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepC.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("getting a result", func() {
+		Context("when all steps are successful", func() {
+			BeforeEach(func() {
+				outStepA.ResultStub = successResult(true)
+				outStepB.ResultStub = successResult(true)
+				outStepC.ResultStub = successResult(true)
+			})
+
+			It("indicates success", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(bool(success)).To(BeTrue())
+			})
+		})
+
+		Context("when a step fails", func() {
+			BeforeEach(func() {
+				outStepA.ResultStub = successResult(true)
+				outStepB.ResultStub = successResult(false)
+				outStepC.ResultStub = successResult(true)
+			})
+
+			It("does not indicate success", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(bool(success)).To(BeFalse())
+			})
+		})
+
+		Context("when no steps indicate success", func() {
+			It("returns false", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				var success Success
+				Expect(step.Result(&success)).To(BeFalse())
+			})
+		})
+
+		Context("when there are no steps", func() {
+			BeforeEach(func() {
+				inParallel = InParallel{}
+			})
+
+			It("indicates success", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(bool(success)).To(BeTrue())
+			})
+		})
+	})
+})
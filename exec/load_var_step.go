@@ -0,0 +1,185 @@
+package exec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"gopkg.in/yaml.v2"
+)
+
+// LoadVarStep loads a value from a file in the SourceRepository into the
+// build's variables, so that later steps can refer to it as ((.:name)).
+type LoadVarStep struct {
+	logger    lager.Logger
+	delegate  LoadVarDelegate
+	variables *creds.BuildVariables
+	plan      atc.LoadVarPlan
+
+	repository *SourceRepository
+
+	succeeded bool
+}
+
+func newLoadVarStep(
+	logger lager.Logger,
+	delegate LoadVarDelegate,
+	variables *creds.BuildVariables,
+	plan atc.LoadVarPlan,
+) LoadVarStep {
+	return LoadVarStep{
+		logger:    logger,
+		delegate:  delegate,
+		variables: variables,
+		plan:      plan,
+	}
+}
+
+// Using finishes construction of the LoadVarStep and returns a *LoadVarStep.
+// If the *LoadVarStep errors, its error is reported to the delegate.
+func (step LoadVarStep) Using(prev Step, repo *SourceRepository) Step {
+	step.repository = repo
+
+	return errorReporter{
+		Step:          &step,
+		ReportFailure: step.delegate.Failed,
+	}
+}
+
+// Run reads the file out of the SourceRepository and parses it according to
+// the plan's format. If no format is given, it is determined by the file's
+// extension: .json files are parsed as JSON, .yml and .yaml files as YAML,
+// and anything else is loaded as a raw string with surrounding whitespace
+// trimmed.
+func (step *LoadVarStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	step.delegate.Initializing()
+
+	close(ready)
+
+	content, err := readArtifactFile(step.repository, step.plan.File)
+	if err != nil {
+		return err
+	}
+
+	format := step.format()
+
+	val, err := parseVar(content, format)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s as %s: %s", step.plan.File, format, err)
+	}
+
+	step.variables.SetLocalVar(step.plan.Name, val)
+
+	fmt.Fprintf(step.delegate.Stdout(), "loaded %s as ((%s%s)) (%s)\n", step.plan.File, creds.LocalVarPrefix, step.plan.Name, format)
+
+	step.succeeded = true
+	step.delegate.Finished(ExitStatus(0))
+
+	return nil
+}
+
+// Release does nothing, as the step has no resources to release.
+func (step *LoadVarStep) Release() {}
+
+// Result indicates Success as true if the var was loaded.
+//
+// All other types are ignored.
+func (step *LoadVarStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.succeeded)
+		return true
+
+	default:
+		return false
+	}
+}
+
+func (step *LoadVarStep) format() string {
+	if step.plan.Format != "" {
+		return step.plan.Format
+	}
+
+	switch filepath.Ext(step.plan.File) {
+	case ".json":
+		return "json"
+	case ".yml", ".yaml":
+		return "yaml"
+	default:
+		return "raw"
+	}
+}
+
+func parseVar(content []byte, format string) (interface{}, error) {
+	switch format {
+	case "json":
+		var val interface{}
+		err := json.Unmarshal(content, &val)
+		if err != nil {
+			return nil, err
+		}
+
+		return val, nil
+
+	case "yaml":
+		var val interface{}
+		err := yaml.Unmarshal(content, &val)
+		if err != nil {
+			return nil, err
+		}
+
+		return sanitizeYAML(val)
+
+	case "raw":
+		return strings.TrimSpace(string(content)), nil
+
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+}
+
+// sanitizeYAML converts the maps produced when unmarshaling YAML into maps
+// with string keys, as they would be if unmarshaled from JSON.
+func sanitizeYAML(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		sanitized := make(map[string]interface{}, len(v))
+		for key, sub := range v {
+			str, ok := key.(string)
+			if !ok {
+				return nil, errors.New("non-string key")
+			}
+
+			sanitizedSub, err := sanitizeYAML(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			sanitized[str] = sanitizedSub
+		}
+
+		return sanitized, nil
+
+	case []interface{}:
+		sanitized := make([]interface{}, len(v))
+		for i, sub := range v {
+			sanitizedSub, err := sanitizeYAML(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			sanitized[i] = sanitizedSub
+		}
+
+		return sanitized, nil
+
+	default:
+		return val, nil
+	}
+}
@@ -0,0 +1,172 @@
+package exec_test
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/baggageclaim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("LoadVarStep", func() {
+	var (
+		fakeDelegate *execfakes.FakeLoadVarDelegate
+		fakeSource   *execfakes.FakeArtifactSource
+		variables    *creds.BuildVariables
+
+		stdoutBuf *gbytes.Buffer
+
+		factory Factory
+		plan    atc.LoadVarPlan
+		repo    *SourceRepository
+		content string
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeDelegate = new(execfakes.FakeLoadVarDelegate)
+		stdoutBuf = gbytes.NewBuffer()
+		fakeDelegate.StdoutReturns(stdoutBuf)
+
+		variables = creds.NewBuildVariables(new(credsfakes.FakeVariables))
+
+		fakeSource = new(execfakes.FakeArtifactSource)
+		fakeSource.StreamFileStub = func(string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(content)), nil
+		}
+
+		repo = NewSourceRepository()
+		repo.RegisterSource("some-artifact", fakeSource)
+
+		factory = NewGardenFactory(nil, nil, nil, new(dbfakes.FakeTeamDBFactory), atc.ContainerLimits{}, atc.ContainerLimits{})
+
+		content = "1.2.3\n"
+		plan = atc.LoadVarPlan{
+			Name: "some-var",
+			File: "some-artifact/version",
+		}
+	})
+
+	JustBeforeEach(func() {
+		step = factory.LoadVar(lagertest.NewTestLogger("test"), fakeDelegate, variables, plan).Using(nil, repo)
+		process = ifrit.Invoke(step)
+	})
+
+	loadedVar := func() interface{} {
+		val, found, err := variables.Get(".:some-var")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return val
+	}
+
+	It("reads the file from the artifact", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+		Expect(fakeSource.StreamFileArgsForCall(0)).To(Equal("version"))
+	})
+
+	It("loads the trimmed contents as a raw string by default", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+		Expect(loadedVar()).To(Equal("1.2.3"))
+	})
+
+	It("says what it loaded without showing the value", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+		Expect(stdoutBuf).To(gbytes.Say(`loaded some-artifact/version as \(\(\.:some-var\)\) \(raw\)`))
+		Expect(string(stdoutBuf.Contents())).NotTo(ContainSubstring("1.2.3"))
+	})
+
+	It("finishes successfully", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+
+		Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(0)))
+
+		var success Success
+		Expect(step.Result(&success)).To(BeTrue())
+		Expect(bool(success)).To(BeTrue())
+	})
+
+	Context("when the file has a .json extension", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/version.json"
+			content = `{"version": "1.2.3", "build": 4}`
+		})
+
+		It("parses it as JSON", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(loadedVar()).To(Equal(map[string]interface{}{
+				"version": "1.2.3",
+				"build":   float64(4),
+			}))
+		})
+	})
+
+	Context("when the format is yaml", func() {
+		BeforeEach(func() {
+			plan.Format = "yaml"
+			content = "version: 1.2.3\nhosts: [a, b]\n"
+		})
+
+		It("parses it as YAML with string keys", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(loadedVar()).To(Equal(map[string]interface{}{
+				"version": "1.2.3",
+				"hosts":   []interface{}{"a", "b"},
+			}))
+		})
+	})
+
+	Context("when the format is raw but the file is JSON", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/version.json"
+			plan.Format = "raw"
+			content = `{"version": "1.2.3"}`
+		})
+
+		It("loads it as a string", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(loadedVar()).To(Equal(`{"version": "1.2.3"}`))
+		})
+	})
+
+	Context("when the file cannot be parsed", func() {
+		BeforeEach(func() {
+			plan.Format = "json"
+			content = "not json"
+		})
+
+		It("errors and reports the failure", func() {
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Expect(err.Error()).To(ContainSubstring("failed to parse some-artifact/version as json"))
+			Expect(fakeDelegate.FailedArgsForCall(0)).To(Equal(err))
+
+			_, found, _ := variables.Get(".:some-var")
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the file is not found", func() {
+		BeforeEach(func() {
+			fakeSource.StreamFileStub = nil
+			fakeSource.StreamFileReturns(nil, baggageclaim.ErrFileNotFound)
+		})
+
+		It("returns a FileNotFoundError", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(FileNotFoundError{"some-artifact/version"})))
+		})
+	})
+})
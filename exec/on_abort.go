@@ -0,0 +1,108 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// OnAbortStep will run one step, and then a second step if the first step is
+// interrupted because the build was aborted.
+type OnAbortStep struct {
+	stepFactory  StepFactory
+	abortFactory StepFactory
+
+	prev Step
+	repo *SourceRepository
+
+	step    Step
+	onAbort Step
+}
+
+// OnAbort constructs an OnAbortStep factory.
+func OnAbort(firstStep StepFactory, secondStep StepFactory) OnAbortStep {
+	return OnAbortStep{
+		stepFactory:  firstStep,
+		abortFactory: secondStep,
+	}
+}
+
+// Using constructs an *OnAbortStep.
+func (o OnAbortStep) Using(prev Step, repo *SourceRepository) Step {
+	o.repo = repo
+	o.prev = prev
+
+	o.step = o.stepFactory.Using(o.prev, o.repo)
+	return &o
+}
+
+// Run will call Run on the first step and wait for it to complete. OnAbortStep
+// is ready as soon as the first step is ready.
+//
+// If the first step returns ErrInterrupted because of a signal it was sent,
+// the second step is executed and ErrInterrupted is returned regardless of
+// how the second step went, so that the interruption keeps propagating; the
+// second step reports its own errors. Otherwise the first step's result is
+// returned as-is.
+//
+// Steps stopped because a sibling failed in a fail_fast in_parallel step were
+// not aborted, so the second step is not executed for them.
+func (o *OnAbortStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	process := ifrit.Background(o.step)
+	stepReady := process.Ready()
+	stepExited := process.Wait()
+
+	var stepRunErr error
+	aborted := false
+
+dance:
+	for {
+		select {
+		case <-stepReady:
+			close(ready)
+			stepReady = nil
+		case stepRunErr = <-stepExited:
+			break dance
+		case sig := <-signals:
+			if sig != failedFast {
+				aborted = true
+			}
+
+			process.Signal(sig)
+		}
+	}
+
+	if stepRunErr != ErrInterrupted || !aborted {
+		return stepRunErr
+	}
+
+	o.onAbort = o.abortFactory.Using(o.step, o.repo)
+	o.onAbort.Run(signals, make(chan struct{}))
+
+	return ErrInterrupted
+}
+
+// Result indicates Success as the first step does; the hook does not change
+// the outcome.
+//
+// Any other type is ignored.
+func (o *OnAbortStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		return o.step.Result(v)
+
+	default:
+		return false
+	}
+}
+
+// Release releases both steps.
+func (o *OnAbortStep) Release() {
+	if o.step != nil {
+		o.step.Release()
+	}
+
+	if o.onAbort != nil {
+		o.onAbort.Release()
+	}
+}
@@ -0,0 +1,141 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/resource"
+)
+
+var _ = Describe("On Abort Step", func() {
+	var (
+		stepFactory  *execfakes.FakeStepFactory
+		abortFactory *execfakes.FakeStepFactory
+
+		step *execfakes.FakeStep
+		hook *execfakes.FakeStep
+
+		previousStep *execfakes.FakeStep
+
+		repo *exec.SourceRepository
+
+		onAbortFactory exec.StepFactory
+		onAbortStep    exec.Step
+	)
+
+	BeforeEach(func() {
+		stepFactory = &execfakes.FakeStepFactory{}
+		abortFactory = &execfakes.FakeStepFactory{}
+
+		step = &execfakes.FakeStep{}
+		hook = &execfakes.FakeStep{}
+
+		previousStep = &execfakes.FakeStep{}
+
+		stepFactory.UsingReturns(step)
+		abortFactory.UsingReturns(hook)
+
+		repo = exec.NewSourceRepository()
+
+		onAbortFactory = exec.OnAbort(stepFactory, abortFactory)
+		onAbortStep = onAbortFactory.Using(previousStep, repo)
+	})
+
+	Context("when the step is interrupted", func() {
+		BeforeEach(func() {
+			step.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+
+				<-signals
+				return exec.ErrInterrupted
+			}
+		})
+
+		It("runs the abort hook and returns ErrInterrupted", func() {
+			process := ifrit.Background(onAbortStep)
+
+			process.Signal(os.Kill)
+
+			Eventually(hook.RunCallCount).Should(Equal(1))
+			Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+		})
+
+		It("provides the step as the previous step to the hook", func() {
+			process := ifrit.Background(onAbortStep)
+
+			process.Signal(os.Kill)
+
+			Eventually(process.Wait()).Should(Receive())
+
+			argsPrev, argsRepo := abortFactory.UsingArgsForCall(0)
+			Expect(argsPrev).To(Equal(step))
+			Expect(argsRepo).To(Equal(repo))
+		})
+
+		Context("when the hook errors", func() {
+			BeforeEach(func() {
+				hook.RunReturns(errors.New("disaster"))
+			})
+
+			It("still returns ErrInterrupted", func() {
+				process := ifrit.Background(onAbortStep)
+
+				process.Signal(os.Kill)
+
+				Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+			})
+		})
+	})
+
+	It("runs the abort hook if the step is interrupted while fetching a resource", func() {
+		step.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			<-signals
+			return resource.ErrInterrupted
+		}
+
+		process := ifrit.Background(onAbortStep)
+
+		process.Signal(os.Kill)
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+		Expect(hook.RunCallCount()).To(Equal(1))
+	})
+
+	It("does not run the abort hook if the step returns ErrInterrupted without being signalled", func() {
+		step.RunReturns(exec.ErrInterrupted)
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("does not run the abort hook if the step errors", func() {
+		step.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(process.Wait()).Should(Receive(errorMatching("disaster")))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("does not run the abort hook if the step fails", func() {
+		step.ResultStub = successResult(false)
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(process.Wait()).Should(Receive(noError()))
+		Expect(hook.RunCallCount()).To(Equal(0))
+
+		var succeeded exec.Success
+		Expect(onAbortStep.Result(&succeeded)).To(BeTrue())
+		Expect(bool(succeeded)).To(BeFalse())
+	})
+})
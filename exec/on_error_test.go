@@ -0,0 +1,149 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/resource"
+)
+
+var _ = Describe("On Error Step", func() {
+	var (
+		stepFactory  *execfakes.FakeStepFactory
+		errorFactory *execfakes.FakeStepFactory
+
+		step *execfakes.FakeStep
+		hook *execfakes.FakeStep
+
+		previousStep *execfakes.FakeStep
+
+		repo *exec.SourceRepository
+
+		onErrorFactory exec.StepFactory
+		onErrorStep    exec.Step
+	)
+
+	BeforeEach(func() {
+		stepFactory = &execfakes.FakeStepFactory{}
+		errorFactory = &execfakes.FakeStepFactory{}
+
+		step = &execfakes.FakeStep{}
+		hook = &execfakes.FakeStep{}
+
+		previousStep = &execfakes.FakeStep{}
+
+		stepFactory.UsingReturns(step)
+		errorFactory.UsingReturns(hook)
+
+		repo = exec.NewSourceRepository()
+
+		onErrorFactory = exec.OnError(stepFactory, errorFactory)
+		onErrorStep = onErrorFactory.Using(previousStep, repo)
+	})
+
+	It("runs the error hook if the step errors, and still returns the error", func() {
+		step.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onErrorStep)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(hook.RunCallCount).Should(Equal(1))
+
+		Eventually(process.Wait()).Should(Receive(errorMatching(ContainSubstring("disaster"))))
+	})
+
+	It("provides the step as the previous step to the hook", func() {
+		step.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onErrorStep)
+
+		Eventually(errorFactory.UsingCallCount).Should(Equal(1))
+
+		argsPrev, argsRepo := errorFactory.UsingArgsForCall(0)
+		Expect(argsPrev).To(Equal(step))
+		Expect(argsRepo).To(Equal(repo))
+
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	It("returns the hook's error along with the step's", func() {
+		step.RunReturns(errors.New("disaster"))
+		hook.RunReturns(errors.New("hook disaster"))
+
+		process := ifrit.Background(onErrorStep)
+
+		var err error
+		Eventually(process.Wait()).Should(Receive(&err))
+		Expect(err.Error()).To(ContainSubstring("disaster"))
+		Expect(err.Error()).To(ContainSubstring("hook disaster"))
+	})
+
+	It("does not run the error hook if the step fails", func() {
+		step.ResultStub = successResult(false)
+
+		process := ifrit.Background(onErrorStep)
+
+		Eventually(process.Wait()).Should(Receive(noError()))
+		Expect(hook.RunCallCount()).To(Equal(0))
+
+		var succeeded exec.Success
+		Expect(onErrorStep.Result(&succeeded)).To(BeTrue())
+		Expect(bool(succeeded)).To(BeFalse())
+	})
+
+	It("does not run the error hook if the step succeeds", func() {
+		step.ResultStub = successResult(true)
+
+		process := ifrit.Background(onErrorStep)
+
+		Eventually(process.Wait()).Should(Receive(noError()))
+		Expect(hook.RunCallCount()).To(Equal(0))
+
+		var succeeded exec.Success
+		Expect(onErrorStep.Result(&succeeded)).To(BeTrue())
+		Expect(bool(succeeded)).To(BeTrue())
+	})
+
+	It("does not run the error hook if the step is interrupted", func() {
+		step.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+
+			<-signals
+			return exec.ErrInterrupted
+		}
+
+		process := ifrit.Background(onErrorStep)
+
+		process.Signal(os.Kill)
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("does not run the error hook if the step is interrupted while fetching a resource", func() {
+		step.RunReturns(resource.ErrInterrupted)
+
+		process := ifrit.Background(onErrorStep)
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("releases both steps", func() {
+		step.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onErrorStep)
+		Eventually(process.Wait()).Should(Receive())
+
+		onErrorStep.Release()
+		Expect(step.ReleaseCallCount()).To(Equal(1))
+		Expect(hook.ReleaseCallCount()).To(Equal(1))
+	})
+})
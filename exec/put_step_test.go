@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 	"github.com/concourse/atc/resource"
@@ -52,7 +53,7 @@ var _ = Describe("GardenFactory", func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeResourceFetcher := new(rfakes.FakeFetcher)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, new(dbfakes.FakeTeamDBFactory), atc.ContainerLimits{}, atc.ContainerLimits{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -433,6 +434,25 @@ var _ = Describe("GardenFactory", func() {
 				})
 			})
 
+			Context("when initializing the resource is interrupted", func() {
+				BeforeEach(func() {
+					fakeTracker.InitWithSourcesReturns(nil, nil, resource.ErrInterrupted)
+				})
+
+				It("exits with ErrInterrupted", func() {
+					Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+				})
+
+				It("invokes the delegate's Failed callback without completing", func() {
+					Eventually(process.Wait()).Should(Receive())
+
+					Expect(putDelegate.CompletedCallCount()).To(BeZero())
+
+					Expect(putDelegate.FailedCallCount()).To(Equal(1))
+					Expect(putDelegate.FailedArgsForCall(0)).To(Equal(ErrInterrupted))
+				})
+			})
+
 			Context("when the tracker fails to initialize the resource", func() {
 				disaster := errors.New("nope")
 
@@ -0,0 +1,172 @@
+package exec
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"gopkg.in/yaml.v2"
+)
+
+// SetPipelineStep configures a pipeline in the build's team using a config
+// file from the SourceRepository.
+type SetPipelineStep struct {
+	logger   lager.Logger
+	delegate SetPipelineDelegate
+	teamDB   db.TeamDB
+	plan     atc.SetPipelinePlan
+
+	repository *SourceRepository
+
+	succeeded bool
+}
+
+func newSetPipelineStep(
+	logger lager.Logger,
+	delegate SetPipelineDelegate,
+	teamDB db.TeamDB,
+	plan atc.SetPipelinePlan,
+) SetPipelineStep {
+	return SetPipelineStep{
+		logger:   logger,
+		delegate: delegate,
+		teamDB:   teamDB,
+		plan:     plan,
+	}
+}
+
+// Using finishes construction of the SetPipelineStep and returns a
+// *SetPipelineStep. If the *SetPipelineStep errors, its error is reported to
+// the delegate.
+func (step SetPipelineStep) Using(prev Step, repo *SourceRepository) Step {
+	step.repository = repo
+
+	return errorReporter{
+		Step:          &step,
+		ReportFailure: step.delegate.Failed,
+	}
+}
+
+// Run reads the pipeline config file out of the SourceRepository and
+// validates it. Any warnings are written to stderr.
+//
+// If the config is invalid, the errors are written to stderr and the step
+// fails. Otherwise the changes to the pipeline's existing config are written
+// to stdout and the config is saved, leaving the pipeline's paused state
+// untouched. New pipelines start paused.
+func (step *SetPipelineStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	step.delegate.Initializing()
+
+	close(ready)
+
+	pipelineConfig, err := step.fetchConfig()
+	if err != nil {
+		return err
+	}
+
+	stdout := step.delegate.Stdout()
+	stderr := step.delegate.Stderr()
+
+	warnings, errorMessages := config.ValidateConfig(pipelineConfig)
+
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+	}
+
+	if len(errorMessages) > 0 {
+		fmt.Fprintln(stderr, "invalid pipeline config:")
+
+		for _, message := range errorMessages {
+			fmt.Fprintln(stderr, message)
+		}
+
+		step.delegate.Finished(ExitStatus(1))
+		return nil
+	}
+
+	existingConfig, _, version, err := step.teamDB.GetConfig(step.plan.Name)
+	if err != nil {
+		return err
+	}
+
+	writeConfigDiff(stdout, existingConfig, pipelineConfig)
+
+	_, created, err := step.teamDB.SaveConfig(step.plan.Name, pipelineConfig, version, db.PipelineNoChange)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Fprintf(stdout, "pipeline '%s' created; it starts paused\n", step.plan.Name)
+	} else {
+		fmt.Fprintf(stdout, "pipeline '%s' configured\n", step.plan.Name)
+	}
+
+	step.succeeded = true
+	step.delegate.Finished(ExitStatus(0))
+
+	return nil
+}
+
+// Release does nothing, as the step has no resources to release.
+func (step *SetPipelineStep) Release() {}
+
+// Result indicates Success as true if the pipeline config was saved.
+//
+// All other types are ignored.
+func (step *SetPipelineStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.succeeded)
+		return true
+
+	default:
+		return false
+	}
+}
+
+func (step *SetPipelineStep) fetchConfig() (atc.Config, error) {
+	streamedFile, err := readArtifactFile(step.repository, step.plan.File)
+	if err != nil {
+		if _, ok := err.(FileNotFoundError); ok {
+			return atc.Config{}, fmt.Errorf("pipeline config '%s' not found", step.plan.File)
+		}
+		return atc.Config{}, err
+	}
+
+	var pipelineConfig atc.Config
+	err = yaml.Unmarshal(streamedFile, &pipelineConfig)
+	if err != nil {
+		return atc.Config{}, fmt.Errorf("failed to load %s: %s", step.plan.File, err)
+	}
+
+	return pipelineConfig, nil
+}
+
+func writeConfigDiff(w io.Writer, oldConfig atc.Config, newConfig atc.Config) {
+	diff := config.DiffConfigs(oldConfig, newConfig)
+
+	writeItemDiffs(w, "group", diff.Groups)
+	writeItemDiffs(w, "resource", diff.Resources)
+	writeItemDiffs(w, "resource type", diff.ResourceTypes)
+	writeItemDiffs(w, "job", diff.Jobs)
+
+	if diff.IsEmpty() {
+		fmt.Fprintln(w, "no changes to apply")
+	}
+}
+
+func writeItemDiffs(w io.Writer, kind string, items []config.ItemDiff) {
+	for _, item := range items {
+		switch item.Action {
+		case config.DiffActionChanged:
+			fmt.Fprintf(w, "%s %s has changed\n", kind, item.Name)
+		default:
+			fmt.Fprintf(w, "%s %s has been %s\n", kind, item.Name, item.Action)
+		}
+	}
+}
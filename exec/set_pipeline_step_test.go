@@ -0,0 +1,212 @@
+package exec_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/baggageclaim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	var (
+		fakeTeamDBFactory *dbfakes.FakeTeamDBFactory
+		fakeTeamDB        *dbfakes.FakeTeamDB
+		fakeDelegate      *execfakes.FakeSetPipelineDelegate
+		fakeSource        *execfakes.FakeArtifactSource
+
+		stdoutBuf *gbytes.Buffer
+		stderrBuf *gbytes.Buffer
+
+		factory Factory
+		plan    atc.SetPipelinePlan
+		repo    *SourceRepository
+
+		step    Step
+		process ifrit.Process
+	)
+
+	validConfig := `
+resources:
+- name: some-resource
+  type: git
+  source: {uri: some-uri}
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+
+	BeforeEach(func() {
+		fakeTeamDBFactory = new(dbfakes.FakeTeamDBFactory)
+		fakeTeamDB = new(dbfakes.FakeTeamDB)
+		fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
+
+		fakeDelegate = new(execfakes.FakeSetPipelineDelegate)
+		stdoutBuf = gbytes.NewBuffer()
+		stderrBuf = gbytes.NewBuffer()
+		fakeDelegate.StdoutReturns(stdoutBuf)
+		fakeDelegate.StderrReturns(stderrBuf)
+
+		fakeSource = new(execfakes.FakeArtifactSource)
+		fakeSource.StreamFileReturns(ioutil.NopCloser(strings.NewReader(validConfig)), nil)
+
+		repo = NewSourceRepository()
+		repo.RegisterSource("some-artifact", fakeSource)
+
+		factory = NewGardenFactory(nil, nil, nil, fakeTeamDBFactory, atc.ContainerLimits{}, atc.ContainerLimits{})
+
+		plan = atc.SetPipelinePlan{
+			Name: "some-pipeline",
+			File: "some-artifact/pipeline.yml",
+		}
+	})
+
+	JustBeforeEach(func() {
+		step = factory.SetPipeline(lagertest.NewTestLogger("test"), fakeDelegate, "some-team", plan).Using(nil, repo)
+		process = ifrit.Invoke(step)
+	})
+
+	It("uses the build's team", func() {
+		Expect(fakeTeamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+	})
+
+	It("reads the config file from the artifact", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+		Expect(fakeSource.StreamFileArgsForCall(0)).To(Equal("pipeline.yml"))
+	})
+
+	Context("when the pipeline does not exist yet", func() {
+		BeforeEach(func() {
+			fakeTeamDB.SaveConfigReturns(db.SavedPipeline{}, true, nil)
+		})
+
+		It("saves the config without changing the paused state", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(fakeTeamDB.SaveConfigCallCount()).To(Equal(1))
+			name, config, version, pausedState := fakeTeamDB.SaveConfigArgsForCall(0)
+			Expect(name).To(Equal("some-pipeline"))
+			Expect(config.Jobs).To(HaveLen(1))
+			Expect(config.Jobs[0].Name).To(Equal("some-job"))
+			Expect(version).To(Equal(db.ConfigVersion(0)))
+			Expect(pausedState).To(Equal(db.PipelineNoChange))
+		})
+
+		It("writes the changes to stdout", func() {
+			Eventually(stdoutBuf).Should(gbytes.Say("resource some-resource has been added"))
+			Eventually(stdoutBuf).Should(gbytes.Say("job some-job has been added"))
+			Eventually(stdoutBuf).Should(gbytes.Say("pipeline 'some-pipeline' created"))
+		})
+
+		It("finishes successfully", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(0)))
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(bool(success)).To(BeTrue())
+		})
+	})
+
+	Context("when the pipeline already exists", func() {
+		BeforeEach(func() {
+			fakeTeamDB.GetConfigReturns(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-other-uri"}},
+				},
+				Jobs: atc.JobConfigs{
+					{Name: "some-old-job"},
+				},
+			}, atc.RawConfig(""), db.ConfigVersion(42), nil)
+		})
+
+		It("saves the config against the existing version", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			name, _, version, _ := fakeTeamDB.SaveConfigArgsForCall(0)
+			Expect(name).To(Equal("some-pipeline"))
+			Expect(version).To(Equal(db.ConfigVersion(42)))
+		})
+
+		It("writes the changes to stdout", func() {
+			Eventually(stdoutBuf).Should(gbytes.Say("resource some-resource has changed"))
+			Eventually(stdoutBuf).Should(gbytes.Say("job some-job has been added"))
+			Eventually(stdoutBuf).Should(gbytes.Say("job some-old-job has been removed"))
+			Eventually(stdoutBuf).Should(gbytes.Say("pipeline 'some-pipeline' configured"))
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			fakeSource.StreamFileReturns(ioutil.NopCloser(strings.NewReader(`
+jobs:
+- name: some-job
+  plan:
+  - get: some-bogus-resource
+`)), nil)
+		})
+
+		It("writes the errors to stderr and fails without saving", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stderrBuf).To(gbytes.Say("invalid pipeline config"))
+			Expect(stderrBuf).To(gbytes.Say("refers to a resource that does not exist"))
+
+			Expect(fakeTeamDB.SaveConfigCallCount()).To(BeZero())
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(1)))
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(bool(success)).To(BeFalse())
+		})
+	})
+
+	Context("when the config file is not found", func() {
+		BeforeEach(func() {
+			fakeSource.StreamFileReturns(nil, baggageclaim.ErrFileNotFound)
+		})
+
+		It("errors and reports the failure", func() {
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Expect(err).To(MatchError("pipeline config 'some-artifact/pipeline.yml' not found"))
+			Expect(fakeDelegate.FailedArgsForCall(0)).To(Equal(err))
+		})
+	})
+
+	Context("when the artifact source is unknown", func() {
+		BeforeEach(func() {
+			plan.File = "bogus-artifact/pipeline.yml"
+		})
+
+		It("returns an UnknownArtifactSourceError", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(UnknownArtifactSourceError{"bogus-artifact"})))
+		})
+	})
+
+	Context("when saving the config fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeTeamDB.SaveConfigReturns(db.SavedPipeline{}, false, disaster)
+		})
+
+		It("errors", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+		})
+	})
+})
@@ -110,6 +110,11 @@ func (repo *SourceRepository) VolumeOn(worker worker.Worker) (worker.Volume, boo
 	return nil, false, nil
 }
 
+// VolumeRef returns nothing, for the same reason.
+func (repo *SourceRepository) VolumeRef() (worker.VolumeRef, bool) {
+	return worker.VolumeRef{}, false
+}
+
 // ScopedTo returns a new SourceRepository restricted to the given set of
 // SourceNames. This is used by the Put step to stream in the sources that did
 // not have a volume available on its destination.
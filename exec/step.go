@@ -1,16 +1,20 @@
 package exec
 
 import (
-	"errors"
 	"os"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/resource"
 	"github.com/tedsuo/ifrit"
 )
 
 // ErrInterrupted is returned by steps when they exited as a result of
 // receiving a signal.
-var ErrInterrupted = errors.New("interrupted")
+//
+// It is the same error that resources return when interrupted, so that steps
+// interrupted while fetching a resource or an image are treated as aborted
+// too, e.g. by the on_abort and on_error hooks.
+var ErrInterrupted = resource.ErrInterrupted
 
 //go:generate counterfeiter . StepFactory
 
@@ -24,6 +24,10 @@ import (
 const taskProcessPropertyName = "concourse:task-process"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
+// the event garden records when a container is killed for exceeding its
+// memory limit
+const outOfMemoryEvent = "out of memory"
+
 // MissingInputsError is returned when any of the task's required inputs are
 // missing.
 type MissingInputsError struct {
@@ -59,6 +63,9 @@ type TaskStep struct {
 	containerSuccessTTL time.Duration
 	containerFailureTTL time.Duration
 
+	defaultLimits atc.ContainerLimits
+	maxLimits     atc.ContainerLimits
+
 	process garden.Process
 
 	exitStatus int
@@ -82,6 +89,8 @@ func newTaskStep(
 	clock clock.Clock,
 	containerSuccessTTL time.Duration,
 	containerFailureTTL time.Duration,
+	defaultLimits atc.ContainerLimits,
+	maxLimits atc.ContainerLimits,
 ) TaskStep {
 	return TaskStep{
 		logger:              logger,
@@ -101,6 +110,8 @@ func newTaskStep(
 		clock:               clock,
 		containerSuccessTTL: containerSuccessTTL,
 		containerFailureTTL: containerFailureTTL,
+		defaultLimits:       defaultLimits,
+		maxLimits:           maxLimits,
 	}
 }
 
@@ -173,6 +184,11 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			}
 
 			step.registerSource(config)
+
+			if step.exitStatus == 0 {
+				step.registerCaches(config)
+			}
+
 			return nil
 		}
 
@@ -200,19 +216,22 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			Platform: config.Platform,
 			Tags:     step.tags,
 			TeamID:   step.teamID,
+			Volumes:  step.volumeRefs(config.Inputs),
 		}
 
 		if config.ImageResource != nil {
 			workerSpec.ResourceType = config.ImageResource.Type
 		}
 
-		compatibleWorkers, err := step.workerPool.AllSatisfying(workerSpec, step.resourceTypes)
+		chosenWorker, reason, err := step.workerPool.Choose(step.logger, workerSpec, step.resourceTypes)
 		if err != nil {
 			return err
 		}
 
+		fmt.Fprintf(step.delegate.Stderr(), "placing container on worker %s: %s\n", chosenWorker.Name(), reason)
+
 		var inputsToStream []inputPair
-		step.container, inputsToStream, err = step.createContainer(compatibleWorkers, config, signals)
+		step.container, inputsToStream, err = step.createContainer(chosenWorker, config, signals)
 
 		if err != nil {
 			return err
@@ -286,19 +305,29 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 		step.exitStatus = processStatus
 
+		if processStatus == 0 {
+			step.registerCaches(config)
+		}
+
 		err := step.container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", processStatus))
 		if err != nil {
 			return err
 		}
 
+		if processStatus != 0 && step.outOfMemory() {
+			fmt.Fprintln(step.delegate.Stderr(), "task was killed for exceeding its memory limit")
+			step.delegate.FinishedOutOfMemory(ExitStatus(processStatus))
+			return nil
+		}
+
 		step.delegate.Finished(ExitStatus(processStatus))
 
 		return nil
 	}
 }
 
-func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config atc.TaskConfig, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
-	chosenWorker, inputMounts, inputsToStream, err := step.chooseWorkerWithMostVolumes(compatibleWorkers, config.Inputs)
+func (step *TaskStep) createContainer(chosenWorker worker.Worker, config atc.TaskConfig, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
+	inputMounts, inputsToStream, err := step.inputsOn(config.Inputs, chosenWorker)
 	if err != nil {
 		return nil, []inputPair{}, err
 	}
@@ -331,6 +360,11 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		step.logger.Debug("created-output-volume", lager.Data{"volume-Handle": outVolume.Handle()})
 	}
 
+	cacheMounts, err := step.cachesOn(chosenWorker, config.Caches)
+	if err != nil {
+		return nil, []inputPair{}, err
+	}
+
 	var imageSpec worker.ImageSpec
 	if step.imageArtifactName != "" {
 		source, found := step.repo.SourceFor(SourceName(step.imageArtifactName))
@@ -418,9 +452,10 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		Tags:      step.tags,
 		TeamID:    step.teamID,
 		Inputs:    inputMounts,
-		Outputs:   outputMounts,
+		Outputs:   append(outputMounts, cacheMounts...),
 		ImageSpec: imageSpec,
 		User:      config.Run.User,
+		Limits:    step.containerLimits(config),
 	}
 
 	runContainerID := step.containerID
@@ -449,9 +484,177 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		mount.Volume.Release(nil)
 	}
 
+	for _, mount := range cacheMounts {
+		// stop heartbeating ourselves now that container has picked up the
+		// volumes
+		mount.Volume.Release(nil)
+	}
+
 	return container, inputsToStream, err
 }
 
+// containerLimits returns the limits of the task's container: the task's own
+// limits, with the default for any it does not configure, capped at the max.
+func (step *TaskStep) containerLimits(config atc.TaskConfig) atc.ContainerLimits {
+	limits := step.defaultLimits
+	if config.ContainerLimits != nil {
+		limits = limits.Merge(*config.ContainerLimits)
+	}
+
+	if step.maxLimits.CPU != 0 && (limits.CPU == 0 || limits.CPU > step.maxLimits.CPU) {
+		limits.CPU = step.maxLimits.CPU
+	}
+
+	if step.maxLimits.Memory != 0 && (limits.Memory == 0 || limits.Memory > step.maxLimits.Memory) {
+		limits.Memory = step.maxLimits.Memory
+	}
+
+	return limits
+}
+
+// outOfMemory returns true if garden killed the task's container for exceeding
+// its memory limit.
+func (step *TaskStep) outOfMemory() bool {
+	info, err := step.container.Info()
+	if err != nil {
+		step.logger.Error("failed-to-get-container-info", err)
+		return false
+	}
+
+	for _, event := range info.Events {
+		if strings.EqualFold(event, outOfMemoryEvent) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cachesOn creates a volume for each of the task's caches on the chosen
+// worker. If the worker already has a cache for the path, its contents are
+// copied into the new volume, so that the cache is left intact if the task
+// fails. The copy does not depend on the previous cache, so that it can be
+// reaped once replaced rather than each build adding a layer to the last.
+func (step *TaskStep) cachesOn(chosenWorker worker.Worker, caches []atc.CacheConfig) ([]worker.VolumeMount, error) {
+	cacheMounts := []worker.VolumeMount{}
+
+	for _, cache := range caches {
+		var previousCache worker.Volume
+
+		// one-off builds have no job to persist caches for, so they always
+		// start with an empty cache
+		if step.metadata.JobName != "" {
+			volume, found, err := chosenWorker.FindVolume(step.logger, worker.VolumeSpec{
+				Strategy: worker.TaskCacheStrategy{
+					TaskCache: step.taskCacheIdentifier(cache, chosenWorker.Name()),
+				},
+				Privileged: bool(step.privileged),
+				TTL:        worker.VolumeTTL,
+			})
+			if err == worker.ErrNoVolumeManager {
+				break
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				step.logger.Debug("found-cache-volume", lager.Data{"volume-handle": volume.Handle(), "path": cache.Path})
+				defer volume.Release(nil)
+				previousCache = volume
+			}
+		}
+
+		cacheVolume, err := chosenWorker.CreateVolume(
+			step.logger,
+			worker.VolumeSpec{
+				Strategy:   worker.TaskCacheWorkspaceStrategy{},
+				Privileged: bool(step.privileged),
+				TTL:        worker.VolumeTTL,
+			},
+			step.teamID,
+		)
+		if err == worker.ErrNoVolumeManager {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		cacheMounts = append(cacheMounts, worker.VolumeMount{
+			Volume:    cacheVolume,
+			MountPath: step.cacheDestination(cache),
+		})
+
+		step.logger.Debug("created-cache-volume", lager.Data{"volume-handle": cacheVolume.Handle(), "path": cache.Path})
+
+		if previousCache != nil {
+			err := copyVolume(previousCache, cacheVolume)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return cacheMounts, nil
+}
+
+func copyVolume(src worker.Volume, dst worker.Volume) error {
+	out, err := src.StreamOut(".")
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	return dst.StreamIn(".", out)
+}
+
+// registerCaches marks the task's cache volumes as the caches to use for
+// future builds of the job. Failing to do so does not fail the task; the
+// next build will just use the previous cache.
+func (step *TaskStep) registerCaches(config atc.TaskConfig) {
+	if step.metadata.JobName == "" {
+		return
+	}
+
+	volumeMounts := step.container.VolumeMounts()
+
+	for _, cache := range config.Caches {
+		cachePath := step.cacheDestination(cache)
+
+		for _, mount := range volumeMounts {
+			if mount.MountPath != cachePath {
+				continue
+			}
+
+			err := mount.Volume.MarkAsTaskCache(step.taskCacheIdentifier(cache, step.container.WorkerName()))
+			if err != nil {
+				step.logger.Error("failed-to-mark-volume-as-task-cache", err, lager.Data{
+					"volume-handle": mount.Volume.Handle(),
+					"path":          cache.Path,
+				})
+			}
+		}
+	}
+}
+
+func (step *TaskStep) taskCacheIdentifier(cache atc.CacheConfig, workerName string) db.TaskCacheIdentifier {
+	return db.TaskCacheIdentifier{
+		PipelineID: step.metadata.PipelineID,
+		JobName:    step.metadata.JobName,
+		StepName:   step.metadata.StepName,
+		Path:       filepath.Clean(cache.Path),
+		WorkerName: workerName,
+	}
+}
+
+func (step *TaskStep) cacheDestination(cache atc.CacheConfig) string {
+	return filepath.Join(step.artifactsRoot, cache.Path)
+}
+
 func (step *TaskStep) registerSource(config atc.TaskConfig) {
 	volumeMounts := step.container.VolumeMounts()
 
@@ -509,33 +712,37 @@ func (step *TaskStep) Release() {
 	}
 }
 
-func (step *TaskStep) chooseWorkerWithMostVolumes(compatibleWorkers []worker.Worker, inputs []atc.TaskInputConfig) (worker.Worker, []worker.VolumeMount, []inputPair, error) {
-	inputMounts := []worker.VolumeMount{}
-	inputsToStream := []inputPair{}
-
-	var chosenWorker worker.Worker
-	for _, w := range compatibleWorkers {
-		mounts, toStream, err := step.inputsOn(inputs, w)
-		if err != nil {
-			return nil, nil, nil, err
+// volumeRefs refers to the volumes holding the task's inputs and image
+// artifact, so that the worker pool can prefer a worker already holding them.
+func (step *TaskStep) volumeRefs(inputs []atc.TaskInputConfig) []worker.VolumeRef {
+	sourceNames := []string{}
+	for _, input := range inputs {
+		inputName := input.Name
+		if sourceName, ok := step.inputMapping[inputName]; ok {
+			inputName = sourceName
 		}
 
-		if len(mounts) >= len(inputMounts) {
-			for _, mount := range inputMounts {
-				mount.Volume.Release(nil)
-			}
+		sourceNames = append(sourceNames, inputName)
+	}
 
-			inputMounts = mounts
-			inputsToStream = toStream
-			chosenWorker = w
-		} else {
-			for _, mount := range mounts {
-				mount.Volume.Release(nil)
-			}
+	if step.imageArtifactName != "" {
+		sourceNames = append(sourceNames, step.imageArtifactName)
+	}
+
+	refs := []worker.VolumeRef{}
+	for _, sourceName := range sourceNames {
+		source, found := step.repo.SourceFor(SourceName(sourceName))
+		if !found {
+			continue
+		}
+
+		ref, found := source.VolumeRef()
+		if found {
+			refs = append(refs, ref)
 		}
 	}
 
-	return chosenWorker, inputMounts, inputsToStream, nil
+	return refs
 }
 
 type inputPair struct {
@@ -728,6 +935,14 @@ func (src *containerSource) VolumeOn(w worker.Worker) (worker.Volume, bool, erro
 	return w.LookupVolume(src.logger, src.volumeHandle)
 }
 
+func (src *containerSource) VolumeRef() (worker.VolumeRef, bool) {
+	if src.volumeHandle == "" {
+		return worker.VolumeRef{}, false
+	}
+
+	return worker.VolumeRef{Handle: src.volumeHandle}, true
+}
+
 func artifactsPath(outputConfig atc.TaskOutputConfig, artifactsRoot string) string {
 	outputSrc := outputConfig.Path
 	if len(outputSrc) == 0 {
@@ -18,6 +18,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 	rfakes "github.com/concourse/atc/resource/resourcefakes"
@@ -44,19 +45,28 @@ var _ = Describe("GardenFactory", func() {
 		imageArtifactName string
 		identifier        worker.Identifier
 		workerMetadata    worker.Metadata
+
+		defaultLimits atc.ContainerLimits
+		maxLimits     atc.ContainerLimits
 	)
 
 	BeforeEach(func() {
 		fakeWorkerClient = new(wfakes.FakeClient)
 		fakeTracker = new(rfakes.FakeTracker)
-		fakeResourceFetcher := new(rfakes.FakeFetcher)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		defaultLimits = atc.ContainerLimits{}
+		maxLimits = atc.ContainerLimits{}
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
 	})
 
+	JustBeforeEach(func() {
+		fakeResourceFetcher := new(rfakes.FakeFetcher)
+
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, new(dbfakes.FakeTeamDBFactory), defaultLimits, maxLimits)
+	})
+
 	Describe("Task", func() {
 		var (
 			taskDelegate  *execfakes.FakeTaskDelegate
@@ -172,7 +182,7 @@ var _ = Describe("GardenFactory", func() {
 					disaster := errors.New("nope")
 
 					BeforeEach(func() {
-						fakeWorkerClient.AllSatisfyingReturns(nil, disaster)
+						fakeWorkerClient.ChooseReturns(nil, "", disaster)
 					})
 
 					It("exits with the error", func() {
@@ -185,7 +195,8 @@ var _ = Describe("GardenFactory", func() {
 
 					BeforeEach(func() {
 						fakeWorker = new(wfakes.FakeWorker)
-						fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{fakeWorker}, nil)
+						fakeWorker.NameReturns("some-worker")
+						fakeWorkerClient.ChooseReturns(fakeWorker, "some reason", nil)
 					})
 
 					Context("when creating the task's container works", func() {
@@ -221,8 +232,8 @@ var _ = Describe("GardenFactory", func() {
 						})
 
 						It("found the worker with the right spec", func() {
-							Expect(fakeWorkerClient.AllSatisfyingCallCount()).To(Equal(1))
-							spec, actualResourceTypes := fakeWorkerClient.AllSatisfyingArgsForCall(0)
+							Expect(fakeWorkerClient.ChooseCallCount()).To(Equal(1))
+							_, spec, actualResourceTypes := fakeWorkerClient.ChooseArgsForCall(0)
 							Expect(spec.Platform).To(Equal("some-platform"))
 							Expect(spec.TeamID).To(Equal(teamID))
 							Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{
@@ -234,6 +245,10 @@ var _ = Describe("GardenFactory", func() {
 							}))
 						})
 
+						It("writes the worker that was chosen and why to the delegate", func() {
+							Eventually(stderrBuf).Should(gbytes.Say("placing container on worker some-worker: some reason"))
+						})
+
 						It("looked up the container via the session ID across the entire pool", func() {
 							_, findID := fakeWorkerClient.FindContainerForIdentifierArgsForCall(0)
 							Expect(findID).To(Equal(worker.Identifier{
@@ -286,6 +301,85 @@ var _ = Describe("GardenFactory", func() {
 							}))
 						})
 
+						It("creates a container without limits", func() {
+							_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+							Expect(spec.Limits).To(Equal(atc.ContainerLimits{}))
+						})
+
+						Context("when the config has container limits", func() {
+							BeforeEach(func() {
+								fetchedConfig.ContainerLimits = &atc.ContainerLimits{
+									CPU:    512,
+									Memory: 1024,
+								}
+
+								configSource.FetchConfigReturns(fetchedConfig, nil)
+							})
+
+							It("creates a container with the limits", func() {
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.Limits).To(Equal(atc.ContainerLimits{
+									CPU:    512,
+									Memory: 1024,
+								}))
+							})
+
+							Context("when the limits exceed the max", func() {
+								BeforeEach(func() {
+									maxLimits = atc.ContainerLimits{
+										CPU:    256,
+										Memory: 2048,
+									}
+								})
+
+								It("caps the limits at the max", func() {
+									_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.Limits).To(Equal(atc.ContainerLimits{
+										CPU:    256,
+										Memory: 1024,
+									}))
+								})
+							})
+						})
+
+						Context("when there are default limits", func() {
+							BeforeEach(func() {
+								defaultLimits = atc.ContainerLimits{
+									CPU:    128,
+									Memory: 4096,
+								}
+
+								fetchedConfig.ContainerLimits = &atc.ContainerLimits{
+									Memory: 1024,
+								}
+
+								configSource.FetchConfigReturns(fetchedConfig, nil)
+							})
+
+							It("uses the default for any limit the config does not set", func() {
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.Limits).To(Equal(atc.ContainerLimits{
+									CPU:    128,
+									Memory: 1024,
+								}))
+							})
+						})
+
+						Context("when there are max limits and the config sets none", func() {
+							BeforeEach(func() {
+								maxLimits = atc.ContainerLimits{
+									Memory: 2048,
+								}
+							})
+
+							It("limits the container to the max", func() {
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.Limits).To(Equal(atc.ContainerLimits{
+									Memory: 2048,
+								}))
+							})
+						})
+
 						It("ensures artifacts root exists by streaming in an empty payload", func() {
 							Expect(fakeContainer.StreamInCallCount()).To(Equal(1))
 
@@ -1144,6 +1238,174 @@ var _ = Describe("GardenFactory", func() {
 							})
 						})
 
+						Context("when the configuration specifies caches", func() {
+							var (
+								fakeCacheVolume     *wfakes.FakeVolume
+								fakeWorkspaceVolume *wfakes.FakeVolume
+
+								cacheMountPath = "/tmp/build/a1f5c0c1/some/cache"
+							)
+
+							BeforeEach(func() {
+								workerMetadata.PipelineID = 42
+
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Platform: "some-platform",
+									Image:    "some-image",
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Caches: []atc.CacheConfig{
+										{Path: "some/cache"},
+									},
+								}, nil)
+
+								fakeWorker.NameReturns("some-worker")
+
+								fakeCacheVolume = new(wfakes.FakeVolume)
+								fakeCacheVolume.HandleReturns("some-cache-handle")
+
+								fakeWorkspaceVolume = new(wfakes.FakeVolume)
+								fakeWorkspaceVolume.HandleReturns("some-workspace-handle")
+								fakeWorker.CreateVolumeReturns(fakeWorkspaceVolume, nil)
+
+								fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+									{
+										Volume:    fakeWorkspaceVolume,
+										MountPath: cacheMountPath,
+									},
+								})
+								fakeContainer.WorkerNameReturns("some-worker")
+							})
+
+							expectedCacheIdentifier := db.TaskCacheIdentifier{
+								PipelineID: 42,
+								JobName:    "some-job",
+								StepName:   "some-step",
+								Path:       "some/cache",
+								WorkerName: "some-worker",
+							}
+
+							It("looks up the job's cache on the chosen worker", func() {
+								Expect(fakeWorker.FindVolumeCallCount()).To(Equal(1))
+								_, vSpec := fakeWorker.FindVolumeArgsForCall(0)
+								Expect(vSpec.Strategy).To(Equal(worker.TaskCacheStrategy{
+									TaskCache: expectedCacheIdentifier,
+								}))
+							})
+
+							Context("when the worker has a cache", func() {
+								var cacheContents io.ReadCloser
+
+								BeforeEach(func() {
+									fakeWorker.FindVolumeReturns(fakeCacheVolume, true, nil)
+
+									cacheContents = ioutil.NopCloser(bytes.NewBufferString("some-cache-contents"))
+									fakeCacheVolume.StreamOutReturns(cacheContents, nil)
+								})
+
+								It("mounts an empty volume, with no parent", func() {
+									Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(1))
+									_, vSpec, actualTeamID := fakeWorker.CreateVolumeArgsForCall(0)
+									Expect(vSpec).To(Equal(worker.VolumeSpec{
+										Strategy:   worker.TaskCacheWorkspaceStrategy{},
+										TTL:        worker.VolumeTTL,
+										Privileged: bool(privileged),
+									}))
+									Expect(actualTeamID).To(Equal(teamID))
+
+									_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.Outputs).To(Equal([]worker.VolumeMount{
+										{
+											Volume:    fakeWorkspaceVolume,
+											MountPath: cacheMountPath,
+										},
+									}))
+								})
+
+								It("copies the cache into the volume", func() {
+									Expect(fakeCacheVolume.StreamOutCallCount()).To(Equal(1))
+									Expect(fakeCacheVolume.StreamOutArgsForCall(0)).To(Equal("."))
+
+									Expect(fakeWorkspaceVolume.StreamInCallCount()).To(Equal(1))
+									path, stream := fakeWorkspaceVolume.StreamInArgsForCall(0)
+									Expect(path).To(Equal("."))
+									Expect(stream).To(Equal(cacheContents))
+								})
+
+								It("releases the volumes", func() {
+									Expect(fakeCacheVolume.ReleaseCallCount()).To(Equal(1))
+									Expect(fakeWorkspaceVolume.ReleaseCallCount()).To(Equal(1))
+								})
+
+								Context("when copying the cache fails", func() {
+									disaster := errors.New("nope")
+
+									BeforeEach(func() {
+										fakeCacheVolume.StreamOutReturns(nil, disaster)
+									})
+
+									It("exits with the error, without creating the container", func() {
+										Expect(<-process.Wait()).To(Equal(disaster))
+										Expect(fakeWorker.CreateContainerCallCount()).To(BeZero())
+									})
+								})
+							})
+
+							Context("when the worker has no cache", func() {
+								BeforeEach(func() {
+									fakeWorker.FindVolumeReturns(nil, false, nil)
+								})
+
+								It("mounts an empty volume, without copying anything into it", func() {
+									Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(1))
+									_, vSpec, _ := fakeWorker.CreateVolumeArgsForCall(0)
+									Expect(vSpec.Strategy).To(Equal(worker.TaskCacheWorkspaceStrategy{}))
+									Expect(fakeWorkspaceVolume.StreamInCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the build is a one-off build", func() {
+								BeforeEach(func() {
+									workerMetadata.JobName = ""
+								})
+
+								It("does not look up a cache", func() {
+									Expect(fakeWorker.FindVolumeCallCount()).To(BeZero())
+									Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(1))
+								})
+
+								It("does not mark the volume as a cache", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(fakeWorkspaceVolume.MarkAsTaskCacheCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the process exits 0", func() {
+								BeforeEach(func() {
+									fakeProcess.WaitReturns(0, nil)
+								})
+
+								It("marks the mounted volume as the new cache", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(fakeWorkspaceVolume.MarkAsTaskCacheCallCount()).To(Equal(1))
+									Expect(fakeWorkspaceVolume.MarkAsTaskCacheArgsForCall(0)).To(Equal(expectedCacheIdentifier))
+								})
+							})
+
+							Context("when the process exits nonzero", func() {
+								BeforeEach(func() {
+									fakeProcess.WaitReturns(1, nil)
+								})
+
+								It("does not mark the mounted volume as a cache", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(fakeWorkspaceVolume.MarkAsTaskCacheCallCount()).To(BeZero())
+								})
+							})
+						})
+
 						Context("when output is remapped", func() {
 							BeforeEach(func() {
 								outputMapping = map[string]string{"generic-remapped-output": "specific-remapped-output"}
@@ -1783,6 +2045,49 @@ var _ = Describe("GardenFactory", func() {
 									Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
 									Expect(taskDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(1)))
 								})
+
+								Context("when the container was killed for exceeding its memory limit", func() {
+									BeforeEach(func() {
+										fakeContainer.InfoReturns(garden.ContainerInfo{
+											Events: []string{"Out of memory"},
+										}, nil)
+									})
+
+									It("invokes the delegate's FinishedOutOfMemory callback instead", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(taskDelegate.FinishedCallCount()).To(BeZero())
+										Expect(taskDelegate.FinishedOutOfMemoryCallCount()).To(Equal(1))
+										Expect(taskDelegate.FinishedOutOfMemoryArgsForCall(0)).To(Equal(ExitStatus(1)))
+									})
+
+									It("says so on stderr", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(stderrBuf).To(gbytes.Say("task was killed for exceeding its memory limit"))
+									})
+
+									It("is not successful", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										var success Success
+										Expect(step.Result(&success)).To(BeTrue())
+										Expect(bool(success)).To(BeFalse())
+									})
+								})
+
+								Context("when getting the container's info fails", func() {
+									BeforeEach(func() {
+										fakeContainer.InfoReturns(garden.ContainerInfo{}, errors.New("nope"))
+									})
+
+									It("still invokes the delegate's Finished callback", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
+										Expect(taskDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(1)))
+									})
+								})
 							})
 
 							Context("when saving the exit status fails", func() {
@@ -1944,116 +2249,78 @@ var _ = Describe("GardenFactory", func() {
 					})
 				})
 
-				Context("when more than one worker can be located", func() {
+				Context("when the configuration has inputs", func() {
 					var fakeWorker *wfakes.FakeWorker
-					var fakeWorker2 *wfakes.FakeWorker
-					var fakeWorker3 *wfakes.FakeWorker
+					var inputSource *execfakes.FakeArtifactSource
+					var otherInputSource *execfakes.FakeArtifactSource
+					var inputVolume *wfakes.FakeVolume
+					var otherInputVolume *wfakes.FakeVolume
 
 					BeforeEach(func() {
 						fakeWorker = new(wfakes.FakeWorker)
-						fakeWorker2 = new(wfakes.FakeWorker)
-						fakeWorker3 = new(wfakes.FakeWorker)
-
-						fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{fakeWorker, fakeWorker2, fakeWorker3}, nil)
+						fakeWorker.CreateContainerReturns(nil, errors.New("fall out of method here"))
+						fakeWorkerClient.ChooseReturns(fakeWorker, "some reason", nil)
+
+						inputVolume = new(wfakes.FakeVolume)
+						inputVolume.HandleReturns("input-volume")
+
+						otherInputVolume = new(wfakes.FakeVolume)
+						otherInputVolume.HandleReturns("other-input-volume")
+
+						inputSource = new(execfakes.FakeArtifactSource)
+						inputSource.VolumeRefReturns(worker.VolumeRef{Handle: "input-volume"}, true)
+						inputSource.VolumeOnReturns(inputVolume, true, nil)
+
+						otherInputSource = new(execfakes.FakeArtifactSource)
+						otherInputSource.VolumeOnReturns(otherInputVolume, true, nil)
+
+						repo.RegisterSource("some-input", inputSource)
+						repo.RegisterSource("some-other-input", otherInputSource)
+
+						configSource.FetchConfigReturns(atc.TaskConfig{
+							Platform: "some-platform",
+							Image:    "some-image",
+							Params:   map[string]string{"SOME": "params"},
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+								Args: []string{"some", "args"},
+							},
+							Inputs: []atc.TaskInputConfig{
+								{Name: "some-input"},
+								{Name: "some-other-input"},
+							},
+						}, nil)
 					})
 
-					Context("when the configuration has inputs", func() {
-						var inputSource *execfakes.FakeArtifactSource
-						var otherInputSource *execfakes.FakeArtifactSource
-
-						BeforeEach(func() {
-							inputSource = new(execfakes.FakeArtifactSource)
-							otherInputSource = new(execfakes.FakeArtifactSource)
-
-							configSource.FetchConfigReturns(atc.TaskConfig{
-								Platform: "some-platform",
-								Image:    "some-image",
-								Params:   map[string]string{"SOME": "params"},
-								Run: atc.TaskRunConfig{
-									Path: "ls",
-									Args: []string{"some", "args"},
-								},
-								Inputs: []atc.TaskInputConfig{
-									{Name: "some-input"},
-									{Name: "some-other-input"},
-								},
-							}, nil)
-						})
-
-						Context("when all inputs are present in the in source repository", func() {
-							BeforeEach(func() {
-								repo.RegisterSource("some-input", inputSource)
-								repo.RegisterSource("some-other-input", otherInputSource)
-							})
-
-							Context("and some workers have more matching input volumes than others", func() {
-								var rootVolume *wfakes.FakeVolume
-								var inputVolume *wfakes.FakeVolume
-								var inputVolume2 *wfakes.FakeVolume
-								var inputVolume3 *wfakes.FakeVolume
-								var otherInputVolume *wfakes.FakeVolume
-
-								BeforeEach(func() {
-									rootVolume = new(wfakes.FakeVolume)
-									rootVolume.HandleReturns("root-volume")
-
-									inputVolume = new(wfakes.FakeVolume)
-									inputVolume.HandleReturns("input-volume")
-
-									inputVolume2 = new(wfakes.FakeVolume)
-									inputVolume2.HandleReturns("input-volume")
-
-									inputVolume3 = new(wfakes.FakeVolume)
-									inputVolume3.HandleReturns("input-volume")
+					It("asks the pool for a worker holding the inputs' volumes", func() {
+						<-process.Wait()
 
-									otherInputVolume = new(wfakes.FakeVolume)
-									otherInputVolume.HandleReturns("other-input-volume")
+						Expect(fakeWorkerClient.ChooseCallCount()).To(Equal(1))
+						_, spec, _ := fakeWorkerClient.ChooseArgsForCall(0)
+						Expect(spec.Volumes).To(Equal([]worker.VolumeRef{{Handle: "input-volume"}}))
+					})
 
-									fakeWorker2.CreateVolumeReturns(rootVolume, nil)
+					It("only looks for the inputs' volumes on the chosen worker", func() {
+						<-process.Wait()
 
-									inputSource.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
-										if w == fakeWorker {
-											return inputVolume, true, nil
-										} else if w == fakeWorker2 {
-											return inputVolume2, true, nil
-										} else if w == fakeWorker3 {
-											return inputVolume3, true, nil
-										} else {
-											return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
-										}
-									}
+						Expect(inputSource.VolumeOnCallCount()).To(Equal(1))
+						Expect(inputSource.VolumeOnArgsForCall(0)).To(Equal(fakeWorker))
 
-									otherInputSource.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
-										if w == fakeWorker {
-											return nil, false, nil
-										} else if w == fakeWorker2 {
-											return otherInputVolume, true, nil
-										} else if w == fakeWorker3 {
-											return nil, false, nil
-										} else {
-											return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
-										}
-									}
+						Expect(otherInputSource.VolumeOnCallCount()).To(Equal(1))
+						Expect(otherInputSource.VolumeOnArgsForCall(0)).To(Equal(fakeWorker))
+					})
 
-									fakeWorker.CreateContainerReturns(nil, errors.New("fall out of method here"))
-									fakeWorker2.CreateContainerReturns(nil, errors.New("fall out of method here"))
-								})
+					It("creates the container on the chosen worker", func() {
+						<-process.Wait()
 
-								It("picks the worker that has the most", func() {
-									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(0))
-									Expect(fakeWorker2.CreateContainerCallCount()).To(Equal(1))
-									Expect(fakeWorker3.CreateContainerCallCount()).To(Equal(0))
-								})
+						Expect(fakeWorker.CreateContainerCallCount()).To(Equal(1))
+					})
 
-								It("releases the volumes on the unused workers", func() {
-									Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
-									Expect(inputVolume3.ReleaseCallCount()).To(Equal(1))
+					It("releases the input volumes", func() {
+						<-process.Wait()
 
-									Expect(inputVolume2.ReleaseCallCount()).To(Equal(1))
-									Expect(otherInputVolume.ReleaseCallCount()).To(Equal(1))
-								})
-							})
-						})
+						Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
+						Expect(otherInputVolume.ReleaseCallCount()).To(Equal(1))
 					})
 				})
 			})
@@ -1,7 +1,11 @@
 package buildreaper
 
 import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -9,6 +13,7 @@ import (
 
 type BuildReaperDB interface {
 	GetAllPipelines() ([]db.SavedPipeline, error)
+	GetUnreapedOneOffBuildsEndedBefore(endTime time.Time, limit int) ([]db.Build, error)
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 }
 
@@ -21,19 +26,44 @@ type buildReaper struct {
 	db                BuildReaperDB
 	pipelineDBFactory db.PipelineDBFactory
 	batchSize         int
+	clock             clock.Clock
+
+	defaultRetention  atc.BuildLogRetention
+	maxRetention      atc.BuildLogRetention
+	archivedRetention atc.BuildLogRetention
+	oneOffDays        int
 }
 
+// NewBuildReaper constructs a BuildReaper which reaps the logs of up to
+// batchSize builds per job per run.
+//
+// The default retention applies to any job which does not configure the
+// builds or days to retain, and the max retention caps whatever a job
+// configures. The archived retention replaces both for every job of an
+// archived pipeline. The logs of one-off builds are reaped oneOffDays after
+// they finish. A zero value for any of these means no limit.
 func NewBuildReaper(
 	logger lager.Logger,
 	db BuildReaperDB,
 	pipelineDBFactory db.PipelineDBFactory,
 	batchSize int,
+	clock clock.Clock,
+	defaultRetention atc.BuildLogRetention,
+	maxRetention atc.BuildLogRetention,
+	archivedRetention atc.BuildLogRetention,
+	oneOffDays int,
 ) BuildReaper {
 	return &buildReaper{
 		logger:            logger,
 		db:                db,
 		pipelineDBFactory: pipelineDBFactory,
 		batchSize:         batchSize,
+		clock:             clock,
+
+		defaultRetention:  defaultRetention,
+		maxRetention:      maxRetention,
+		archivedRetention: archivedRetention,
+		oneOffDays:        oneOffDays,
 	}
 }
 
@@ -44,8 +74,14 @@ func (br *buildReaper) Run() error {
 		return err
 	}
 
+	archivedRetentionIsForever := br.archivedRetention.Builds == 0 && br.archivedRetention.Days == 0
+
 	for _, pipeline := range pipelines {
-		if pipeline.Paused {
+		if pipeline.Archived {
+			if archivedRetentionIsForever {
+				continue
+			}
+		} else if pipeline.Paused {
 			continue
 		}
 
@@ -58,97 +94,254 @@ func (br *buildReaper) Run() error {
 		}
 
 		for _, job := range jobs {
-			if job.Config.BuildLogsToRetain == 0 {
-				continue
+			retention := br.retentionFor(job.Config)
+			if pipeline.Archived {
+				retention = br.archivedRetention
 			}
 
-			buildsToConsiderDeleting := []db.Build{}
-			until := job.FirstLoggedBuildID - 1
-			limit := br.batchSize
+			err := br.reapJobBuilds(pipelineDB, job, retention)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return br.reapOneOffBuilds()
+}
+
+func (br *buildReaper) reapJobBuilds(pipelineDB db.PipelineDB, job db.SavedJob, retention atc.BuildLogRetention) error {
+	if retention.Builds == 0 && retention.Days == 0 {
+		return nil
+	}
 
-			if job.FirstLoggedBuildID <= 1 {
-				until = 1
+	firstBuildToRetain := 0
+	if retention.Builds != 0 {
+		buildsToRetain, _, err := pipelineDB.GetJobBuilds(
+			job.Job.Name,
+			db.Page{Limit: retention.Builds},
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-to-retain", err)
+			return err
+		}
 
-				buildsToConsiderDeleting, _, err = pipelineDB.GetJobBuilds(
-					job.Job.Name,
-					db.Page{Since: 2, Limit: 1},
-				)
-				if err != nil {
-					br.logger.Error("could-not-get-job-build-1-to-delete", err)
-					return err
-				}
+		if len(buildsToRetain) == 0 {
+			return nil
+		}
 
-				limit -= len(buildsToConsiderDeleting)
-			}
+		firstBuildToRetain = buildsToRetain[len(buildsToRetain)-1].ID()
+	}
+
+	succeededBuildsToRetain := map[int]bool{}
+	if retention.MinimumSucceededBuilds != 0 {
+		succeededBuilds, err := pipelineDB.GetLatestSucceededJobBuilds(
+			job.Job.Name,
+			retention.MinimumSucceededBuilds,
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-succeeded-job-builds-to-retain", err)
+			return err
+		}
+
+		for _, build := range succeededBuilds {
+			succeededBuildsToRetain[build.ID()] = true
+		}
+	}
+
+	expiredBefore := br.clock.Now().AddDate(0, 0, -retention.Days)
+
+	isExpired := func(build db.Build) bool {
+		if retention.Builds != 0 && build.ID() < firstBuildToRetain {
+			return true
+		}
+
+		if retention.Days != 0 && !build.EndTime().IsZero() && build.EndTime().Before(expiredBefore) {
+			return true
+		}
+
+		return false
+	}
+
+	buildsToConsiderDeleting, err := br.oldestLoggedBuilds(pipelineDB, job)
+	if err != nil {
+		return err
+	}
+
+	buildIDsToDelete := []int{}
+	newFirstLoggedBuildID := 0
+
+	// builds retained because they succeeded do not stop the reaping of newer
+	// builds, but they do keep the first logged build ID from moving past them
+	retainingSucceededBuild := false
 
-			if limit > 0 {
-				moreBuildsToConsiderDeleting, _, err := pipelineDB.GetJobBuilds(
-					job.Job.Name,
-					db.Page{Until: until, Limit: limit},
-				)
-				if err != nil {
-					br.logger.Error("could-not-get-job-builds-to-delete", err)
-					return err
-				}
-
-				buildsToConsiderDeleting = append(
-					moreBuildsToConsiderDeleting,
-					buildsToConsiderDeleting...,
-				)
+	done := false
+	for {
+		for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
+			build := buildsToConsiderDeleting[i]
+
+			if build.IsRunning() {
+				done = true
+				break
 			}
 
-			buildIDsToConsiderDeleting := []int{}
-			for _, build := range buildsToConsiderDeleting {
-				buildIDsToConsiderDeleting = append(buildIDsToConsiderDeleting, build.ID())
+			if succeededBuildsToRetain[build.ID()] {
+				retainingSucceededBuild = true
+				continue
 			}
 
-			buildsToRetain, _, err := pipelineDB.GetJobBuilds(
-				job.Job.Name,
-				db.Page{Limit: job.Config.BuildLogsToRetain},
-			)
-			if err != nil {
-				br.logger.Error("could-not-get-job-builds-to-retain", err)
-				return err
+			if !isExpired(build) {
+				done = true
+				break
 			}
 
-			buildIDsToRetain := []int{}
-			for _, build := range buildsToRetain {
-				buildIDsToRetain = append(buildIDsToRetain, build.ID())
+			if !retainingSucceededBuild {
+				newFirstLoggedBuildID = build.ID() + 1
 			}
 
-			if len(buildsToRetain) == 0 {
+			if !build.ReapTime().IsZero() {
+				// reaped by an earlier run while a succeeded build was retained
 				continue
 			}
 
-			firstBuildToRetain := buildsToRetain[len(buildsToRetain)-1].ID()
+			buildIDsToDelete = append(buildIDsToDelete, build.ID())
 
-			buildIDsToDelete := []int{}
-			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
-				build := buildsToConsiderDeleting[i]
+			if len(buildIDsToDelete) == br.batchSize {
+				done = true
+				break
+			}
+		}
 
-				if build.ID() >= firstBuildToRetain || build.IsRunning() {
-					break
-				}
+		if done || !retainingSucceededBuild || len(buildsToConsiderDeleting) == 0 {
+			break
+		}
 
-				buildIDsToDelete = append(buildIDsToDelete, build.ID())
-			}
+		// keep looking past the retained succeeded builds, so that they do not
+		// hold back the reaping of the builds after them; the builds reaped by
+		// earlier runs are skipped so that they are not scanned again
+		buildsToConsiderDeleting, err = pipelineDB.GetUnreapedJobBuildsAfter(
+			job.Job.Name,
+			buildsToConsiderDeleting[0].ID(),
+			br.batchSize,
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-to-delete", err)
+			return err
+		}
+	}
 
-			if len(buildIDsToDelete) == 0 {
-				continue
-			}
+	if len(buildIDsToDelete) != 0 {
+		err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+		if err != nil {
+			br.logger.Error("could-not-delete-build-events", err)
+			return err
+		}
+	}
 
-			err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
-			if err != nil {
-				br.logger.Error("could-not-delete-build-events", err)
-				return err
-			}
+	if newFirstLoggedBuildID > job.FirstLoggedBuildID {
+		err = pipelineDB.UpdateFirstLoggedBuildID(job.Job.Name, newFirstLoggedBuildID)
+		if err != nil {
+			br.logger.Error("could-not-update-first-logged-build-id", err)
+			return err
+		}
+	}
 
-			err = pipelineDB.UpdateFirstLoggedBuildID(job.Job.Name, buildIDsToDelete[len(buildIDsToDelete)-1]+1)
-			if err != nil {
-				br.logger.Error("could-not-update-first-logged-build-id", err)
-				return err
-			}
+	return nil
+}
+
+// oldestLoggedBuilds returns up to batchSize builds of the job, starting with
+// its first logged build, newest first.
+func (br *buildReaper) oldestLoggedBuilds(pipelineDB db.PipelineDB, job db.SavedJob) ([]db.Build, error) {
+	var err error
+
+	buildsToConsiderDeleting := []db.Build{}
+	until := job.FirstLoggedBuildID - 1
+	limit := br.batchSize
+
+	if job.FirstLoggedBuildID <= 1 {
+		until = 1
+
+		buildsToConsiderDeleting, _, err = pipelineDB.GetJobBuilds(
+			job.Job.Name,
+			db.Page{Since: 2, Limit: 1},
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-job-build-1-to-delete", err)
+			return nil, err
 		}
+
+		limit -= len(buildsToConsiderDeleting)
+	}
+
+	if limit > 0 {
+		moreBuildsToConsiderDeleting, _, err := pipelineDB.GetJobBuilds(
+			job.Job.Name,
+			db.Page{Until: until, Limit: limit},
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-to-delete", err)
+			return nil, err
+		}
+
+		buildsToConsiderDeleting = append(
+			moreBuildsToConsiderDeleting,
+			buildsToConsiderDeleting...,
+		)
+	}
+
+	return buildsToConsiderDeleting, nil
+}
+
+func (br *buildReaper) retentionFor(config atc.JobConfig) atc.BuildLogRetention {
+	retention := atc.BuildLogRetention{Builds: config.BuildLogsToRetain}
+	if config.BuildLogRetention != nil {
+		retention = *config.BuildLogRetention
+	}
+
+	if retention.Builds == 0 {
+		retention.Builds = br.defaultRetention.Builds
+	}
+
+	if retention.Days == 0 {
+		retention.Days = br.defaultRetention.Days
+	}
+
+	if br.maxRetention.Builds != 0 && (retention.Builds == 0 || retention.Builds > br.maxRetention.Builds) {
+		retention.Builds = br.maxRetention.Builds
+	}
+
+	if br.maxRetention.Days != 0 && (retention.Days == 0 || retention.Days > br.maxRetention.Days) {
+		retention.Days = br.maxRetention.Days
+	}
+
+	return retention
+}
+
+func (br *buildReaper) reapOneOffBuilds() error {
+	if br.oneOffDays == 0 {
+		return nil
+	}
+
+	expiredBefore := br.clock.Now().AddDate(0, 0, -br.oneOffDays)
+
+	builds, err := br.db.GetUnreapedOneOffBuildsEndedBefore(expiredBefore, br.batchSize)
+	if err != nil {
+		br.logger.Error("could-not-get-one-off-builds-to-delete", err)
+		return err
+	}
+
+	if len(builds) == 0 {
+		return nil
+	}
+
+	buildIDsToDelete := []int{}
+	for _, build := range builds {
+		buildIDsToDelete = append(buildIDsToDelete, build.ID())
+	}
+
+	err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+	if err != nil {
+		br.logger.Error("could-not-delete-one-off-build-events", err)
+		return err
 	}
 
 	return nil
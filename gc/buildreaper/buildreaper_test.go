@@ -3,7 +3,9 @@ package buildreaper_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -20,13 +22,23 @@ var _ = Describe("BuildReaper", func() {
 		buildReaper           BuildReaper
 		fakeBuildReaperDB     *buildreaperfakes.FakeBuildReaperDB
 		fakePipelineDBFactory *dbfakes.FakePipelineDBFactory
+		fakeClock             *fakeclock.FakeClock
 		batchSize             int
+		defaultRetention      atc.BuildLogRetention
+		maxRetention          atc.BuildLogRetention
+		archivedRetention     atc.BuildLogRetention
+		oneOffDays            int
 	)
 
 	BeforeEach(func() {
 		fakeBuildReaperDB = new(buildreaperfakes.FakeBuildReaperDB)
 		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Date(2017, time.March, 10, 12, 0, 0, 0, time.UTC))
 		batchSize = 5
+		defaultRetention = atc.BuildLogRetention{}
+		maxRetention = atc.BuildLogRetention{}
+		archivedRetention = atc.BuildLogRetention{}
+		oneOffDays = 0
 	})
 
 	JustBeforeEach(func() {
@@ -36,6 +48,11 @@ var _ = Describe("BuildReaper", func() {
 			fakeBuildReaperDB,
 			fakePipelineDBFactory,
 			batchSize,
+			fakeClock,
+			defaultRetention,
+			maxRetention,
+			archivedRetention,
+			oneOffDays,
 		)
 	})
 
@@ -276,6 +293,56 @@ var _ = Describe("BuildReaper", func() {
 				})
 			})
 
+			Context("when all the builds after the retained succeeded build in the batch were already reaped", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetJobsReturns([]db.SavedJob{
+						db.SavedJob{
+							Job:                db.Job{Name: "job-1"},
+							FirstLoggedBuildID: 7,
+							Config: atc.JobConfig{
+								BuildLogRetention: &atc.BuildLogRetention{
+									Builds:                 10,
+									MinimumSucceededBuilds: 1,
+								},
+							},
+						},
+					}, nil)
+
+					fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+						if job == "job-1" && page == (db.Page{Limit: 10}) {
+							return []db.Build{sb(25), sb(24), sb(23), sb(22), sb(21), sb(20), sb(19), sb(18), sb(17), sb(16)}, db.Pagination{}, nil
+						} else if job == "job-1" && page == (db.Page{Until: 6, Limit: 5}) {
+							return []db.Build{reapedBuild(11), reapedBuild(10), reapedBuild(9), reapedBuild(8), sb(7)}, db.Pagination{}, nil
+						} else {
+							Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+						}
+						return nil, db.Pagination{}, nil
+					}
+
+					fakePipelineDB.GetUnreapedJobBuildsAfterReturns([]db.Build{sb(15), sb(14)}, nil)
+				})
+
+				It("only looks at the unreaped builds after them", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipelineDB.GetUnreapedJobBuildsAfterCallCount()).To(Equal(1))
+					job, buildID, limit := fakePipelineDB.GetUnreapedJobBuildsAfterArgsForCall(0)
+					Expect(job).To(Equal("job-1"))
+					Expect(buildID).To(Equal(11))
+					Expect(limit).To(Equal(5))
+				})
+
+				It("reaps the unreaped builds", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(14, 15))
+				})
+			})
+
 			Context("when no builds exist", func() {
 				BeforeEach(func() {
 					fakePipelineDB.GetJobBuildsReturns(nil, db.Pagination{}, nil)
@@ -509,6 +576,402 @@ var _ = Describe("BuildReaper", func() {
 				Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
 			})
 		})
+
+		Context("when the job retains build logs by age", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetJobsReturns([]db.SavedJob{
+					db.SavedJob{
+						Job:                db.Job{Name: "job-1"},
+						FirstLoggedBuildID: 6,
+						Config: atc.JobConfig{
+							BuildLogRetention: &atc.BuildLogRetention{Days: 7},
+						},
+					},
+				}, nil)
+
+				now := fakeClock.Now()
+
+				fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+					if job == "job-1" && page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{
+							finishedBuild(10, now.Add(-24*time.Hour)),
+							finishedBuild(9, now.Add(-2*24*time.Hour)),
+							finishedBuild(8, now.Add(-8*24*time.Hour)),
+							finishedBuild(7, now.Add(-9*24*time.Hour)),
+							finishedBuild(6, now.Add(-10*24*time.Hour)),
+						}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+			})
+
+			It("reaps the builds that finished before the retention period", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+				Expect(actualBuildIDs).To(ConsistOf(6, 7, 8))
+			})
+
+			It("updates FirstLoggedBuildID to n+1, n = latest reaped build ID", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+				_, actualNewFirstLoggedBuildID := fakePipelineDB.UpdateFirstLoggedBuildIDArgsForCall(0)
+				Expect(actualNewFirstLoggedBuildID).To(Equal(9))
+			})
+
+			Context("when the max retention period is shorter", func() {
+				BeforeEach(func() {
+					maxRetention = atc.BuildLogRetention{Days: 1}
+				})
+
+				It("reaps the builds that finished before the max retention period", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6, 7, 8, 9))
+				})
+			})
+		})
+
+		Context("when the job does not configure build log retention", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetJobsReturns([]db.SavedJob{
+					db.SavedJob{
+						Job:                db.Job{Name: "job-1"},
+						FirstLoggedBuildID: 6,
+					},
+				}, nil)
+
+				fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+					if job == "job-1" && page == (db.Page{Limit: 10}) {
+						return []db.Build{sb(18), sb(17), sb(16), sb(15), sb(14), sb(13), sb(12), sb(11), sb(10), sb(9)}, db.Pagination{}, nil
+					} else if job == "job-1" && page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{sb(10), sb(9), sb(8), sb(7), sb(6)}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+			})
+
+			It("doesn't reap any builds", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineDB.GetJobBuildsCallCount()).To(BeZero())
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+			})
+
+			Context("when there is a default retention", func() {
+				BeforeEach(func() {
+					defaultRetention = atc.BuildLogRetention{Builds: 10}
+				})
+
+				It("reaps the builds using the default retention", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6, 7, 8))
+				})
+			})
+
+			Context("when there is a max retention", func() {
+				BeforeEach(func() {
+					maxRetention = atc.BuildLogRetention{Builds: 10}
+				})
+
+				It("reaps the builds using the max retention", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6, 7, 8))
+				})
+			})
+		})
+
+		Context("when the job retains more builds than the max retention", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetJobsReturns([]db.SavedJob{
+					db.SavedJob{
+						Job:                db.Job{Name: "job-1"},
+						FirstLoggedBuildID: 6,
+						Config: atc.JobConfig{
+							BuildLogsToRetain: 100,
+						},
+					},
+				}, nil)
+
+				maxRetention = atc.BuildLogRetention{Builds: 10}
+
+				fakePipelineDB.GetJobBuildsReturns(nil, db.Pagination{}, nil)
+			})
+
+			It("retains only the max number of builds", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineDB.GetJobBuildsCallCount()).To(Equal(1))
+				_, page := fakePipelineDB.GetJobBuildsArgsForCall(0)
+				Expect(page).To(Equal(db.Page{Limit: 10}))
+			})
+		})
+
+		Context("when the job retains a minimum number of succeeded builds", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetJobsReturns([]db.SavedJob{
+					db.SavedJob{
+						Job:                db.Job{Name: "job-1"},
+						FirstLoggedBuildID: 6,
+						Config: atc.JobConfig{
+							BuildLogRetention: &atc.BuildLogRetention{
+								Builds:                 10,
+								MinimumSucceededBuilds: 1,
+							},
+						},
+					},
+				}, nil)
+
+				fakePipelineDB.GetLatestSucceededJobBuildsReturns([]db.Build{sb(7)}, nil)
+
+				fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+					if job == "job-1" && page == (db.Page{Limit: 10}) {
+						return []db.Build{sb(25), sb(24), sb(23), sb(22), sb(21), sb(20), sb(19), sb(18), sb(17), sb(16)}, db.Pagination{}, nil
+					} else if job == "job-1" && page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{sb(10), sb(9), sb(8), sb(7), sb(6)}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+
+				fakePipelineDB.GetUnreapedJobBuildsAfterStub = func(job string, buildID int, limit int) ([]db.Build, error) {
+					if job == "job-1" && buildID == 10 && limit == 5 {
+						return []db.Build{sb(15), sb(14), sb(13), sb(12), sb(11)}, nil
+					} else {
+						Fail(fmt.Sprintf("GetUnreapedJobBuildsAfter called with unexpected arguments: job=%s, buildID=%d, limit=%d", job, buildID, limit))
+					}
+					return nil, nil
+				}
+			})
+
+			It("looks up the latest succeeded builds", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineDB.GetLatestSucceededJobBuildsCallCount()).To(Equal(1))
+				job, limit := fakePipelineDB.GetLatestSucceededJobBuildsArgsForCall(0)
+				Expect(job).To(Equal("job-1"))
+				Expect(limit).To(Equal(1))
+			})
+
+			It("reaps n builds around the retained succeeded build, n = batchSize", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+				Expect(actualBuildIDs).To(ConsistOf(6, 8, 9, 10, 11))
+			})
+
+			It("updates FirstLoggedBuildID to the retained succeeded build", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+				_, actualNewFirstLoggedBuildID := fakePipelineDB.UpdateFirstLoggedBuildIDArgsForCall(0)
+				Expect(actualNewFirstLoggedBuildID).To(Equal(7))
+			})
+
+			Context("when builds after the retained succeeded build were already reaped", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetJobsReturns([]db.SavedJob{
+						db.SavedJob{
+							Job:                db.Job{Name: "job-1"},
+							FirstLoggedBuildID: 7,
+							Config: atc.JobConfig{
+								BuildLogRetention: &atc.BuildLogRetention{
+									Builds:                 10,
+									MinimumSucceededBuilds: 1,
+								},
+							},
+						},
+					}, nil)
+
+					fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+						if job == "job-1" && page == (db.Page{Limit: 10}) {
+							return []db.Build{sb(25), sb(24), sb(23), sb(22), sb(21), sb(20), sb(19), sb(18), sb(17), sb(16)}, db.Pagination{}, nil
+						} else if job == "job-1" && page == (db.Page{Until: 6, Limit: 5}) {
+							return []db.Build{sb(11), sb(10), reapedBuild(9), reapedBuild(8), sb(7)}, db.Pagination{}, nil
+						} else {
+							Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+						}
+						return nil, db.Pagination{}, nil
+					}
+
+					fakePipelineDB.GetUnreapedJobBuildsAfterReturns([]db.Build{}, nil)
+				})
+
+				It("only reaps the builds that were not yet reaped", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(10, 11))
+				})
+
+				It("doesn't update FirstLoggedBuildID", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when all the builds after the retained succeeded build in the batch were already reaped", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetJobsReturns([]db.SavedJob{
+						db.SavedJob{
+							Job:                db.Job{Name: "job-1"},
+							FirstLoggedBuildID: 7,
+							Config: atc.JobConfig{
+								BuildLogRetention: &atc.BuildLogRetention{
+									Builds:                 10,
+									MinimumSucceededBuilds: 1,
+								},
+							},
+						},
+					}, nil)
+
+					fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+						if job == "job-1" && page == (db.Page{Limit: 10}) {
+							return []db.Build{sb(25), sb(24), sb(23), sb(22), sb(21), sb(20), sb(19), sb(18), sb(17), sb(16)}, db.Pagination{}, nil
+						} else if job == "job-1" && page == (db.Page{Until: 6, Limit: 5}) {
+							return []db.Build{reapedBuild(11), reapedBuild(10), reapedBuild(9), reapedBuild(8), sb(7)}, db.Pagination{}, nil
+						} else {
+							Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+						}
+						return nil, db.Pagination{}, nil
+					}
+
+					fakePipelineDB.GetUnreapedJobBuildsAfterReturns([]db.Build{sb(15), sb(14)}, nil)
+				})
+
+				It("only looks at the unreaped builds after them", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipelineDB.GetUnreapedJobBuildsAfterCallCount()).To(Equal(1))
+					job, buildID, limit := fakePipelineDB.GetUnreapedJobBuildsAfterArgsForCall(0)
+					Expect(job).To(Equal("job-1"))
+					Expect(buildID).To(Equal(11))
+					Expect(limit).To(Equal(5))
+				})
+
+				It("reaps the unreaped builds", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(14, 15))
+				})
+			})
+
+			Context("when getting the succeeded builds fails", func() {
+				var disaster error
+
+				BeforeEach(func() {
+					disaster = errors.New("major malfunction")
+
+					fakePipelineDB.GetLatestSucceededJobBuildsReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					err := buildReaper.Run()
+					Expect(err).To(Equal(disaster))
+				})
+			})
+		})
+	})
+
+	Context("when one-off build logs are retained for some days", func() {
+		BeforeEach(func() {
+			oneOffDays = 3
+		})
+
+		Context("when there are one-off builds to reap", func() {
+			BeforeEach(func() {
+				fakeBuildReaperDB.GetUnreapedOneOffBuildsEndedBeforeReturns([]db.Build{sb(1), sb(4)}, nil)
+			})
+
+			It("looks up the one-off builds that finished before the retention period", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.GetUnreapedOneOffBuildsEndedBeforeCallCount()).To(Equal(1))
+				endTime, limit := fakeBuildReaperDB.GetUnreapedOneOffBuildsEndedBeforeArgsForCall(0)
+				Expect(endTime).To(Equal(fakeClock.Now().Add(-3 * 24 * time.Hour)))
+				Expect(limit).To(Equal(batchSize))
+			})
+
+			It("reaps them", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(Equal([]int{1, 4}))
+			})
+		})
+
+		Context("when there are no one-off builds to reap", func() {
+			BeforeEach(func() {
+				fakeBuildReaperDB.GetUnreapedOneOffBuildsEndedBeforeReturns([]db.Build{}, nil)
+			})
+
+			It("doesn't reap any builds", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when getting the one-off builds fails", func() {
+			var disaster error
+
+			BeforeEach(func() {
+				disaster = errors.New("major malfunction")
+
+				fakeBuildReaperDB.GetUnreapedOneOffBuildsEndedBeforeReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				err := buildReaper.Run()
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when one-off build logs are retained forever", func() {
+		It("doesn't look for one-off builds to reap", func() {
+			err := buildReaper.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuildReaperDB.GetUnreapedOneOffBuildsEndedBeforeCallCount()).To(BeZero())
+		})
 	})
 
 	Context("when there is a paused pipeline", func() {
@@ -527,6 +990,56 @@ var _ = Describe("BuildReaper", func() {
 		})
 	})
 
+	Context("when there is an archived pipeline", func() {
+		var fakePipelineDB *dbfakes.FakePipelineDB
+
+		BeforeEach(func() {
+			fakeBuildReaperDB.GetAllPipelinesReturns([]db.SavedPipeline{
+				{ID: 42, Paused: true, Archived: true},
+			}, nil)
+
+			fakePipelineDB = new(dbfakes.FakePipelineDB)
+			fakePipelineDBFactory.BuildReturns(fakePipelineDB)
+
+			fakePipelineDB.GetJobsReturns([]db.SavedJob{
+				db.SavedJob{
+					Job:                db.Job{Name: "job-1"},
+					FirstLoggedBuildID: 6,
+					Config: atc.JobConfig{
+						BuildLogsToRetain: 100,
+					},
+				},
+			}, nil)
+
+			fakePipelineDB.GetJobBuildsReturns(nil, db.Pagination{}, nil)
+		})
+
+		It("retains all of its build logs by default", func() {
+			err := buildReaper.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePipelineDBFactory.BuildCallCount()).To(BeZero())
+			Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+		})
+
+		Context("when there is an archived retention", func() {
+			BeforeEach(func() {
+				archivedRetention = atc.BuildLogRetention{Builds: 5}
+				defaultRetention = atc.BuildLogRetention{Builds: 50}
+				maxRetention = atc.BuildLogRetention{Builds: 20}
+			})
+
+			It("reaps its builds using the archived retention, regardless of the job's", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineDB.GetJobBuildsCallCount()).To(Equal(1))
+				_, page := fakePipelineDB.GetJobBuildsArgsForCall(0)
+				Expect(page).To(Equal(db.Page{Limit: 5}))
+			})
+		})
+	})
+
 	Context("when getting the pipelines fails", func() {
 		var disaster error
 
@@ -550,6 +1063,20 @@ func sb(id int) db.Build {
 	return build
 }
 
+func finishedBuild(id int, endTime time.Time) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.EndTimeReturns(endTime)
+	return build
+}
+
+func reapedBuild(id int) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.ReapTimeReturns(time.Now())
+	return build
+}
+
 func runningBuild(id int) db.Build {
 	build := new(dbfakes.FakeBuild)
 	build.IDReturns(id)
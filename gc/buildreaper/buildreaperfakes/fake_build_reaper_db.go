@@ -3,6 +3,7 @@ package buildreaperfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/gc/buildreaper"
@@ -24,6 +25,16 @@ type FakeBuildReaperDB struct {
 	deleteBuildEventsByBuildIDsReturns struct {
 		result1 error
 	}
+	GetUnreapedOneOffBuildsEndedBeforeStub        func(endTime time.Time, limit int) ([]db.Build, error)
+	getUnreapedOneOffBuildsEndedBeforeMutex       sync.RWMutex
+	getUnreapedOneOffBuildsEndedBeforeArgsForCall []struct {
+		endTime time.Time
+		limit   int
+	}
+	getUnreapedOneOffBuildsEndedBeforeReturns struct {
+		result1 []db.Build
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -92,6 +103,41 @@ func (fake *FakeBuildReaperDB) DeleteBuildEventsByBuildIDsReturns(result1 error)
 	}{result1}
 }
 
+func (fake *FakeBuildReaperDB) GetUnreapedOneOffBuildsEndedBefore(endTime time.Time, limit int) ([]db.Build, error) {
+	fake.getUnreapedOneOffBuildsEndedBeforeMutex.Lock()
+	fake.getUnreapedOneOffBuildsEndedBeforeArgsForCall = append(fake.getUnreapedOneOffBuildsEndedBeforeArgsForCall, struct {
+		endTime time.Time
+		limit   int
+	}{endTime, limit})
+	fake.recordInvocation("GetUnreapedOneOffBuildsEndedBefore", []interface{}{endTime, limit})
+	fake.getUnreapedOneOffBuildsEndedBeforeMutex.Unlock()
+	if fake.GetUnreapedOneOffBuildsEndedBeforeStub != nil {
+		return fake.GetUnreapedOneOffBuildsEndedBeforeStub(endTime, limit)
+	} else {
+		return fake.getUnreapedOneOffBuildsEndedBeforeReturns.result1, fake.getUnreapedOneOffBuildsEndedBeforeReturns.result2
+	}
+}
+
+func (fake *FakeBuildReaperDB) GetUnreapedOneOffBuildsEndedBeforeCallCount() int {
+	fake.getUnreapedOneOffBuildsEndedBeforeMutex.RLock()
+	defer fake.getUnreapedOneOffBuildsEndedBeforeMutex.RUnlock()
+	return len(fake.getUnreapedOneOffBuildsEndedBeforeArgsForCall)
+}
+
+func (fake *FakeBuildReaperDB) GetUnreapedOneOffBuildsEndedBeforeArgsForCall(i int) (time.Time, int) {
+	fake.getUnreapedOneOffBuildsEndedBeforeMutex.RLock()
+	defer fake.getUnreapedOneOffBuildsEndedBeforeMutex.RUnlock()
+	return fake.getUnreapedOneOffBuildsEndedBeforeArgsForCall[i].endTime, fake.getUnreapedOneOffBuildsEndedBeforeArgsForCall[i].limit
+}
+
+func (fake *FakeBuildReaperDB) GetUnreapedOneOffBuildsEndedBeforeReturns(result1 []db.Build, result2 error) {
+	fake.GetUnreapedOneOffBuildsEndedBeforeStub = nil
+	fake.getUnreapedOneOffBuildsEndedBeforeReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildReaperDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -99,6 +145,8 @@ func (fake *FakeBuildReaperDB) Invocations() map[string][][]interface{} {
 	defer fake.getAllPipelinesMutex.RUnlock()
 	fake.deleteBuildEventsByBuildIDsMutex.RLock()
 	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
+	fake.getUnreapedOneOffBuildsEndedBeforeMutex.RLock()
+	defer fake.getUnreapedOneOffBuildsEndedBeforeMutex.RUnlock()
 	return fake.invocations
 }
 
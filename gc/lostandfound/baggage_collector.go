@@ -2,6 +2,7 @@ package lostandfound
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"time"
 
@@ -108,6 +109,8 @@ func (bc *baggageCollector) getLatestVersionSet() (hashedVersionSet, error) {
 		}
 
 		for _, pipelineJob := range pipeline.Config.Jobs {
+			insertOrIncreaseVersionTTL(latestVersions, taskCacheHashKey(pipeline.ID, pipelineJob.Name), 0) // live forever
+
 			logger := bc.logger.WithData(lager.Data{
 				"pipeline": pipeline.Name,
 				"job":      pipelineJob.Name,
@@ -164,6 +167,13 @@ func resourceCacheHashKey(volume db.SavedVolume) (string, bool) {
 	return string(version) + resourceCacheID.ResourceHash, true
 }
 
+// taskCacheHashKey is the key under which task caches for a job are kept
+// alive; it can not collide with resource cache keys, which start with the
+// version's JSON.
+func taskCacheHashKey(pipelineID int, jobName string) string {
+	return fmt.Sprintf("task-cache:%d/%s", pipelineID, jobName)
+}
+
 func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error {
 	volumesToExpire, err := bc.db.GetVolumes()
 	if err != nil {
@@ -195,6 +205,7 @@ func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error
 		}
 
 		var hashKey string
+		var ttlForVol time.Duration
 		switch {
 		case volumeToExpire.Volume.Identifier.ResourceCache != nil:
 			version, err := json.Marshal(volumeToExpire.Volume.Identifier.ResourceCache.ResourceVersion)
@@ -210,22 +221,32 @@ func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error
 			}
 
 			hashKey = identifier.WorkerName + identifier.Path + *identifier.Version
+		case volumeToExpire.Volume.Identifier.TaskCache != nil:
+			// there is only ever one current cache per task cache identifier, so
+			// there are no duplicates to look for
+			identifier := volumeToExpire.Volume.Identifier.TaskCache
+			if ttl, found := latestVersions[taskCacheHashKey(identifier.PipelineID, identifier.JobName)]; found && !identifier.Cleared {
+				ttlForVol = ttl
+			} else {
+				ttlForVol = bc.oldResourceGracePeriod
+			}
 		default:
 			continue
 		}
 
-		identifier := hashKey + volumeToExpire.WorkerName
+		if hashKey != "" {
+			identifier := hashKey + volumeToExpire.WorkerName
 
-		var ttlForVol time.Duration
-		if _, found := seenIdentifiers[identifier]; found {
-			ttlForVol = bc.oldResourceGracePeriod
-		} else if ttl, found := latestVersions[hashKey]; found {
-			ttlForVol = ttl
-		} else {
-			ttlForVol = bc.oldResourceGracePeriod
-		}
+			if _, found := seenIdentifiers[identifier]; found {
+				ttlForVol = bc.oldResourceGracePeriod
+			} else if ttl, found := latestVersions[hashKey]; found {
+				ttlForVol = ttl
+			} else {
+				ttlForVol = bc.oldResourceGracePeriod
+			}
 
-		seenIdentifiers[identifier] = true
+			seenIdentifiers[identifier] = true
+		}
 
 		if volumeToExpire.TTL == ttlForVol ||
 			(volumeToExpire.ContainerTTL != nil && ttlGreater(*volumeToExpire.ContainerTTL, ttlForVol)) {
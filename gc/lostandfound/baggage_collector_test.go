@@ -47,6 +47,7 @@ var _ = Describe("Baggage Collector", func() {
 
 	type baggageCollectionExample struct {
 		pipelineData map[string][]resourceConfigAndVersions
+		jobData      map[string][]atc.JobConfig
 		volumeData   []db.Volume
 		expectedTTLs map[string]time.Duration
 	}
@@ -101,7 +102,10 @@ var _ = Describe("Baggage Collector", func() {
 					config.Resources = append(config.Resources, resourceData.config)
 				}
 
+				config.Jobs = example.jobData[name]
+
 				savedPipelines = append(savedPipelines, db.SavedPipeline{
+					ID: len(savedPipelines) + 1,
 					Pipeline: db.Pipeline{
 						Name:   name,
 						Config: config,
@@ -838,5 +842,79 @@ var _ = Describe("Baggage Collector", func() {
 				"some-volume-handle": expectedOldResourceGracePeriod,
 			},
 		}),
+		Entry("when there are task cache volumes present", baggageCollectionExample{
+			pipelineData: map[string][]resourceConfigAndVersions{
+				"pipeline-a": []resourceConfigAndVersions{},
+			},
+			jobData: map[string][]atc.JobConfig{
+				"pipeline-a": []atc.JobConfig{
+					{Name: "some-job"},
+				},
+			},
+			volumeData: []db.Volume{
+				{
+					WorkerName: "some-worker",
+					TTL:        expectedLatestVersionTTL,
+					Handle:     "some-volume-handle-1",
+					Identifier: db.VolumeIdentifier{
+						TaskCache: &db.TaskCacheIdentifier{
+							PipelineID: 1,
+							JobName:    "some-job",
+							StepName:   "some-task",
+							Path:       "some/cache",
+							WorkerName: "some-worker",
+						},
+					},
+				},
+				{
+					WorkerName: "some-worker",
+					TTL:        expectedLatestVersionTTL,
+					Handle:     "some-volume-handle-2",
+					Identifier: db.VolumeIdentifier{
+						TaskCache: &db.TaskCacheIdentifier{
+							PipelineID: 1,
+							JobName:    "some-job",
+							StepName:   "some-task",
+							Path:       "some/cache",
+							WorkerName: "some-worker",
+							Cleared:    true,
+						},
+					},
+				},
+				{
+					WorkerName: "some-worker",
+					TTL:        expectedLatestVersionTTL,
+					Handle:     "some-volume-handle-3",
+					Identifier: db.VolumeIdentifier{
+						TaskCache: &db.TaskCacheIdentifier{
+							PipelineID: 1,
+							JobName:    "some-removed-job",
+							StepName:   "some-task",
+							Path:       "some/cache",
+							WorkerName: "some-worker",
+						},
+					},
+				},
+				{
+					WorkerName: "some-worker",
+					TTL:        expectedOldResourceGracePeriod,
+					Handle:     "some-volume-handle-4",
+					Identifier: db.VolumeIdentifier{
+						TaskCache: &db.TaskCacheIdentifier{
+							PipelineID: 1,
+							JobName:    "some-job",
+							StepName:   "some-other-task",
+							Path:       "some/cache",
+							WorkerName: "some-worker",
+						},
+					},
+				},
+			},
+			expectedTTLs: map[string]time.Duration{
+				"some-volume-handle-2": expectedOldResourceGracePeriod,
+				"some-volume-handle-3": expectedOldResourceGracePeriod,
+				"some-volume-handle-4": expectedLatestVersionTTL,
+			},
+		}),
 	)
 })
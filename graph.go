@@ -0,0 +1,71 @@
+package atc
+
+// PipelineGraph describes how the jobs and resources of one or more pipelines
+// depend on each other.
+type PipelineGraph struct {
+	Pipelines []GraphPipeline `json:"pipelines"`
+	Nodes     []GraphNode     `json:"nodes"`
+	Edges     []GraphEdge     `json:"edges"`
+}
+
+type GraphPipeline struct {
+	TeamName string `json:"team_name"`
+	Name     string `json:"name"`
+	Paused   bool   `json:"paused"`
+}
+
+type GraphNodeType string
+
+const (
+	GraphNodeJob      GraphNodeType = "job"
+	GraphNodeResource GraphNodeType = "resource"
+)
+
+type GraphNode struct {
+	ID           string        `json:"id"`
+	Type         GraphNodeType `json:"type"`
+	TeamName     string        `json:"team_name"`
+	PipelineName string        `json:"pipeline_name"`
+	Name         string        `json:"name"`
+	Groups       []string      `json:"groups,omitempty"`
+
+	Paused bool `json:"paused"`
+
+	// Status is the status of a job's latest finished build, if it has one.
+	Status BuildStatus `json:"status,omitempty"`
+
+	// NextBuildStatus is the status of a job's pending or running build, if it
+	// has one.
+	NextBuildStatus BuildStatus `json:"next_build_status,omitempty"`
+
+	// CheckError is the error from a resource's latest check, if it failed.
+	CheckError string `json:"check_error,omitempty"`
+}
+
+type GraphEdgeType string
+
+const (
+	// a job gets a resource, without a passed constraint
+	GraphEdgeInput GraphEdgeType = "input"
+
+	// a job puts a resource
+	GraphEdgeOutput GraphEdgeType = "output"
+
+	// a job gets a resource which must have passed through an upstream job
+	GraphEdgePassed GraphEdgeType = "passed"
+
+	// resources in different pipelines have the same type and source
+	GraphEdgeShared GraphEdgeType = "shared"
+)
+
+type GraphEdge struct {
+	Source string        `json:"source"`
+	Target string        `json:"target"`
+	Type   GraphEdgeType `json:"type"`
+
+	// Resource is the resource a passed edge carries.
+	Resource string `json:"resource,omitempty"`
+
+	// Trigger is set on input and passed edges whose input triggers the job.
+	Trigger bool `json:"trigger,omitempty"`
+}
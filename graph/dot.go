@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+var statusColors = map[atc.BuildStatus]string{
+	atc.StatusSucceeded: "#11c560",
+	atc.StatusFailed:    "#e74c3c",
+	atc.StatusErrored:   "#e67e22",
+	atc.StatusAborted:   "#8f4b2d",
+}
+
+const (
+	pausedColor     = "#3498db"
+	noBuildsColor   = "#ecf0f1"
+	failingColor    = "#e74c3c"
+	resourceColor   = "#ffffff"
+	runningPenWidth = "3"
+)
+
+// DOT renders the graph in the Graphviz DOT language. Each pipeline is drawn
+// as a cluster, with jobs as boxes colored by the status of their latest
+// build and resources as ellipses.
+func DOT(graph atc.PipelineGraph) string {
+	buf := new(bytes.Buffer)
+
+	name := "pipelines"
+	if len(graph.Pipelines) > 0 {
+		name = graph.Pipelines[0].Name
+	}
+
+	fmt.Fprintf(buf, "digraph %s {\n", quote(name))
+	fmt.Fprintln(buf, "\trankdir=LR;")
+	fmt.Fprintln(buf, "\tnode [fontname=\"Helvetica\"];")
+
+	for i, pipeline := range graph.Pipelines {
+		fmt.Fprintf(buf, "\n\tsubgraph %s {\n", quote(fmt.Sprintf("cluster_%d", i)))
+
+		label := pipeline.Name
+		if pipeline.Paused {
+			label += " (paused)"
+		}
+
+		fmt.Fprintf(buf, "\t\tlabel=%s;\n", quote(label))
+
+		for _, node := range graph.Nodes {
+			if node.PipelineName != pipeline.Name {
+				continue
+			}
+
+			fmt.Fprintf(buf, "\t\t%s [%s];\n", quote(node.ID), nodeAttributes(node))
+		}
+
+		fmt.Fprintln(buf, "\t}")
+	}
+
+	if len(graph.Edges) > 0 {
+		fmt.Fprintln(buf)
+	}
+
+	for _, edge := range graph.Edges {
+		attrs := edgeAttributes(edge)
+		if attrs == "" {
+			fmt.Fprintf(buf, "\t%s -> %s;\n", quote(edge.Source), quote(edge.Target))
+		} else {
+			fmt.Fprintf(buf, "\t%s -> %s [%s];\n", quote(edge.Source), quote(edge.Target), attrs)
+		}
+	}
+
+	fmt.Fprintln(buf, "}")
+
+	return buf.String()
+}
+
+func nodeAttributes(node atc.GraphNode) string {
+	attrs := []string{"label=" + quote(node.Name)}
+
+	switch node.Type {
+	case atc.GraphNodeJob:
+		color, found := statusColors[node.Status]
+		if !found {
+			color = noBuildsColor
+		}
+
+		if node.Paused {
+			color = pausedColor
+		}
+
+		attrs = append(attrs, "shape=box", "style=filled", "fillcolor="+quote(color))
+
+		if node.NextBuildStatus != "" {
+			attrs = append(attrs, "penwidth="+runningPenWidth)
+		}
+
+	case atc.GraphNodeResource:
+		color := resourceColor
+		if node.CheckError != "" {
+			color = failingColor
+		}
+
+		if node.Paused {
+			color = pausedColor
+		}
+
+		attrs = append(attrs, "shape=ellipse", "style=filled", "fillcolor="+quote(color))
+	}
+
+	return strings.Join(attrs, ", ")
+}
+
+func edgeAttributes(edge atc.GraphEdge) string {
+	var attrs []string
+
+	switch edge.Type {
+	case atc.GraphEdgeInput, atc.GraphEdgePassed:
+		if !edge.Trigger {
+			attrs = append(attrs, "style=dashed")
+		}
+
+		if edge.Resource != "" {
+			attrs = append(attrs, "label="+quote(edge.Resource))
+		}
+
+	case atc.GraphEdgeShared:
+		attrs = append(attrs, "style=dotted", "dir=none", "constraint=false")
+	}
+
+	return strings.Join(attrs, ", ")
+}
+
+func quote(id string) string {
+	return `"` + strings.Replace(strings.Replace(id, `\`, `\\`, -1), `"`, `\"`, -1) + `"`
+}
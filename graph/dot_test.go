@@ -0,0 +1,86 @@
+package graph_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/graph"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DOT", func() {
+	It("renders each pipeline as a cluster of jobs and resources", func() {
+		dot := graph.DOT(atc.PipelineGraph{
+			Pipelines: []atc.GraphPipeline{
+				{TeamName: "some-team", Name: "some-pipeline"},
+				{TeamName: "some-team", Name: "other-pipeline", Paused: true},
+			},
+			Nodes: []atc.GraphNode{
+				{
+					ID:           "job:some-pipeline/unit",
+					Type:         atc.GraphNodeJob,
+					PipelineName: "some-pipeline",
+					Name:         "unit",
+					Status:       atc.StatusSucceeded,
+				},
+				{
+					ID:              "job:some-pipeline/deploy",
+					Type:            atc.GraphNodeJob,
+					PipelineName:    "some-pipeline",
+					Name:            "deploy",
+					Status:          atc.StatusFailed,
+					NextBuildStatus: atc.StatusStarted,
+				},
+				{
+					ID:           "resource:some-pipeline/some-repo",
+					Type:         atc.GraphNodeResource,
+					PipelineName: "some-pipeline",
+					Name:         "some-repo",
+					CheckError:   "some-error",
+				},
+				{
+					ID:           "resource:other-pipeline/some-\"repo\"",
+					Type:         atc.GraphNodeResource,
+					PipelineName: "other-pipeline",
+					Name:         "some-\"repo\"",
+					Paused:       true,
+				},
+			},
+			Edges: []atc.GraphEdge{
+				{Source: "resource:some-pipeline/some-repo", Target: "job:some-pipeline/unit", Type: atc.GraphEdgeInput, Trigger: true},
+				{Source: "job:some-pipeline/unit", Target: "job:some-pipeline/deploy", Type: atc.GraphEdgePassed, Resource: "some-repo"},
+				{Source: "resource:some-pipeline/some-repo", Target: "resource:other-pipeline/some-\"repo\"", Type: atc.GraphEdgeShared},
+			},
+		})
+
+		Expect(dot).To(Equal(`digraph "some-pipeline" {
+	rankdir=LR;
+	node [fontname="Helvetica"];
+
+	subgraph "cluster_0" {
+		label="some-pipeline";
+		"job:some-pipeline/unit" [label="unit", shape=box, style=filled, fillcolor="#11c560"];
+		"job:some-pipeline/deploy" [label="deploy", shape=box, style=filled, fillcolor="#e74c3c", penwidth=3];
+		"resource:some-pipeline/some-repo" [label="some-repo", shape=ellipse, style=filled, fillcolor="#e74c3c"];
+	}
+
+	subgraph "cluster_1" {
+		label="other-pipeline (paused)";
+		"resource:other-pipeline/some-\"repo\"" [label="some-\"repo\"", shape=ellipse, style=filled, fillcolor="#3498db"];
+	}
+
+	"resource:some-pipeline/some-repo" -> "job:some-pipeline/unit";
+	"job:some-pipeline/unit" -> "job:some-pipeline/deploy" [style=dashed, label="some-repo"];
+	"resource:some-pipeline/some-repo" -> "resource:other-pipeline/some-\"repo\"" [style=dotted, dir=none, constraint=false];
+}
+`))
+	})
+
+	It("renders an empty graph", func() {
+		Expect(graph.DOT(atc.PipelineGraph{})).To(Equal(`digraph "pipelines" {
+	rankdir=LR;
+	node [fontname="Helvetica"];
+}
+`))
+	})
+})
@@ -0,0 +1,319 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+)
+
+// Pipeline is a pipeline whose jobs and resources are to be included in a
+// graph.
+type Pipeline struct {
+	TeamName string
+	Name     string
+	Config   atc.Config
+
+	// Groups restricts the pipeline to the jobs in the named groups and the
+	// resources they use. If empty, the whole pipeline is included.
+	Groups []string
+}
+
+// JobNodeID returns the ID of the node for a job.
+func JobNodeID(pipelineName string, jobName string) string {
+	return fmt.Sprintf("job:%s/%s", pipelineName, jobName)
+}
+
+// ResourceNodeID returns the ID of the node for a resource.
+func ResourceNodeID(pipelineName string, resourceName string) string {
+	return fmt.Sprintf("resource:%s/%s", pipelineName, resourceName)
+}
+
+// Build constructs the graph of the given pipelines' jobs and resources.
+//
+// Jobs are linked to the resources they get and put, and to the upstream jobs
+// named by their inputs' passed constraints. Passed constraints on jobs in
+// other pipelines are only linked if those pipelines are included. Resources
+// in different pipelines with the same type and source are linked as shared.
+// Sources are compared as configured, without evaluating their ((vars)), just
+// as they are when matching versions for passed constraints on other pipelines.
+//
+// Nodes carry no status; that is left to the caller.
+func Build(pipelines []Pipeline) atc.PipelineGraph {
+	graph := atc.PipelineGraph{
+		Pipelines: []atc.GraphPipeline{},
+		Nodes:     []atc.GraphNode{},
+		Edges:     []atc.GraphEdge{},
+	}
+
+	nodes := map[string]bool{}
+	var edges []atc.GraphEdge
+
+	for _, pipeline := range pipelines {
+		graph.Pipelines = append(graph.Pipelines, atc.GraphPipeline{
+			TeamName: pipeline.TeamName,
+			Name:     pipeline.Name,
+		})
+
+		jobs, resources := included(pipeline)
+
+		for _, job := range pipeline.Config.Jobs {
+			if !jobs[job.Name] {
+				continue
+			}
+
+			id := JobNodeID(pipeline.Name, job.Name)
+			nodes[id] = true
+
+			graph.Nodes = append(graph.Nodes, atc.GraphNode{
+				ID:           id,
+				Type:         atc.GraphNodeJob,
+				TeamName:     pipeline.TeamName,
+				PipelineName: pipeline.Name,
+				Name:         job.Name,
+				Groups:       jobGroups(pipeline.Config.Groups, job.Name),
+			})
+
+			for _, input := range config.JobInputs(job) {
+				if len(input.Passed) == 0 {
+					edges = append(edges, atc.GraphEdge{
+						Source:  ResourceNodeID(pipeline.Name, input.Resource),
+						Target:  id,
+						Type:    atc.GraphEdgeInput,
+						Trigger: input.Trigger,
+					})
+
+					continue
+				}
+
+				for _, passed := range input.Passed {
+					upstreamPipeline, upstreamJob := pipeline.Name, passed
+					if otherPipeline, otherJob, ok := config.CrossPipelineJob(passed); ok {
+						upstreamPipeline, upstreamJob = otherPipeline, otherJob
+					}
+
+					edges = append(edges, atc.GraphEdge{
+						Source:   JobNodeID(upstreamPipeline, upstreamJob),
+						Target:   id,
+						Type:     atc.GraphEdgePassed,
+						Resource: input.Resource,
+						Trigger:  input.Trigger,
+					})
+				}
+			}
+
+			for _, output := range config.JobOutputs(job) {
+				edges = append(edges, atc.GraphEdge{
+					Source: id,
+					Target: ResourceNodeID(pipeline.Name, output.Resource),
+					Type:   atc.GraphEdgeOutput,
+				})
+			}
+		}
+
+		for _, resource := range pipeline.Config.Resources {
+			if !resources[resource.Name] {
+				continue
+			}
+
+			id := ResourceNodeID(pipeline.Name, resource.Name)
+			nodes[id] = true
+
+			graph.Nodes = append(graph.Nodes, atc.GraphNode{
+				ID:           id,
+				Type:         atc.GraphNodeResource,
+				TeamName:     pipeline.TeamName,
+				PipelineName: pipeline.Name,
+				Name:         resource.Name,
+				Groups:       resourceGroups(pipeline.Config, resource.Name),
+			})
+		}
+	}
+
+	for i, pipeline := range pipelines {
+		for _, other := range pipelines[i+1:] {
+			for _, pair := range sharedResources(pipeline.Config, other.Config) {
+				edges = append(edges, atc.GraphEdge{
+					Source: ResourceNodeID(pipeline.Name, pair[0]),
+					Target: ResourceNodeID(other.Name, pair[1]),
+					Type:   atc.GraphEdgeShared,
+				})
+			}
+		}
+	}
+
+	seen := map[atc.GraphEdge]bool{}
+	for _, edge := range edges {
+		if !nodes[edge.Source] || !nodes[edge.Target] || seen[edge] {
+			continue
+		}
+
+		seen[edge] = true
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	return graph
+}
+
+// Sharing returns the pipelines among candidates that are connected to the
+// given pipeline, either directly or through other candidates, by sharing a
+// resource (i.e. one with the same type and source) or by a passed constraint
+// on a job in the other pipeline. The given pipeline comes first.
+func Sharing(pipeline Pipeline, candidates []Pipeline) []Pipeline {
+	connected := []Pipeline{pipeline}
+	included := map[string]bool{pipeline.Name: true}
+
+	for i := 0; i < len(connected); i++ {
+		for _, candidate := range candidates {
+			if included[candidate.Name] {
+				continue
+			}
+
+			if linked(connected[i].Config, candidate) || linked(candidate.Config, connected[i]) ||
+				len(sharedResources(connected[i].Config, candidate.Config)) > 0 {
+				included[candidate.Name] = true
+				connected = append(connected, candidate)
+			}
+		}
+	}
+
+	return connected
+}
+
+// linked returns true if any job in the config has a passed constraint on a
+// job in the given pipeline.
+func linked(pipelineConfig atc.Config, pipeline Pipeline) bool {
+	for _, job := range pipelineConfig.Jobs {
+		for _, input := range config.JobInputs(job) {
+			for _, passed := range input.Passed {
+				if otherPipeline, _, ok := config.CrossPipelineJob(passed); ok && otherPipeline == pipeline.Name {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func included(pipeline Pipeline) (map[string]bool, map[string]bool) {
+	jobs := map[string]bool{}
+	resources := map[string]bool{}
+
+	if len(pipeline.Groups) == 0 {
+		for _, job := range pipeline.Config.Jobs {
+			jobs[job.Name] = true
+		}
+
+		for _, resource := range pipeline.Config.Resources {
+			resources[resource.Name] = true
+		}
+
+		return jobs, resources
+	}
+
+	for _, groupName := range pipeline.Groups {
+		group, found := pipeline.Config.Groups.Lookup(groupName)
+		if !found {
+			continue
+		}
+
+		for _, name := range group.Jobs {
+			jobs[name] = true
+		}
+
+		for _, name := range group.Resources {
+			resources[name] = true
+		}
+	}
+
+	for _, job := range pipeline.Config.Jobs {
+		if !jobs[job.Name] {
+			continue
+		}
+
+		for _, input := range config.JobInputs(job) {
+			resources[input.Resource] = true
+		}
+
+		for _, output := range config.JobOutputs(job) {
+			resources[output.Resource] = true
+		}
+	}
+
+	return jobs, resources
+}
+
+func jobGroups(groups atc.GroupConfigs, jobName string) []string {
+	var names []string
+	for _, group := range groups {
+		for _, name := range group.Jobs {
+			if name == jobName {
+				names = append(names, group.Name)
+				break
+			}
+		}
+	}
+
+	return names
+}
+
+// resourceGroups returns the groups which list the resource or contain a job
+// that uses it.
+func resourceGroups(pipelineConfig atc.Config, resourceName string) []string {
+	var names []string
+
+	for _, group := range pipelineConfig.Groups {
+		if groupUsesResource(pipelineConfig, group, resourceName) {
+			names = append(names, group.Name)
+		}
+	}
+
+	return names
+}
+
+func groupUsesResource(pipelineConfig atc.Config, group atc.GroupConfig, resourceName string) bool {
+	for _, name := range group.Resources {
+		if name == resourceName {
+			return true
+		}
+	}
+
+	for _, jobName := range group.Jobs {
+		job, found := pipelineConfig.Jobs.Lookup(jobName)
+		if !found {
+			continue
+		}
+
+		for _, input := range config.JobInputs(job) {
+			if input.Resource == resourceName {
+				return true
+			}
+		}
+
+		for _, output := range config.JobOutputs(job) {
+			if output.Resource == resourceName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// sharedResources returns the names of pairs of resources, one from each
+// config, which have the same type and source.
+func sharedResources(a atc.Config, b atc.Config) [][2]string {
+	var pairs [][2]string
+
+	for _, resourceA := range a.Resources {
+		for _, resourceB := range b.Resources {
+			if resourceA.Type == resourceB.Type && reflect.DeepEqual(resourceA.Source, resourceB.Source) {
+				pairs = append(pairs, [2]string{resourceA.Name, resourceB.Name})
+			}
+		}
+	}
+
+	return pairs
+}
@@ -0,0 +1,273 @@
+package graph_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/graph"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build", func() {
+	var pipeline graph.Pipeline
+
+	BeforeEach(func() {
+		pipeline = graph.Pipeline{
+			TeamName: "some-team",
+			Name:     "some-pipeline",
+			Config: atc.Config{
+				Groups: atc.GroupConfigs{
+					{Name: "build", Jobs: []string{"unit"}},
+					{Name: "ship", Jobs: []string{"deploy"}, Resources: []string{"some-unused-resource"}},
+				},
+				Resources: atc.ResourceConfigs{
+					{Name: "some-repo", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+					{Name: "some-image", Type: "docker-image", Source: atc.Source{"repository": "some-repository"}},
+					{Name: "some-unused-resource", Type: "time", Source: atc.Source{"interval": "1h"}},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "unit",
+						Plan: atc.PlanSequence{
+							{Get: "some-repo", Trigger: true},
+						},
+					},
+					{
+						Name: "deploy",
+						Plan: atc.PlanSequence{
+							{Get: "some-repo", Passed: []string{"unit"}, Trigger: true},
+							{Put: "some-image"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("has a node for each job and resource", func() {
+		g := graph.Build([]graph.Pipeline{pipeline})
+
+		Expect(g.Pipelines).To(Equal([]atc.GraphPipeline{
+			{TeamName: "some-team", Name: "some-pipeline"},
+		}))
+
+		Expect(g.Nodes).To(Equal([]atc.GraphNode{
+			{
+				ID:           "job:some-pipeline/unit",
+				Type:         atc.GraphNodeJob,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				Name:         "unit",
+				Groups:       []string{"build"},
+			},
+			{
+				ID:           "job:some-pipeline/deploy",
+				Type:         atc.GraphNodeJob,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				Name:         "deploy",
+				Groups:       []string{"ship"},
+			},
+			{
+				ID:           "resource:some-pipeline/some-repo",
+				Type:         atc.GraphNodeResource,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				Name:         "some-repo",
+				Groups:       []string{"build", "ship"},
+			},
+			{
+				ID:           "resource:some-pipeline/some-image",
+				Type:         atc.GraphNodeResource,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				Name:         "some-image",
+				Groups:       []string{"ship"},
+			},
+			{
+				ID:           "resource:some-pipeline/some-unused-resource",
+				Type:         atc.GraphNodeResource,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				Name:         "some-unused-resource",
+				Groups:       []string{"ship"},
+			},
+		}))
+	})
+
+	It("links jobs to their inputs, outputs and upstream jobs", func() {
+		g := graph.Build([]graph.Pipeline{pipeline})
+
+		Expect(g.Edges).To(Equal([]atc.GraphEdge{
+			{
+				Source:  "resource:some-pipeline/some-repo",
+				Target:  "job:some-pipeline/unit",
+				Type:    atc.GraphEdgeInput,
+				Trigger: true,
+			},
+			{
+				Source:   "job:some-pipeline/unit",
+				Target:   "job:some-pipeline/deploy",
+				Type:     atc.GraphEdgePassed,
+				Resource: "some-repo",
+				Trigger:  true,
+			},
+			{
+				Source: "job:some-pipeline/deploy",
+				Target: "resource:some-pipeline/some-image",
+				Type:   atc.GraphEdgeOutput,
+			},
+		}))
+	})
+
+	Context("when restricted to groups", func() {
+		BeforeEach(func() {
+			pipeline.Groups = []string{"build"}
+		})
+
+		It("only includes the groups' jobs and the resources they use", func() {
+			g := graph.Build([]graph.Pipeline{pipeline})
+
+			var ids []string
+			for _, node := range g.Nodes {
+				ids = append(ids, node.ID)
+			}
+
+			Expect(ids).To(Equal([]string{
+				"job:some-pipeline/unit",
+				"resource:some-pipeline/some-repo",
+			}))
+
+			Expect(g.Edges).To(Equal([]atc.GraphEdge{
+				{
+					Source:  "resource:some-pipeline/some-repo",
+					Target:  "job:some-pipeline/unit",
+					Type:    atc.GraphEdgeInput,
+					Trigger: true,
+				},
+			}))
+		})
+	})
+
+	Context("with another pipeline", func() {
+		var otherPipeline graph.Pipeline
+
+		BeforeEach(func() {
+			otherPipeline = graph.Pipeline{
+				TeamName: "some-team",
+				Name:     "other-pipeline",
+				Config: atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "image", Type: "docker-image", Source: atc.Source{"repository": "some-repository"}},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "smoke",
+							Plan: atc.PlanSequence{
+								{Get: "image", Trigger: true},
+							},
+						},
+						{
+							Name: "promote",
+							Plan: atc.PlanSequence{
+								{Get: "image", Passed: []string{"some-pipeline/deploy"}},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("links resources with the same type and source, and passed constraints across pipelines", func() {
+			g := graph.Build([]graph.Pipeline{pipeline, otherPipeline})
+
+			Expect(g.Edges).To(ContainElement(atc.GraphEdge{
+				Source: "resource:some-pipeline/some-image",
+				Target: "resource:other-pipeline/image",
+				Type:   atc.GraphEdgeShared,
+			}))
+
+			Expect(g.Edges).To(ContainElement(atc.GraphEdge{
+				Source:   "job:some-pipeline/deploy",
+				Target:   "job:other-pipeline/promote",
+				Type:     atc.GraphEdgePassed,
+				Resource: "image",
+			}))
+		})
+
+		It("leaves out passed constraints on pipelines that are not included", func() {
+			g := graph.Build([]graph.Pipeline{otherPipeline})
+
+			for _, edge := range g.Edges {
+				Expect(edge.Type).NotTo(Equal(atc.GraphEdgePassed))
+			}
+		})
+	})
+})
+
+var _ = Describe("Sharing", func() {
+	var pipeline graph.Pipeline
+
+	pipelineWith := func(name string, resources atc.ResourceConfigs, jobs atc.JobConfigs) graph.Pipeline {
+		return graph.Pipeline{
+			TeamName: "some-team",
+			Name:     name,
+			Config: atc.Config{
+				Resources: resources,
+				Jobs:      jobs,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		pipeline = pipelineWith("a", atc.ResourceConfigs{
+			{Name: "repo", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+		}, nil)
+	})
+
+	It("returns the pipelines which share resources, directly or transitively", func() {
+		b := pipelineWith("b", atc.ResourceConfigs{
+			{Name: "other-repo", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+			{Name: "image", Type: "docker-image", Source: atc.Source{"repository": "some-repository"}},
+		}, nil)
+
+		c := pipelineWith("c", atc.ResourceConfigs{
+			{Name: "image", Type: "docker-image", Source: atc.Source{"repository": "some-repository"}},
+		}, nil)
+
+		d := pipelineWith("d", atc.ResourceConfigs{
+			{Name: "repo", Type: "git", Source: atc.Source{"uri": "some-other-uri"}},
+		}, nil)
+
+		Expect(graph.Sharing(pipeline, []graph.Pipeline{d, c, pipeline, b})).To(Equal([]graph.Pipeline{pipeline, b, c}))
+	})
+
+	It("returns pipelines linked by passed constraints", func() {
+		b := pipelineWith("b", nil, atc.JobConfigs{
+			{
+				Name: "some-job",
+				Plan: atc.PlanSequence{
+					{Get: "repo", Passed: []string{"a/some-job"}},
+				},
+			},
+		})
+
+		Expect(graph.Sharing(pipeline, []graph.Pipeline{b})).To(Equal([]graph.Pipeline{pipeline, b}))
+	})
+
+	It("compares sources as configured, without evaluating their vars", func() {
+		pipeline = pipelineWith("a", atc.ResourceConfigs{
+			{Name: "repo", Type: "git", Source: atc.Source{"uri": "((uri))"}},
+		}, nil)
+
+		b := pipelineWith("b", atc.ResourceConfigs{
+			{Name: "repo", Type: "git", Source: atc.Source{"uri": "((uri))"}},
+		}, nil)
+
+		c := pipelineWith("c", atc.ResourceConfigs{
+			{Name: "repo", Type: "git", Source: atc.Source{"uri": "((other-uri))"}},
+		}, nil)
+
+		Expect(graph.Sharing(pipeline, []graph.Pipeline{b, c})).To(Equal([]graph.Pipeline{pipeline, b}))
+	})
+})
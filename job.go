@@ -13,6 +13,10 @@ type Job struct {
 	Outputs []JobOutput `json:"outputs"`
 
 	Groups []string `json:"groups"`
+
+	// NextScheduledTimes are the next times each of the job's schedules will
+	// trigger it, in ascending order.
+	NextScheduledTimes []int64 `json:"next_scheduled_times,omitempty"`
 }
 
 type JobInput struct {
@@ -4,48 +4,63 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/bigdatadev/goryman"
 )
 
+type EventState string
+
+const (
+	EventStateOK       EventState = "ok"
+	EventStateWarning  EventState = "warning"
+	EventStateCritical EventState = "critical"
+)
+
+type Event struct {
+	Name       string
+	Value      float64
+	State      EventState
+	Attributes map[string]string
+	Host       string
+	Tags       []string
+	Time       time.Time
+}
+
+//go:generate counterfeiter . Emitter
+
+// Emitter sends events to a metrics backend.
+type Emitter interface {
+	Emit(lager.Logger, Event)
+}
+
 type eventEmission struct {
-	event  goryman.Event
+	event  Event
 	logger lager.Logger
 }
 
-var riemannClient *goryman.GorymanClient
+var emitters []Emitter
 var eventHost string
 var eventTags []string
 var eventAttributes map[string]string
-var eventPrefix string
 
-var clientConnected bool
 var emissions = make(chan eventEmission, 1000)
 
-func Initialize(logger lager.Logger, riemannAddr string, host string, tags []string, attributes map[string]string, prefix string) {
-	client := goryman.NewGorymanClient(riemannAddr)
-
-	riemannClient = client
+func Initialize(logger lager.Logger, host string, tags []string, attributes map[string]string, configuredEmitters []Emitter) {
+	emitters = configuredEmitters
 	eventHost = host
 	eventTags = tags
 	eventAttributes = attributes
-	eventPrefix = prefix
 
 	go emitLoop()
 }
 
-func emit(logger lager.Logger, event goryman.Event) {
+func emit(logger lager.Logger, event Event) {
 	logger.Debug("emit")
 
-	if riemannClient == nil {
+	if len(emitters) == 0 {
 		return
 	}
 
-	if eventPrefix != "" {
-		event.Service = eventPrefix + event.Service
-	}
-
 	event.Host = eventHost
-	event.Time = time.Now().Unix()
+	event.Time = time.Now()
 	event.Tags = append(event.Tags, eventTags...)
 
 	mergedAttributes := map[string]string{}
@@ -70,25 +85,8 @@ func emit(logger lager.Logger, event goryman.Event) {
 
 func emitLoop() {
 	for emission := range emissions {
-		if !clientConnected {
-			err := riemannClient.Connect()
-			if err != nil {
-				emission.logger.Error("connection-failed", err)
-				continue
-			}
-
-			clientConnected = true
-		}
-
-		err := riemannClient.SendEvent(&emission.event)
-		if err != nil {
-			emission.logger.Error("failed-to-emit", err)
-
-			if err := riemannClient.Close(); err != nil {
-				emission.logger.Error("failed-to-close", err)
-			}
-
-			clientConnected = false
+		for _, emitter := range emitters {
+			emitter.Emit(emission.logger, emission.event)
 		}
 	}
 }
@@ -0,0 +1,184 @@
+package metric
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PrometheusEmitter keeps track of emitted events as Prometheus metrics, to
+// be scraped from its Handler.
+type PrometheusEmitter struct {
+	registry *prometheus.Registry
+
+	schedulingFullDuration         *prometheus.HistogramVec
+	schedulingLoadVersionsDuration *prometheus.HistogramVec
+	schedulingJobDuration          *prometheus.HistogramVec
+
+	trackedContainers   prometheus.Gauge
+	trackedVolumes      prometheus.Gauge
+	databaseQueries     prometheus.Counter
+	databaseConnections prometheus.Gauge
+
+	workerContainers *prometheus.GaugeVec
+
+	httpResponseDuration *prometheus.HistogramVec
+
+	buildsStarted  *prometheus.CounterVec
+	buildsFinished *prometheus.CounterVec
+	buildDuration  *prometheus.HistogramVec
+}
+
+func NewPrometheusEmitter() *PrometheusEmitter {
+	emitter := &PrometheusEmitter{
+		registry: prometheus.NewRegistry(),
+
+		schedulingFullDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "full_duration_seconds",
+			Help:      "Time taken to schedule an entire pipeline.",
+		}, []string{"pipeline"}),
+
+		schedulingLoadVersionsDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "loading_versions_duration_seconds",
+			Help:      "Time taken to load the version history of a pipeline for scheduling.",
+		}, []string{"pipeline"}),
+
+		schedulingJobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "job_duration_seconds",
+			Help:      "Time taken to schedule a single job.",
+		}, []string{"pipeline", "job"}),
+
+		trackedContainers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Name:      "tracked_containers",
+			Help:      "Number of containers being heartbeated by the ATC.",
+		}),
+
+		trackedVolumes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Name:      "tracked_volumes",
+			Help:      "Number of volumes being heartbeated by the ATC.",
+		}),
+
+		databaseQueries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "queries_total",
+			Help:      "Number of queries made to the database.",
+		}),
+
+		databaseConnections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "connections",
+			Help:      "Number of open database connections.",
+		}),
+
+		workerContainers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "containers",
+			Help:      "Number of containers on each worker, as last reported by the worker.",
+		}, []string{"worker"}),
+
+		httpResponseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "http",
+			Name:      "response_duration_seconds",
+			Help:      "Time taken to respond to API requests.",
+		}, []string{"route"}),
+
+		buildsStarted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "started_total",
+			Help:      "Number of builds started.",
+		}, []string{"team", "pipeline", "job"}),
+
+		buildsFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "finished_total",
+			Help:      "Number of builds finished, by status.",
+		}, []string{"team", "pipeline", "job", "status"}),
+
+		buildDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "duration_seconds",
+			Help:      "Time taken by finished builds, by status.",
+			Buckets:   []float64{10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200},
+		}, []string{"team", "pipeline", "job", "status"}),
+	}
+
+	emitter.registry.MustRegister(
+		emitter.schedulingFullDuration,
+		emitter.schedulingLoadVersionsDuration,
+		emitter.schedulingJobDuration,
+		emitter.trackedContainers,
+		emitter.trackedVolumes,
+		emitter.databaseQueries,
+		emitter.databaseConnections,
+		emitter.workerContainers,
+		emitter.httpResponseDuration,
+		emitter.buildsStarted,
+		emitter.buildsFinished,
+		emitter.buildDuration,
+	)
+
+	return emitter
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (emitter *PrometheusEmitter) Handler() http.Handler {
+	return promhttp.HandlerFor(emitter.registry, promhttp.HandlerOpts{})
+}
+
+func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event Event) {
+	attr := event.Attributes
+
+	switch event.Name {
+	case "scheduling: full duration (ms)":
+		emitter.schedulingFullDuration.WithLabelValues(attr["pipeline"]).Observe(seconds(event.Value))
+	case "scheduling: loading versions duration (ms)":
+		emitter.schedulingLoadVersionsDuration.WithLabelValues(attr["pipeline"]).Observe(seconds(event.Value))
+	case "scheduling: job duration (ms)":
+		emitter.schedulingJobDuration.WithLabelValues(attr["pipeline"], attr["job"]).Observe(seconds(event.Value))
+	case "tracked containers":
+		emitter.trackedContainers.Set(event.Value)
+	case "tracked volumes":
+		emitter.trackedVolumes.Set(event.Value)
+	case "database queries":
+		// emitted as the number of queries since the last emission
+		emitter.databaseQueries.Add(event.Value)
+	case "database connections":
+		emitter.databaseConnections.Set(event.Value)
+	case "worker containers":
+		emitter.workerContainers.WithLabelValues(attr["worker"]).Set(event.Value)
+	case "http response time":
+		// the path is left out, as it would give every build and resource its
+		// own series
+		emitter.httpResponseDuration.WithLabelValues(attr["route"]).Observe(seconds(event.Value))
+	case "build started":
+		emitter.buildsStarted.WithLabelValues(attr["team"], attr["pipeline"], attr["job"]).Inc()
+	case "build finished":
+		labels := []string{attr["team"], attr["pipeline"], attr["job"], attr["build_status"]}
+		emitter.buildsFinished.WithLabelValues(labels...).Inc()
+		emitter.buildDuration.WithLabelValues(labels...).Observe(seconds(event.Value))
+	default:
+		// runtime stats (goroutines, GC, etc.) are only emitted to Riemann
+		logger.Debug("ignoring-event", lager.Data{"event": event.Name})
+	}
+}
+
+func seconds(ms float64) float64 {
+	return ms / 1000
+}
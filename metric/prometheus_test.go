@@ -0,0 +1,92 @@
+package metric_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/concourse/atc/metric"
+)
+
+var _ = Describe("PrometheusEmitter", func() {
+	var emitter *PrometheusEmitter
+
+	BeforeEach(func() {
+		emitter = NewPrometheusEmitter()
+	})
+
+	scrape := func() string {
+		request, err := http.NewRequest("GET", "/metrics", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		recorder := httptest.NewRecorder()
+		emitter.Handler().ServeHTTP(recorder, request)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		return recorder.Body.String()
+	}
+
+	emit := func(event Event) {
+		emitter.Emit(lagertest.NewTestLogger("test"), event)
+	}
+
+	It("exposes gauges with their latest value", func() {
+		emit(Event{Name: "tracked containers", Value: 3})
+		emit(Event{Name: "tracked containers", Value: 5})
+		emit(Event{Name: "database connections", Value: 2})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring("concourse_tracked_containers 5\n"))
+		Expect(metrics).To(ContainSubstring("concourse_db_connections 2\n"))
+	})
+
+	It("accumulates database queries", func() {
+		emit(Event{Name: "database queries", Value: 10})
+		emit(Event{Name: "database queries", Value: 5})
+
+		Expect(scrape()).To(ContainSubstring("concourse_db_queries_total 15\n"))
+	})
+
+	It("observes HTTP response times by route, in seconds", func() {
+		emit(Event{
+			Name:  "http response time",
+			Value: 1500,
+			Attributes: map[string]string{
+				"route": "GetBuild",
+				"path":  "/api/v1/builds/1",
+			},
+		})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring(`concourse_http_response_duration_seconds_sum{route="GetBuild"} 1.5`))
+		Expect(metrics).NotTo(ContainSubstring("/api/v1/builds/1"))
+	})
+
+	It("labels finished builds by team, pipeline, job and status", func() {
+		emit(Event{
+			Name:  "build finished",
+			Value: 60000,
+			Attributes: map[string]string{
+				"team":         "some-team",
+				"pipeline":     "some-pipeline",
+				"job":          "some-job",
+				"build_name":   "42",
+				"build_id":     "1234",
+				"build_status": "succeeded",
+			},
+		})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring(`concourse_builds_finished_total{job="some-job",pipeline="some-pipeline",status="succeeded",team="some-team"} 1`))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_sum{job="some-job",pipeline="some-pipeline",status="succeeded",team="some-team"} 60`))
+	})
+
+	It("ignores events it does not know about", func() {
+		emit(Event{Name: "goroutines", Value: 42})
+
+		Expect(scrape()).NotTo(ContainSubstring("goroutines"))
+	})
+})
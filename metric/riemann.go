@@ -0,0 +1,53 @@
+package metric
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/bigdatadev/goryman"
+)
+
+type riemannEmitter struct {
+	client          *goryman.GorymanClient
+	servicePrefix   string
+	clientConnected bool
+}
+
+// NewRiemannEmitter returns an Emitter which sends events to the Riemann
+// server at the given address. Events are only ever emitted from a single
+// goroutine, so the connection is not synchronized.
+func NewRiemannEmitter(riemannAddr string, servicePrefix string) Emitter {
+	return &riemannEmitter{
+		client:        goryman.NewGorymanClient(riemannAddr),
+		servicePrefix: servicePrefix,
+	}
+}
+
+func (emitter *riemannEmitter) Emit(logger lager.Logger, event Event) {
+	if !emitter.clientConnected {
+		err := emitter.client.Connect()
+		if err != nil {
+			logger.Error("connection-failed", err)
+			return
+		}
+
+		emitter.clientConnected = true
+	}
+
+	err := emitter.client.SendEvent(&goryman.Event{
+		Service:    emitter.servicePrefix + event.Name,
+		Metric:     event.Value,
+		State:      string(event.State),
+		Attributes: event.Attributes,
+		Host:       event.Host,
+		Tags:       event.Tags,
+		Time:       event.Time.Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-emit", err)
+
+		if err := emitter.client.Close(); err != nil {
+			logger.Error("failed-to-close", err)
+		}
+
+		emitter.clientConnected = false
+	}
+}
@@ -5,6 +5,7 @@ type Pipeline struct {
 	URL      string       `json:"url"`
 	Paused   bool         `json:"paused"`
 	Public   bool         `json:"public"`
+	Archived bool         `json:"archived"`
 	Groups   GroupConfigs `json:"groups,omitempty"`
 	TeamName string       `json:"team_name"`
 }
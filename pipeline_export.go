@@ -0,0 +1,72 @@
+package atc
+
+import "encoding/json"
+
+// PipelineExport is a pipeline's config along with the versions of its
+// resources and the builds of its jobs, so that it can be moved to another
+// cluster without losing its history. IDs are only meaningful within the
+// export; they are remapped when it is imported.
+type PipelineExport struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+	Public bool   `json:"public"`
+	Config Config `json:"config"`
+
+	Resources []ExportedResource `json:"resources"`
+	Jobs      []ExportedJob      `json:"jobs"`
+	Versions  []ExportedVersion  `json:"versions"`
+	Builds    []ExportedBuild    `json:"builds"`
+}
+
+type ExportedResource struct {
+	Name            string `json:"name"`
+	Paused          bool   `json:"paused"`
+	PinnedVersionID int    `json:"pinned_version_id,omitempty"`
+}
+
+type ExportedJob struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+}
+
+type ExportedVersion struct {
+	ID         int             `json:"id"`
+	Resource   string          `json:"resource"`
+	Type       string          `json:"type"`
+	Version    Version         `json:"version"`
+	Metadata   []MetadataField `json:"metadata,omitempty"`
+	Enabled    bool            `json:"enabled"`
+	CheckOrder int             `json:"check_order"`
+}
+
+type ExportedBuild struct {
+	ID        int    `json:"id"`
+	JobName   string `json:"job_name"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	StartTime int64  `json:"start_time,omitempty"`
+	EndTime   int64  `json:"end_time,omitempty"`
+
+	Inputs  []ExportedBuildInput  `json:"inputs"`
+	Outputs []ExportedBuildOutput `json:"outputs"`
+
+	// Events is only present if the export was made with build events.
+	Events []ExportedBuildEvent `json:"events,omitempty"`
+}
+
+type ExportedBuildInput struct {
+	Name      string `json:"name"`
+	VersionID int    `json:"version_id"`
+}
+
+type ExportedBuildOutput struct {
+	VersionID int  `json:"version_id"`
+	Explicit  bool `json:"explicit"`
+}
+
+type ExportedBuildEvent struct {
+	ID      int             `json:"id"`
+	Type    EventType       `json:"type"`
+	Version EventVersion    `json:"version"`
+	Payload json.RawMessage `json:"payload"`
+}
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/radar"
@@ -25,25 +26,31 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	tracker  resource.Tracker
-	interval time.Duration
-	engine   engine.Engine
+	tracker           resource.Tracker
+	interval          time.Duration
+	webhookInterval   time.Duration
+	engine            engine.Engine
+	credentialManager creds.CredentialManager
 }
 
 func NewRadarSchedulerFactory(
 	tracker resource.Tracker,
 	interval time.Duration,
+	webhookInterval time.Duration,
 	engine engine.Engine,
+	credentialManager creds.CredentialManager,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		tracker:  tracker,
-		interval: interval,
-		engine:   engine,
+		tracker:           tracker,
+		interval:          interval,
+		webhookInterval:   webhookInterval,
+		engine:            engine,
+		credentialManager: credentialManager,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
+	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, rsf.webhookInterval, pipelineDB, clock.NewClock(), externalURL, rsf.variablesFor(pipelineDB))
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, externalURL string) scheduler.BuildScheduler {
@@ -51,8 +58,10 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 		clock.NewClock(),
 		rsf.tracker,
 		rsf.interval,
+		rsf.webhookInterval,
 		pipelineDB,
 		externalURL,
+		rsf.variablesFor(pipelineDB),
 	)
 	return &scheduler.Scheduler{
 		DB: pipelineDB,
@@ -70,5 +79,10 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 			rsf.engine,
 		),
 		Scanner: scanner,
+		Clock:   clock.NewClock(),
 	}
 }
+
+func (rsf *radarSchedulerFactory) variablesFor(pipelineDB db.PipelineDB) creds.Variables {
+	return creds.NewVariables(rsf.credentialManager, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
+}
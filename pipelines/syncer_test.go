@@ -225,6 +225,43 @@ var _ = Describe("Pipelines Syncer", func() {
 		})
 	})
 
+	Context("when a pipeline is archived", func() {
+		pipelines := []db.SavedPipeline{
+			{
+				ID:       1,
+				Archived: true,
+				Pipeline: db.Pipeline{
+					Name: "pipeline",
+				},
+			},
+			{
+				ID: 2,
+				Pipeline: db.Pipeline{
+					Name: "other-pipeline",
+				},
+			},
+		}
+
+		JustBeforeEach(func() {
+			Eventually(fakeRunner.RunCallCount).Should(Equal(1))
+			Eventually(otherFakeRunner.RunCallCount).Should(Equal(1))
+
+			syncherDB.GetAllPipelinesReturns(pipelines, nil)
+
+			syncer.Sync()
+		})
+
+		It("stops the process", func() {
+			signals, _ := fakeRunner.RunArgsForCall(0)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+		})
+
+		It("does not start it again", func() {
+			syncer.Sync()
+			Consistently(fakeRunner.RunCallCount).Should(Equal(1))
+		})
+	})
+
 	Context("when the pipeline's process exits", func() {
 		BeforeEach(func() {
 			fakeRunnerExitChan <- nil
@@ -5,13 +5,20 @@ type Plan struct {
 	Attempts []int  `json:"attempts,omitempty"`
 
 	Aggregate    *AggregatePlan    `json:"aggregate,omitempty"`
+	InParallel   *InParallelPlan   `json:"in_parallel,omitempty"`
+	Across       *AcrossPlan       `json:"across,omitempty"`
 	Do           *DoPlan           `json:"do,omitempty"`
 	Get          *GetPlan          `json:"get,omitempty"`
 	Put          *PutPlan          `json:"put,omitempty"`
 	Task         *TaskPlan         `json:"task,omitempty"`
+	SetPipeline  *SetPipelinePlan  `json:"set_pipeline,omitempty"`
+	LoadVar      *LoadVarPlan      `json:"load_var,omitempty"`
+	Approval     *ApprovalPlan     `json:"approval,omitempty"`
 	Ensure       *EnsurePlan       `json:"ensure,omitempty"`
 	OnSuccess    *OnSuccessPlan    `json:"on_success,omitempty"`
 	OnFailure    *OnFailurePlan    `json:"on_failure,omitempty"`
+	OnError      *OnErrorPlan      `json:"on_error,omitempty"`
+	OnAbort      *OnAbortPlan      `json:"on_abort,omitempty"`
 	Try          *TryPlan          `json:"try,omitempty"`
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
@@ -51,6 +58,16 @@ type OnFailurePlan struct {
 	Next Plan `json:"on_failure"`
 }
 
+type OnErrorPlan struct {
+	Step Plan `json:"step"`
+	Next Plan `json:"on_error"`
+}
+
+type OnAbortPlan struct {
+	Step Plan `json:"step"`
+	Next Plan `json:"on_abort"`
+}
+
 type EnsurePlan struct {
 	Step Plan `json:"step"`
 	Next Plan `json:"ensure"`
@@ -72,6 +89,31 @@ type TryPlan struct {
 
 type AggregatePlan []Plan
 
+type InParallelPlan struct {
+	Steps    []Plan `json:"steps"`
+	Limit    int    `json:"limit,omitempty"`
+	FailFast bool   `json:"fail_fast,omitempty"`
+}
+
+type AcrossPlan struct {
+	Vars     []AcrossVar     `json:"vars"`
+	Steps    []VarScopedPlan `json:"steps"`
+	FailFast bool            `json:"fail_fast,omitempty"`
+}
+
+type AcrossVar struct {
+	Var         string        `json:"name"`
+	Values      []interface{} `json:"values"`
+	MaxInFlight int           `json:"max_in_flight"`
+}
+
+// A VarScopedPlan is the step run for one combination of an across step's
+// values, in the same order as the vars.
+type VarScopedPlan struct {
+	Step   Plan          `json:"step"`
+	Values []interface{} `json:"values"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -118,4 +160,21 @@ type TaskPlan struct {
 	ResourceTypes ResourceTypes `json:"resource_types,omitempty"`
 }
 
+type SetPipelinePlan struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
+type LoadVarPlan struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Format string `json:"format,omitempty"`
+}
+
+type ApprovalPlan struct {
+	Approvers []string `json:"approvers,omitempty"`
+	Timeout   string   `json:"timeout,omitempty"`
+	Message   string   `json:"message,omitempty"`
+}
+
 type RetryPlan []Plan
@@ -27,6 +27,10 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 	switch t := step.(type) {
 	case AggregatePlan:
 		plan.Aggregate = &t
+	case InParallelPlan:
+		plan.InParallel = &t
+	case AcrossPlan:
+		plan.Across = &t
 	case DoPlan:
 		plan.Do = &t
 	case GetPlan:
@@ -35,12 +39,22 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Put = &t
 	case TaskPlan:
 		plan.Task = &t
+	case SetPipelinePlan:
+		plan.SetPipeline = &t
+	case LoadVarPlan:
+		plan.LoadVar = &t
+	case ApprovalPlan:
+		plan.Approval = &t
 	case EnsurePlan:
 		plan.Ensure = &t
 	case OnSuccessPlan:
 		plan.OnSuccess = &t
 	case OnFailurePlan:
 		plan.OnFailure = &t
+	case OnErrorPlan:
+		plan.OnError = &t
+	case OnAbortPlan:
+		plan.OnAbort = &t
 	case TryPlan:
 		plan.Try = &t
 	case DependentGetPlan:
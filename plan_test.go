@@ -420,6 +420,256 @@ var _ = Describe("Plan", func() {
     }
   ]
 }
+`))
+	})
+
+	It("returns a sanitized form of on_error and on_abort plans", func() {
+		task := func(id atc.PlanID) atc.Plan {
+			return atc.Plan{
+				ID: id,
+				Task: &atc.TaskPlan{
+					Name:       "name",
+					ConfigPath: "some/config/path.yml",
+					Config: &atc.TaskConfig{
+						Params: map[string]string{"some": "secret"},
+					},
+				},
+			}
+		}
+
+		plan := atc.Plan{
+			ID: "0",
+			OnAbort: &atc.OnAbortPlan{
+				Step: atc.Plan{
+					ID: "1",
+					OnError: &atc.OnErrorPlan{
+						Step: task("2"),
+						Next: task("3"),
+					},
+				},
+				Next: task("4"),
+			},
+		}
+
+		json := plan.Public()
+		Expect(json).ToNot(BeNil())
+		Expect([]byte(*json)).To(MatchJSON(`{
+  "id": "0",
+  "on_abort": {
+    "step": {
+      "id": "1",
+      "on_error": {
+        "step": {
+          "id": "2",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        },
+        "on_error": {
+          "id": "3",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        }
+      }
+    },
+    "on_abort": {
+      "id": "4",
+      "task": {
+        "name": "name",
+        "privileged": false
+      }
+    }
+  }
+}
+`))
+	})
+
+	It("returns a sanitized form of in_parallel plans", func() {
+		plan := atc.Plan{
+			ID: "0",
+			InParallel: &atc.InParallelPlan{
+				Steps: []atc.Plan{
+					atc.Plan{
+						ID: "1",
+						Task: &atc.TaskPlan{
+							Name:       "name",
+							ConfigPath: "some/config/path.yml",
+							Config: &atc.TaskConfig{
+								Params: map[string]string{"some": "secret"},
+							},
+						},
+					},
+				},
+				Limit:    2,
+				FailFast: true,
+			},
+		}
+
+		json := plan.Public()
+		Expect(json).ToNot(BeNil())
+		Expect([]byte(*json)).To(MatchJSON(`{
+  "id": "0",
+  "in_parallel": {
+    "steps": [
+      {
+        "id": "1",
+        "task": {
+          "name": "name",
+          "privileged": false
+        }
+      }
+    ],
+    "limit": 2,
+    "fail_fast": true
+  }
+}
+`))
+	})
+
+	It("returns a sanitized form of across plans", func() {
+		plan := atc.Plan{
+			ID: "0",
+			Across: &atc.AcrossPlan{
+				Vars: []atc.AcrossVar{
+					{
+						Var:         "go_version",
+						Values:      []interface{}{"1.7", "1.8"},
+						MaxInFlight: 2,
+					},
+				},
+				Steps: []atc.VarScopedPlan{
+					{
+						Step: atc.Plan{
+							ID: "1",
+							Task: &atc.TaskPlan{
+								Name: "name",
+								Config: &atc.TaskConfig{
+									Params: map[string]string{"GO_VERSION": "1.7"},
+								},
+							},
+						},
+						Values: []interface{}{"1.7"},
+					},
+					{
+						Step: atc.Plan{
+							ID: "2",
+							Task: &atc.TaskPlan{
+								Name: "name",
+								Config: &atc.TaskConfig{
+									Params: map[string]string{"GO_VERSION": "1.8"},
+								},
+							},
+						},
+						Values: []interface{}{"1.8"},
+					},
+				},
+				FailFast: true,
+			},
+		}
+
+		json := plan.Public()
+		Expect(json).ToNot(BeNil())
+		Expect([]byte(*json)).To(MatchJSON(`{
+  "id": "0",
+  "across": {
+    "vars": [
+      {
+        "name": "go_version",
+        "values": ["1.7", "1.8"],
+        "max_in_flight": 2
+      }
+    ],
+    "steps": [
+      {
+        "values": ["1.7"],
+        "step": {
+          "id": "1",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        }
+      },
+      {
+        "values": ["1.8"],
+        "step": {
+          "id": "2",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        }
+      }
+    ],
+    "fail_fast": true
+  }
+}
+`))
+	})
+
+	It("returns a sanitized form of set_pipeline plans", func() {
+		plan := atc.Plan{
+			ID: "0",
+			SetPipeline: &atc.SetPipelinePlan{
+				Name: "some-pipeline",
+				File: "some-artifact/pipeline.yml",
+			},
+		}
+
+		json := plan.Public()
+		Expect(json).ToNot(BeNil())
+		Expect([]byte(*json)).To(MatchJSON(`{
+  "id": "0",
+  "set_pipeline": {
+    "name": "some-pipeline"
+  }
+}
+`))
+	})
+
+	It("returns a sanitized form of load_var plans", func() {
+		plan := atc.Plan{
+			ID: "0",
+			LoadVar: &atc.LoadVarPlan{
+				Name:   "some-var",
+				File:   "some-artifact/version",
+				Format: "raw",
+			},
+		}
+
+		json := plan.Public()
+		Expect(json).ToNot(BeNil())
+		Expect([]byte(*json)).To(MatchJSON(`{
+  "id": "0",
+  "load_var": {
+    "name": "some-var"
+  }
+}
+`))
+	})
+
+	It("returns a sanitized form of approval plans", func() {
+		plan := atc.Plan{
+			ID: "0",
+			Approval: &atc.ApprovalPlan{
+				Approvers: []string{"some-user"},
+				Timeout:   "24h",
+				Message:   "deploy to production?",
+			},
+		}
+
+		json := plan.Public()
+		Expect(json).ToNot(BeNil())
+		Expect([]byte(*json)).To(MatchJSON(`{
+  "id": "0",
+  "approval": {
+    "approvers": ["some-user"],
+    "message": "deploy to production?"
+  }
+}
 `))
 	})
 })
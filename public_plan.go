@@ -7,13 +7,20 @@ func (plan Plan) Public() *json.RawMessage {
 		ID PlanID `json:"id"`
 
 		Aggregate    *json.RawMessage `json:"aggregate,omitempty"`
+		InParallel   *json.RawMessage `json:"in_parallel,omitempty"`
+		Across       *json.RawMessage `json:"across,omitempty"`
 		Do           *json.RawMessage `json:"do,omitempty"`
 		Get          *json.RawMessage `json:"get,omitempty"`
 		Put          *json.RawMessage `json:"put,omitempty"`
 		Task         *json.RawMessage `json:"task,omitempty"`
+		SetPipeline  *json.RawMessage `json:"set_pipeline,omitempty"`
+		LoadVar      *json.RawMessage `json:"load_var,omitempty"`
+		Approval     *json.RawMessage `json:"approval,omitempty"`
 		Ensure       *json.RawMessage `json:"ensure,omitempty"`
 		OnSuccess    *json.RawMessage `json:"on_success,omitempty"`
 		OnFailure    *json.RawMessage `json:"on_failure,omitempty"`
+		OnError      *json.RawMessage `json:"on_error,omitempty"`
+		OnAbort      *json.RawMessage `json:"on_abort,omitempty"`
 		Try          *json.RawMessage `json:"try,omitempty"`
 		DependentGet *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout      *json.RawMessage `json:"timeout,omitempty"`
@@ -26,6 +33,14 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Aggregate = plan.Aggregate.Public()
 	}
 
+	if plan.InParallel != nil {
+		public.InParallel = plan.InParallel.Public()
+	}
+
+	if plan.Across != nil {
+		public.Across = plan.Across.Public()
+	}
+
 	if plan.Do != nil {
 		public.Do = plan.Do.Public()
 	}
@@ -42,6 +57,18 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Task = plan.Task.Public()
 	}
 
+	if plan.SetPipeline != nil {
+		public.SetPipeline = plan.SetPipeline.Public()
+	}
+
+	if plan.LoadVar != nil {
+		public.LoadVar = plan.LoadVar.Public()
+	}
+
+	if plan.Approval != nil {
+		public.Approval = plan.Approval.Public()
+	}
+
 	if plan.Ensure != nil {
 		public.Ensure = plan.Ensure.Public()
 	}
@@ -54,6 +81,14 @@ func (plan Plan) Public() *json.RawMessage {
 		public.OnFailure = plan.OnFailure.Public()
 	}
 
+	if plan.OnError != nil {
+		public.OnError = plan.OnError.Public()
+	}
+
+	if plan.OnAbort != nil {
+		public.OnAbort = plan.OnAbort.Public()
+	}
+
 	if plan.Try != nil {
 		public.Try = plan.Try.Public()
 	}
@@ -83,6 +118,50 @@ func (plan AggregatePlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan AcrossPlan) Public() *json.RawMessage {
+	type scopedStep struct {
+		Values []interface{}    `json:"values"`
+		Step   *json.RawMessage `json:"step"`
+	}
+
+	steps := make([]scopedStep, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = scopedStep{
+			Values: plan.Steps[i].Values,
+			Step:   plan.Steps[i].Step.Public(),
+		}
+	}
+
+	return enc(struct {
+		Vars     []AcrossVar  `json:"vars"`
+		Steps    []scopedStep `json:"steps"`
+		FailFast bool         `json:"fail_fast,omitempty"`
+	}{
+		Vars:     plan.Vars,
+		Steps:    steps,
+		FailFast: plan.FailFast,
+	})
+}
+
+func (plan InParallelPlan) Public() *json.RawMessage {
+	steps := make([]*json.RawMessage, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = plan.Steps[i].Public()
+	}
+
+	return enc(struct {
+		Steps    []*json.RawMessage `json:"steps"`
+		Limit    int                `json:"limit,omitempty"`
+		FailFast bool               `json:"fail_fast,omitempty"`
+	}{
+		Steps:    steps,
+		Limit:    plan.Limit,
+		FailFast: plan.FailFast,
+	})
+}
+
 func (plan DoPlan) Public() *json.RawMessage {
 	public := make([]*json.RawMessage, len(plan))
 
@@ -139,6 +218,26 @@ func (plan OnFailurePlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan OnErrorPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step *json.RawMessage `json:"step"`
+		Next *json.RawMessage `json:"on_error"`
+	}{
+		Step: plan.Step.Public(),
+		Next: plan.Next.Public(),
+	})
+}
+
+func (plan OnAbortPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step *json.RawMessage `json:"step"`
+		Next *json.RawMessage `json:"on_abort"`
+	}{
+		Step: plan.Step.Public(),
+		Next: plan.Next.Public(),
+	})
+}
+
 func (plan OnSuccessPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
@@ -171,6 +270,32 @@ func (plan TaskPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan SetPipelinePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
+func (plan LoadVarPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
+func (plan ApprovalPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Approvers []string `json:"approvers,omitempty"`
+		Message   string   `json:"message,omitempty"`
+	}{
+		Approvers: plan.Approvers,
+		Message:   plan.Message,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -15,6 +15,7 @@ type RadarDB interface {
 	GetPipelineID() int
 	ScopedName(string) string
 	TeamID() int
+	TeamName() string
 	Config() atc.Config
 
 	IsPaused() (bool, error)
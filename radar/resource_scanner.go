@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -17,23 +18,29 @@ type resourceScanner struct {
 	clock           clock.Clock
 	tracker         resource.Tracker
 	defaultInterval time.Duration
+	webhookInterval time.Duration
 	db              RadarDB
 	externalURL     string
+	variables       creds.Variables
 }
 
 func NewResourceScanner(
 	clock clock.Clock,
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	webhookInterval time.Duration,
 	db RadarDB,
 	externalURL string,
+	variables creds.Variables,
 ) Scanner {
 	return &resourceScanner{
 		clock:           clock,
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
+		webhookInterval: webhookInterval,
 		db:              db,
 		externalURL:     externalURL,
+		variables:       variables,
 	}
 }
 
@@ -217,6 +224,18 @@ func (scanner *resourceScanner) scan(
 		return errPipelineRemoved
 	}
 
+	source, resourceTypes, err := scanner.evaluateCredentials(savedResource)
+	if err != nil {
+		logger.Error("failed-to-evaluate-credentials", err)
+
+		setErr := scanner.db.SetResourceCheckError(savedResource, err)
+		if setErr != nil {
+			logger.Error("failed-to-set-check-error", err)
+		}
+
+		return err
+	}
+
 	res, err := scanner.tracker.Init(
 		logger,
 		resource.TrackerMetadata{
@@ -226,9 +245,9 @@ func (scanner *resourceScanner) scan(
 		},
 		session,
 		resource.ResourceType(savedResource.Config.Type),
-		[]string{},
+		savedResource.Config.Tags,
 		scanner.db.TeamID(),
-		scanner.db.Config().ResourceTypes,
+		resourceTypes,
 		worker.NoopImageFetchingDelegate{},
 	)
 	if err != nil {
@@ -242,7 +261,7 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(savedResource.Config.Source, fromVersion)
+	newVersions, err := res.Check(source, fromVersion)
 
 	setErr := scanner.db.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
@@ -279,6 +298,20 @@ func (scanner *resourceScanner) scan(
 	return nil
 }
 
+func (scanner *resourceScanner) evaluateCredentials(savedResource db.SavedResource) (atc.Source, atc.ResourceTypes, error) {
+	source, err := creds.EvaluateSource(scanner.variables, savedResource.Config.Source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resourceTypes, err := creds.EvaluateResourceTypes(scanner.variables, scanner.db.Config().ResourceTypes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return source, resourceTypes, nil
+}
+
 func swallowErrResourceScriptFailed(err error) error {
 	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
 		return nil
@@ -288,6 +321,12 @@ func swallowErrResourceScriptFailed(err error) error {
 
 func (scanner *resourceScanner) checkInterval(resourceConfig atc.ResourceConfig) (time.Duration, error) {
 	interval := scanner.defaultInterval
+	if resourceConfig.WebhookToken != "" && scanner.webhookInterval != 0 {
+		// resources with a webhook are checked when the webhook is called, so
+		// polling is only a fallback
+		interval = scanner.webhookInterval
+	}
+
 	if resourceConfig.CheckEvery != "" {
 		configuredInterval, err := time.ParseDuration(resourceConfig.CheckEvery)
 		if err != nil {
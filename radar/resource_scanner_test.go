@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 
@@ -24,10 +25,12 @@ var _ = Describe("ResourceScanner", func() {
 	var (
 		epoch time.Time
 
-		fakeTracker *rfakes.FakeTracker
-		fakeRadarDB *radarfakes.FakeRadarDB
-		fakeClock   *fakeclock.FakeClock
-		interval    time.Duration
+		fakeTracker     *rfakes.FakeTracker
+		fakeRadarDB     *radarfakes.FakeRadarDB
+		fakeClock       *fakeclock.FakeClock
+		fakeVariables   *credsfakes.FakeVariables
+		interval        time.Duration
+		webhookInterval time.Duration
 
 		scanner Scanner
 
@@ -43,15 +46,19 @@ var _ = Describe("ResourceScanner", func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
 		fakeClock = fakeclock.NewFakeClock(epoch)
+		fakeVariables = new(credsfakes.FakeVariables)
 		interval = 1 * time.Minute
+		webhookInterval = 1 * time.Hour
 
 		fakeRadarDB.GetPipelineIDReturns(42)
 		scanner = NewResourceScanner(
 			fakeClock,
 			fakeTracker,
 			interval,
+			webhookInterval,
 			fakeRadarDB,
 			"https://www.example.com",
+			fakeVariables,
 		)
 
 		resourceConfig = atc.ResourceConfig{
@@ -169,6 +176,59 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(actualTeamID).To(Equal(teamID))
 			})
 
+			Context("when the resource has tags", func() {
+				BeforeEach(func() {
+					resourceConfig.Tags = atc.Tags{"some", "tags"}
+					savedResource.Config = resourceConfig
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+				})
+
+				It("constructs the resource on workers with those tags", func() {
+					_, _, _, _, tags, _, _, _ := fakeTracker.InitArgsForCall(0)
+					Expect(tags).To(Equal(atc.Tags{"some", "tags"}))
+				})
+			})
+
+			Context("when the source contains credential placeholders", func() {
+				BeforeEach(func() {
+					savedResource.Config.Source = atc.Source{"uri": "((uri))"}
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+				})
+
+				Context("when the credentials are found", func() {
+					BeforeEach(func() {
+						fakeVariables.GetReturns("http://secret.example.com", true, nil)
+					})
+
+					It("checks with the evaluated source", func() {
+						Expect(fakeResource.CheckCallCount()).To(Equal(1))
+
+						source, _ := fakeResource.CheckArgsForCall(0)
+						Expect(source).To(Equal(atc.Source{"uri": "http://secret.example.com"}))
+					})
+
+					It("identifies the container by the unevaluated source", func() {
+						_, _, session, _, _, _, _, _ := fakeTracker.InitArgsForCall(0)
+						Expect(session.ID.CheckSource).To(Equal(atc.Source{"uri": "((uri))"}))
+					})
+				})
+
+				Context("when the credentials are not found", func() {
+					BeforeEach(func() {
+						fakeVariables.GetReturns(nil, false, nil)
+					})
+
+					It("sets the check error and returns it", func() {
+						Expect(runErr).To(HaveOccurred())
+						Expect(fakeResource.CheckCallCount()).To(Equal(0))
+
+						Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+						_, checkErr := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+						Expect(checkErr).To(Equal(runErr))
+					})
+				})
+			})
+
 			Context("when the resource config has a specified check interval", func() {
 				BeforeEach(func() {
 					savedResource.Config.CheckEvery = "10ms"
@@ -210,6 +270,35 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the resource config has a webhook token", func() {
+				BeforeEach(func() {
+					savedResource.Config.WebhookToken = "some-token"
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+				})
+
+				It("leases for the webhook interval", func() {
+					Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(Equal(1))
+
+					_, _, leaseInterval, _ := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
+					Expect(leaseInterval).To(Equal(webhookInterval))
+				})
+
+				It("returns the webhook interval", func() {
+					Expect(actualInterval).To(Equal(webhookInterval))
+				})
+
+				Context("and a specified check interval", func() {
+					BeforeEach(func() {
+						savedResource.Config.CheckEvery = "10ms"
+						fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+					})
+
+					It("returns the configured interval", func() {
+						Expect(actualInterval).To(Equal(10 * time.Millisecond))
+					})
+				})
+			})
+
 			It("grabs a periodic resource checking lock before checking, breaks lock after done", func() {
 				Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(Equal(1))
 
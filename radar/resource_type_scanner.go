@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -15,6 +16,7 @@ type resourceTypeScanner struct {
 	defaultInterval time.Duration
 	db              RadarDB
 	externalURL     string
+	variables       creds.Variables
 }
 
 func NewResourceTypeScanner(
@@ -22,12 +24,14 @@ func NewResourceTypeScanner(
 	defaultInterval time.Duration,
 	db RadarDB,
 	externalURL string,
+	variables creds.Variables,
 ) Scanner {
 	return &resourceTypeScanner{
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
 		db:              db,
 		externalURL:     externalURL,
+		variables:       variables,
 	}
 }
 
@@ -53,21 +57,27 @@ func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeName st
 		return 0, db.ResourceTypeNotFoundError{Name: resourceTypeName}
 	}
 
+	interval, err := scanner.checkInterval(savedResourceType.Config)
+	if err != nil {
+		logger.Error("failed-to-determine-check-interval", err)
+		return 0, err
+	}
+
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource-type": resourceTypeName,
 	})
 
-	lock, acquired, err := scanner.db.AcquireResourceTypeCheckingLock(logger, savedResourceType, scanner.defaultInterval, false)
+	lock, acquired, err := scanner.db.AcquireResourceTypeCheckingLock(logger, savedResourceType, interval, false)
 	if err != nil {
 		lockLogger.Error("failed-to-get-lock", err, lager.Data{
 			"resource-type": resourceTypeName,
 		})
-		return scanner.defaultInterval, ErrFailedToAcquireLease
+		return interval, ErrFailedToAcquireLease
 	}
 
 	if !acquired {
 		lockLogger.Debug("did-not-get-lock")
-		return scanner.defaultInterval, ErrFailedToAcquireLease
+		return interval, ErrFailedToAcquireLease
 	}
 
 	defer lock.Release()
@@ -77,7 +87,15 @@ func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeName st
 		return 0, err
 	}
 
-	return scanner.defaultInterval, nil
+	return interval, nil
+}
+
+func (scanner *resourceTypeScanner) checkInterval(resourceType atc.ResourceType) (time.Duration, error) {
+	if resourceType.CheckEvery == "" {
+		return scanner.defaultInterval, nil
+	}
+
+	return time.ParseDuration(resourceType.CheckEvery)
 }
 
 func (scanner *resourceTypeScanner) Scan(logger lager.Logger, resourceTypeName string) error {
@@ -91,6 +109,12 @@ func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourc
 func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resourceType atc.ResourceType, fromVersion db.Version) error {
 	pipelineID := scanner.db.GetPipelineID()
 
+	source, err := creds.EvaluateSource(scanner.variables, resourceType.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-credentials", err)
+		return err
+	}
+
 	session := resource.Session{
 		ID: worker.Identifier{
 			Stage:               db.ContainerStageCheck,
@@ -113,7 +137,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 		resource.EmptyMetadata{},
 		session,
 		resource.ResourceType(resourceType.Type),
-		[]string{},
+		resourceType.Tags,
 		scanner.db.TeamID(),
 		atc.ResourceTypes{},
 		worker.NoopImageFetchingDelegate{},
@@ -126,7 +150,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 
 	logger.Debug("checking")
 
-	newVersions, err := res.Check(resourceType.Source, atc.Version(fromVersion))
+	newVersions, err := res.Check(source, atc.Version(fromVersion))
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
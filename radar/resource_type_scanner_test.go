@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/radar"
 	"github.com/concourse/atc/radar/radarfakes"
@@ -43,6 +44,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			interval,
 			fakeRadarDB,
 			"https://www.example.com",
+			new(credsfakes.FakeVariables),
 		)
 
 		fakeRadarDB.ScopedNameStub = func(thing string) string {
@@ -160,6 +162,47 @@ var _ = Describe("ResourceTypeScanner", func() {
 				Eventually(fakeResource.ReleaseCallCount).Should(Equal(1))
 			})
 
+			Context("when the resource type has tags", func() {
+				BeforeEach(func() {
+					savedResourceType.Config.Tags = atc.Tags{"some-tag"}
+					fakeRadarDB.GetResourceTypeReturns(savedResourceType, true, nil)
+				})
+
+				It("checks on workers with those tags", func() {
+					_, _, _, _, tags, _, _, _ := fakeTracker.InitArgsForCall(0)
+					Expect(tags).To(Equal(atc.Tags{"some-tag"}))
+				})
+			})
+
+			Context("when the resource type has a check_every", func() {
+				BeforeEach(func() {
+					savedResourceType.Config.CheckEvery = "10ms"
+					fakeRadarDB.GetResourceTypeReturns(savedResourceType, true, nil)
+				})
+
+				It("grabs the lock for that interval", func() {
+					_, _, leaseInterval, _ := fakeRadarDB.AcquireResourceTypeCheckingLockArgsForCall(0)
+					Expect(leaseInterval).To(Equal(10 * time.Millisecond))
+				})
+
+				It("returns that interval", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+					Expect(actualInterval).To(Equal(10 * time.Millisecond))
+				})
+
+				Context("when the check_every cannot be parsed", func() {
+					BeforeEach(func() {
+						savedResourceType.Config.CheckEvery = "bogus"
+						fakeRadarDB.GetResourceTypeReturns(savedResourceType, true, nil)
+					})
+
+					It("returns an error without checking", func() {
+						Expect(runErr).To(HaveOccurred())
+						Expect(fakeResource.CheckCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			Context("when there is no current version", func() {
 				It("checks from nil", func() {
 					_, version := fakeResource.CheckArgsForCall(0)
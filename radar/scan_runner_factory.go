@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 	"github.com/tedsuo/ifrit"
 
@@ -33,22 +34,27 @@ type scanRunnerFactory struct {
 func NewScanRunnerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	webhookInterval time.Duration,
 	db RadarDB,
 	clock clock.Clock,
 	externalURL string,
+	variables creds.Variables,
 ) ScanRunnerFactory {
 	resourceScanner := NewResourceScanner(
 		clock,
 		tracker,
 		defaultInterval,
+		webhookInterval,
 		db,
 		externalURL,
+		variables,
 	)
 	resourceTypeScanner := NewResourceTypeScanner(
 		tracker,
 		defaultInterval,
 		db,
 		externalURL,
+		variables,
 	)
 
 	return &scanRunnerFactory{
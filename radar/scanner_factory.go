@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 )
 
@@ -12,23 +13,30 @@ type ScannerFactory interface {
 }
 
 type scannerFactory struct {
-	tracker         resource.Tracker
-	defaultInterval time.Duration
-	externalURL     string
+	tracker           resource.Tracker
+	defaultInterval   time.Duration
+	webhookInterval   time.Duration
+	externalURL       string
+	credentialManager creds.CredentialManager
 }
 
 func NewScannerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	webhookInterval time.Duration,
 	externalURL string,
+	credentialManager creds.CredentialManager,
 ) ScannerFactory {
 	return &scannerFactory{
-		tracker:         tracker,
-		defaultInterval: defaultInterval,
-		externalURL:     externalURL,
+		tracker:           tracker,
+		defaultInterval:   defaultInterval,
+		webhookInterval:   webhookInterval,
+		externalURL:       externalURL,
+		credentialManager: credentialManager,
 	}
 }
 
 func (f *scannerFactory) NewResourceScanner(db RadarDB) Scanner {
-	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.externalURL)
+	variables := creds.NewVariables(f.credentialManager, db.TeamName(), db.GetPipelineName())
+	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, f.webhookInterval, db, f.externalURL, variables)
 }
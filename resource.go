@@ -6,7 +6,8 @@ type Resource struct {
 	Groups []string `json:"groups"`
 	URL    string   `json:"url"`
 
-	Paused bool `json:"paused,omitempty"`
+	Paused        bool    `json:"paused,omitempty"`
+	PinnedVersion Version `json:"pinned_version,omitempty"`
 
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
@@ -102,6 +102,17 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		TeamID:       f.teamID,
 	}
 
+	if cache := f.cacheIdentifier.VolumeIdentifier().ResourceCache; cache != nil {
+		resourceSpec.Volumes = []worker.VolumeRef{
+			{
+				Strategy: worker.ResourceCacheStrategy{
+					ResourceHash:    cache.ResourceHash,
+					ResourceVersion: cache.ResourceVersion,
+				},
+			},
+		}
+	}
+
 	chosenWorker, err := f.workerClient.Satisfying(resourceSpec, f.resourceTypes)
 	if err != nil {
 		f.logger.Error("no-workers-satisfying-spec", err)
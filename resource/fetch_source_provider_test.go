@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/resource"
 	"github.com/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/atc/worker"
@@ -93,6 +94,31 @@ var _ = Describe("FetchSourceProvider", func() {
 				Expect(actualResourceTypes).To(Equal(resourceTypes))
 			})
 
+			Context("when the cache identifies a resource cache", func() {
+				BeforeEach(func() {
+					cacheID.VolumeIdentifierReturns(worker.VolumeIdentifier{
+						ResourceCache: &db.ResourceCacheIdentifier{
+							ResourceHash:    "some-resource-hash",
+							ResourceVersion: atc.Version{"some": "version"},
+						},
+					})
+				})
+
+				It("asks for a worker that has the cache volume", func() {
+					_, err := fetchSourceProvider.Get()
+					Expect(err).NotTo(HaveOccurred())
+					resourceSpec, _ := fakeWorkerClient.SatisfyingArgsForCall(0)
+					Expect(resourceSpec.Volumes).To(Equal([]worker.VolumeRef{
+						{
+							Strategy: worker.ResourceCacheStrategy{
+								ResourceHash:    "some-resource-hash",
+								ResourceVersion: atc.Version{"some": "version"},
+							},
+						},
+					}))
+				})
+			})
+
 			Context("when worker is found for resource types", func() {
 				var fakeWorker *workerfakes.FakeWorker
 
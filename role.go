@@ -0,0 +1,45 @@
+package atc
+
+// Role is what a user who has logged in to a team is permitted to do within
+// it. Each role permits everything the roles below it permit.
+type Role string
+
+const (
+	// RoleOwner may do anything within the team, including configuring its
+	// auth.
+	RoleOwner Role = "owner"
+
+	// RoleMember may configure pipelines, run one-off builds and hijack
+	// containers.
+	RoleMember Role = "member"
+
+	// RolePipelineOperator may operate existing pipelines: trigger and abort
+	// builds, pause and unpause, and check and pin resources.
+	RolePipelineOperator Role = "pipeline-operator"
+
+	// RoleViewer may only read.
+	RoleViewer Role = "viewer"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer:           0,
+	RolePipelineOperator: 1,
+	RoleMember:           2,
+	RoleOwner:            3,
+}
+
+// IsValid returns whether the role is one of the known roles.
+func (role Role) IsValid() bool {
+	_, found := roleRanks[role]
+	return found
+}
+
+// Permits returns whether the role is at least the given role.
+func (role Role) Permits(minimum Role) bool {
+	rank, found := roleRanks[role]
+	if !found {
+		return false
+	}
+
+	return rank >= roleRanks[minimum]
+}
@@ -0,0 +1,56 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Role", func() {
+	Describe("Permits", func() {
+		It("permits its own role and the roles below it", func() {
+			Expect(atc.RoleOwner.Permits(atc.RoleOwner)).To(BeTrue())
+			Expect(atc.RoleOwner.Permits(atc.RoleViewer)).To(BeTrue())
+			Expect(atc.RoleMember.Permits(atc.RolePipelineOperator)).To(BeTrue())
+			Expect(atc.RolePipelineOperator.Permits(atc.RoleViewer)).To(BeTrue())
+		})
+
+		It("does not permit the roles above it", func() {
+			Expect(atc.RoleViewer.Permits(atc.RolePipelineOperator)).To(BeFalse())
+			Expect(atc.RolePipelineOperator.Permits(atc.RoleMember)).To(BeFalse())
+			Expect(atc.RoleMember.Permits(atc.RoleOwner)).To(BeFalse())
+		})
+
+		It("does not permit anything for an unknown role", func() {
+			Expect(atc.Role("bogus").Permits(atc.RoleViewer)).To(BeFalse())
+		})
+	})
+
+	Describe("IsValid", func() {
+		It("is true for the known roles only", func() {
+			Expect(atc.RoleOwner.IsValid()).To(BeTrue())
+			Expect(atc.RoleMember.IsValid()).To(BeTrue())
+			Expect(atc.RolePipelineOperator.IsValid()).To(BeTrue())
+			Expect(atc.RoleViewer.IsValid()).To(BeTrue())
+			Expect(atc.Role("bogus").IsValid()).To(BeFalse())
+		})
+	})
+
+	Describe("RouteRoles", func() {
+		It("declares a valid minimum role for every route", func() {
+			for _, route := range atc.Routes {
+				role, found := atc.RouteRoles[route.Name]
+				Expect(found).To(BeTrue(), "no role for "+route.Name)
+				Expect(role.IsValid()).To(BeTrue(), "invalid role for "+route.Name)
+			}
+		})
+
+		It("does not let viewers modify pipelines or hijack containers", func() {
+			Expect(atc.RoleViewer.Permits(atc.RouteRoles[atc.SaveConfig])).To(BeFalse())
+			Expect(atc.RoleViewer.Permits(atc.RouteRoles[atc.HijackContainer])).To(BeFalse())
+			Expect(atc.RoleViewer.Permits(atc.RouteRoles[atc.AbortBuild])).To(BeFalse())
+			Expect(atc.RoleViewer.Permits(atc.RouteRoles[atc.GetBuild])).To(BeTrue())
+		})
+	})
+})
@@ -3,8 +3,13 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig         = "SaveConfig"
+	GetConfig          = "GetConfig"
+	ValidateConfig     = "ValidateConfig"
+	ListConfigVersions = "ListConfigVersions"
+	GetConfigVersion   = "GetConfigVersion"
+	DiffConfigVersions = "DiffConfigVersions"
+	RollbackConfig     = "RollbackConfig"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
@@ -13,6 +18,7 @@ const (
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	ApproveBuild        = "ApproveBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 
 	GetJob         = "GetJob"
@@ -23,19 +29,23 @@ const (
 	GetJobBuild    = "GetJobBuild"
 	PauseJob       = "PauseJob"
 	UnpauseJob     = "UnpauseJob"
+	ClearJobCaches = "ClearJobCaches"
 	GetVersionsDB  = "GetVersionsDB"
 	JobBadge       = "JobBadge"
 	MainJobBadge   = "MainJobBadge"
 
-	ListResources   = "ListResources"
-	GetResource     = "GetResource"
-	PauseResource   = "PauseResource"
-	UnpauseResource = "UnpauseResource"
-	CheckResource   = "CheckResource"
+	ListResources        = "ListResources"
+	GetResource          = "GetResource"
+	PauseResource        = "PauseResource"
+	UnpauseResource      = "UnpauseResource"
+	CheckResource        = "CheckResource"
+	CheckResourceWebHook = "CheckResourceWebHook"
 
 	ListResourceVersions          = "ListResourceVersions"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
+	PinResourceVersion            = "PinResourceVersion"
+	UnpinResourceVersion          = "UnpinResourceVersion"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 
@@ -49,6 +59,10 @@ const (
 	ExposePipeline   = "ExposePipeline"
 	HidePipeline     = "HidePipeline"
 	RenamePipeline   = "RenamePipeline"
+	ArchivePipeline  = "ArchivePipeline"
+	ExportPipeline   = "ExportPipeline"
+	ImportPipeline   = "ImportPipeline"
+	GetPipelineGraph = "GetPipelineGraph"
 
 	CreatePipe = "CreatePipe"
 	WritePipe  = "WritePipe"
@@ -80,6 +94,11 @@ const (
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/validate", Method: "POST", Name: ValidateConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", Method: "GET", Name: ListConfigVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", Method: "GET", Name: GetConfigVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/diff", Method: "GET", Name: DiffConfigVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/rollback", Method: "PUT", Name: RollbackConfig},
 
 	{Path: "/api/v1/builds", Method: "POST", Name: CreateBuild},
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
@@ -88,6 +107,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "POST", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/approvals", Method: "POST", Name: ApproveBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -98,6 +118,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", Method: "DELETE", Name: ClearJobCaches},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: MainJobBadge},
 
@@ -112,16 +133,23 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/hide", Method: "PUT", Name: HidePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/archive", Method: "PUT", Name: ArchivePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/export", Method: "GET", Name: ExportPipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/import", Method: "PUT", Name: ImportPipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/graph", Method: "GET", Name: GetPipelineGraph},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "DELETE", Name: UnpinResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 
@@ -151,3 +179,85 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
 })
+
+// RouteRoles is the minimum role within a team that a user must have been
+// granted to use each route. Routes which are not scoped to a team require
+// the role within the team the user is logged in to.
+var RouteRoles = map[string]Role{
+	GetConfig:                     RoleViewer,
+	ValidateConfig:                RoleViewer,
+	ListConfigVersions:            RoleViewer,
+	GetConfigVersion:              RoleViewer,
+	DiffConfigVersions:            RoleViewer,
+	ListBuilds:                    RoleViewer,
+	GetBuild:                      RoleViewer,
+	GetBuildPlan:                  RoleViewer,
+	BuildEvents:                   RoleViewer,
+	BuildResources:                RoleViewer,
+	GetBuildPreparation:           RoleViewer,
+	ListJobs:                      RoleViewer,
+	GetJob:                        RoleViewer,
+	ListJobBuilds:                 RoleViewer,
+	ListJobInputs:                 RoleViewer,
+	GetJobBuild:                   RoleViewer,
+	JobBadge:                      RoleViewer,
+	MainJobBadge:                  RoleViewer,
+	ListAllPipelines:              RoleViewer,
+	ListPipelines:                 RoleViewer,
+	GetPipeline:                   RoleViewer,
+	GetPipelineGraph:              RoleViewer,
+	GetVersionsDB:                 RoleViewer,
+	ListResources:                 RoleViewer,
+	GetResource:                   RoleViewer,
+	CheckResourceWebHook:          RoleViewer,
+	ListResourceVersions:          RoleViewer,
+	ListBuildsWithVersionAsInput:  RoleViewer,
+	ListBuildsWithVersionAsOutput: RoleViewer,
+	ListWorkers:                   RoleViewer,
+	GetLogLevel:                   RoleViewer,
+	DownloadCLI:                   RoleViewer,
+	GetInfo:                       RoleViewer,
+	ListContainers:                RoleViewer,
+	GetContainer:                  RoleViewer,
+	ListVolumes:                   RoleViewer,
+	ListAuthMethods:               RoleViewer,
+	GetAuthToken:                  RoleViewer,
+	GetUser:                       RoleViewer,
+	ListTeams:                     RoleViewer,
+
+	AbortBuild:             RolePipelineOperator,
+	ApproveBuild:           RolePipelineOperator,
+	CreateJobBuild:         RolePipelineOperator,
+	PauseJob:               RolePipelineOperator,
+	UnpauseJob:             RolePipelineOperator,
+	ClearJobCaches:         RolePipelineOperator,
+	PausePipeline:          RolePipelineOperator,
+	UnpausePipeline:        RolePipelineOperator,
+	PauseResource:          RolePipelineOperator,
+	UnpauseResource:        RolePipelineOperator,
+	CheckResource:          RolePipelineOperator,
+	EnableResourceVersion:  RolePipelineOperator,
+	DisableResourceVersion: RolePipelineOperator,
+	PinResourceVersion:     RolePipelineOperator,
+	UnpinResourceVersion:   RolePipelineOperator,
+
+	SaveConfig:      RoleMember,
+	RollbackConfig:  RoleMember,
+	CreateBuild:     RoleMember,
+	DeletePipeline:  RoleMember,
+	OrderPipelines:  RoleMember,
+	ExposePipeline:  RoleMember,
+	HidePipeline:    RoleMember,
+	RenamePipeline:  RoleMember,
+	ArchivePipeline: RoleMember,
+	CreatePipe:      RoleMember,
+	WritePipe:       RoleMember,
+	ReadPipe:        RoleMember,
+	RegisterWorker:  RoleMember,
+	HijackContainer: RoleMember,
+
+	SetLogLevel:    RoleOwner,
+	SetTeam:        RoleOwner,
+	ExportPipeline: RoleOwner,
+	ImportPipeline: RoleOwner,
+}
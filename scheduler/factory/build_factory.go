@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 )
 
@@ -99,6 +100,10 @@ func (factory *buildFactory) constructPlanFromConfig(
 	resourceTypes atc.ResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if len(planConfig.Across) > 0 {
+		return factory.across(planConfig, resources, resourceTypes, inputs)
+	}
+
 	var plan atc.Plan
 	var err error
 
@@ -131,6 +136,83 @@ func (factory *buildFactory) constructPlanFromConfig(
 	})
 }
 
+// across constructs the step once for every combination of the values of
+// its across vars, with the first var varying the slowest. Each combination
+// gets its own copy of the step, with the values interpolated into it, and
+// its own hooks.
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.ResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	acrossPlan := atc.AcrossPlan{
+		FailFast: planConfig.FailFast,
+	}
+
+	for _, acrossVar := range planConfig.Across {
+		maxInFlight := acrossVar.MaxInFlight
+		if maxInFlight == 0 {
+			maxInFlight = 1
+		}
+
+		acrossPlan.Vars = append(acrossPlan.Vars, atc.AcrossVar{
+			Var:         acrossVar.Var,
+			Values:      acrossVar.Values,
+			MaxInFlight: maxInFlight,
+		})
+	}
+
+	stepConfig := planConfig
+	stepConfig.Across = nil
+	stepConfig.FailFast = false
+
+	for _, combination := range acrossCombinations(planConfig.Across) {
+		vals := map[string]interface{}{}
+		for i, acrossVar := range planConfig.Across {
+			vals[acrossVar.Var] = combination[i]
+		}
+
+		scopedConfig, err := creds.EvaluateAcrossVars(vals, stepConfig)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		step, err := factory.constructPlanFromConfig(scopedConfig, resources, resourceTypes, inputs)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		acrossPlan.Steps = append(acrossPlan.Steps, atc.VarScopedPlan{
+			Step:   step,
+			Values: combination,
+		})
+	}
+
+	return factory.planFactory.NewPlan(acrossPlan), nil
+}
+
+func acrossCombinations(vars []atc.AcrossVarConfig) [][]interface{} {
+	combinations := [][]interface{}{{}}
+
+	for _, acrossVar := range vars {
+		next := [][]interface{}{}
+
+		for _, combination := range combinations {
+			for _, val := range acrossVar.Values {
+				extended := make([]interface{}, len(combination), len(combination)+1)
+				copy(extended, combination)
+
+				next = append(next, append(extended, val))
+			}
+		}
+
+		combinations = next
+	}
+
+	return combinations
+}
+
 func (factory *buildFactory) constructUnhookedPlan(
 	planConfig atc.PlanConfig,
 	resources atc.ResourceConfigs,
@@ -172,7 +254,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Resource:      resourceName,
 			Source:        resource.Source,
 			Params:        planConfig.Params,
-			Tags:          planConfig.Tags,
+			Tags:          stepTags(planConfig, resource),
 			ResourceTypes: resourceTypes,
 		}
 
@@ -182,7 +264,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			PipelineID:    factory.PipelineID,
 			Resource:      resourceName,
 			Params:        planConfig.GetParams,
-			Tags:          planConfig.Tags,
+			Tags:          stepTags(planConfig, resource),
 			Source:        resource.Source,
 			ResourceTypes: resourceTypes,
 		}
@@ -220,7 +302,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Source:        resource.Source,
 			Params:        planConfig.Params,
 			Version:       atc.Version(version),
-			Tags:          planConfig.Tags,
+			Tags:          stepTags(planConfig, resource),
 			ResourceTypes: resourceTypes,
 		})
 
@@ -238,6 +320,23 @@ func (factory *buildFactory) constructUnhookedPlan(
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
 		})
+	case planConfig.LoadVar != "":
+		plan = factory.planFactory.NewPlan(atc.LoadVarPlan{
+			Name:   planConfig.LoadVar,
+			File:   planConfig.TaskConfigPath,
+			Format: planConfig.Format,
+		})
+	case planConfig.Approval != nil:
+		plan = factory.planFactory.NewPlan(atc.ApprovalPlan{
+			Approvers: planConfig.Approval.Approvers,
+			Timeout:   planConfig.Approval.Timeout,
+			Message:   planConfig.Approval.Message,
+		})
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name: planConfig.SetPipeline,
+			File: planConfig.TaskConfigPath,
+		})
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -271,6 +370,29 @@ func (factory *buildFactory) constructUnhookedPlan(
 		}
 
 		plan = factory.planFactory.NewPlan(aggregate)
+
+	case planConfig.InParallel != nil:
+		inParallel := atc.InParallelPlan{
+			Steps:    []atc.Plan{},
+			Limit:    planConfig.InParallel.Limit,
+			FailFast: planConfig.InParallel.FailFast,
+		}
+
+		for _, planConfig := range planConfig.InParallel.Steps {
+			nextStep, err := factory.constructPlanFromConfig(
+				planConfig,
+				resources,
+				resourceTypes,
+				inputs,
+			)
+			if err != nil {
+				return atc.Plan{}, err
+			}
+
+			inParallel.Steps = append(inParallel.Steps, nextStep)
+		}
+
+		plan = factory.planFactory.NewPlan(inParallel)
 	}
 
 	if planConfig.Timeout != "" {
@@ -294,6 +416,16 @@ type constructionParams struct {
 func (factory *buildFactory) applyHooks(cp constructionParams) (atc.Plan, error) {
 	var err error
 
+	cp, err = factory.errorIfPresent(cp)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	cp, err = factory.abortIfPresent(cp)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
 	cp, err = factory.failureIfPresent(cp)
 	if err != nil {
 		return atc.Plan{}, err
@@ -354,6 +486,48 @@ func (factory *buildFactory) failureIfPresent(cp constructionParams) (constructi
 	return cp, nil
 }
 
+func (factory *buildFactory) errorIfPresent(cp constructionParams) (constructionParams, error) {
+	if cp.hooks.Error != nil {
+		nextPlan, err := factory.constructPlanFromConfig(
+			*cp.hooks.Error,
+			cp.resources,
+			cp.resourceTypes,
+			cp.inputs,
+		)
+		if err != nil {
+			return constructionParams{}, err
+		}
+
+		cp.plan = factory.planFactory.NewPlan(atc.OnErrorPlan{
+			Step: cp.plan,
+			Next: nextPlan,
+		})
+	}
+
+	return cp, nil
+}
+
+func (factory *buildFactory) abortIfPresent(cp constructionParams) (constructionParams, error) {
+	if cp.hooks.Abort != nil {
+		nextPlan, err := factory.constructPlanFromConfig(
+			*cp.hooks.Abort,
+			cp.resources,
+			cp.resourceTypes,
+			cp.inputs,
+		)
+		if err != nil {
+			return constructionParams{}, err
+		}
+
+		cp.plan = factory.planFactory.NewPlan(atc.OnAbortPlan{
+			Step: cp.plan,
+			Next: nextPlan,
+		})
+	}
+
+	return cp, nil
+}
+
 func (factory *buildFactory) ensureIfPresent(cp constructionParams) (constructionParams, error) {
 	if cp.hooks.Ensure != nil {
 		nextPlan, err := factory.constructPlanFromConfig(
@@ -373,3 +547,13 @@ func (factory *buildFactory) ensureIfPresent(cp constructionParams) (constructio
 	}
 	return cp, nil
 }
+
+// stepTags returns the tags to run a get or put step with, which are the
+// resource's own tags unless the step overrides them.
+func stepTags(planConfig atc.PlanConfig, resource atc.ResourceConfig) atc.Tags {
+	if len(planConfig.Tags) > 0 {
+		return planConfig.Tags
+	}
+
+	return resource.Tags
+}
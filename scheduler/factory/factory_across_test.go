@@ -0,0 +1,153 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.ResourceTypes
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		resourceTypes = atc.ResourceTypes{}
+	})
+
+	Context("when a task has an across modifier", func() {
+		It("constructs the task for every combination of values", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+						TaskConfig: &atc.TaskConfig{
+							Params: map[string]string{
+								"GO_VERSION": "((.:go_version))",
+								"OS":         "((.:os))",
+							},
+						},
+						Across: []atc.AcrossVarConfig{
+							{Var: "go_version", Values: []interface{}{"1.7", "1.8"}, MaxInFlight: 2},
+							{Var: "os", Values: []interface{}{"linux", "darwin"}},
+						},
+						FailFast: true,
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			taskPlan := func(goVersion string, os string) atc.Plan {
+				return expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-task",
+					PipelineID: 42,
+					Config: &atc.TaskConfig{
+						Params: map[string]string{
+							"GO_VERSION": goVersion,
+							"OS":         os,
+						},
+					},
+					ResourceTypes: resourceTypes,
+				})
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+				Vars: []atc.AcrossVar{
+					{Var: "go_version", Values: []interface{}{"1.7", "1.8"}, MaxInFlight: 2},
+					{Var: "os", Values: []interface{}{"linux", "darwin"}, MaxInFlight: 1},
+				},
+				Steps: []atc.VarScopedPlan{
+					{Step: taskPlan("1.7", "linux"), Values: []interface{}{"1.7", "linux"}},
+					{Step: taskPlan("1.7", "darwin"), Values: []interface{}{"1.7", "darwin"}},
+					{Step: taskPlan("1.8", "linux"), Values: []interface{}{"1.8", "linux"}},
+					{Step: taskPlan("1.8", "darwin"), Values: []interface{}{"1.8", "darwin"}},
+				},
+				FailFast: true,
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
+	Context("when a put with hooks has an across modifier", func() {
+		It("applies the hooks to every combination", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Put:    "some-resource",
+						Params: atc.Params{"tag": "((.:tag))"},
+						Failure: &atc.PlanConfig{
+							Task: "some-alert",
+						},
+						Across: []atc.AcrossVarConfig{
+							{Var: "tag", Values: []interface{}{"a", "b"}},
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			putPlan := func(tag string) atc.Plan {
+				return expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+					Step: expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+						Step: expectedPlanFactory.NewPlan(atc.PutPlan{
+							Type:          "git",
+							Name:          "some-resource",
+							Resource:      "some-resource",
+							PipelineID:    42,
+							Source:        atc.Source{"uri": "git://some-resource"},
+							Params:        atc.Params{"tag": tag},
+							ResourceTypes: resourceTypes,
+						}),
+						Next: expectedPlanFactory.NewPlan(atc.DependentGetPlan{
+							Type:          "git",
+							Name:          "some-resource",
+							Resource:      "some-resource",
+							PipelineID:    42,
+							Source:        atc.Source{"uri": "git://some-resource"},
+							ResourceTypes: resourceTypes,
+						}),
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "some-alert",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				})
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+				Vars: []atc.AcrossVar{
+					{Var: "tag", Values: []interface{}{"a", "b"}, MaxInFlight: 1},
+				},
+				Steps: []atc.VarScopedPlan{
+					{Step: putPlan("a"), Values: []interface{}{"a"}},
+					{Step: putPlan("b"), Values: []interface{}{"b"}},
+				},
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
@@ -71,6 +71,63 @@ var _ = Describe("Factory Get", func() {
 		})
 	})
 
+	Context("with a get of a resource that has tags", func() {
+		BeforeEach(func() {
+			resources[0].Tags = atc.Tags{"resource-tag"}
+
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:      "some-get",
+						Resource: "some-resource",
+					},
+				},
+			}
+		})
+
+		It("runs the get with the resource's tags", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.GetPlan{
+				Type:       "git",
+				Name:       "some-get",
+				Resource:   "some-resource",
+				PipelineID: 42,
+				Source: atc.Source{
+					"uri": "git://some-resource",
+				},
+				Tags:          atc.Tags{"resource-tag"},
+				ResourceTypes: resourceTypes,
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+
+		Context("when the get specifies its own tags", func() {
+			BeforeEach(func() {
+				input.Plan[0].Tags = atc.Tags{"step-tag"}
+			})
+
+			It("runs the get with the step's tags", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.GetPlan{
+					Type:       "git",
+					Name:       "some-get",
+					Resource:   "some-resource",
+					PipelineID: 42,
+					Source: atc.Source{
+						"uri": "git://some-resource",
+					},
+					Tags:          atc.Tags{"step-tag"},
+					ResourceTypes: resourceTypes,
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+	})
+
 	Context("with a get for a non-existent resource", func() {
 		BeforeEach(func() {
 			input = atc.JobConfig{
@@ -88,6 +88,61 @@ var _ = Describe("Factory Hooks", func() {
 		})
 	})
 
+	Context("when there are error and abort hooks alongside the other hooks", func() {
+		var input atc.JobConfig
+
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "those who resist our will",
+						Error: &atc.PlanConfig{
+							Task: "step error",
+						},
+						Abort: &atc.PlanConfig{
+							Task: "step abort",
+						},
+						Failure: &atc.PlanConfig{
+							Task: "step failure",
+						},
+					},
+				},
+				Error: &atc.PlanConfig{
+					Task: "job error",
+				},
+			}
+		})
+
+		It("wraps the step with the error and abort hooks first", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			task := func(name string) atc.Plan {
+				return expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          name,
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				})
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.OnErrorPlan{
+				Step: expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+					Step: expectedPlanFactory.NewPlan(atc.OnAbortPlan{
+						Step: expectedPlanFactory.NewPlan(atc.OnErrorPlan{
+							Step: task("those who resist our will"),
+							Next: task("step error"),
+						}),
+						Next: task("step abort"),
+					}),
+					Next: task("step failure"),
+				}),
+				Next: task("job error"),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("when there is a do with three steps with a hook", func() {
 		var input atc.JobConfig
 
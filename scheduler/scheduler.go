@@ -4,20 +4,27 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/cron"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/buildstarter"
 	"github.com/concourse/atc/scheduler/inputmapper"
 )
 
+// MaxCatchUpBuilds is the most builds a schedule will create for the ticks
+// it missed, so that a long outage does not flood the job with builds.
+const MaxCatchUpBuilds = 10
+
 type Scheduler struct {
 	DB           SchedulerDB
 	InputMapper  inputmapper.InputMapper
 	BuildStarter buildstarter.BuildStarter
 	Scanner      Scanner
+	Clock        clock.Clock
 }
 
 //go:generate counterfeiter . SchedulerDB
@@ -30,6 +37,7 @@ type SchedulerDB interface {
 	Config() atc.Config
 	CreateJobBuild(job string) (db.Build, error)
 	EnsurePendingBuildExists(jobName string) error
+	UpdateJobScheduleCheckedTime(jobName string, checkedTime time.Time) (time.Time, error)
 	AcquireResourceCheckingForJobLock(logger lager.Logger, job string) (db.Lock, bool, error)
 	GetAllPendingBuilds() (map[string][]db.Build, error)
 	GetPendingBuildsForJob(jobName string) ([]db.Build, error)
@@ -53,6 +61,10 @@ func (s *Scheduler) Schedule(
 	for _, jobConfig := range jobConfigs {
 		jStart := time.Now()
 		err := s.ensurePendingBuildExists(logger, versions, jobConfig)
+		if err == nil {
+			err = s.ensureScheduledBuildsExist(logger, jobConfig)
+		}
+
 		jobSchedulingTime[jobConfig.Name] = time.Since(jStart)
 
 		if err != nil {
@@ -112,6 +124,79 @@ func (s *Scheduler) ensurePendingBuildExists(
 	return nil
 }
 
+// ensureScheduledBuildsExist creates pending builds for the ticks of the
+// job's schedule that have passed since it was last checked. The first time
+// a schedule is checked, no builds are created; ticks are only counted from
+// then on. Schedules that catch up create at most MaxCatchUpBuilds builds for
+// the ticks they missed.
+func (s *Scheduler) ensureScheduledBuildsExist(
+	logger lager.Logger,
+	jobConfig atc.JobConfig,
+) error {
+	if len(jobConfig.Schedule) == 0 {
+		return nil
+	}
+
+	logger = logger.Session("schedule", lager.Data{"job": jobConfig.Name})
+
+	now := s.Clock.Now()
+
+	lastChecked, err := s.DB.UpdateJobScheduleCheckedTime(jobConfig.Name, now)
+	if err != nil {
+		logger.Error("failed-to-update-schedule-checked-time", err)
+		return err
+	}
+
+	if lastChecked.IsZero() {
+		return nil
+	}
+
+	ticked := false
+
+	for _, scheduleConfig := range jobConfig.Schedule {
+		schedule, err := cron.Parse(scheduleConfig.Cron, scheduleConfig.Location)
+		if err != nil {
+			// the config is validated when it is saved, so this should only
+			// happen if e.g. the location's timezone data went missing
+			logger.Error("failed-to-parse-schedule", err, lager.Data{"cron": scheduleConfig.Cron})
+			continue
+		}
+
+		builds := 0
+		for tick := schedule.Next(lastChecked); !tick.IsZero() && !tick.After(now); tick = schedule.Next(tick) {
+			if !scheduleConfig.CatchUp {
+				ticked = true
+				break
+			}
+
+			if builds == MaxCatchUpBuilds {
+				logger.Info("skipping-missed-ticks", lager.Data{"cron": scheduleConfig.Cron, "from": tick})
+				break
+			}
+
+			builds++
+
+			logger.Info("creating-build-for-tick", lager.Data{"tick": tick})
+
+			_, err := s.DB.CreateJobBuild(jobConfig.Name)
+			if err != nil {
+				logger.Error("failed-to-create-job-build", err)
+				return err
+			}
+		}
+	}
+
+	if ticked {
+		err := s.DB.EnsurePendingBuildExists(jobConfig.Name)
+		if err != nil {
+			logger.Error("failed-to-ensure-pending-build-exists", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 type Waiter interface {
 	Wait()
 }
@@ -2,7 +2,9 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
@@ -23,6 +25,7 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *buildstarterfakes.FakeBuildStarter
 		fakeScanner      *schedulerfakes.FakeScanner
+		fakeClock        *fakeclock.FakeClock
 
 		scheduler *Scheduler
 
@@ -34,12 +37,14 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(buildstarterfakes.FakeBuildStarter)
 		fakeScanner = new(schedulerfakes.FakeScanner)
+		fakeClock = fakeclock.NewFakeClock(time.Date(2017, time.March, 27, 2, 0, 30, 0, time.UTC))
 
 		scheduler = &Scheduler{
 			DB:           fakeDB,
 			InputMapper:  fakeInputMapper,
 			BuildStarter: fakeBuildStarter,
 			Scanner:      fakeScanner,
+			Clock:        fakeClock,
 		}
 
 		disaster = errors.New("bad thing")
@@ -234,6 +239,131 @@ var _ = Describe("Scheduler", func() {
 				})
 			})
 		})
+
+		Context("when the job has a schedule", func() {
+			BeforeEach(func() {
+				jobConfigs = atc.JobConfigs{
+					{
+						Name: "some-job",
+						Schedule: []atc.ScheduleConfig{
+							{Cron: "0 2 * * 1-5"},
+						},
+					},
+				}
+
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+				fakeBuildStarter.TryStartPendingBuildsForJobReturns(nil)
+			})
+
+			It("records that the schedule was checked as of now", func() {
+				Expect(fakeDB.UpdateJobScheduleCheckedTimeCallCount()).To(Equal(1))
+				jobName, checkedTime := fakeDB.UpdateJobScheduleCheckedTimeArgsForCall(0)
+				Expect(jobName).To(Equal("some-job"))
+				Expect(checkedTime).To(Equal(fakeClock.Now()))
+			})
+
+			Context("when the schedule has never been checked", func() {
+				BeforeEach(func() {
+					fakeDB.UpdateJobScheduleCheckedTimeReturns(time.Time{}, nil)
+				})
+
+				It("does not create a build", func() {
+					Expect(fakeDB.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					Expect(fakeDB.CreateJobBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the schedule has not ticked since it was last checked", func() {
+				BeforeEach(func() {
+					fakeDB.UpdateJobScheduleCheckedTimeReturns(fakeClock.Now().Add(-10*time.Second), nil)
+				})
+
+				It("does not create a build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeDB.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					Expect(fakeDB.CreateJobBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the schedule has ticked since it was last checked", func() {
+				BeforeEach(func() {
+					fakeDB.UpdateJobScheduleCheckedTimeReturns(fakeClock.Now().Add(-time.Minute), nil)
+				})
+
+				It("ensures a pending build exists", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeDB.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+					Expect(fakeDB.EnsurePendingBuildExistsArgsForCall(0)).To(Equal("some-job"))
+				})
+
+				Context("when creating the pending build fails", func() {
+					BeforeEach(func() {
+						fakeDB.EnsurePendingBuildExistsReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+			})
+
+			Context("when the schedule has ticked several times since it was last checked", func() {
+				BeforeEach(func() {
+					// Wednesday, Thursday, Friday and Monday
+					fakeDB.UpdateJobScheduleCheckedTimeReturns(time.Date(2017, time.March, 22, 0, 0, 0, 0, time.UTC), nil)
+				})
+
+				It("ensures a single pending build exists", func() {
+					Expect(fakeDB.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+					Expect(fakeDB.CreateJobBuildCallCount()).To(BeZero())
+				})
+
+				Context("when the schedule catches up on missed ticks", func() {
+					BeforeEach(func() {
+						jobConfigs[0].Schedule[0].CatchUp = true
+					})
+
+					It("creates a build for each tick", func() {
+						Expect(scheduleErr).NotTo(HaveOccurred())
+						Expect(fakeDB.EnsurePendingBuildExistsCallCount()).To(BeZero())
+						Expect(fakeDB.CreateJobBuildCallCount()).To(Equal(4))
+						Expect(fakeDB.CreateJobBuildArgsForCall(0)).To(Equal("some-job"))
+					})
+
+					Context("when more ticks were missed than are caught up on", func() {
+						BeforeEach(func() {
+							fakeDB.UpdateJobScheduleCheckedTimeReturns(time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), nil)
+						})
+
+						It("creates at most MaxCatchUpBuilds builds", func() {
+							Expect(scheduleErr).NotTo(HaveOccurred())
+							Expect(fakeDB.CreateJobBuildCallCount()).To(Equal(MaxCatchUpBuilds))
+						})
+					})
+
+					Context("when creating a build fails", func() {
+						BeforeEach(func() {
+							fakeDB.CreateJobBuildReturns(nil, disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(scheduleErr).To(Equal(disaster))
+							Expect(fakeDB.CreateJobBuildCallCount()).To(Equal(1))
+						})
+					})
+				})
+			})
+
+			Context("when recording the checked time fails", func() {
+				BeforeEach(func() {
+					fakeDB.UpdateJobScheduleCheckedTimeReturns(time.Time{}, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(scheduleErr).To(Equal(disaster))
+				})
+			})
+		})
 	})
 
 	Describe("TriggerImmediately", func() {
@@ -67,6 +67,16 @@ type FakeSchedulerDB struct {
 	ensurePendingBuildExistsReturns struct {
 		result1 error
 	}
+	UpdateJobScheduleCheckedTimeStub        func(jobName string, checkedTime time.Time) (time.Time, error)
+	updateJobScheduleCheckedTimeMutex       sync.RWMutex
+	updateJobScheduleCheckedTimeArgsForCall []struct {
+		jobName     string
+		checkedTime time.Time
+	}
+	updateJobScheduleCheckedTimeReturns struct {
+		result1 time.Time
+		result2 error
+	}
 	AcquireResourceCheckingForJobLockStub        func(logger lager.Logger, job string) (db.Lock, bool, error)
 	acquireResourceCheckingForJobLockMutex       sync.RWMutex
 	acquireResourceCheckingForJobLockArgsForCall []struct {
@@ -303,6 +313,41 @@ func (fake *FakeSchedulerDB) EnsurePendingBuildExistsReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSchedulerDB) UpdateJobScheduleCheckedTime(jobName string, checkedTime time.Time) (time.Time, error) {
+	fake.updateJobScheduleCheckedTimeMutex.Lock()
+	fake.updateJobScheduleCheckedTimeArgsForCall = append(fake.updateJobScheduleCheckedTimeArgsForCall, struct {
+		jobName     string
+		checkedTime time.Time
+	}{jobName, checkedTime})
+	fake.recordInvocation("UpdateJobScheduleCheckedTime", []interface{}{jobName, checkedTime})
+	fake.updateJobScheduleCheckedTimeMutex.Unlock()
+	if fake.UpdateJobScheduleCheckedTimeStub != nil {
+		return fake.UpdateJobScheduleCheckedTimeStub(jobName, checkedTime)
+	} else {
+		return fake.updateJobScheduleCheckedTimeReturns.result1, fake.updateJobScheduleCheckedTimeReturns.result2
+	}
+}
+
+func (fake *FakeSchedulerDB) UpdateJobScheduleCheckedTimeCallCount() int {
+	fake.updateJobScheduleCheckedTimeMutex.RLock()
+	defer fake.updateJobScheduleCheckedTimeMutex.RUnlock()
+	return len(fake.updateJobScheduleCheckedTimeArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) UpdateJobScheduleCheckedTimeArgsForCall(i int) (string, time.Time) {
+	fake.updateJobScheduleCheckedTimeMutex.RLock()
+	defer fake.updateJobScheduleCheckedTimeMutex.RUnlock()
+	return fake.updateJobScheduleCheckedTimeArgsForCall[i].jobName, fake.updateJobScheduleCheckedTimeArgsForCall[i].checkedTime
+}
+
+func (fake *FakeSchedulerDB) UpdateJobScheduleCheckedTimeReturns(result1 time.Time, result2 error) {
+	fake.UpdateJobScheduleCheckedTimeStub = nil
+	fake.updateJobScheduleCheckedTimeReturns = struct {
+		result1 time.Time
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSchedulerDB) AcquireResourceCheckingForJobLock(logger lager.Logger, job string) (db.Lock, bool, error) {
 	fake.acquireResourceCheckingForJobLockMutex.Lock()
 	fake.acquireResourceCheckingForJobLockArgsForCall = append(fake.acquireResourceCheckingForJobLockArgsForCall, struct {
@@ -416,6 +461,8 @@ func (fake *FakeSchedulerDB) Invocations() map[string][][]interface{} {
 	defer fake.createJobBuildMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
+	fake.updateJobScheduleCheckedTimeMutex.RLock()
+	defer fake.updateJobScheduleCheckedTimeMutex.RUnlock()
 	fake.acquireResourceCheckingForJobLockMutex.RLock()
 	defer fake.acquireResourceCheckingForJobLockMutex.RUnlock()
 	fake.getAllPendingBuildsMutex.RLock()
@@ -31,11 +31,41 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// Paths which are cached between builds of the same job on a worker.
+	Caches []CacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Limits on the CPU and memory of the task's container.
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+}
+
+// ContainerLimits caps the resources a container may use. A zero value for
+// either means no limit.
+type ContainerLimits struct {
+	// CPU shares, relative to the other containers on the worker.
+	CPU uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Memory in bytes. The container is killed if it exceeds this.
+	Memory uint64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
+}
+
+// Merge overrides each limit which is set in other.
+func (limits ContainerLimits) Merge(other ContainerLimits) ContainerLimits {
+	if other.CPU != 0 {
+		limits.CPU = other.CPU
+	}
+
+	if other.Memory != 0 {
+		limits.Memory = other.Memory
+	}
+
+	return limits
 }
 
 type ImageResource struct {
 	Type   string `yaml:"type" json:"type" mapstructure:"type"`
 	Source Source `yaml:"source" json:"source" mapstructure:"source"`
+	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 }
 
 func LoadTaskConfig(configBytes []byte) (TaskConfig, error) {
@@ -106,10 +136,23 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Inputs = other.Inputs
 	}
 
+	if len(other.Caches) != 0 {
+		config.Caches = other.Caches
+	}
+
 	if other.Run.Path != "" {
 		config.Run = other.Run
 	}
 
+	if other.ContainerLimits != nil {
+		limits := *other.ContainerLimits
+		if config.ContainerLimits != nil {
+			limits = config.ContainerLimits.Merge(limits)
+		}
+
+		config.ContainerLimits = &limits
+	}
+
 	return config
 }
 
@@ -125,6 +168,7 @@ func (config TaskConfig) Validate() error {
 	}
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
+	messages = append(messages, config.validateCaches()...)
 
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
@@ -327,6 +371,35 @@ func (config TaskConfig) validateInputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateCaches() []string {
+	messages := []string{}
+
+	paths := map[string]bool{}
+
+	for i, cache := range config.Caches {
+		if cache.Path == "" {
+			messages = append(messages, fmt.Sprintf("  cache in position %d is missing a path", i))
+			continue
+		}
+
+		// clean the path first, so that e.g. 'foo/../..' is seen to escape
+		path := filepath.Clean(cache.Path)
+
+		if path == "." || path == ".." || filepath.IsAbs(path) || strings.HasPrefix(path, "../") {
+			messages = append(messages, fmt.Sprintf("  cache path '%s' must be a relative path within the working directory", cache.Path))
+			continue
+		}
+
+		if paths[path] {
+			messages = append(messages, fmt.Sprintf(duplicateErrorMessage, "cache", path))
+		}
+
+		paths[path] = true
+	}
+
+	return messages
+}
+
 type TaskRunConfig struct {
 	Path string   `json:"path" yaml:"path"`
 	Args []string `json:"args,omitempty" yaml:"args"`
@@ -360,6 +433,10 @@ func (output TaskOutputConfig) resolvePath() string {
 	return output.Name
 }
 
+type CacheConfig struct {
+	Path string `json:"path" yaml:"path"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
@@ -99,6 +99,24 @@ run: {path: a/file}
 					Expect(err).ToNot(HaveOccurred())
 					Expect(config.Params["testParam"]).To(Equal(`{"foo":"bar"}`))
 				})
+
+				It("decodes container limits", func() {
+					data := []byte(`
+platform: beos
+
+container_limits:
+  cpu: 512
+  memory: 1073741824
+
+run: {path: a/file}
+`)
+					config, err := LoadTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(config.ContainerLimits).To(Equal(&ContainerLimits{
+						CPU:    512,
+						Memory: 1073741824,
+					}))
+				})
 			})
 
 			Context("given a valid task config with numeric params", func() {
@@ -234,6 +252,78 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has caches", func() {
+			BeforeEach(func() {
+				validConfig.Caches = append(validConfig.Caches, CacheConfig{Path: "some/cache"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when cache.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some/cache"}, CacheConfig{Path: ""})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 1 is missing a path")))
+				})
+			})
+
+			Context("when cache.path is outside of the working directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "../some/cache"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache path '../some/cache' must be a relative path within the working directory")))
+				})
+			})
+
+			Context("when cache.path is the parent of the working directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: ".."})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache path '..' must be a relative path within the working directory")))
+				})
+			})
+
+			Context("when cache.path only escapes the working directory once cleaned", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some/../../cache"}, CacheConfig{Path: "some/.."})
+				})
+
+				It("returns an error for each", func() {
+					err := invalidConfig.Validate()
+					Expect(err).To(MatchError(ContainSubstring("  cache path 'some/../../cache' must be a relative path within the working directory")))
+					Expect(err).To(MatchError(ContainSubstring("  cache path 'some/..' must be a relative path within the working directory")))
+				})
+			})
+
+			Context("when two caches have the same path once cleaned", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some/cache"}, CacheConfig{Path: "some/other/../cache/"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cannot have more than one cache using the same path 'some/cache'")))
+				})
+			})
+
+			Context("when two caches have the same path", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some/cache"}, CacheConfig{Path: "./some/cache"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cannot have more than one cache using the same path 'some/cache'")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
@@ -723,5 +813,26 @@ run: {path: a/file}
 				}))
 
 		})
+
+		It("merges container limits", func() {
+			Expect(TaskConfig{
+				ContainerLimits: &ContainerLimits{CPU: 512, Memory: 1024},
+			}.Merge(TaskConfig{
+				ContainerLimits: &ContainerLimits{Memory: 2048},
+			})).To(Equal(TaskConfig{
+				ContainerLimits: &ContainerLimits{CPU: 512, Memory: 2048},
+			}))
+		})
+
+		It("preserves container limits if none are given", func() {
+			Expect(TaskConfig{
+				ContainerLimits: &ContainerLimits{CPU: 512},
+			}.Merge(TaskConfig{
+				Image: "some-image",
+			})).To(Equal(TaskConfig{
+				Image:           "some-image",
+				ContainerLimits: &ContainerLimits{CPU: 512},
+			}))
+		})
 	})
 })
@@ -17,6 +17,9 @@ type Team struct {
 type BasicAuth struct {
 	BasicAuthUsername string `json:"basic_auth_username,omitempty"`
 	BasicAuthPassword string `json:"basic_auth_password,omitempty"`
+
+	// Role is the role granted to the basic auth user. It defaults to owner.
+	Role Role `json:"role,omitempty"`
 }
 
 type GitHubAuth struct {
@@ -28,6 +31,18 @@ type GitHubAuth struct {
 	AuthURL       string       `json:"auth_url,omitempty"`
 	TokenURL      string       `json:"token_url,omitempty"`
 	APIURL        string       `json:"api_url,omitempty"`
+
+	// Roles grants the matching users a role other than owner.
+	Roles []GitHubRole `json:"roles,omitempty"`
+}
+
+// GitHubRole grants a role to GitHub users who are not already owners by
+// way of the team's organizations, teams or users.
+type GitHubRole struct {
+	Role          Role         `json:"role"`
+	Organizations []string     `json:"organizations,omitempty"`
+	Teams         []GitHubTeam `json:"teams,omitempty"`
+	Users         []string     `json:"users,omitempty"`
 }
 
 type GitHubTeam struct {
@@ -43,6 +58,17 @@ type UAAAuth struct {
 	CFSpaces     []string `json:"cf_spaces,omitempty"`
 	CFURL        string   `json:"cf_url,omitempty"`
 	CFCACert     string   `json:"cf_ca_cert,omitempty"`
+
+	// Roles grants the developers of the matching spaces a role other than
+	// owner.
+	Roles []UAARole `json:"roles,omitempty"`
+}
+
+// UAARole grants a role to the developers of CF spaces which are not already
+// owners by way of the team's spaces.
+type UAARole struct {
+	Role     Role     `json:"role"`
+	CFSpaces []string `json:"cf_spaces,omitempty"`
 }
 
 type GenericOAuth struct {
@@ -53,4 +79,14 @@ type GenericOAuth struct {
 	TokenURL      string            `json:"token_url,omitempty"`
 	AuthURLParams map[string]string `json:"auth_url_params,omitempty"`
 	Scope         string            `json:"scope,omitempty"`
+
+	// Roles grants users with the matching scopes a role other than owner.
+	Roles []GenericOAuthRole `json:"roles,omitempty"`
+}
+
+// GenericOAuthRole grants a role to users with a scope, when they are not
+// already owners by way of the team's scope.
+type GenericOAuthRole struct {
+	Role  Role   `json:"role"`
+	Scope string `json:"scope"`
 }
@@ -33,6 +33,7 @@ type Client interface {
 	ListVolumes(lager.Logger, VolumeProperties) ([]Volume, error)
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
 
+	Choose(lager.Logger, WorkerSpec, atc.ResourceTypes) (Worker, string, error)
 	Satisfying(WorkerSpec, atc.ResourceTypes) (Worker, error)
 	AllSatisfying(WorkerSpec, atc.ResourceTypes) ([]Worker, error)
 	Workers() ([]Worker, error)
@@ -128,6 +129,42 @@ func (strategy ContainerRootFSStrategy) dbIdentifier() db.VolumeIdentifier {
 	}
 }
 
+// TaskCacheStrategy is used to look up the current cache for a task's cache
+// path on a worker.
+type TaskCacheStrategy struct {
+	TaskCache db.TaskCacheIdentifier
+}
+
+func (TaskCacheStrategy) baggageclaimStrategy() baggageclaim.Strategy {
+	return baggageclaim.EmptyStrategy{}
+}
+
+func (strategy TaskCacheStrategy) dbIdentifier() db.VolumeIdentifier {
+	return db.VolumeIdentifier{
+		TaskCache: &db.TaskCacheIdentifier{
+			PipelineID: strategy.TaskCache.PipelineID,
+			JobName:    strategy.TaskCache.JobName,
+			StepName:   strategy.TaskCache.StepName,
+			Path:       strategy.TaskCache.Path,
+			WorkerName: strategy.TaskCache.WorkerName,
+		},
+	}
+}
+
+// TaskCacheWorkspaceStrategy creates the empty volume a task's cache path is
+// mounted from while the task runs, into which the previous cache is copied.
+// It only becomes the cache once marked with Volume.MarkAsTaskCache, so it
+// cannot be used to look up volumes.
+type TaskCacheWorkspaceStrategy struct{}
+
+func (TaskCacheWorkspaceStrategy) baggageclaimStrategy() baggageclaim.Strategy {
+	return baggageclaim.EmptyStrategy{}
+}
+
+func (TaskCacheWorkspaceStrategy) dbIdentifier() db.VolumeIdentifier {
+	return db.VolumeIdentifier{}
+}
+
 type HostRootFSStrategy struct {
 	Path       string
 	WorkerName string
@@ -13,6 +13,17 @@ type WorkerSpec struct {
 	ResourceType string
 	Tags         []string
 	TeamID       int
+
+	// Volumes the container would like to use, if a worker already has them.
+	// Only considered when choosing between satisfying workers.
+	Volumes []VolumeRef
+}
+
+// VolumeRef refers to a volume a container would like to use: either a
+// particular volume, by its handle, or any volume created with the strategy.
+type VolumeRef struct {
+	Handle   string
+	Strategy Strategy
 }
 
 type ContainerSpec struct {
@@ -31,6 +42,9 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Limits on the container's CPU and memory. Zero values mean no limit.
+	Limits atc.ContainerLimits
 }
 
 type ImageSpec struct {
@@ -31,6 +31,7 @@ type WorkerDB interface {
 	ReapVolume(handle string) error
 	SetVolumeTTLAndSizeInBytes(string, time.Duration, int64) error
 	SetVolumeTTL(string, time.Duration) error
+	MarkVolumeAsTaskCache(string, db.TaskCacheIdentifier) error
 }
 
 type dbProvider struct {
@@ -0,0 +1,126 @@
+package worker
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	RandomPlacementStrategyName                 = "random"
+	FewestActiveContainersPlacementStrategyName = "fewest-active-containers"
+	VolumeLocalityPlacementStrategyName         = "volume-locality"
+)
+
+//go:generate counterfeiter . ContainerPlacementStrategy
+
+// ContainerPlacementStrategy chooses which of the workers satisfying a spec a
+// container is placed on. Along with the worker, it returns a human-readable
+// reason for the choice.
+type ContainerPlacementStrategy interface {
+	Choose(lager.Logger, []Worker, WorkerSpec) (Worker, string, error)
+}
+
+// NewContainerPlacementStrategy returns the strategy with the given name.
+func NewContainerPlacementStrategy(name string) (ContainerPlacementStrategy, error) {
+	switch name {
+	case RandomPlacementStrategyName:
+		return NewRandomPlacementStrategy(), nil
+	case FewestActiveContainersPlacementStrategyName:
+		return NewFewestActiveContainersPlacementStrategy(), nil
+	case VolumeLocalityPlacementStrategyName:
+		return NewVolumeLocalityPlacementStrategy(), nil
+	default:
+		return nil, fmt.Errorf("unknown container placement strategy: %s", name)
+	}
+}
+
+type randomPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+// NewRandomPlacementStrategy returns a strategy that chooses any of the
+// workers at random.
+func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+	return &randomPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *randomPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec WorkerSpec) (Worker, string, error) {
+	if len(workers) == 0 {
+		return nil, "", ErrNoWorkers
+	}
+
+	chosenWorker := workers[strategy.rand.Intn(len(workers))]
+
+	return chosenWorker, fmt.Sprintf("chosen at random from %d workers", len(workers)), nil
+}
+
+type fewestActiveContainersPlacementStrategy struct{}
+
+// NewFewestActiveContainersPlacementStrategy returns a strategy that chooses
+// the worker running the fewest containers. Ties go to the earliest worker in
+// the list.
+func NewFewestActiveContainersPlacementStrategy() ContainerPlacementStrategy {
+	return fewestActiveContainersPlacementStrategy{}
+}
+
+func (fewestActiveContainersPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec WorkerSpec) (Worker, string, error) {
+	if len(workers) == 0 {
+		return nil, "", ErrNoWorkers
+	}
+
+	chosenWorker := workers[0]
+	for _, w := range workers[1:] {
+		if w.ActiveContainers() < chosenWorker.ActiveContainers() {
+			chosenWorker = w
+		}
+	}
+
+	return chosenWorker, fmt.Sprintf("fewest active containers (%d)", chosenWorker.ActiveContainers()), nil
+}
+
+type volumeLocalityPlacementStrategy struct {
+	fallback ContainerPlacementStrategy
+}
+
+// NewVolumeLocalityPlacementStrategy returns a strategy that chooses the
+// worker already holding the most of the spec's volumes, so that they do not
+// have to be streamed. If no worker holds any of them, the worker running
+// the fewest containers is chosen.
+func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
+	return volumeLocalityPlacementStrategy{
+		fallback: NewFewestActiveContainersPlacementStrategy(),
+	}
+}
+
+func (strategy volumeLocalityPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec WorkerSpec) (Worker, string, error) {
+	if len(workers) == 0 {
+		return nil, "", ErrNoWorkers
+	}
+
+	var chosenWorker Worker
+	mostVolumes := 0
+
+	for _, w := range workers {
+		found, err := w.CountVolumes(logger, spec.Volumes)
+		if err != nil {
+			logger.Error("failed-to-count-volumes", err, lager.Data{"worker-name": w.Name()})
+			continue
+		}
+
+		if found > mostVolumes {
+			chosenWorker = w
+			mostVolumes = found
+		}
+	}
+
+	if chosenWorker == nil {
+		return strategy.fallback.Choose(logger, workers, spec)
+	}
+
+	return chosenWorker, fmt.Sprintf("holds %d of %d volumes", mostVolumes, len(spec.Volumes)), nil
+}
@@ -0,0 +1,167 @@
+package worker_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerPlacementStrategy", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		strategy ContainerPlacementStrategy
+		spec     WorkerSpec
+
+		workerA *workerfakes.FakeWorker
+		workerB *workerfakes.FakeWorker
+		workerC *workerfakes.FakeWorker
+		workers []Worker
+
+		chosenWorker Worker
+		reason       string
+		chooseErr    error
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		spec = WorkerSpec{}
+
+		workerA = new(workerfakes.FakeWorker)
+		workerB = new(workerfakes.FakeWorker)
+		workerC = new(workerfakes.FakeWorker)
+		workers = []Worker{workerA, workerB, workerC}
+	})
+
+	JustBeforeEach(func() {
+		chosenWorker, reason, chooseErr = strategy.Choose(logger, workers, spec)
+	})
+
+	Describe("NewContainerPlacementStrategy", func() {
+		It("returns the strategy for each known name", func() {
+			for _, name := range []string{
+				RandomPlacementStrategyName,
+				FewestActiveContainersPlacementStrategyName,
+				VolumeLocalityPlacementStrategyName,
+			} {
+				strategy, err := NewContainerPlacementStrategy(name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strategy).NotTo(BeNil())
+			}
+		})
+
+		It("errors for an unknown name", func() {
+			_, err := NewContainerPlacementStrategy("bogus")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("random", func() {
+		BeforeEach(func() {
+			strategy = NewRandomPlacementStrategy()
+		})
+
+		It("chooses one of the workers", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(workers).To(ContainElement(chosenWorker))
+			Expect(reason).To(Equal("chosen at random from 3 workers"))
+		})
+
+		Context("with no workers", func() {
+			BeforeEach(func() {
+				workers = []Worker{}
+			})
+
+			It("returns ErrNoWorkers", func() {
+				Expect(chooseErr).To(Equal(ErrNoWorkers))
+			})
+		})
+	})
+
+	Describe("fewest active containers", func() {
+		BeforeEach(func() {
+			strategy = NewFewestActiveContainersPlacementStrategy()
+
+			workerA.ActiveContainersReturns(250)
+			workerB.ActiveContainersReturns(3)
+			workerC.ActiveContainersReturns(3)
+		})
+
+		It("chooses the first worker with the fewest active containers", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerB))
+			Expect(reason).To(Equal("fewest active containers (3)"))
+		})
+
+		Context("with no workers", func() {
+			BeforeEach(func() {
+				workers = []Worker{}
+			})
+
+			It("returns ErrNoWorkers", func() {
+				Expect(chooseErr).To(Equal(ErrNoWorkers))
+			})
+		})
+	})
+
+	Describe("volume locality", func() {
+		BeforeEach(func() {
+			strategy = NewVolumeLocalityPlacementStrategy()
+
+			spec.Volumes = []VolumeRef{
+				{Handle: "some-handle"},
+				{Strategy: OutputStrategy{Name: "some-other-output"}},
+			}
+
+			workerA.ActiveContainersReturns(1)
+			workerB.ActiveContainersReturns(5)
+			workerC.ActiveContainersReturns(10)
+		})
+
+		Context("when a worker holds the most volumes", func() {
+			BeforeEach(func() {
+				workerB.CountVolumesReturns(2, nil)
+				workerC.CountVolumesReturns(1, nil)
+			})
+
+			It("chooses it", func() {
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(chosenWorker).To(Equal(workerB))
+				Expect(reason).To(Equal("holds 2 of 2 volumes"))
+			})
+
+			It("counts the volumes on each worker", func() {
+				for _, w := range []*workerfakes.FakeWorker{workerA, workerB, workerC} {
+					Expect(w.CountVolumesCallCount()).To(Equal(1))
+					_, refs := w.CountVolumesArgsForCall(0)
+					Expect(refs).To(Equal(spec.Volumes))
+				}
+			})
+		})
+
+		Context("when counting the volumes on a worker fails", func() {
+			BeforeEach(func() {
+				workerA.CountVolumesReturns(0, errors.New("nope"))
+				workerC.CountVolumesReturns(1, nil)
+			})
+
+			It("treats the volumes as missing from the worker", func() {
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(chosenWorker).To(Equal(workerC))
+			})
+		})
+
+		Context("when no worker holds any of the volumes", func() {
+			It("chooses the worker with the fewest active containers", func() {
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(chosenWorker).To(Equal(workerA))
+				Expect(reason).To(Equal("fewest active containers (1)"))
+			})
+		})
+	})
+})
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -46,15 +45,16 @@ func (err NoCompatibleWorkersError) Error() string {
 }
 
 type pool struct {
+	logger   lager.Logger
 	provider WorkerProvider
-
-	rand *rand.Rand
+	strategy ContainerPlacementStrategy
 }
 
-func NewPool(provider WorkerProvider) Client {
+func NewPool(logger lager.Logger, provider WorkerProvider, strategy ContainerPlacementStrategy) Client {
 	return &pool{
+		logger:   logger,
 		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		strategy: strategy,
 	}
 }
 
@@ -122,20 +122,39 @@ func (pool *pool) AllSatisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes
 }
 
 func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
+	worker, _, err := pool.Choose(pool.logger, spec, resourceTypes)
+	return worker, err
+}
+
+// Choose chooses one of the workers satisfying the spec with the pool's
+// placement strategy, returning it along with the reason it was chosen.
+func (pool *pool) Choose(logger lager.Logger, spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, string, error) {
 	compatibleWorkers, err := pool.AllSatisfying(spec, resourceTypes)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	chosenWorker, reason, err := pool.strategy.Choose(logger, compatibleWorkers, spec)
+	if err != nil {
+		return nil, "", err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
-	return randomWorker, nil
+
+	logger.Info("chose-worker", lager.Data{
+		"worker-name": chosenWorker.Name(),
+		"reason":      reason,
+	})
+
+	return chosenWorker, reason, nil
 }
 
 func (pool *pool) CreateContainer(logger lager.Logger, signals <-chan os.Signal, delegate ImageFetchingDelegate, id Identifier, metadata Metadata, spec ContainerSpec, resourceTypes atc.ResourceTypes) (Container, error) {
-	worker, err := pool.Satisfying(spec.WorkerSpec(), resourceTypes)
+	worker, reason, err := pool.Choose(logger, spec.WorkerSpec(), resourceTypes)
 	if err != nil {
 		return nil, err
 	}
 
+	fmt.Fprintf(delegate.Stderr(), "placing container on worker %s: %s\n", worker.Name(), reason)
+
 	container, err := worker.CreateContainer(logger, signals, delegate, id, metadata, spec, resourceTypes)
 	if err != nil {
 		return nil, err
@@ -11,6 +11,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("Pool", func() {
@@ -25,7 +26,7 @@ var _ = Describe("Pool", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		pool = NewPool(logger, fakeProvider, NewRandomPlacementStrategy())
 	})
 
 	Describe("GetWorker", func() {
@@ -151,6 +152,38 @@ var _ = Describe("Pool", func() {
 				Expect(chosenCount[workerC]).To(BeZero())
 			})
 
+			Context("with a placement strategy", func() {
+				var fakeStrategy *workerfakes.FakeContainerPlacementStrategy
+
+				BeforeEach(func() {
+					fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+					fakeStrategy.ChooseReturns(workerB, "some reason", nil)
+
+					pool = NewPool(logger, fakeProvider, fakeStrategy)
+				})
+
+				It("chooses between the satisfying workers with the strategy", func() {
+					Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+					_, workers, actualSpec := fakeStrategy.ChooseArgsForCall(0)
+					Expect(workers).To(ConsistOf(workerA, workerB))
+					Expect(actualSpec).To(Equal(spec))
+
+					Expect(satisfyingWorker).To(Equal(workerB))
+				})
+
+				Context("when the strategy fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeStrategy.ChooseReturns(nil, "", disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(satisfyingErr).To(Equal(disaster))
+					})
+				})
+			})
+
 			Context("when no workers satisfy the spec", func() {
 				BeforeEach(func() {
 					workerA.SatisfyingReturns(nil, errors.New("nope"))
@@ -363,6 +396,7 @@ var _ = Describe("Pool", func() {
 	Describe("CreateContainer", func() {
 		var (
 			fakeImageFetchingDelegate *workerfakes.FakeImageFetchingDelegate
+			stderr                    *gbytes.Buffer
 
 			id   Identifier
 			spec ContainerSpec
@@ -374,6 +408,8 @@ var _ = Describe("Pool", func() {
 
 		BeforeEach(func() {
 			fakeImageFetchingDelegate = new(workerfakes.FakeImageFetchingDelegate)
+			stderr = gbytes.NewBuffer()
+			fakeImageFetchingDelegate.StderrReturns(stderr)
 			id = Identifier{
 				ResourceID: 1234,
 			}
@@ -476,6 +512,14 @@ var _ = Describe("Pool", func() {
 				Expect(workerC.CreateContainerCallCount()).To(BeZero())
 			})
 
+			It("writes the worker that was chosen and why to the delegate", func() {
+				Expect(stderr).To(gbytes.Say("placing container on worker .*: chosen at random from 2 workers"))
+			})
+
+			It("logs the worker that was chosen and why", func() {
+				Expect(logger).To(gbytes.Say("chose-worker.*chosen at random from 2 workers"))
+			})
+
 			Context("when creating the container fails", func() {
 				disaster := errors.New("nope")
 
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/baggageclaim"
 )
@@ -19,6 +20,7 @@ type VolumeFactoryDB interface {
 	ReapVolume(handle string) error
 	SetVolumeTTLAndSizeInBytes(handle string, ttl time.Duration, sizeInBytes int64) error
 	SetVolumeTTL(handle string, ttl time.Duration) error
+	MarkVolumeAsTaskCache(handle string, id db.TaskCacheIdentifier) error
 }
 
 //go:generate counterfeiter . VolumeFactory
@@ -83,6 +85,10 @@ type Volume interface {
 
 	// a noop method to ensure things aren't just returning baggageclaim.Volume
 	HeartbeatingToDB()
+
+	// MarkAsTaskCache makes the volume the cache for the given task cache
+	// identifier, replacing any previous cache
+	MarkAsTaskCache(db.TaskCacheIdentifier) error
 }
 
 type volume struct {
@@ -102,6 +108,10 @@ type VolumeMount struct {
 
 func (*volume) HeartbeatingToDB() {}
 
+func (v *volume) MarkAsTaskCache(id db.TaskCacheIdentifier) error {
+	return v.db.MarkVolumeAsTaskCache(v.Handle(), id)
+}
+
 func (v *volume) Release(finalTTL *time.Duration) {
 	v.releaseOnce.Do(func() {
 		v.release <- finalTTL
@@ -51,6 +51,10 @@ type Worker interface {
 
 	ActiveContainers() int
 
+	// CountVolumes returns how many of the referenced volumes the worker
+	// holds. The volumes are only looked up, leaving their TTLs alone.
+	CountVolumes(lager.Logger, []VolumeRef) (int, error)
+
 	Description() string
 	Name() string
 	Uptime() time.Duration
@@ -154,6 +158,50 @@ func (worker *gardenWorker) FindVolume(logger lager.Logger, volumeSpec VolumeSpe
 	return worker.volumeClient.FindVolume(logger, volumeSpec)
 }
 
+func (worker *gardenWorker) CountVolumes(logger lager.Logger, refs []VolumeRef) (int, error) {
+	if worker.baggageclaimClient == nil {
+		return 0, nil
+	}
+
+	count := 0
+	for _, ref := range refs {
+		found, err := worker.holdsVolume(logger, ref)
+		if err != nil {
+			return 0, err
+		}
+
+		if found {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (worker *gardenWorker) holdsVolume(logger lager.Logger, ref VolumeRef) (bool, error) {
+	if ref.Handle != "" {
+		_, found, err := worker.baggageclaimClient.LookupVolume(logger, ref.Handle)
+		return found, err
+	}
+
+	if ref.Strategy == nil {
+		return false, nil
+	}
+
+	savedVolumes, err := worker.db.GetVolumesByIdentifier(ref.Strategy.dbIdentifier())
+	if err != nil {
+		return false, err
+	}
+
+	for _, savedVolume := range savedVolumes {
+		if savedVolume.WorkerName == worker.name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (worker *gardenWorker) CreateVolume(logger lager.Logger, volumeSpec VolumeSpec, teamID int) (Volume, error) {
 	return worker.volumeClient.CreateVolume(logger, volumeSpec, teamID)
 }
@@ -185,6 +233,7 @@ func (worker *gardenWorker) getImage(
 			imageResource = &atc.ImageResource{
 				Source: resourceType.Source,
 				Type:   resourceType.Type,
+				Params: resourceType.Params,
 			}
 		}
 	}
@@ -356,6 +405,10 @@ func (worker *gardenWorker) CreateContainer(
 	spec ContainerSpec,
 	resourceTypes atc.ResourceTypes,
 ) (Container, error) {
+	if resourceType, found := resourceTypes.Lookup(spec.ImageSpec.ResourceType); found && resourceType.Privileged != nil {
+		spec.ImageSpec.Privileged = *resourceType.Privileged
+	}
+
 	imageVolume, imageMetadata, resourceTypeVersion, imageURL, err := worker.getImage(
 		logger,
 		spec.ImageSpec,
@@ -461,6 +514,10 @@ func (worker *gardenWorker) CreateContainer(
 		Properties: gardenProperties,
 		RootFSPath: imageURL,
 		Env:        env,
+		Limits: garden.Limits{
+			CPU:    garden.CPULimits{LimitInShares: spec.Limits.CPU},
+			Memory: garden.MemoryLimits{LimitInBytes: spec.Limits.Memory},
+		},
 	}
 
 	gardenContainer, err := worker.gardenClient.Create(gardenSpec)
@@ -624,7 +681,12 @@ func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.Resour
 		}
 	}
 
-	if !worker.tagsMatch(spec.Tags) {
+	tags := spec.Tags
+	if resourceType, found := resourceTypes.Lookup(spec.ResourceType); found && len(resourceType.Tags) > 0 {
+		tags = append(append([]string{}, spec.Tags...), resourceType.Tags...)
+	}
+
+	if !worker.tagsMatch(tags) {
 		return nil, ErrMismatchedTags
 	}
 
@@ -646,6 +708,10 @@ func determineUnderlyingTypeName(typeName string, resourceTypes atc.ResourceType
 	return underlyingTypeName
 }
 
+func (worker *gardenWorker) Choose(logger lager.Logger, spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, string, error) {
+	return nil, "", errors.New("Not implemented")
+}
+
 func (worker *gardenWorker) AllSatisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) ([]Worker, error) {
 	return nil, errors.New("Not implemented")
 }
@@ -219,6 +219,29 @@ var _ = Describe("Worker", func() {
 			Expect(volumeHandles).To(BeEmpty())
 		})
 
+		It("creates the container without limits", func() {
+			actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
+			Expect(actualGardenSpec.Limits).To(Equal(garden.Limits{}))
+		})
+
+		Context("when the spec specifies limits", func() {
+			BeforeEach(func() {
+				containerSpec.Limits = atc.ContainerLimits{
+					CPU:    512,
+					Memory: 1024,
+				}
+			})
+
+			It("creates the container with the limits", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualGardenSpec.Limits).To(Equal(garden.Limits{
+					CPU:    garden.CPULimits{LimitInShares: 512},
+					Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				}))
+			})
+		})
+
 		Context("when the spec does not specify ImageURL", func() {
 			BeforeEach(func() {
 				containerSpec.ImageSpec.ImageURL = ""
@@ -754,6 +777,21 @@ var _ = Describe("Worker", func() {
 				Expect(fetchPrivileged).To(Equal(true))
 			})
 
+			Context("when the resource type has params", func() {
+				BeforeEach(func() {
+					customTypes[1].Params = atc.Params{"some": "params"}
+				})
+
+				It("fetches the image with them", func() {
+					_, _, fetchImageConfig, _, _, _, _, _, _, _, _ := fakeImageFactory.NewImageArgsForCall(0)
+					Expect(fetchImageConfig).To(Equal(atc.ImageResource{
+						Type:   "some-resource",
+						Source: atc.Source{"some": "source"},
+						Params: atc.Params{"some": "params"},
+					}))
+				})
+			})
+
 			It("creates the container with the fetched image's URL as the rootfs", func() {
 				Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
 				actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
@@ -800,6 +838,44 @@ var _ = Describe("Worker", func() {
 				Expect(actualGardenSpec.Privileged).To(BeTrue())
 			})
 
+			Context("when the resource type is configured to not be privileged", func() {
+				BeforeEach(func() {
+					privileged := false
+					customTypes[1].Privileged = &privileged
+				})
+
+				It("fetches the image unprivileged", func() {
+					_, _, _, _, _, _, _, _, _, _, fetchPrivileged := fakeImageFactory.NewImageArgsForCall(0)
+					Expect(fetchPrivileged).To(BeFalse())
+				})
+
+				It("sets Privileged to false in the garden spec", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+					actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
+					Expect(actualGardenSpec.Privileged).To(BeFalse())
+				})
+			})
+
+			Context("when the resource type is configured to be privileged", func() {
+				BeforeEach(func() {
+					privileged := true
+					customTypes[1].Privileged = &privileged
+				})
+
+				It("fetches the image privileged", func() {
+					_, _, _, _, _, _, _, _, _, _, fetchPrivileged := fakeImageFactory.NewImageArgsForCall(0)
+					Expect(fetchPrivileged).To(BeTrue())
+				})
+
+				It("sets Privileged to true in the garden spec", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+					actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
+					Expect(actualGardenSpec.Privileged).To(BeTrue())
+				})
+			})
+
 			Context("when the spec specifies Ephemeral", func() {
 				BeforeEach(func() {
 					containerSpec.Ephemeral = true
@@ -1974,6 +2050,84 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("CountVolumes", func() {
+		var (
+			refs []VolumeRef
+
+			count    int
+			countErr error
+		)
+
+		BeforeEach(func() {
+			refs = []VolumeRef{
+				{Handle: "some-handle"},
+				{Strategy: OutputStrategy{Name: "some-output"}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			count, countErr = gardenWorker.CountVolumes(logger, refs)
+		})
+
+		Context("when the worker holds all of the volumes", func() {
+			BeforeEach(func() {
+				fakeBaggageclaimClient.LookupVolumeReturns(new(bfakes.FakeVolume), true, nil)
+				fakeGardenWorkerDB.GetVolumesByIdentifierReturns([]db.SavedVolume{
+					{Volume: db.Volume{Handle: "other-handle", WorkerName: "other-worker"}},
+					{Volume: db.Volume{Handle: "output-handle", WorkerName: workerName}},
+				}, nil)
+			})
+
+			It("counts them", func() {
+				Expect(countErr).NotTo(HaveOccurred())
+				Expect(count).To(Equal(2))
+			})
+
+			It("looks up the volume with the handle on the worker", func() {
+				Expect(fakeBaggageclaimClient.LookupVolumeCallCount()).To(Equal(1))
+				_, handle := fakeBaggageclaimClient.LookupVolumeArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+			})
+
+			It("looks up the volumes with the strategy's identifier", func() {
+				Expect(fakeGardenWorkerDB.GetVolumesByIdentifierCallCount()).To(Equal(1))
+				Expect(fakeGardenWorkerDB.GetVolumesByIdentifierArgsForCall(0)).To(Equal(db.VolumeIdentifier{
+					Output: &db.OutputIdentifier{Name: "some-output"},
+				}))
+			})
+
+			It("does not heartbeat the volumes", func() {
+				Expect(fakeGardenWorkerDB.SetVolumeTTLAndSizeInBytesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the volumes are only on other workers", func() {
+			BeforeEach(func() {
+				fakeBaggageclaimClient.LookupVolumeReturns(nil, false, nil)
+				fakeGardenWorkerDB.GetVolumesByIdentifierReturns([]db.SavedVolume{
+					{Volume: db.Volume{Handle: "other-handle", WorkerName: "other-worker"}},
+				}, nil)
+			})
+
+			It("does not count them", func() {
+				Expect(countErr).NotTo(HaveOccurred())
+				Expect(count).To(BeZero())
+			})
+		})
+
+		Context("when looking up the volumes fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBaggageclaimClient.LookupVolumeReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(countErr).To(Equal(disaster))
+			})
+		})
+	})
+
 	Describe("Satisfying", func() {
 		var (
 			spec WorkerSpec
@@ -2158,6 +2312,31 @@ var _ = Describe("Worker", func() {
 			It("returns no error", func() {
 				Expect(satisfyingErr).NotTo(HaveOccurred())
 			})
+
+			Context("when the custom type has tags that the worker has", func() {
+				BeforeEach(func() {
+					spec.Tags = []string{"some"}
+					customTypes[2].Tags = atc.Tags{"tags"}
+				})
+
+				It("returns the worker", func() {
+					Expect(satisfyingWorker).To(Equal(gardenWorker))
+				})
+
+				It("returns no error", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when the custom type has tags that the worker does not have", func() {
+				BeforeEach(func() {
+					customTypes[2].Tags = atc.Tags{"bogus"}
+				})
+
+				It("returns ErrMismatchedTags", func() {
+					Expect(satisfyingErr).To(Equal(ErrMismatchedTags))
+				})
+			})
 		})
 
 		Context("when the resource type is a custom type that overrides one supported by the worker", func() {
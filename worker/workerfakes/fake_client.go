@@ -110,6 +110,18 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	ChooseStub        func(lager.Logger, worker.WorkerSpec, atc.ResourceTypes) (worker.Worker, string, error)
+	chooseMutex       sync.RWMutex
+	chooseArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.WorkerSpec
+		arg3 atc.ResourceTypes
+	}
+	chooseReturns struct {
+		result1 worker.Worker
+		result2 string
+		result3 error
+	}
 	SatisfyingStub        func(worker.WorkerSpec, atc.ResourceTypes) (worker.Worker, error)
 	satisfyingMutex       sync.RWMutex
 	satisfyingArgsForCall []struct {
@@ -473,6 +485,43 @@ func (fake *FakeClient) LookupVolumeReturns(result1 worker.Volume, result2 bool,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) Choose(arg1 lager.Logger, arg2 worker.WorkerSpec, arg3 atc.ResourceTypes) (worker.Worker, string, error) {
+	fake.chooseMutex.Lock()
+	fake.chooseArgsForCall = append(fake.chooseArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.WorkerSpec
+		arg3 atc.ResourceTypes
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Choose", []interface{}{arg1, arg2, arg3})
+	fake.chooseMutex.Unlock()
+	if fake.ChooseStub != nil {
+		return fake.ChooseStub(arg1, arg2, arg3)
+	} else {
+		return fake.chooseReturns.result1, fake.chooseReturns.result2, fake.chooseReturns.result3
+	}
+}
+
+func (fake *FakeClient) ChooseCallCount() int {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return len(fake.chooseArgsForCall)
+}
+
+func (fake *FakeClient) ChooseArgsForCall(i int) (lager.Logger, worker.WorkerSpec, atc.ResourceTypes) {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return fake.chooseArgsForCall[i].arg1, fake.chooseArgsForCall[i].arg2, fake.chooseArgsForCall[i].arg3
+}
+
+func (fake *FakeClient) ChooseReturns(result1 worker.Worker, result2 string, result3 error) {
+	fake.ChooseStub = nil
+	fake.chooseReturns = struct {
+		result1 worker.Worker
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Satisfying(arg1 worker.WorkerSpec, arg2 atc.ResourceTypes) (worker.Worker, error) {
 	fake.satisfyingMutex.Lock()
 	fake.satisfyingArgsForCall = append(fake.satisfyingArgsForCall, struct {
@@ -624,6 +673,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listVolumesMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
 	fake.satisfyingMutex.RLock()
 	defer fake.satisfyingMutex.RUnlock()
 	fake.allSatisfyingMutex.RLock()
@@ -0,0 +1,90 @@
+// This file was generated by counterfeiter
+package workerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/worker"
+)
+
+type FakeContainerPlacementStrategy struct {
+	ChooseStub        func(lager.Logger, []worker.Worker, worker.WorkerSpec) (worker.Worker, string, error)
+	chooseMutex       sync.RWMutex
+	chooseArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []worker.Worker
+		arg3 worker.WorkerSpec
+	}
+	chooseReturns struct {
+		result1 worker.Worker
+		result2 string
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeContainerPlacementStrategy) Choose(arg1 lager.Logger, arg2 []worker.Worker, arg3 worker.WorkerSpec) (worker.Worker, string, error) {
+	var arg2Copy []worker.Worker
+	if arg2 != nil {
+		arg2Copy = make([]worker.Worker, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.chooseMutex.Lock()
+	fake.chooseArgsForCall = append(fake.chooseArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []worker.Worker
+		arg3 worker.WorkerSpec
+	}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("Choose", []interface{}{arg1, arg2Copy, arg3})
+	fake.chooseMutex.Unlock()
+	if fake.ChooseStub != nil {
+		return fake.ChooseStub(arg1, arg2, arg3)
+	} else {
+		return fake.chooseReturns.result1, fake.chooseReturns.result2, fake.chooseReturns.result3
+	}
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseCallCount() int {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return len(fake.chooseArgsForCall)
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseArgsForCall(i int) (lager.Logger, []worker.Worker, worker.WorkerSpec) {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return fake.chooseArgsForCall[i].arg1, fake.chooseArgsForCall[i].arg2, fake.chooseArgsForCall[i].arg3
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseReturns(result1 worker.Worker, result2 string, result3 error) {
+	fake.ChooseStub = nil
+	fake.chooseReturns = struct {
+		result1 worker.Worker
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeContainerPlacementStrategy) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeContainerPlacementStrategy) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.ContainerPlacementStrategy = new(FakeContainerPlacementStrategy)
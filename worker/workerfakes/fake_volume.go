@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/baggageclaim"
 )
@@ -94,8 +95,16 @@ type FakeVolume struct {
 	HeartbeatingToDBStub        func()
 	heartbeatingToDBMutex       sync.RWMutex
 	heartbeatingToDBArgsForCall []struct{}
-	invocations                 map[string][][]interface{}
-	invocationsMutex            sync.RWMutex
+	MarkAsTaskCacheStub         func(db.TaskCacheIdentifier) error
+	markAsTaskCacheMutex        sync.RWMutex
+	markAsTaskCacheArgsForCall  []struct {
+		arg1 db.TaskCacheIdentifier
+	}
+	markAsTaskCacheReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
 }
 
 func (fake *FakeVolume) Handle() string {
@@ -427,6 +436,39 @@ func (fake *FakeVolume) HeartbeatingToDBCallCount() int {
 	return len(fake.heartbeatingToDBArgsForCall)
 }
 
+func (fake *FakeVolume) MarkAsTaskCache(arg1 db.TaskCacheIdentifier) error {
+	fake.markAsTaskCacheMutex.Lock()
+	fake.markAsTaskCacheArgsForCall = append(fake.markAsTaskCacheArgsForCall, struct {
+		arg1 db.TaskCacheIdentifier
+	}{arg1})
+	fake.recordInvocation("MarkAsTaskCache", []interface{}{arg1})
+	fake.markAsTaskCacheMutex.Unlock()
+	if fake.MarkAsTaskCacheStub != nil {
+		return fake.MarkAsTaskCacheStub(arg1)
+	} else {
+		return fake.markAsTaskCacheReturns.result1
+	}
+}
+
+func (fake *FakeVolume) MarkAsTaskCacheCallCount() int {
+	fake.markAsTaskCacheMutex.RLock()
+	defer fake.markAsTaskCacheMutex.RUnlock()
+	return len(fake.markAsTaskCacheArgsForCall)
+}
+
+func (fake *FakeVolume) MarkAsTaskCacheArgsForCall(i int) db.TaskCacheIdentifier {
+	fake.markAsTaskCacheMutex.RLock()
+	defer fake.markAsTaskCacheMutex.RUnlock()
+	return fake.markAsTaskCacheArgsForCall[i].arg1
+}
+
+func (fake *FakeVolume) MarkAsTaskCacheReturns(result1 error) {
+	fake.MarkAsTaskCacheStub = nil
+	fake.markAsTaskCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -454,6 +496,8 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.destroyMutex.RUnlock()
 	fake.heartbeatingToDBMutex.RLock()
 	defer fake.heartbeatingToDBMutex.RUnlock()
+	fake.markAsTaskCacheMutex.RLock()
+	defer fake.markAsTaskCacheMutex.RUnlock()
 	return fake.invocations
 }
 
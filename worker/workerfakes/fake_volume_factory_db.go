@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 )
 
@@ -46,6 +47,15 @@ type FakeVolumeFactoryDB struct {
 	setVolumeTTLReturns struct {
 		result1 error
 	}
+	MarkVolumeAsTaskCacheStub        func(handle string, id db.TaskCacheIdentifier) error
+	markVolumeAsTaskCacheMutex       sync.RWMutex
+	markVolumeAsTaskCacheArgsForCall []struct {
+		handle string
+		id     db.TaskCacheIdentifier
+	}
+	markVolumeAsTaskCacheReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -187,6 +197,40 @@ func (fake *FakeVolumeFactoryDB) SetVolumeTTLReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolumeFactoryDB) MarkVolumeAsTaskCache(handle string, id db.TaskCacheIdentifier) error {
+	fake.markVolumeAsTaskCacheMutex.Lock()
+	fake.markVolumeAsTaskCacheArgsForCall = append(fake.markVolumeAsTaskCacheArgsForCall, struct {
+		handle string
+		id     db.TaskCacheIdentifier
+	}{handle, id})
+	fake.recordInvocation("MarkVolumeAsTaskCache", []interface{}{handle, id})
+	fake.markVolumeAsTaskCacheMutex.Unlock()
+	if fake.MarkVolumeAsTaskCacheStub != nil {
+		return fake.MarkVolumeAsTaskCacheStub(handle, id)
+	} else {
+		return fake.markVolumeAsTaskCacheReturns.result1
+	}
+}
+
+func (fake *FakeVolumeFactoryDB) MarkVolumeAsTaskCacheCallCount() int {
+	fake.markVolumeAsTaskCacheMutex.RLock()
+	defer fake.markVolumeAsTaskCacheMutex.RUnlock()
+	return len(fake.markVolumeAsTaskCacheArgsForCall)
+}
+
+func (fake *FakeVolumeFactoryDB) MarkVolumeAsTaskCacheArgsForCall(i int) (string, db.TaskCacheIdentifier) {
+	fake.markVolumeAsTaskCacheMutex.RLock()
+	defer fake.markVolumeAsTaskCacheMutex.RUnlock()
+	return fake.markVolumeAsTaskCacheArgsForCall[i].handle, fake.markVolumeAsTaskCacheArgsForCall[i].id
+}
+
+func (fake *FakeVolumeFactoryDB) MarkVolumeAsTaskCacheReturns(result1 error) {
+	fake.MarkVolumeAsTaskCacheStub = nil
+	fake.markVolumeAsTaskCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolumeFactoryDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -198,6 +242,8 @@ func (fake *FakeVolumeFactoryDB) Invocations() map[string][][]interface{} {
 	defer fake.setVolumeTTLAndSizeInBytesMutex.RUnlock()
 	fake.setVolumeTTLMutex.RLock()
 	defer fake.setVolumeTTLMutex.RUnlock()
+	fake.markVolumeAsTaskCacheMutex.RLock()
+	defer fake.markVolumeAsTaskCacheMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -111,6 +111,18 @@ type FakeWorker struct {
 		result2 bool
 		result3 error
 	}
+	ChooseStub        func(lager.Logger, worker.WorkerSpec, atc.ResourceTypes) (worker.Worker, string, error)
+	chooseMutex       sync.RWMutex
+	chooseArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.WorkerSpec
+		arg3 atc.ResourceTypes
+	}
+	chooseReturns struct {
+		result1 worker.Worker
+		result2 string
+		result3 error
+	}
 	SatisfyingStub        func(worker.WorkerSpec, atc.ResourceTypes) (worker.Worker, error)
 	satisfyingMutex       sync.RWMutex
 	satisfyingArgsForCall []struct {
@@ -153,6 +165,16 @@ type FakeWorker struct {
 	activeContainersReturns     struct {
 		result1 int
 	}
+	CountVolumesStub        func(lager.Logger, []worker.VolumeRef) (int, error)
+	countVolumesMutex       sync.RWMutex
+	countVolumesArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []worker.VolumeRef
+	}
+	countVolumesReturns struct {
+		result1 int
+		result2 error
+	}
 	DescriptionStub        func() string
 	descriptionMutex       sync.RWMutex
 	descriptionArgsForCall []struct{}
@@ -504,6 +526,43 @@ func (fake *FakeWorker) LookupVolumeReturns(result1 worker.Volume, result2 bool,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeWorker) Choose(arg1 lager.Logger, arg2 worker.WorkerSpec, arg3 atc.ResourceTypes) (worker.Worker, string, error) {
+	fake.chooseMutex.Lock()
+	fake.chooseArgsForCall = append(fake.chooseArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.WorkerSpec
+		arg3 atc.ResourceTypes
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Choose", []interface{}{arg1, arg2, arg3})
+	fake.chooseMutex.Unlock()
+	if fake.ChooseStub != nil {
+		return fake.ChooseStub(arg1, arg2, arg3)
+	} else {
+		return fake.chooseReturns.result1, fake.chooseReturns.result2, fake.chooseReturns.result3
+	}
+}
+
+func (fake *FakeWorker) ChooseCallCount() int {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return len(fake.chooseArgsForCall)
+}
+
+func (fake *FakeWorker) ChooseArgsForCall(i int) (lager.Logger, worker.WorkerSpec, atc.ResourceTypes) {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return fake.chooseArgsForCall[i].arg1, fake.chooseArgsForCall[i].arg2, fake.chooseArgsForCall[i].arg3
+}
+
+func (fake *FakeWorker) ChooseReturns(result1 worker.Worker, result2 string, result3 error) {
+	fake.ChooseStub = nil
+	fake.chooseReturns = struct {
+		result1 worker.Worker
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeWorker) Satisfying(arg1 worker.WorkerSpec, arg2 atc.ResourceTypes) (worker.Worker, error) {
 	fake.satisfyingMutex.Lock()
 	fake.satisfyingArgsForCall = append(fake.satisfyingArgsForCall, struct {
@@ -659,6 +718,46 @@ func (fake *FakeWorker) ActiveContainersReturns(result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) CountVolumes(arg1 lager.Logger, arg2 []worker.VolumeRef) (int, error) {
+	var arg2Copy []worker.VolumeRef
+	if arg2 != nil {
+		arg2Copy = make([]worker.VolumeRef, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.countVolumesMutex.Lock()
+	fake.countVolumesArgsForCall = append(fake.countVolumesArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []worker.VolumeRef
+	}{arg1, arg2Copy})
+	fake.recordInvocation("CountVolumes", []interface{}{arg1, arg2Copy})
+	fake.countVolumesMutex.Unlock()
+	if fake.CountVolumesStub != nil {
+		return fake.CountVolumesStub(arg1, arg2)
+	} else {
+		return fake.countVolumesReturns.result1, fake.countVolumesReturns.result2
+	}
+}
+
+func (fake *FakeWorker) CountVolumesCallCount() int {
+	fake.countVolumesMutex.RLock()
+	defer fake.countVolumesMutex.RUnlock()
+	return len(fake.countVolumesArgsForCall)
+}
+
+func (fake *FakeWorker) CountVolumesArgsForCall(i int) (lager.Logger, []worker.VolumeRef) {
+	fake.countVolumesMutex.RLock()
+	defer fake.countVolumesMutex.RUnlock()
+	return fake.countVolumesArgsForCall[i].arg1, fake.countVolumesArgsForCall[i].arg2
+}
+
+func (fake *FakeWorker) CountVolumesReturns(result1 int, result2 error) {
+	fake.CountVolumesStub = nil
+	fake.countVolumesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Description() string {
 	fake.descriptionMutex.Lock()
 	fake.descriptionArgsForCall = append(fake.descriptionArgsForCall, struct{}{})
@@ -780,6 +879,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.listVolumesMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
 	fake.satisfyingMutex.RLock()
 	defer fake.satisfyingMutex.RUnlock()
 	fake.allSatisfyingMutex.RLock()
@@ -790,6 +891,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.getWorkerMutex.RUnlock()
 	fake.activeContainersMutex.RLock()
 	defer fake.activeContainersMutex.RUnlock()
+	fake.countVolumesMutex.RLock()
+	defer fake.countVolumesMutex.RUnlock()
 	fake.descriptionMutex.RLock()
 	defer fake.descriptionMutex.RUnlock()
 	fake.nameMutex.RLock()
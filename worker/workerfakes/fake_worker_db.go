@@ -139,6 +139,15 @@ type FakeWorkerDB struct {
 	setVolumeTTLReturns struct {
 		result1 error
 	}
+	MarkVolumeAsTaskCacheStub        func(string, db.TaskCacheIdentifier) error
+	markVolumeAsTaskCacheMutex       sync.RWMutex
+	markVolumeAsTaskCacheArgsForCall []struct {
+		arg1 string
+		arg2 db.TaskCacheIdentifier
+	}
+	markVolumeAsTaskCacheReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -621,6 +630,40 @@ func (fake *FakeWorkerDB) SetVolumeTTLReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorkerDB) MarkVolumeAsTaskCache(arg1 string, arg2 db.TaskCacheIdentifier) error {
+	fake.markVolumeAsTaskCacheMutex.Lock()
+	fake.markVolumeAsTaskCacheArgsForCall = append(fake.markVolumeAsTaskCacheArgsForCall, struct {
+		arg1 string
+		arg2 db.TaskCacheIdentifier
+	}{arg1, arg2})
+	fake.recordInvocation("MarkVolumeAsTaskCache", []interface{}{arg1, arg2})
+	fake.markVolumeAsTaskCacheMutex.Unlock()
+	if fake.MarkVolumeAsTaskCacheStub != nil {
+		return fake.MarkVolumeAsTaskCacheStub(arg1, arg2)
+	} else {
+		return fake.markVolumeAsTaskCacheReturns.result1
+	}
+}
+
+func (fake *FakeWorkerDB) MarkVolumeAsTaskCacheCallCount() int {
+	fake.markVolumeAsTaskCacheMutex.RLock()
+	defer fake.markVolumeAsTaskCacheMutex.RUnlock()
+	return len(fake.markVolumeAsTaskCacheArgsForCall)
+}
+
+func (fake *FakeWorkerDB) MarkVolumeAsTaskCacheArgsForCall(i int) (string, db.TaskCacheIdentifier) {
+	fake.markVolumeAsTaskCacheMutex.RLock()
+	defer fake.markVolumeAsTaskCacheMutex.RUnlock()
+	return fake.markVolumeAsTaskCacheArgsForCall[i].arg1, fake.markVolumeAsTaskCacheArgsForCall[i].arg2
+}
+
+func (fake *FakeWorkerDB) MarkVolumeAsTaskCacheReturns(result1 error) {
+	fake.MarkVolumeAsTaskCacheStub = nil
+	fake.markVolumeAsTaskCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -652,6 +695,8 @@ func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.setVolumeTTLAndSizeInBytesMutex.RUnlock()
 	fake.setVolumeTTLMutex.RLock()
 	defer fake.setVolumeTTLMutex.RUnlock()
+	fake.markVolumeAsTaskCacheMutex.RLock()
+	defer fake.markVolumeAsTaskCacheMutex.RUnlock()
 	return fake.invocations
 }
 
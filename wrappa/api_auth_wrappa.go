@@ -39,6 +39,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 	rejector := auth.UnauthorizedRejector{}
 
 	for name, handler := range handlers {
+		role, found := atc.RouteRoles[name]
+		if !found {
+			panic("you missed a spot")
+		}
+
+		if role != atc.RoleViewer {
+			handler = auth.CheckRoleHandler(handler, role, rejector)
+		}
+
 		newHandler := handler
 
 		switch name {
@@ -50,7 +59,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAllPipelines,
 			atc.ListPipelines,
 			atc.ListBuilds,
-			atc.MainJobBadge:
+			atc.MainJobBadge,
+			atc.CheckResourceWebHook:
 
 		// pipeline is public or authorized
 		case atc.GetBuild,
@@ -64,11 +74,13 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.ApproveBuild:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// pipeline is public or authorized
 		case atc.GetPipeline,
+			atc.GetPipelineGraph,
 			atc.GetJobBuild,
 			atc.JobBadge,
 			atc.ListJobs,
@@ -98,28 +110,39 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.ExportPipeline,
+			atc.ImportPipeline:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
 		case atc.CheckResource,
+			atc.ClearJobCaches,
 			atc.CreateJobBuild,
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.GetConfig,
+			atc.ValidateConfig,
+			atc.ListConfigVersions,
+			atc.GetConfigVersion,
+			atc.DiffConfigVersions,
+			atc.RollbackConfig,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
 			atc.PauseResource,
+			atc.PinResourceVersion,
 			atc.RenamePipeline,
 			atc.UnpauseJob,
 			atc.UnpausePipeline,
 			atc.UnpauseResource,
+			atc.UnpinResourceVersion,
 			atc.ExposePipeline,
 			atc.HidePipeline,
+			atc.ArchivePipeline,
 			atc.SaveConfig:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
@@ -136,6 +136,14 @@ var _ = Describe("APIAuthWrappa", func() {
 		)
 	}
 
+	requiresRole := func(role atc.Role, handler http.Handler) http.Handler {
+		return auth.CheckRoleHandler(
+			handler,
+			role,
+			auth.UnauthorizedRejector{},
+		)
+	}
+
 	Describe("Wrap", func() {
 		var (
 			inputHandlers    rata.Handlers
@@ -153,14 +161,15 @@ var _ = Describe("APIAuthWrappa", func() {
 
 			expectedHandlers = rata.Handlers{
 				// unauthenticated / delegating to handler
-				atc.GetInfo:          unauthenticated(inputHandlers[atc.GetInfo]),
-				atc.DownloadCLI:      unauthenticated(inputHandlers[atc.DownloadCLI]),
-				atc.ListAuthMethods:  unauthenticated(inputHandlers[atc.ListAuthMethods]),
-				atc.ListAllPipelines: unauthenticated(inputHandlers[atc.ListAllPipelines]),
-				atc.ListBuilds:       unauthenticated(inputHandlers[atc.ListBuilds]),
-				atc.ListPipelines:    unauthenticated(inputHandlers[atc.ListPipelines]),
-				atc.ListTeams:        unauthenticated(inputHandlers[atc.ListTeams]),
-				atc.MainJobBadge:     unauthenticated(inputHandlers[atc.MainJobBadge]),
+				atc.GetInfo:              unauthenticated(inputHandlers[atc.GetInfo]),
+				atc.DownloadCLI:          unauthenticated(inputHandlers[atc.DownloadCLI]),
+				atc.ListAuthMethods:      unauthenticated(inputHandlers[atc.ListAuthMethods]),
+				atc.ListAllPipelines:     unauthenticated(inputHandlers[atc.ListAllPipelines]),
+				atc.ListBuilds:           unauthenticated(inputHandlers[atc.ListBuilds]),
+				atc.ListPipelines:        unauthenticated(inputHandlers[atc.ListPipelines]),
+				atc.ListTeams:            unauthenticated(inputHandlers[atc.ListTeams]),
+				atc.MainJobBadge:         unauthenticated(inputHandlers[atc.MainJobBadge]),
+				atc.CheckResourceWebHook: unauthenticated(inputHandlers[atc.CheckResourceWebHook]),
 
 				// authorized or public pipeline
 				atc.GetBuild:       doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuild]),
@@ -172,10 +181,12 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:   checkWritePermissionForBuild(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.AbortBuild])),
+				atc.ApproveBuild: checkWritePermissionForBuild(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.ApproveBuild])),
 
 				// belongs to public pipeline or authorized
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
+				atc.GetPipelineGraph:              openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipelineGraph]),
 				atc.GetJobBuild:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobBuild]),
 				atc.JobBadge:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.JobBadge]),
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
@@ -188,45 +199,56 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersions]),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
-				atc.CreatePipe:      authenticated(inputHandlers[atc.CreatePipe]),
+				atc.CreateBuild:     authenticated(requiresRole(atc.RoleMember, inputHandlers[atc.CreateBuild])),
+				atc.CreatePipe:      authenticated(requiresRole(atc.RoleMember, inputHandlers[atc.CreatePipe])),
 				atc.GetAuthToken:    authenticatedWithGetTokenValidator(inputHandlers[atc.GetAuthToken]),
 				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
+				atc.HijackContainer: authenticated(requiresRole(atc.RoleMember, inputHandlers[atc.HijackContainer])),
 				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
 				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
 				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
-				atc.ReadPipe:        authenticated(inputHandlers[atc.ReadPipe]),
-				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
+				atc.ReadPipe:        authenticated(requiresRole(atc.RoleMember, inputHandlers[atc.ReadPipe])),
+				atc.RegisterWorker:  authenticated(requiresRole(atc.RoleMember, inputHandlers[atc.RegisterWorker])),
 
-				atc.SetTeam:   authenticated(inputHandlers[atc.SetTeam]),
-				atc.WritePipe: authenticated(inputHandlers[atc.WritePipe]),
+				atc.SetTeam:   authenticated(requiresRole(atc.RoleOwner, inputHandlers[atc.SetTeam])),
+				atc.WritePipe: authenticated(requiresRole(atc.RoleMember, inputHandlers[atc.WritePipe])),
 				atc.GetUser:   authenticated(inputHandlers[atc.GetUser]),
 
 				// authenticated and is admin
-				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.GetLogLevel:    authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
+				atc.SetLogLevel:    authenticatedAndAdmin(requiresRole(atc.RoleOwner, inputHandlers[atc.SetLogLevel])),
+				atc.ExportPipeline: authenticatedAndAdmin(requiresRole(atc.RoleOwner, inputHandlers[atc.ExportPipeline])),
+				atc.ImportPipeline: authenticatedAndAdmin(requiresRole(atc.RoleOwner, inputHandlers[atc.ImportPipeline])),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.CheckResource:          authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.CheckResource])),
+				atc.CreateJobBuild:         authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.CreateJobBuild])),
+				atc.DeletePipeline:         authorized(requiresRole(atc.RoleMember, inputHandlers[atc.DeletePipeline])),
+				atc.ArchivePipeline:        authorized(requiresRole(atc.RoleMember, inputHandlers[atc.ArchivePipeline])),
+				atc.DisableResourceVersion: authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.DisableResourceVersion])),
+				atc.EnableResourceVersion:  authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.EnableResourceVersion])),
+				atc.PinResourceVersion:     authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.PinResourceVersion])),
+				atc.UnpinResourceVersion:   authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.UnpinResourceVersion])),
 				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
+				atc.ValidateConfig:         authorized(inputHandlers[atc.ValidateConfig]),
+				atc.ListConfigVersions:     authorized(inputHandlers[atc.ListConfigVersions]),
+				atc.GetConfigVersion:       authorized(inputHandlers[atc.GetConfigVersion]),
+				atc.DiffConfigVersions:     authorized(inputHandlers[atc.DiffConfigVersions]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
+				atc.OrderPipelines:         authorized(requiresRole(atc.RoleMember, inputHandlers[atc.OrderPipelines])),
+				atc.PauseJob:               authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.PauseJob])),
+				atc.ClearJobCaches:         authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.ClearJobCaches])),
+				atc.PausePipeline:          authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.PausePipeline])),
+				atc.PauseResource:          authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.PauseResource])),
+				atc.RenamePipeline:         authorized(requiresRole(atc.RoleMember, inputHandlers[atc.RenamePipeline])),
+				atc.SaveConfig:             authorized(requiresRole(atc.RoleMember, inputHandlers[atc.SaveConfig])),
+				atc.RollbackConfig:         authorized(requiresRole(atc.RoleMember, inputHandlers[atc.RollbackConfig])),
+				atc.UnpauseJob:             authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.UnpauseJob])),
+				atc.UnpausePipeline:        authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.UnpausePipeline])),
+				atc.UnpauseResource:        authorized(requiresRole(atc.RolePipelineOperator, inputHandlers[atc.UnpauseResource])),
+				atc.ExposePipeline:         authorized(requiresRole(atc.RoleMember, inputHandlers[atc.ExposePipeline])),
+				atc.HidePipeline:           authorized(requiresRole(atc.RoleMember, inputHandlers[atc.HidePipeline])),
 			}
 		})
 